@@ -34,8 +34,10 @@ func init() {
 	RootCmd.AddCommand(
 		StartCommand,
 		PingCommand,
+		NetworkCommand,
 		EthereumCommand,
 		MaintainerCommand,
+		PreParamsCommand,
 	)
 }
 
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local_tbtc"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/generator"
+	"github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/storage"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+const (
+	developerLocalFlag           = "developer-local"
+	developerLocalClientsFlag    = "developer-local-clients"
+	developerLocalDefaultClients = 3
+
+	// developerLocalWalletCreationPeriodBlocks determines how often a new
+	// wallet is requested on the local chain.
+	developerLocalWalletCreationPeriodBlocks = 100
+	// developerLocalHeartbeatPeriodBlocks determines how often a heartbeat
+	// is requested from the active wallet on the local chain.
+	developerLocalHeartbeatPeriodBlocks = 50
+	// developerLocalStatusCheckTick is the sortition pool status check tick
+	// of clients run in the developer local mode. It is short so clients join
+	// the pool as soon as they have enough pre-parameters.
+	developerLocalStatusCheckTick = 10 * time.Second
+
+	// #nosec G101 (look for hardcoded credentials)
+	// Key material generated in the developer local mode is meaningful
+	// only for the in-memory chain of a single run.
+	developerLocalStoragePassword = "developer-local"
+)
+
+// isDeveloperLocal checks whether the command should be run in the developer
+// local mode.
+func isDeveloperLocal(cmd *cobra.Command) bool {
+	isLocal, err := cmd.Flags().GetBool(developerLocalFlag)
+	return err == nil && isLocal
+}
+
+// startDeveloperLocal starts several tbtc clients in one process. The clients
+// use a shared local chain and a local network so no Ethereum node nor network
+// connectivity is needed. The local chain requests new wallets and heartbeats
+// periodically.
+func startDeveloperLocal(cmd *cobra.Command) error {
+	ctx := context.Background()
+
+	clientsCount, err := cmd.Flags().GetInt(developerLocalClientsFlag)
+	if err != nil {
+		return fmt.Errorf("cannot read number of clients: [%v]", err)
+	}
+
+	if clientsCount < 1 {
+		return fmt.Errorf("at least one client is required")
+	}
+
+	logger.Infof(
+		"Starting [%v] clients against the local chain...",
+		clientsCount,
+	)
+
+	blockCounter, err := local_v1.BlockCounter()
+	if err != nil {
+		return fmt.Errorf("cannot create local block counter: [%v]", err)
+	}
+
+	localChain := local_tbtc.NewChain(
+		ctx,
+		blockCounter,
+		local_tbtc.DefaultDKGParameters,
+	)
+
+	scheduler := generator.StartScheduler()
+
+	for i := 0; i < clientsCount; i++ {
+		if err := startDeveloperLocalClient(
+			ctx,
+			i,
+			localChain,
+			scheduler,
+		); err != nil {
+			return fmt.Errorf("cannot start client [%v]: [%v]", i, err)
+		}
+	}
+
+	go driveDeveloperLocalChain(ctx, localChain, blockCounter)
+
+	<-ctx.Done()
+	return fmt.Errorf("shutting down the node because its context has ended")
+}
+
+// startDeveloperLocalClient starts a single tbtc client with a newly generated
+// operator key. Each client uses a separate storage directory.
+func startDeveloperLocalClient(
+	ctx context.Context,
+	index int,
+	localChain *local_tbtc.Chain,
+	scheduler *generator.Scheduler,
+) error {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair(
+		local_v1.DefaultCurve,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot generate operator key: [%v]", err)
+	}
+
+	chainHandle := localChain.Connect(operatorPrivateKey)
+	netProvider := local.ConnectWithKey(operatorPublicKey)
+
+	storageDir := filepath.Join(
+		clientConfig.Storage.Dir,
+		developerLocalFlag,
+		fmt.Sprintf("client-%v", index),
+	)
+	if err := os.MkdirAll(storageDir, 0700); err != nil {
+		return fmt.Errorf("cannot create storage directory: [%v]", err)
+	}
+
+	clientStorage, err := storage.Initialize(
+		storage.Config{Dir: storageDir},
+		developerLocalStoragePassword,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot initialize storage: [%w]", err)
+	}
+
+	keyStorePersistence, err := clientStorage.InitializeKeyStorePersistence(
+		"tbtc",
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot initialize tbtc keystore persistence: [%w]",
+			err,
+		)
+	}
+
+	workPersistence, err := clientStorage.InitializeWorkPersistence("tbtc")
+	if err != nil {
+		return fmt.Errorf(
+			"cannot initialize tbtc data persistence: [%w]",
+			err,
+		)
+	}
+
+	tbtcConfig := clientConfig.Tbtc
+	tbtcConfig.SortitionPoolStatusCheckTick = developerLocalStatusCheckTick
+
+	_, err = tbtc.Initialize(
+		ctx,
+		chainHandle,
+		netProvider,
+		keyStorePersistence,
+		workPersistence,
+		scheduler,
+		tbtcConfig,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("error initializing TBTC: [%v]", err)
+	}
+
+	logger.Infof(
+		"started client [%v] with operator [%v]",
+		index,
+		chainHandle.Signing().Address(),
+	)
+
+	return nil
+}
+
+// driveDeveloperLocalChain plays the role of the Bridge on the local chain.
+// It periodically requests a new wallet and a heartbeat from the most
+// recently created wallet.
+func driveDeveloperLocalChain(
+	ctx context.Context,
+	localChain *local_tbtc.Chain,
+	blockCounter chain.BlockCounter,
+) {
+	blocksChan := blockCounter.WatchBlocks(ctx)
+
+	for {
+		select {
+		case block := <-blocksChan:
+			if block%developerLocalWalletCreationPeriodBlocks == 0 {
+				if err := localChain.RequestNewWallet(); err != nil {
+					logger.Infof(
+						"new wallet not requested at block [%v]: [%v]",
+						block,
+						err,
+					)
+				}
+			}
+
+			if block%developerLocalHeartbeatPeriodBlocks == 0 {
+				walletPublicKey, ok := localChain.ActiveWalletPublicKey()
+				if !ok {
+					continue
+				}
+
+				if err := localChain.RequestHeartbeat(
+					walletPublicKey,
+					local_tbtc.HeartbeatMessages(block),
+				); err != nil {
+					logger.Errorf(
+						"cannot request heartbeat at block [%v]: [%v]",
+						block,
+						err,
+					)
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
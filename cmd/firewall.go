@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// clientFirewall is the firewall of the client combining the firewall
+// policies according to the firewall configuration. The policies can be
+// reloaded from the configuration file without restarting the client.
+type clientFirewall struct {
+	*firewall.Reloadable
+
+	configFilePath     string
+	addressResolver    firewall.AddressResolver
+	applicationPolicy  net.Firewall
+	allowList          *firewall.AllowList
+	stakingApplication firewall.StakingApplication
+
+	mutex         sync.Mutex
+	config        *firewall.Config
+	signingGroups firewall.SigningGroups
+}
+
+// newClientFirewall creates the firewall of the client. The applicationPolicy
+// is reused across reloads to preserve its results caches.
+func newClientFirewall(
+	firewallConfig *firewall.Config,
+	configFilePath string,
+	addressResolver firewall.AddressResolver,
+	applicationPolicy net.Firewall,
+	allowList *firewall.AllowList,
+	stakingApplication firewall.StakingApplication,
+) *clientFirewall {
+	cf := &clientFirewall{
+		configFilePath:     configFilePath,
+		addressResolver:    addressResolver,
+		applicationPolicy:  applicationPolicy,
+		allowList:          allowList,
+		stakingApplication: stakingApplication,
+		config:             firewallConfig,
+	}
+
+	cf.Reloadable = firewall.NewReloadable(cf.policy())
+
+	return cf
+}
+
+// setSigningGroups sets the source of signing groups members admitted by the
+// firewall if the firewall configuration allows them. Signing groups are
+// known only once the tbtc application is initialized, after the client
+// connects to the network.
+func (cf *clientFirewall) setSigningGroups(signingGroups firewall.SigningGroups) {
+	cf.mutex.Lock()
+	defer cf.mutex.Unlock()
+
+	cf.signingGroups = signingGroups
+	cf.Reload(cf.policy())
+}
+
+// reload reads the firewall configuration from the configuration file and
+// replaces the firewall policies accordingly.
+func (cf *clientFirewall) reload() error {
+	if cf.configFilePath == "" {
+		return fmt.Errorf("config file path is not set")
+	}
+
+	firewallConfig, err := config.ReadFirewallConfig(cf.configFilePath)
+	if err != nil {
+		return err
+	}
+
+	cf.mutex.Lock()
+	defer cf.mutex.Unlock()
+
+	cf.config = firewallConfig
+	cf.Reload(cf.policy())
+
+	return nil
+}
+
+// reloadOnSignal reloads the firewall every time the process receives
+// the SIGHUP signal, until the context is done.
+func (cf *clientFirewall) reloadOnSignal(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(signals)
+
+		for {
+			select {
+			case <-signals:
+				if err := cf.reload(); err != nil {
+					logger.Errorf("could not reload firewall: [%v]", err)
+					continue
+				}
+
+				logger.Infof("firewall reloaded")
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// policy combines the firewall policies according to the current firewall
+// configuration. Operators on the deny list are always rejected. Otherwise,
+// allowlisted operators, signing groups members if allowed, and operators
+// recognized by applications and having the minimum stake are accepted.
+func (cf *clientFirewall) policy() net.Firewall {
+	recognizedPolicy := cf.applicationPolicy
+	if cf.config.MinimumStake > 0 {
+		recognizedPolicy = firewall.AllOf(
+			recognizedPolicy,
+			firewall.MinimumStakePolicy(
+				cf.addressResolver,
+				cf.stakingApplication,
+				cf.config.MinimumStakeInBaseUnits(),
+			),
+		)
+	}
+
+	acceptancePolicies := []net.Firewall{firewall.AllowListPolicy(cf.allowList)}
+	if cf.config.AllowSigningGroupMembers && cf.signingGroups != nil {
+		acceptancePolicies = append(
+			acceptancePolicies,
+			firewall.SigningGroupMembersPolicy(
+				cf.addressResolver,
+				cf.signingGroups,
+			),
+		)
+	}
+	acceptancePolicies = append(acceptancePolicies, recognizedPolicy)
+
+	deniedAddresses := make([]chain.Address, len(cf.config.DenyList))
+	for i, address := range cf.config.DenyList {
+		deniedAddresses[i] = chain.Address(address)
+	}
+
+	return firewall.AllOf(
+		firewall.DenyListPolicy(
+			cf.addressResolver,
+			firewall.NewDenyList(deniedAddresses),
+		),
+		firewall.AnyOf(acceptancePolicies...),
+	)
+}
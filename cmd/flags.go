@@ -108,7 +108,7 @@ func initEthereumFlags(cmd *cobra.Command, cfg *config.Config) {
 		&cfg.Ethereum.URL,
 		"ethereum.url",
 		"",
-		"WS connection URL for Ethereum client.",
+		"WS connection URL for Ethereum client. Multiple comma-separated URLs enable failover.",
 	)
 
 	cmd.Flags().StringVar(
@@ -152,7 +152,49 @@ func initEthereumFlags(cmd *cobra.Command, cfg *config.Config) {
 		&cfg.Ethereum.BalanceAlertThreshold,
 		"ethereum.balanceAlertThreshold",
 		*commonEthereum.WrapWei(big.NewInt(500000000000000000)), // 0.5 ether
-		"The minimum balance of operator account below which client starts reporting errors in logs.",
+		"The minimum balance of operator account below which client starts reporting warnings in logs.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.EthereumSigner.URL,
+		"ethereumSigner.url",
+		"",
+		"IPC path or HTTP/WS URL of an external signer holding the operator key (Clef external API). If set, transactions and messages are signed by the external signer.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.EthereumSigner.Address,
+		"ethereumSigner.address",
+		"",
+		"Address of the operator account managed by the external signer. Required if the signer manages more than one account.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.TbtcChain.URL,
+		"tbtcChain.url",
+		"",
+		"WS connection URL for a separate EVM chain the tBTC application runs on. Multiple comma-separated URLs enable failover. If not set, tBTC runs on Ethereum.",
+	)
+
+	cmd.Flags().Uint64Var(
+		&cfg.TbtcChain.ChainID,
+		"tbtcChain.chainId",
+		0,
+		"Expected chain ID of the separate tBTC chain.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.TbtcChain.BridgeAddress,
+		"tbtcChain.bridgeAddress",
+		"",
+		"Address of the Bridge smart contract on the separate tBTC chain.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.TbtcChain.TokenStakingAddress,
+		"tbtcChain.tokenStakingAddress",
+		"",
+		"Address of the TokenStaking smart contract on the separate tBTC chain.",
 	)
 }
 
@@ -249,6 +291,69 @@ func initNetworkFlags(cmd *cobra.Command, cfg *config.Config) {
 		0,
 		"Specifies courtesy message dissemination time in seconds for topics the node is not subscribed to. Should be used only on selected bootstrap nodes. (0 = none)",
 	)
+
+	cmd.Flags().BoolVar(
+		&cfg.LibP2P.AutoNat,
+		"network.autoNat",
+		false,
+		"Makes bootstrap nodes serve reachability checks of other peers. The node's own reachability is always detected.",
+	)
+
+	cmd.Flags().BoolVar(
+		&cfg.LibP2P.Relay,
+		"network.relay",
+		false,
+		"Enables circuit relay support. Bootstrap nodes act as relays and other nodes use bootstrap nodes as relays when they are not publicly reachable.",
+	)
+
+	cmd.Flags().BoolVar(
+		&cfg.LibP2P.HolePunching,
+		"network.holePunching",
+		false,
+		"Enables hole punching to upgrade relayed connections to direct ones.",
+	)
+
+	cmd.Flags().IntVar(
+		&cfg.LibP2P.MaxMessageSize,
+		"network.maxMessageSize",
+		libp2p.DefaultMaxMessageSize,
+		"The maximum size in bytes of a broadcast channel message. Larger messages are rejected both on send and receive.",
+	)
+
+	cmd.Flags().BoolVar(
+		&cfg.LibP2P.Compression,
+		"network.compression",
+		false,
+		"Enables compression of large broadcast channel messages.",
+	)
+
+	cmd.Flags().Float64Var(
+		&cfg.LibP2P.MessageRateLimit,
+		"network.messageRateLimit",
+		libp2p.DefaultMessageRateLimit,
+		"The number of messages per second a single peer can publish in a single topic.",
+	)
+
+	cmd.Flags().IntVar(
+		&cfg.LibP2P.MessageRateBurst,
+		"network.messageRateBurst",
+		libp2p.DefaultMessageRateBurst,
+		"The maximum number of messages a single peer can publish in a single topic at once, exceeding the message rate limit.",
+	)
+
+	cmd.Flags().Float64Var(
+		&cfg.LibP2P.PeerScoreThreshold,
+		"network.peerScoreThreshold",
+		libp2p.DefaultPeerScoreThreshold,
+		"The misbehavior score above which a peer gets disconnected and banned.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.LibP2P.PeerBanDuration,
+		"network.peerBanDuration",
+		libp2p.DefaultPeerBanDuration,
+		"The duration of a misbehaving peer ban.",
+	)
 }
 
 // Initialize flags for Storage configuration.
@@ -314,6 +419,15 @@ func initTbtcFlags(cmd *cobra.Command, cfg *config.Config) {
 		"tECDSA pre-parameters generation concurrency.",
 	)
 
+	cmd.Flags().DurationVar(
+		&cfg.Tbtc.PreParamsMaxAge,
+		"tbtc.preParamsMaxAge",
+		tbtc.DefaultPreParamsMaxAge,
+		"tECDSA pre-parameters maximum age. Older pre-parameters are "+
+			"removed from the pool. Zero, the default, disables the expiry "+
+			"so pre-parameters never expire.",
+	)
+
 	cmd.Flags().IntVar(
 		&cfg.Tbtc.KeyGenerationConcurrency,
 		"tbtc.keyGenerationConcurrency",
@@ -351,3 +465,21 @@ func initDeveloperFlags(command *cobra.Command) {
 	initContractAddressFlag(chainEthereum.TokenStakingContractName)
 	initContractAddressFlag(chainEthereum.WalletRegistryContractName)
 }
+
+// Initialize flags for the developer local mode. The flags are not part of
+// the configuration and are read directly from the command.
+func initDeveloperLocalFlags(command *cobra.Command) {
+	command.Flags().Bool(
+		developerLocalFlag,
+		false,
+		"Run several clients in one process against a local, in-memory "+
+			"chain and network. No Ethereum node is needed. For development "+
+			"and testing only.",
+	)
+
+	command.Flags().Int(
+		developerLocalClientsFlag,
+		developerLocalDefaultClients,
+		"Number of clients run in the developer local mode.",
+	)
+}
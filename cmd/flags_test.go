@@ -171,6 +171,69 @@ var cmdFlagsTests = map[string]struct {
 		expectedValueFromFlag: 486,
 		defaultValue:          0,
 	},
+	"network.autoNat": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.AutoNat },
+		flagName:              "--network.autoNat",
+		flagValue:             "true",
+		expectedValueFromFlag: true,
+		defaultValue:          false,
+	},
+	"network.relay": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.Relay },
+		flagName:              "--network.relay",
+		flagValue:             "true",
+		expectedValueFromFlag: true,
+		defaultValue:          false,
+	},
+	"network.holePunching": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.HolePunching },
+		flagName:              "--network.holePunching",
+		flagValue:             "true",
+		expectedValueFromFlag: true,
+		defaultValue:          false,
+	},
+	"network.maxMessageSize": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.MaxMessageSize },
+		flagName:              "--network.maxMessageSize",
+		flagValue:             "2097152",
+		expectedValueFromFlag: 2097152,
+		defaultValue:          1048576,
+	},
+	"network.compression": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.Compression },
+		flagName:              "--network.compression",
+		flagValue:             "true",
+		expectedValueFromFlag: true,
+		defaultValue:          false,
+	},
+	"network.messageRateLimit": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.MessageRateLimit },
+		flagName:              "--network.messageRateLimit",
+		flagValue:             "20.5",
+		expectedValueFromFlag: 20.5,
+		defaultValue:          float64(10),
+	},
+	"network.messageRateBurst": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.MessageRateBurst },
+		flagName:              "--network.messageRateBurst",
+		flagValue:             "200",
+		expectedValueFromFlag: 200,
+		defaultValue:          100,
+	},
+	"network.peerScoreThreshold": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.PeerScoreThreshold },
+		flagName:              "--network.peerScoreThreshold",
+		flagValue:             "50",
+		expectedValueFromFlag: float64(50),
+		defaultValue:          float64(100),
+	},
+	"network.peerBanDuration": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.PeerBanDuration },
+		flagName:              "--network.peerBanDuration",
+		flagValue:             "2h",
+		expectedValueFromFlag: 2 * time.Hour,
+		defaultValue:          time.Hour,
+	},
 	"storage.dir": {
 		readValueFunc: func(c *config.Config) interface{} { return c.Storage.Dir },
 		flagName:      "--storage.dir",
@@ -226,6 +289,13 @@ var cmdFlagsTests = map[string]struct {
 		expectedValueFromFlag: 2,
 		defaultValue:          1,
 	},
+	"tbtc.preParamsMaxAge": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.PreParamsMaxAge },
+		flagName:              "--tbtc.preParamsMaxAge",
+		flagValue:             "720h",
+		expectedValueFromFlag: 720 * time.Hour,
+		defaultValue:          time.Duration(0),
+	},
 	"tbtc.keyGenConcurrency": {
 		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.KeyGenerationConcurrency },
 		flagName:              "--tbtc.keyGenerationConcurrency",
@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	"github.com/keep-network/keep-core/pkg/net/retransmission"
+)
+
+// NetworkCommand contains the definition of the network command-line
+// subcommand.
+var NetworkCommand = &cobra.Command{
+	Use:   "network",
+	Short: "Diagnoses the network topology and connected peers",
+	Long:  networkDescription,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if err := clientConfig.ReadConfig(
+			configFilePath,
+			cmd.Flags(),
+			config.NetworkDiagnosticsCategories...,
+		); err != nil {
+			logger.Fatalf("error reading config: %v", err)
+		}
+	},
+	RunE: networkDiagnostics,
+}
+
+const networkDescription = `The network command connects to the network with
+   the operator key and reports: connected peers with their chain addresses,
+   firewall status of each peer for the beacon and tbtc applications,
+   topics known in the network along with subscribed peers and message
+   rates, and round-trip latency to each connected peer.
+
+   The command subscribes to all topics announced by peers to measure
+   message rates. It should use a different network port than the client
+   running with the same operator key.`
+
+const (
+	// defaultNetworkObservationPeriod is the default duration of each of
+	// the two observation phases: discovering peers and topics, and
+	// measuring message rates.
+	defaultNetworkObservationPeriod = 1 * time.Minute
+	// pingTimeout is the maximum time to wait for a ping response.
+	pingTimeout = 10 * time.Second
+)
+
+var networkObservationPeriod time.Duration
+
+func init() {
+	initFlags(
+		NetworkCommand,
+		&configFilePath,
+		clientConfig,
+		config.NetworkDiagnosticsCategories...,
+	)
+
+	NetworkCommand.Flags().DurationVar(
+		&networkObservationPeriod,
+		"observationPeriod",
+		defaultNetworkObservationPeriod,
+		"Duration of peers discovery and, separately, of message rates measurement.",
+	)
+}
+
+// networkDiagnostics connects to the network, observes it for the configured
+// period of time, and prints out the report.
+func networkDiagnostics(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	beaconChain, tbtcChain, blockCounter, signing, operatorPrivateKey, err :=
+		ethereum.Connect(
+			ctx,
+			clientConfig.Ethereum,
+			clientConfig.EthereumSigner,
+			clientConfig.TbtcChain,
+		)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	bootstrapPeersPublicKeys, err := libp2p.ExtractPeersPublicKeys(
+		clientConfig.LibP2P.Peers,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error extracting bootstrap peers public keys: [%v]",
+			err,
+		)
+	}
+
+	networkPrivateKey, err := loadNetworkPrivateKey(
+		operatorPrivateKey,
+		clientConfig.Storage,
+		clientConfig.Ethereum.KeyFilePassword,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot get network key: [%v]", err)
+	}
+
+	netProvider, err := libp2p.Connect(
+		ctx,
+		clientConfig.LibP2P,
+		networkPrivateKey,
+		firewall.AnyApplicationPolicy(
+			[]firewall.Application{beaconChain, tbtcChain},
+			firewall.NewAllowList(bootstrapPeersPublicKeys),
+		),
+		retransmission.NewTicker(blockCounter.WatchBlocks(ctx)),
+		libp2p.WithTopicsDiagnostics(),
+		libp2p.WithOperatorSigning(signing),
+	)
+	if err != nil {
+		return fmt.Errorf("failed while creating the network provider: [%v]", err)
+	}
+
+	diagnostics, ok := netProvider.(libp2p.Diagnostics)
+	if !ok {
+		return fmt.Errorf("network provider does not expose diagnostics")
+	}
+
+	fmt.Printf(
+		"Discovering peers and topics for [%v]...\n",
+		networkObservationPeriod,
+	)
+	time.Sleep(networkObservationPeriod)
+
+	for _, topic := range diagnostics.Topics() {
+		if _, err := netProvider.BroadcastChannelFor(topic.Name); err != nil {
+			fmt.Printf(
+				"Could not subscribe to topic [%v]: [%v]\n",
+				topic.Name,
+				err,
+			)
+		}
+	}
+
+	fmt.Printf(
+		"Measuring message rates for [%v]...\n",
+		networkObservationPeriod,
+	)
+
+	messagesReceivedBefore := make(map[string]uint64)
+	for _, topic := range diagnostics.Topics() {
+		messagesReceivedBefore[topic.Name] = topic.MessagesReceived
+	}
+
+	time.Sleep(networkObservationPeriod)
+
+	peers := collectPeersReports(
+		ctx,
+		netProvider,
+		diagnostics,
+		signing,
+		beaconChain,
+		tbtcChain,
+	)
+
+	printNetworkReport(
+		netProvider,
+		signing,
+		peers,
+		diagnostics.Topics(),
+		messagesReceivedBefore,
+	)
+
+	return nil
+}
+
+type peerReport struct {
+	networkID    string
+	chainAddress string
+	multiaddrs   []string
+	beacon       string
+	tbtc         string
+	latency      string
+}
+
+func collectPeersReports(
+	ctx context.Context,
+	netProvider net.Provider,
+	diagnostics libp2p.Diagnostics,
+	signing chain.Signing,
+	beaconChain firewall.Application,
+	tbtcChain firewall.Application,
+) map[string]*peerReport {
+	connectionManager := netProvider.ConnectionManager()
+
+	peers := make(map[string]*peerReport)
+	for peerID, multiaddrs := range connectionManager.ConnectedPeersAddrInfo() {
+		report := &peerReport{
+			networkID:    peerID,
+			chainAddress: "unknown",
+			multiaddrs:   multiaddrs,
+			beacon:       "unknown",
+			tbtc:         "unknown",
+		}
+		peers[peerID] = report
+
+		pingCtx, cancelPingCtx := context.WithTimeout(ctx, pingTimeout)
+		rtt, err := diagnostics.PingPeer(pingCtx, peerID)
+		cancelPingCtx()
+		if err != nil {
+			report.latency = fmt.Sprintf("error: %v", err)
+		} else {
+			report.latency = rtt.String()
+		}
+
+		peerPublicKey, err := connectionManager.GetPeerPublicKey(peerID)
+		if err != nil {
+			logger.Errorf("error on getting peer public key: [%v]", err)
+			continue
+		}
+
+		peerChainAddress, err := signing.PublicKeyToAddress(peerPublicKey)
+		if err != nil {
+			logger.Errorf("error on getting peer chain address: [%v]", err)
+		} else {
+			report.chainAddress = peerChainAddress.String()
+		}
+
+		report.beacon = firewallStatus(beaconChain.IsRecognized(peerPublicKey))
+		report.tbtc = firewallStatus(tbtcChain.IsRecognized(peerPublicKey))
+	}
+
+	return peers
+}
+
+func firewallStatus(isRecognized bool, err error) string {
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+
+	if isRecognized {
+		return "recognized"
+	}
+
+	return "not recognized"
+}
+
+func printNetworkReport(
+	netProvider net.Provider,
+	signing chain.Signing,
+	peers map[string]*peerReport,
+	topics []libp2p.TopicInfo,
+	messagesReceivedBefore map[string]uint64,
+) {
+	connectionManager := netProvider.ConnectionManager()
+
+	fmt.Printf("\nClient\n")
+	fmt.Printf("  network ID:    %v\n", netProvider.ID())
+	fmt.Printf("  chain address: %v\n", signing.Address())
+	fmt.Printf("  reachability:  %v\n", connectionManager.Reachability())
+	fmt.Printf("  addresses:     %v\n", strings.Join(connectionManager.AddrStrings(), ", "))
+
+	peerIDs := make([]string, 0, len(peers))
+	for peerID := range peers {
+		peerIDs = append(peerIDs, peerID)
+	}
+	sort.Strings(peerIDs)
+
+	fmt.Printf("\nConnected peers [%v]\n", len(peers))
+	for _, peerID := range peerIDs {
+		peer := peers[peerID]
+		fmt.Printf("  %v\n", peer.networkID)
+		fmt.Printf("    chain address: %v\n", peer.chainAddress)
+		fmt.Printf("    addresses:     %v\n", strings.Join(peer.multiaddrs, ", "))
+		fmt.Printf("    beacon:        %v\n", peer.beacon)
+		fmt.Printf("    tbtc:          %v\n", peer.tbtc)
+		fmt.Printf("    latency:       %v\n", peer.latency)
+	}
+
+	fmt.Printf("\nTopics [%v]\n", len(topics))
+	for _, topic := range topics {
+		fmt.Printf("  %v\n", topic.Name)
+		// Message rate is measured only during the measurement phase, when
+		// the client is already subscribed to all known topics.
+		messagesReceived := topic.MessagesReceived - messagesReceivedBefore[topic.Name]
+		messageRate := float64(messagesReceived) / networkObservationPeriod.Seconds()
+
+		fmt.Printf("    subscribed:        %v\n", topic.Subscribed)
+		fmt.Printf("    messages received: %v\n", messagesReceived)
+		fmt.Printf("    message rate:      %.2f/s\n", messageRate)
+		fmt.Printf("    peers [%v]:\n", len(topic.Peers))
+		for _, peerID := range topic.Peers {
+			chainAddress := "unknown"
+			if peer, ok := peers[peerID]; ok {
+				chainAddress = peer.chainAddress
+			}
+			fmt.Printf("      %v (%v)\n", peerID, chainAddress)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/storage"
+)
+
+const (
+	// networkKeyStoreDirectory is the keystore directory holding the network
+	// key used if the operator key is held outside of the client.
+	networkKeyStoreDirectory = "network"
+	networkKeyDirectory      = "key"
+	networkKeyFileName       = "network.key"
+	networkKeyByteLength     = 32
+)
+
+// loadNetworkPrivateKey returns the private key of the client's network
+// identity. If the operator private key is available, it is used as the
+// network key. Otherwise, the operator key is held outside of the client, for
+// example by an external signer, and a separate network key is used. The
+// separate network key is read from the client's keystore or generated and
+// stored there if it does not exist yet. The separate network key is attested
+// by the operator when connecting to the network.
+func loadNetworkPrivateKey(
+	operatorPrivateKey *operator.PrivateKey,
+	storageConfig storage.Config,
+	encryptionPassword string,
+) (*operator.PrivateKey, error) {
+	if operatorPrivateKey != nil {
+		return operatorPrivateKey, nil
+	}
+
+	clientStorage, err := storage.Initialize(storageConfig, encryptionPassword)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize storage: [%w]", err)
+	}
+
+	keyStorePersistence, err := clientStorage.InitializeKeyStorePersistence(
+		networkKeyStoreDirectory,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot initialize network keystore persistence: [%w]",
+			err,
+		)
+	}
+
+	descriptorsChan, errorsChan := keyStorePersistence.ReadAll()
+
+	var (
+		storedKey []byte
+		readErr   error
+	)
+
+	// Descriptors and errors channels are read by two goroutines at the
+	// same time as the channels do not have to be buffered.
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for descriptor := range descriptorsChan {
+			if descriptor.Directory() != networkKeyDirectory ||
+				descriptor.Name() != networkKeyFileName {
+				continue
+			}
+
+			storedKey, readErr = descriptor.Content()
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for err := range errorsChan {
+			logger.Errorf("could not read network keystore: [%v]", err)
+		}
+	}()
+
+	wg.Wait()
+
+	if readErr != nil {
+		return nil, fmt.Errorf("cannot read network key: [%w]", readErr)
+	}
+
+	if storedKey != nil {
+		return unmarshalNetworkPrivateKey(storedKey)
+	}
+
+	networkPrivateKey, _, err := operator.GenerateKeyPair(libp2p.DefaultCurve)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate network key: [%w]", err)
+	}
+
+	paddedKey := make([]byte, networkKeyByteLength)
+	networkPrivateKey.D.FillBytes(paddedKey)
+
+	if err := keyStorePersistence.Save(
+		paddedKey,
+		networkKeyDirectory,
+		networkKeyFileName,
+	); err != nil {
+		return nil, fmt.Errorf("cannot store network key: [%w]", err)
+	}
+
+	logger.Infof("generated a new network key attested by the operator")
+
+	return networkPrivateKey, nil
+}
+
+func unmarshalNetworkPrivateKey(keyBytes []byte) (*operator.PrivateKey, error) {
+	d := new(big.Int).SetBytes(keyBytes)
+	if len(keyBytes) != networkKeyByteLength ||
+		d.Sign() == 0 ||
+		d.Cmp(libp2p.DefaultCurve.Params().N) >= 0 {
+		return nil, fmt.Errorf("stored network key is invalid")
+	}
+
+	x, y := libp2p.DefaultCurve.ScalarBaseMult(keyBytes)
+
+	return &operator.PrivateKey{
+		PublicKey: operator.PublicKey{
+			Curve: operator.Secp256k1,
+			X:     x,
+			Y:     y,
+		},
+		D: d,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/storage"
+)
+
+func TestLoadNetworkPrivateKey(t *testing.T) {
+	storageConfig := storage.Config{Dir: t.TempDir()}
+
+	operatorPrivateKey, _, err := operator.GenerateKeyPair(libp2p.DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := loadNetworkPrivateKey(operatorPrivateKey, storageConfig, "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != operatorPrivateKey {
+		t.Errorf("expected the operator key to be used as the network key")
+	}
+
+	generatedKey, err := loadNetworkPrivateKey(nil, storageConfig, "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storedKey, err := loadNetworkPrivateKey(nil, storageConfig, "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(generatedKey, storedKey) {
+		t.Errorf(
+			"unexpected stored network key\nexpected: %v\nactual:   %v",
+			generatedKey.PublicKey,
+			storedKey.PublicKey,
+		)
+	}
+
+	if _, err := loadNetworkPrivateKey(nil, storageConfig, "wrong"); err == nil {
+		t.Errorf("expected error for the wrong storage password")
+	}
+}
@@ -0,0 +1,368 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/storage"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+	"github.com/keep-network/keep-core/pkg/tecdsa/dkg"
+)
+
+// PreParamsPasswordEnvVariable is the name of the environment variable
+// holding the password used to encrypt and decrypt pre-parameters bundles.
+//
+// #nosec G101 (look for hardcoded credentials)
+// This line doesn't contain any credentials.
+// It's just the name of the environment variable.
+const PreParamsPasswordEnvVariable = "KEEP_PREPARAMS_PASSWORD"
+
+// PreParamsCommand contains the definition of the preparams command-line
+// subcommand and its own subcommands.
+var PreParamsCommand = &cobra.Command{
+	Use:   "preparams",
+	Short: "Manages tECDSA DKG pre-parameters",
+	Long:  preParamsDescription,
+}
+
+const preParamsDescription = `The preparams command manages tECDSA DKG
+   pre-parameters the client needs to join the sortition pool. Generating
+   pre-parameters takes a lot of CPU time so they can be generated offline
+   on a powerful machine, transferred as an encrypted bundle, and imported
+   into the client's pre-parameters pool.
+
+   Bundles are encrypted with the password read from the
+   KEEP_PREPARAMS_PASSWORD environment variable or provided in the prompt.
+
+   The client must be stopped while exporting or importing pre-parameters.
+   Imported pre-parameters are loaded into the pool on the next client start.
+   Pre-parameters must never be used by two clients. That is why exported
+   pre-parameters are removed from the client's pool and imported bundles
+   are deleted. Never copy a bundle to import it into many clients.`
+
+var (
+	preParamsCount                 int
+	preParamsFile                  string
+	preParamsGenerationTimeout     time.Duration
+	preParamsGenerationConcurrency int
+)
+
+var preParamsGenerateCommand = &cobra.Command{
+	Use:   "generate",
+	Short: "Generates pre-parameters into an encrypted bundle",
+	Long: "Generates the given number of pre-parameters and writes them to " +
+		"an encrypted bundle. This command does not need the client " +
+		"configuration and can be run on any machine.",
+	RunE: generatePreParams,
+}
+
+var preParamsExportCommand = &cobra.Command{
+	Use:   "export",
+	Short: "Exports pre-parameters from the client's pool",
+	Long: "Moves the oldest pre-parameters from the client's pool to " +
+		"an encrypted bundle. If the count is not set, all pre-parameters " +
+		"are exported.",
+	PreRun: readPreParamsConfig,
+	RunE:   exportPreParams,
+}
+
+var preParamsImportCommand = &cobra.Command{
+	Use:   "import",
+	Short: "Imports pre-parameters into the client's pool",
+	Long: "Imports pre-parameters from an encrypted bundle into the " +
+		"client's pool. Pre-parameters that fail the validation or are " +
+		"already present in the pool are skipped. The bundle is deleted " +
+		"once imported so it cannot be imported into another client.",
+	PreRun: readPreParamsConfig,
+	RunE:   importPreParams,
+}
+
+func init() {
+	preParamsGenerateCommand.Flags().IntVar(
+		&preParamsCount,
+		"count",
+		tbtc.DefaultPreParamsPoolSize,
+		"Number of pre-parameters to generate.",
+	)
+	preParamsGenerateCommand.Flags().DurationVar(
+		&preParamsGenerationTimeout,
+		"timeout",
+		tbtc.DefaultPreParamsGenerationTimeout,
+		"Timeout for a single pre-parameters generation.",
+	)
+	preParamsGenerateCommand.Flags().IntVar(
+		&preParamsGenerationConcurrency,
+		"concurrency",
+		tbtc.DefaultPreParamsGenerationConcurrency,
+		"Concurrency level for a single pre-parameters generation.",
+	)
+
+	preParamsExportCommand.Flags().IntVar(
+		&preParamsCount,
+		"count",
+		0,
+		"Number of pre-parameters to export. All if not set.",
+	)
+
+	initFlags(
+		preParamsExportCommand,
+		&configFilePath,
+		clientConfig,
+		config.PreParamsCategories...,
+	)
+	initFlags(
+		preParamsImportCommand,
+		&configFilePath,
+		clientConfig,
+		config.PreParamsCategories...,
+	)
+
+	for _, command := range []*cobra.Command{
+		preParamsGenerateCommand,
+		preParamsExportCommand,
+		preParamsImportCommand,
+	} {
+		command.Flags().StringVar(
+			&preParamsFile,
+			"file",
+			"preparams.bundle",
+			"Path to the encrypted pre-parameters bundle.",
+		)
+	}
+
+	PreParamsCommand.AddCommand(
+		preParamsGenerateCommand,
+		preParamsExportCommand,
+		preParamsImportCommand,
+	)
+}
+
+func readPreParamsConfig(cmd *cobra.Command, args []string) {
+	if err := clientConfig.ReadConfig(
+		configFilePath,
+		cmd.Flags(),
+		config.PreParamsCategories...,
+	); err != nil {
+		logger.Fatalf("error reading config: %v", err)
+	}
+}
+
+// generatePreParams generates pre-parameters and writes them to an encrypted
+// bundle.
+func generatePreParams(cmd *cobra.Command, args []string) error {
+	if preParamsCount <= 0 {
+		return fmt.Errorf("count must be greater than zero")
+	}
+
+	password, err := readPreParamsPassword()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancelCtx := signal.NotifyContext(
+		context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+	)
+	defer cancelCtx()
+
+	preParams, err := dkg.GeneratePreParams(
+		ctx,
+		logger,
+		preParamsCount,
+		preParamsGenerationTimeout,
+		preParamsGenerationConcurrency,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot generate pre-parameters: [%w]", err)
+	}
+
+	if err := writePreParamsBundle(preParams, password); err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"Generated [%d] pre-parameters into [%s]\n",
+		len(preParams),
+		preParamsFile,
+	)
+
+	return nil
+}
+
+// exportPreParams moves pre-parameters from the client's pool to an encrypted
+// bundle.
+func exportPreParams(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(preParamsFile); err == nil {
+		return fmt.Errorf("file [%s] already exists", preParamsFile)
+	}
+
+	password, err := readPreParamsPassword()
+	if err != nil {
+		return err
+	}
+
+	workPersistence, err := initializeTbtcWorkPersistence()
+	if err != nil {
+		return err
+	}
+
+	exported, err := dkg.ExportPreParams(
+		workPersistence,
+		logger,
+		preParamsCount,
+		func(preParams []*dkg.PreParams) error {
+			return writePreParamsBundle(preParams, password)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"Exported [%d] pre-parameters into [%s]\n",
+		exported,
+		preParamsFile,
+	)
+
+	return nil
+}
+
+// importPreParams imports pre-parameters from an encrypted bundle into the
+// client's pool.
+func importPreParams(cmd *cobra.Command, args []string) error {
+	bundle, err := os.ReadFile(preParamsFile)
+	if err != nil {
+		return fmt.Errorf("cannot read file [%s]: [%w]", preParamsFile, err)
+	}
+
+	password, err := readPreParamsPassword()
+	if err != nil {
+		return err
+	}
+
+	preParams, err := dkg.DecryptPreParamsBundle(bundle, password)
+	if err != nil {
+		return err
+	}
+
+	workPersistence, err := initializeTbtcWorkPersistence()
+	if err != nil {
+		return err
+	}
+
+	imported, err := dkg.ImportPreParams(workPersistence, logger, preParams)
+	if err != nil {
+		return fmt.Errorf(
+			"imported [%d] out of [%d] pre-parameters: [%w]",
+			imported,
+			len(preParams),
+			err,
+		)
+	}
+
+	fmt.Printf(
+		"Imported [%d] out of [%d] pre-parameters from [%s]\n",
+		imported,
+		len(preParams),
+		preParamsFile,
+	)
+
+	// All pre-parameters from the bundle are in the pool now. Delete the
+	// bundle so it is not imported into another client by mistake. Using
+	// the same pre-parameters by many clients weakens the security of
+	// wallets created by them.
+	if err := os.Remove(preParamsFile); err != nil {
+		return fmt.Errorf(
+			"cannot delete imported bundle [%s]; delete it manually and "+
+				"never import it into another client: [%w]",
+			preParamsFile,
+			err,
+		)
+	}
+
+	fmt.Printf(
+		"Deleted imported bundle [%s]\n"+
+			"WARNING: never import copies of this bundle into other "+
+			"clients; pre-parameters must not be used by two clients\n",
+		preParamsFile,
+	)
+
+	return nil
+}
+
+// writePreParamsBundle encrypts the given pre-parameters with the given
+// password and writes the bundle to the file. The file must not exist.
+func writePreParamsBundle(preParams []*dkg.PreParams, password string) error {
+	bundle, err := dkg.EncryptPreParamsBundle(preParams, password)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(
+		preParamsFile,
+		os.O_WRONLY|os.O_CREATE|os.O_EXCL,
+		0600,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot create file [%s]: [%w]", preParamsFile, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(bundle); err != nil {
+		return fmt.Errorf("cannot write file [%s]: [%w]", preParamsFile, err)
+	}
+
+	return file.Sync()
+}
+
+// initializeTbtcWorkPersistence initializes the tbtc work persistence holding
+// the client's pre-parameters pool.
+func initializeTbtcWorkPersistence() (persistence.BasicHandle, error) {
+	storage, err := storage.Initialize(
+		clientConfig.Storage,
+		clientConfig.Ethereum.KeyFilePassword,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize storage: [%w]", err)
+	}
+
+	workPersistence, err := storage.InitializeWorkPersistence("tbtc")
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot initialize tbtc data persistence: [%w]",
+			err,
+		)
+	}
+
+	return workPersistence, nil
+}
+
+// readPreParamsPassword reads the pre-parameters bundle password from the
+// environment variable or prompts the user for it.
+func readPreParamsPassword() (string, error) {
+	password := os.Getenv(PreParamsPasswordEnvVariable)
+
+	for strings.TrimSpace(password) == "" {
+		fmt.Print("Enter pre-parameters bundle password: ")
+		bytePassword, err := term.ReadPassword(int(syscall.Stdin))
+		fmt.Print("\n")
+		if err != nil {
+			return "", fmt.Errorf("unable to read password: [%w]", err)
+		}
+
+		password = string(bytePassword)
+	}
+
+	return strings.TrimSpace(password), nil
+}
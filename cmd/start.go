@@ -27,11 +27,23 @@ var StartCommand = &cobra.Command{
 	Short: "Starts the Keep Client",
 	Long:  "Starts the Keep Client in the foreground",
 	PreRun: func(cmd *cobra.Command, args []string) {
-		if err := clientConfig.ReadConfig(configFilePath, cmd.Flags(), config.StartCmdCategories...); err != nil {
+		categories := config.StartCmdCategories
+		if isDeveloperLocal(cmd) {
+			categories = config.DeveloperLocalCmdCategories
+		}
+
+		if err := clientConfig.ReadConfig(configFilePath, cmd.Flags(), categories...); err != nil {
 			logger.Fatalf("error reading config: %v", err)
 		}
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		if isDeveloperLocal(cmd) {
+			if err := startDeveloperLocal(cmd); err != nil {
+				logger.Fatal(err)
+			}
+			return
+		}
+
 		if err := start(cmd); err != nil {
 			logger.Fatal(err)
 		}
@@ -40,6 +52,7 @@ var StartCommand = &cobra.Command{
 
 func init() {
 	initFlags(StartCommand, &configFilePath, clientConfig, config.StartCmdCategories...)
+	initDeveloperLocalFlags(StartCommand)
 
 	StartCommand.SetUsageTemplate(
 		fmt.Sprintf(`%s
@@ -64,7 +77,12 @@ func start(cmd *cobra.Command) error {
 	)
 
 	beaconChain, tbtcChain, blockCounter, signing, operatorPrivateKey, err :=
-		ethereum.Connect(ctx, clientConfig.Ethereum)
+		ethereum.Connect(
+			ctx,
+			clientConfig.Ethereum,
+			clientConfig.EthereumSigner,
+			clientConfig.TbtcChain,
+		)
 	if err != nil {
 		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
 	}
@@ -79,17 +97,38 @@ func start(cmd *cobra.Command) error {
 		)
 	}
 
-	firewall := firewall.AnyApplicationPolicy(
-		[]firewall.Application{beaconChain, tbtcChain},
+	clientFirewall := newClientFirewall(
+		&clientConfig.Firewall,
+		configFilePath,
+		signing,
+		firewall.AnyApplicationPolicy(
+			[]firewall.Application{beaconChain, tbtcChain},
+			firewall.EmptyAllowList,
+		),
 		firewall.NewAllowList(bootstrapPeersPublicKeys),
+		tbtcChain,
+	)
+	clientFirewall.reloadOnSignal(ctx)
+
+	networkPrivateKey, err := loadNetworkPrivateKey(
+		operatorPrivateKey,
+		clientConfig.Storage,
+		clientConfig.Ethereum.KeyFilePassword,
 	)
+	if err != nil {
+		return fmt.Errorf("cannot get network key: [%v]", err)
+	}
 
 	netProvider, err := libp2p.Connect(
 		ctx,
 		clientConfig.LibP2P,
-		operatorPrivateKey,
-		firewall,
+		networkPrivateKey,
+		clientFirewall,
 		retransmission.NewTicker(blockCounter.WatchBlocks(ctx)),
+		append(
+			clientConfig.LibP2P.ConnectOptions(),
+			libp2p.WithOperatorSigning(signing),
+		)...,
 	)
 	if err != nil {
 		return fmt.Errorf("failed while creating the network provider: [%v]", err)
@@ -108,6 +147,10 @@ func start(cmd *cobra.Command) error {
 		netProvider,
 		signing,
 		blockCounter,
+		beaconChain,
+		ethEndpointSources(beaconChain.Endpoints()),
+		tbtcChain,
+		ethEndpointSources(tbtcChain.Endpoints()),
 	)
 
 	// Initialize beacon and tbtc only for non-bootstrap nodes.
@@ -150,6 +193,23 @@ func start(cmd *cobra.Command) error {
 			)
 		}
 
+		ethereumDataPersistence, err := storage.InitializeWorkPersistence(
+			"ethereum",
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"cannot initialize ethereum data persistence: [%w]",
+				err,
+			)
+		}
+
+		// Beacon and TBTC chain handles share the Ethereum operator account
+		// so enabling persistence for one of them covers both unless the TBTC
+		// application runs on a separate chain. Pending transactions of the
+		// separate chain are persisted in their own directory.
+		beaconChain.PersistPendingTransactions(ethereumDataPersistence)
+		tbtcChain.PersistPendingTransactions(ethereumDataPersistence)
+
 		scheduler := generator.StartScheduler()
 
 		err = beacon.Initialize(
@@ -158,12 +218,13 @@ func start(cmd *cobra.Command) error {
 			netProvider,
 			beaconKeyStorePersistence,
 			scheduler,
+			clientInfoRegistry,
 		)
 		if err != nil {
 			return fmt.Errorf("error initializing beacon: [%v]", err)
 		}
 
-		err = tbtc.Initialize(
+		signingGroups, err := tbtc.Initialize(
 			ctx,
 			tbtcChain,
 			netProvider,
@@ -176,6 +237,8 @@ func start(cmd *cobra.Command) error {
 		if err != nil {
 			return fmt.Errorf("error initializing TBTC: [%v]", err)
 		}
+
+		clientFirewall.setSigningGroups(signingGroups)
 	}
 
 	<-ctx.Done()
@@ -188,6 +251,10 @@ func initializeClientInfo(
 	netProvider net.Provider,
 	signing chain.Signing,
 	blockCounter chain.BlockCounter,
+	operatorBalance clientinfo.OperatorBalanceSource,
+	ethEndpoints []clientinfo.EthEndpointSource,
+	tbtcChainOperatorBalance clientinfo.OperatorBalanceSource,
+	tbtcChainEndpoints []clientinfo.EthEndpointSource,
 ) *clientinfo.Registry {
 	registry, isConfigured := clientinfo.Initialize(ctx, config.ClientInfo.Port)
 	if !isConfigured {
@@ -211,6 +278,31 @@ func initializeClientInfo(
 		config.ClientInfo.EthereumMetricsTick,
 	)
 
+	registry.ObserveEthEndpoints(
+		ethEndpoints,
+		config.ClientInfo.EthereumMetricsTick,
+	)
+
+	registry.ObserveOperatorBalance(
+		operatorBalance,
+		config.ClientInfo.EthereumMetricsTick,
+	)
+
+	// The TBTC chain handle uses the Ethereum endpoints and operator account
+	// unless it is connected to a separate EVM chain. Observe the separate
+	// chain only to not duplicate the Ethereum metrics.
+	if config.TbtcChain.IsSet() {
+		registry.ObserveTbtcChainEndpoints(
+			tbtcChainEndpoints,
+			config.ClientInfo.EthereumMetricsTick,
+		)
+
+		registry.ObserveTbtcChainOperatorBalance(
+			tbtcChainOperatorBalance,
+			config.ClientInfo.EthereumMetricsTick,
+		)
+	}
+
 	registry.RegisterMetricClientInfo(build.Version)
 
 	registry.RegisterConnectedPeersSource(netProvider, signing)
@@ -228,3 +320,14 @@ func initializeClientInfo(
 
 	return registry
 }
+
+func ethEndpointSources(
+	endpoints []*ethereum.Endpoint,
+) []clientinfo.EthEndpointSource {
+	sources := make([]clientinfo.EthEndpointSource, len(endpoints))
+	for i, endpoint := range endpoints {
+		sources[i] = endpoint
+	}
+
+	return sources
+}
@@ -25,6 +25,14 @@ var StartCmdCategories = []Category{
 	Developer,
 }
 
+// DeveloperLocalCmdCategories are categories needed for the start command
+// run in the developer local mode, against the local chain.
+var DeveloperLocalCmdCategories = []Category{
+	General,
+	Storage,
+	Tbtc,
+}
+
 // MaintainerCategories are categories needed for the maintainer command.
 var MaintainerCategories = []Category{
 	Ethereum,
@@ -32,6 +40,20 @@ var MaintainerCategories = []Category{
 	Maintainer,
 }
 
+// NetworkDiagnosticsCategories are categories needed for the network
+// diagnostics command.
+var NetworkDiagnosticsCategories = []Category{
+	Ethereum,
+	Network,
+}
+
+// PreParamsCategories are categories needed for the pre-parameters export
+// and import commands.
+var PreParamsCategories = []Category{
+	Ethereum,
+	Storage,
+}
+
 // AllCategories are all available categories.
 var AllCategories = []Category{
 	General,
@@ -11,11 +11,14 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"golang.org/x/exp/slices"
 	"golang.org/x/term"
 
 	commonEthereum "github.com/keep-network/keep-common/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
+	chainEthereum "github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/clientinfo"
+	"github.com/keep-network/keep-core/pkg/firewall"
 	"github.com/keep-network/keep-core/pkg/maintainer"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
 	"github.com/keep-network/keep-core/pkg/storage"
@@ -36,13 +39,16 @@ const (
 
 // Config is the top level config structure.
 type Config struct {
-	Ethereum   commonEthereum.Config
-	Bitcoin    BitcoinConfig
-	LibP2P     libp2p.Config `mapstructure:"network"`
-	Storage    storage.Config
-	ClientInfo clientinfo.Config
-	Maintainer maintainer.Config
-	Tbtc       tbtc.Config
+	Ethereum       commonEthereum.Config
+	EthereumSigner chainEthereum.SignerConfig
+	TbtcChain      chainEthereum.TbtcChainConfig
+	Bitcoin        BitcoinConfig
+	LibP2P         libp2p.Config `mapstructure:"network"`
+	Firewall       firewall.Config
+	Storage        storage.Config
+	ClientInfo     clientinfo.Config
+	Maintainer     maintainer.Config
+	Tbtc           tbtc.Config
 }
 
 // BitcoinConfig defines the configuration for Bitcoin.
@@ -138,6 +144,13 @@ func (c *Config) ReadConfig(configFilePath string, flagSet *pflag.FlagSet, categ
 		c.Ethereum.Account.KeyFilePassword = os.Getenv(EthereumPasswordEnvVariable)
 	}
 
+	// The password is needed only if the Ethereum key file is used. It is
+	// also required with an external signer as it encrypts the client
+	// storage holding the network key.
+	if !slices.Contains(categories, Ethereum) {
+		return nil
+	}
+
 	if strings.TrimSpace(c.Ethereum.Account.KeyFilePassword) == "" {
 		var (
 			password string
@@ -173,11 +186,34 @@ func validateConfig(config *Config, categories ...Category) error {
 				))
 			}
 
-			if config.Ethereum.Account.KeyFile == "" {
+			// The key file is not used if the operator key is held by
+			// an external signer.
+			if config.Ethereum.Account.KeyFile == "" &&
+				config.EthereumSigner.URL == "" {
 				result = multierror.Append(result, fmt.Errorf(
 					"missing value for ethereum.keyFile; see ethereum section in configuration",
 				))
 			}
+
+			if config.TbtcChain.IsSet() {
+				if config.TbtcChain.ChainID == 0 {
+					result = multierror.Append(result, fmt.Errorf(
+						"missing value for tbtcChain.chainId; see tbtcChain section in configuration",
+					))
+				}
+
+				if config.TbtcChain.BridgeAddress == "" {
+					result = multierror.Append(result, fmt.Errorf(
+						"missing value for tbtcChain.bridgeAddress; see tbtcChain section in configuration",
+					))
+				}
+
+				if config.TbtcChain.TokenStakingAddress == "" {
+					result = multierror.Append(result, fmt.Errorf(
+						"missing value for tbtcChain.tokenStakingAddress; see tbtcChain section in configuration",
+					))
+				}
+			}
 		case BitcoinElectrum:
 			if config.Bitcoin.Electrum.URL == "" {
 				result = multierror.Append(result, fmt.Errorf(
@@ -220,18 +256,39 @@ func readConfigFile(configFilePath string) error {
 	return nil
 }
 
+// ReadFirewallConfig reads the firewall section of the configuration file at
+// `configFilePath`. Command-line flags and other sections of the file are not
+// taken into account. The function is meant to reload firewall policies
+// without restarting the client.
+func ReadFirewallConfig(configFilePath string) (*firewall.Config, error) {
+	fileViper := viper.New()
+	fileViper.SetConfigFile(configFilePath)
+
+	if err := fileViper.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf(
+			"failed to read configuration from file [%s]: %w",
+			configFilePath,
+			err,
+		)
+	}
+
+	config := &Config{}
+	if err := fileViper.Unmarshal(
+		config,
+		viper.DecodeHook(configDecodeHook()),
+	); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal configuration: %w", err)
+	}
+
+	return &config.Firewall, nil
+}
+
 // unmarshalConfig unmarshals config with viper from config file and command-line
 // flags into a struct.
 func unmarshalConfig(config *Config) error {
 	if err := viper.Unmarshal(
 		config,
-		viper.DecodeHook(
-			mapstructure.ComposeDecodeHookFunc(
-				mapstructure.StringToTimeDurationHookFunc(),
-				mapstructure.StringToSliceHookFunc(","),
-				mapstructure.TextUnmarshallerHookFunc(),
-			),
-		),
+		viper.DecodeHook(configDecodeHook()),
 	); err != nil {
 		return fmt.Errorf("failed to unmarshal configuration: %w", err)
 	}
@@ -239,6 +296,16 @@ func unmarshalConfig(config *Config) error {
 	return nil
 }
 
+// configDecodeHook returns the hook used to decode config values into
+// Config specific types.
+func configDecodeHook() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		mapstructure.TextUnmarshallerHookFunc(),
+	)
+}
+
 // readPassword prompts a user to enter a password. The read password uses
 // the system password reading call that helps to prevent key loggers from
 // capturing the password.
@@ -19,6 +19,7 @@ import (
 	ethereumEcdsa "github.com/keep-network/keep-core/pkg/chain/ethereum/ecdsa/gen"
 	ethereumTbtc "github.com/keep-network/keep-core/pkg/chain/ethereum/tbtc/gen"
 	ethereumThreshold "github.com/keep-network/keep-core/pkg/chain/ethereum/threshold/gen"
+	"github.com/keep-network/keep-core/pkg/firewall"
 )
 
 func TestReadConfigFromFile(t *testing.T) {
@@ -64,6 +65,30 @@ func TestReadConfigFromFile(t *testing.T) {
 			readValueFunc: func(c *Config) interface{} { return c.Ethereum.BalanceAlertThreshold.Int },
 			expectedValue: big.NewInt(2300000000000000000),
 		},
+		"EthereumSigner.URL": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumSigner.URL },
+			expectedValue: "/tmp/clef/clef.ipc",
+		},
+		"EthereumSigner.Address": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumSigner.Address },
+			expectedValue: "0xc2a56884538778bacd91aa5bf343bf882c5fb18b",
+		},
+		"TbtcChain.URL": {
+			readValueFunc: func(c *Config) interface{} { return c.TbtcChain.URL },
+			expectedValue: "ws://192.168.0.1:8546",
+		},
+		"TbtcChain.ChainID": {
+			readValueFunc: func(c *Config) interface{} { return c.TbtcChain.ChainID },
+			expectedValue: uint64(8453),
+		},
+		"TbtcChain.BridgeAddress": {
+			readValueFunc: func(c *Config) interface{} { return c.TbtcChain.BridgeAddress },
+			expectedValue: "0x8d14a6fb7fd4c7c9d2a4eb1e5b0d5f1b4c6a2e10",
+		},
+		"TbtcChain.TokenStakingAddress": {
+			readValueFunc: func(c *Config) interface{} { return c.TbtcChain.TokenStakingAddress },
+			expectedValue: "0x2b8f4c3a5e1d7c9b0a6f8e4d2c1b3a5f7e9d0c12",
+		},
 		"Ethereum.Developer - map": {
 			readValueFunc: func(c *Config) interface{} { return c.Ethereum.ContractAddresses },
 			expectedValue: map[string]string{
@@ -161,6 +186,21 @@ func TestReadConfigFromFile(t *testing.T) {
 			readValueFunc: func(c *Config) interface{} { return c.LibP2P.DisseminationTime },
 			expectedValue: 76,
 		},
+		"Firewall.DenyList": {
+			readValueFunc: func(c *Config) interface{} { return c.Firewall.DenyList },
+			expectedValue: []string{
+				"0x3F3D9B9AC6DE3AB2F3DF8B4C5E2E3B8B6DBC9F3A",
+				"0x52d1c0b5bcd0A5a7d8F1D42e4B5A7C0B0E2f6a12",
+			},
+		},
+		"Firewall.MinimumStake": {
+			readValueFunc: func(c *Config) interface{} { return c.Firewall.MinimumStake },
+			expectedValue: uint64(40000),
+		},
+		"Firewall.AllowSigningGroupMembers": {
+			readValueFunc: func(c *Config) interface{} { return c.Firewall.AllowSigningGroupMembers },
+			expectedValue: true,
+		},
 		"Storage.Dir": {
 			readValueFunc: func(c *Config) interface{} { return c.Storage.Dir },
 			expectedValue: "/my/secure/location",
@@ -203,6 +243,40 @@ func TestReadConfigFromFile(t *testing.T) {
 	}
 }
 
+func TestReadFirewallConfig(t *testing.T) {
+	filePaths := []string{
+		"../test/config.toml",
+		"../test/config.json",
+		"../test/config.yaml",
+	}
+
+	expectedConfig := &firewall.Config{
+		DenyList: []string{
+			"0x3F3D9B9AC6DE3AB2F3DF8B4C5E2E3B8B6DBC9F3A",
+			"0x52d1c0b5bcd0A5a7d8F1D42e4B5A7C0B0E2f6a12",
+		},
+		MinimumStake:             40000,
+		AllowSigningGroupMembers: true,
+	}
+
+	for _, filePath := range filePaths {
+		t.Run(strings.TrimPrefix(filepath.Ext(filePath), "."), func(t *testing.T) {
+			actualConfig, err := ReadFirewallConfig(filePath)
+			if err != nil {
+				t.Fatalf("failed to read firewall config: [%v]", err)
+			}
+
+			if !reflect.DeepEqual(expectedConfig, actualConfig) {
+				t.Errorf(
+					"\nexpected: %+v\nactual:   %+v",
+					expectedConfig,
+					actualConfig,
+				)
+			}
+		})
+	}
+}
+
 func TestReadConfig_ReadPassword(t *testing.T) {
 	expectToPrompt := "expect-to-prompt"
 
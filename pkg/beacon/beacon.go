@@ -15,6 +15,7 @@ import (
 	beaconchain "github.com/keep-network/keep-core/pkg/beacon/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/event"
 	"github.com/keep-network/keep-core/pkg/beacon/registry"
+	"github.com/keep-network/keep-core/pkg/clientinfo"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
@@ -23,6 +24,10 @@ var logger = log.Logger("keep-beacon")
 // ProtocolName denotes the name of the protocol defined by this package.
 const ProtocolName = "beacon"
 
+// groupsExpiryCheckTick is the interval in which the client checks whether
+// the groups it is a member of expired or became stale on-chain.
+const groupsExpiryCheckTick = 1 * time.Hour
+
 // Initialize kicks off the random beacon by initializing internal state,
 // ensuring preconditions like staking are met, and then kicking off the
 // internal random beacon implementation. Returns an error if this failed,
@@ -33,10 +38,28 @@ func Initialize(
 	netProvider net.Provider,
 	persistence persistence.ProtectedHandle,
 	scheduler *generator.Scheduler,
+	clientInfo *clientinfo.Registry,
 ) error {
 	groupRegistry := registry.NewGroupRegistry(logger, beaconChain, persistence)
 	groupRegistry.LoadExistingGroups()
 
+	if clientInfo != nil {
+		// only if client info endpoint is configured
+		clientInfo.ObserveApplicationSource(
+			"beacon",
+			map[string]clientinfo.Source{
+				"active_groups_count": func() float64 {
+					return float64(groupRegistry.ActiveGroupsCount())
+				},
+				"expired_groups_count": func() float64 {
+					return float64(groupRegistry.ExpiredGroupsCount())
+				},
+			},
+		)
+	}
+
+	go monitorGroupsExpiry(ctx, groupRegistry)
+
 	node := newNode(
 		beaconChain,
 		netProvider,
@@ -94,9 +117,12 @@ func Initialize(
 					}
 
 					logger.Infof(
-						"new relay entry requested at block [%v] from group "+
-							"[0x%x] using previous entry [0x%x]",
+						"new relay entry [%v] requested at block [%v] from "+
+							"group [%v] with public key [0x%x] using "+
+							"previous entry [0x%x]",
+						request.RequestID,
 						request.BlockNumber,
+						request.GroupID,
 						request.GroupPublicKey,
 						request.PreviousEntry,
 					)
@@ -155,7 +181,35 @@ func Initialize(
 		}()
 	})
 
-	// TODO: Adjust to v2 requirements.
+	_ = beaconChain.OnDKGResultSubmitted(func(event *event.DKGResultSubmission) {
+		go func() {
+			if ok := eventDeduplicator.NotifyDKGResultSubmitted(
+				event.Seed,
+				event.ResultHash,
+				event.BlockNumber,
+			); !ok {
+				logger.Warnf(
+					"result with hash [0x%x] for DKG with seed [0x%x] "+
+						"and starting block [%v] has been already processed",
+					event.ResultHash,
+					event.Seed,
+					event.BlockNumber,
+				)
+				return
+			}
+
+			logger.Infof(
+				"result with hash [0x%x] for DKG with seed [0x%x] "+
+					"submitted at block [%v]",
+				event.ResultHash,
+				event.Seed,
+				event.BlockNumber,
+			)
+
+			node.ValidateDKGResult(event)
+		}()
+	})
+
 	_ = beaconChain.OnGroupRegistered(func(registration *event.GroupRegistration) {
 		logger.Infof(
 			"new group with public key [0x%x] registered on-chain at block [%v]",
@@ -168,6 +222,27 @@ func Initialize(
 	return nil
 }
 
+// monitorGroupsExpiry periodically checks the groups the client is a member of
+// against the chain. Groups that expired are tracked as such and groups that
+// became stale are unregistered and archived. Groups expire with the passage
+// of time, regardless of new groups being registered, so the check cannot rely
+// only on group registration events.
+func monitorGroupsExpiry(ctx context.Context, groupRegistry *registry.Groups) {
+	ticker := time.NewTicker(groupsExpiryCheckTick)
+	defer ticker.Stop()
+
+	groupRegistry.UnregisterStaleGroups(nil)
+
+	for {
+		select {
+		case <-ticker.C:
+			groupRegistry.UnregisterStaleGroups(nil)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // Before we start relay entry signing process we need to confirm the current
 // relay request start block on the chain. This is to avoid having the client
 // participating in an old relay request signing that has already completed
@@ -17,11 +17,32 @@ import (
 // Maximum value accepted by the chain is 255.
 type GroupMemberIndex = uint8
 
+// DKGState represents the state of the group creation process on the chain.
+type DKGState int
+
+const (
+	Idle DKGState = iota
+	AwaitingSeed
+	AwaitingResult
+	Challenge
+)
+
+// GroupState represents the state of a group on the chain from the group
+// expiry standpoint.
+type GroupState int
+
+const (
+	GroupActive GroupState = iota
+	GroupExpired
+	GroupStale
+)
+
 // RelayEntryInterface defines the subset of the beacon chain interface that
 // pertains specifically to submission and retrieval of relay requests and
 // entries.
 type RelayEntryInterface interface {
-	// SubmitRelayEntry submits a newly created relay entry to the chain.
+	// SubmitRelayEntry submits a newly created relay entry for the current
+	// relay request to the chain.
 	SubmitRelayEntry(entry []byte) error
 	// OnRelayEntrySubmitted is a callback that is invoked when an on-chain
 	// notification of a new, valid relay entry is seen.
@@ -40,6 +61,7 @@ type RelayEntryInterface interface {
 	// IsEntryInProgress checks if a new relay entry is currently in progress.
 	IsEntryInProgress() (bool, error)
 	// CurrentRequestStartBlock returns a start block of a current entry.
+	// Returns zero if there is no relay entry in progress.
 	CurrentRequestStartBlock() (*big.Int, error)
 	// CurrentRequestPreviousEntry returns previous entry of a current request.
 	CurrentRequestPreviousEntry() ([]byte, error)
@@ -50,10 +72,10 @@ type RelayEntryInterface interface {
 // GroupSelectionInterface defines the subset of the beacon chain interface that
 // pertains to the group selection activities.
 type GroupSelectionInterface interface {
-	// SelectGroup returns the group members for the group generated by
-	// the given seed. This function can return an error if the beacon chain's
+	// SelectGroup returns the group members selected for the current group
+	// selection. This function can return an error if the beacon chain's
 	// state does not allow for group selection at the moment.
-	SelectGroup(seed *big.Int) (chain.Addresses, error)
+	SelectGroup() (chain.Addresses, error)
 }
 
 // GroupRegistrationInterface defines the subset of the beacon chain interface
@@ -67,12 +89,15 @@ type GroupRegistrationInterface interface {
 	// IsGroupRegistered checks if group with the given public key is registered
 	// on-chain.
 	IsGroupRegistered(groupPublicKey []byte) (bool, error)
-	// IsStaleGroup checks if a group with the given public key is considered
-	// as stale on-chain. Group is considered as stale if it is expired and when
-	// its expiration time and potentially executed operation timeout are both
-	// in the past. Stale group is never selected by the chain to any new
-	// operation.
-	IsStaleGroup(groupPublicKey []byte) (bool, error)
+	// GetGroupState returns the state of a group with the given public key
+	// from the group expiry standpoint. Expired group is never selected by
+	// the chain to any new operation but it may still complete the operation
+	// it was selected for before the expiration. Group is considered as stale
+	// if it is expired and when its expiration time and potentially executed
+	// operation timeout are both in the past. Group that does not exist
+	// on-chain long after its DKG result could have been approved is
+	// considered as stale as well.
+	GetGroupState(groupPublicKey []byte) (GroupState, error)
 }
 
 // GroupInterface defines the subset of the beacon chain interface that pertains
@@ -105,9 +130,34 @@ type DistributedKeyGenerationInterface interface {
 	OnDKGResultSubmitted(
 		func(event *event.DKGResultSubmission),
 	) subscription.EventSubscription
+	// OnDKGResultChallenged registers a callback that is invoked when an
+	// on-chain notification of the DKG result challenge is seen.
+	OnDKGResultChallenged(
+		func(event *event.DKGResultChallenged),
+	) subscription.EventSubscription
+	// OnDKGResultApproved registers a callback that is invoked when an on-chain
+	// notification of the DKG result approval is seen.
+	OnDKGResultApproved(
+		func(event *event.DKGResultApproved),
+	) subscription.EventSubscription
+	// GetDKGState returns the current state of the DKG procedure.
+	GetDKGState() (DKGState, error)
+	// IsDKGResultValid checks whether the submitted DKG result is valid from
+	// the on-chain contract standpoint.
+	IsDKGResultValid(dkgResult *event.DKGResultSubmission) (bool, error)
+	// ChallengeDKGResult challenges the submitted DKG result.
+	ChallengeDKGResult(dkgResult *event.DKGResultSubmission) error
+	// ApproveDKGResult approves the submitted DKG result.
+	ApproveDKGResult(dkgResult *event.DKGResultSubmission) error
+	// DKGParameters gets the current value of DKG-specific control parameters.
+	DKGParameters() (*DKGParameters, error)
 	// CalculateDKGResultHash calculates 256-bit hash of DKG result in standard
-	// specific for the chain. Operation is performed off-chain.
-	CalculateDKGResultHash(dkgResult *DKGResult) (DKGResultHash, error)
+	// specific for the chain. The startBlock argument is the block at which
+	// the given DKG process started. Operation is performed off-chain.
+	CalculateDKGResultHash(
+		dkgResult *DKGResult,
+		startBlock uint64,
+	) (DKGResultHash, error)
 }
 
 // Interface represents the interface that the random beacon expects to interact
@@ -120,7 +170,8 @@ type Interface interface {
 	// Signing returns the chain's signer.
 	Signing() chain.Signing
 	// OperatorKeyPair returns the key pair of the operator assigned to this
-	// chain handle.
+	// chain handle. The private key is nil if the operator key is held
+	// outside of the client, for example by an external signer.
 	OperatorKeyPair() (*operator.PrivateKey, *operator.PublicKey, error)
 
 	sortition.Chain
@@ -129,8 +180,14 @@ type Interface interface {
 	DistributedKeyGenerationInterface
 }
 
+// DKGParameters contains values of DKG-specific control parameters.
+type DKGParameters struct {
+	SubmissionTimeoutBlocks       uint64
+	ChallengePeriodBlocks         uint64
+	ApprovePrecedencePeriodBlocks uint64
+}
+
 // Config contains the config data needed for the random beacon to operate.
-// TODO: Adjust to the random beacon v2 requirements.
 type Config struct {
 	// GroupSize is the size of a group in the random beacon.
 	GroupSize int
@@ -147,7 +204,9 @@ type Config struct {
 	ResultPublicationBlockStep uint64
 	// RelayEntryTimeout is a timeout in blocks on-chain for a relay
 	// entry to be published by the selected group. Blocks are
-	// counted from the moment relay request occur.
+	// counted from the moment relay request occur. Once the timeout is
+	// reached, the relay entry can no longer be published and the timeout
+	// can be reported.
 	RelayEntryTimeout uint64
 }
 
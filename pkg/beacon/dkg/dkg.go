@@ -17,7 +17,10 @@ import (
 	"github.com/keep-network/keep-core/pkg/protocol/group"
 )
 
-// ExecuteDKG runs the full distributed key generation lifecycle.
+// ExecuteDKG runs the full distributed key generation lifecycle. Along with
+// the threshold signer, it returns the hash of the DKG result computed by
+// the given member. The hash can be compared against results submitted to
+// the chain.
 func ExecuteDKG(
 	logger log.StandardLogger,
 	seed *big.Int,
@@ -27,12 +30,15 @@ func ExecuteDKG(
 	channel net.BroadcastChannel,
 	membershipValidator *group.MembershipValidator,
 	selectedOperators []chain.Address,
-) (*ThresholdSigner, error) {
+) (*ThresholdSigner, beaconchain.DKGResultHash, error) {
 	beaconConfig := beaconChain.GetConfig()
 
 	blockCounter, err := beaconChain.BlockCounter()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get block counter: [%v]", err)
+		return nil, beaconchain.DKGResultHash{}, fmt.Errorf(
+			"failed to get block counter: [%v]",
+			err,
+		)
 	}
 
 	gjkr.RegisterUnmarshallers(channel)
@@ -53,13 +59,22 @@ func ExecuteDKG(
 		startBlockHeight,
 	)
 	if err != nil {
-		return nil, fmt.Errorf(
+		return nil, beaconchain.DKGResultHash{}, fmt.Errorf(
 			"[member:%v] GJKR execution failed [%v]",
 			memberIndex,
 			err,
 		)
 	}
 
+	resultHash, err := dkgResult.Hash(gjkrResult, startBlockHeight, beaconChain)
+	if err != nil {
+		return nil, beaconchain.DKGResultHash{}, fmt.Errorf(
+			"[member:%v] cannot calculate DKG result hash [%v]",
+			memberIndex,
+			err,
+		)
+	}
+
 	startPublicationBlockHeight := gjkrEndBlockHeight
 
 	operatingMemberIndexes := gjkrResult.Group.OperatingMemberIndexes()
@@ -82,6 +97,7 @@ func ExecuteDKG(
 		channel,
 		beaconChain,
 		blockCounter,
+		startBlockHeight,
 		startPublicationBlockHeight,
 	)
 	if err != nil {
@@ -105,7 +121,7 @@ func ExecuteDKG(
 			beaconChain,
 			blockCounter,
 		); err != nil {
-			return nil, err
+			return nil, beaconchain.DKGResultHash{}, err
 		}
 	}
 
@@ -115,7 +131,10 @@ func ExecuteDKG(
 		beaconConfig,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve group operators: [%v]", err)
+		return nil, beaconchain.DKGResultHash{}, fmt.Errorf(
+			"failed to resolve group operators: [%v]",
+			err,
+		)
 	}
 
 	return &ThresholdSigner{
@@ -124,7 +143,7 @@ func ExecuteDKG(
 		groupPrivateKeyShare: gjkrResult.GroupPrivateKeyShare,
 		groupPublicKeyShares: gjkrResult.GroupPublicKeyShares(),
 		groupOperators:       groupOperators,
-	}, nil
+	}, resultHash, nil
 }
 
 // decideMemberFate decides what the member will do in case it failed
@@ -9,6 +9,20 @@ import (
 	"github.com/keep-network/keep-core/pkg/protocol/group"
 )
 
+// Hash calculates the chain-specific hash of the DKG result corresponding to
+// the given GJKR protocol execution result. The dkgStartBlockHeight is the
+// block at which the DKG process started.
+func Hash(
+	gjkrResult *gjkr.Result,
+	dkgStartBlockHeight uint64,
+	beaconChain beaconchain.Interface,
+) (beaconchain.DKGResultHash, error) {
+	return beaconChain.CalculateDKGResultHash(
+		convertGjkrResult(gjkrResult),
+		dkgStartBlockHeight,
+	)
+}
+
 // convertGjkrResult transforms GJKR protocol execution result to a chain
 // specific DKG result form. It serializes a group public key to bytes and
 // converts disqualified and inactive members lists to one list of misbehaving
@@ -27,7 +27,9 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 // chosen result is hashed, signed, and sent over a broadcast channel. Then, all
 // other signatures and results are received and accounted for. Those that match
 // our own result and added to the list of votes. Finally, we submit the result
-// along with everyone's votes.
+// along with everyone's votes. The dkgStartBlockHeight is the block at which
+// the DKG process started and startBlockHeight is the block at which the
+// result publication starts.
 func Publish(
 	logger log.StandardLogger,
 	sessionID string,
@@ -38,6 +40,7 @@ func Publish(
 	channel net.BroadcastChannel,
 	beaconChain beaconchain.Interface,
 	blockCounter chain.BlockCounter,
+	dkgStartBlockHeight uint64,
 	startBlockHeight uint64,
 ) error {
 	initialState := &resultSigningState{
@@ -47,6 +50,7 @@ func Publish(
 		member:                  NewSigningMember(logger, memberIndex, dkgGroup, membershipValidator, sessionID),
 		result:                  convertGjkrResult(result),
 		signatureMessages:       make([]*DKGResultHashSignatureMessage, 0),
+		dkgStartBlockHeight:     dkgStartBlockHeight,
 		signingStartBlockHeight: startBlockHeight,
 	}
 
@@ -51,17 +51,22 @@ func NewSigningMember(
 }
 
 // SignDKGResult calculates hash of DKG result and member's signature over this
-// hash. It packs the hash and signature into a broadcast message.
+// hash. It packs the hash and signature into a broadcast message. The
+// dkgStartBlockHeight is the block at which the DKG process started.
 //
 // See Phase 13 of the protocol specification.
 func (sm *SigningMember) SignDKGResult(
 	dkgResult *beaconchain.DKGResult,
+	dkgStartBlockHeight uint64,
 	beaconChain beaconchain.Interface,
 ) (
 	*DKGResultHashSignatureMessage,
 	error,
 ) {
-	resultHash, err := beaconChain.CalculateDKGResultHash(dkgResult)
+	resultHash, err := beaconChain.CalculateDKGResultHash(
+		dkgResult,
+		dkgStartBlockHeight,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("dkg result hash calculation failed [%v]", err)
 	}
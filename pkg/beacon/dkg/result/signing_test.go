@@ -35,6 +35,7 @@ func TestResultSigningAndVerificationRoundTrip(t *testing.T) {
 	for i, member := range members {
 		message, err := member.SignDKGResult(
 			dkgResult,
+			0,
 			beaconChains[i],
 		)
 		if err != nil {
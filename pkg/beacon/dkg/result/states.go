@@ -41,6 +41,7 @@ type resultSigningState struct {
 
 	signatureMessages []*DKGResultHashSignatureMessage
 
+	dkgStartBlockHeight     uint64
 	signingStartBlockHeight uint64
 }
 
@@ -53,7 +54,11 @@ func (rss *resultSigningState) ActiveBlocks() uint64 {
 }
 
 func (rss *resultSigningState) Initiate(ctx context.Context) error {
-	message, err := rss.member.SignDKGResult(rss.result, rss.beaconChain)
+	message, err := rss.member.SignDKGResult(
+		rss.result,
+		rss.dkgStartBlockHeight,
+		rss.beaconChain,
+	)
 	if err != nil {
 		return err
 	}
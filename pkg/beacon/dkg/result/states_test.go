@@ -25,6 +25,7 @@ func TestAcceptValidSignatureHashMessage(t *testing.T) {
 
 	message2, err := member2.SignDKGResult(
 		dkgResult,
+		0,
 		beaconChain2,
 	)
 	if err != nil {
@@ -70,6 +71,7 @@ func TestDoNotAcceptMessageWithSwappedKey(t *testing.T) {
 
 	message2, err := member2.SignDKGResult(
 		dkgResult,
+		0,
 		beaconChain2,
 	)
 	if err != nil {
@@ -45,6 +45,12 @@ func SignAndSubmit(
 	relayEntrySubmittedChannel := make(chan uint64)
 	subscription := beaconChain.OnRelayEntrySubmitted(
 		func(event *event.RelayEntrySubmitted) {
+			// Entries submitted before the current request started belong
+			// to previous requests and must not stop the signing.
+			if event.BlockNumber < startBlockHeight {
+				return
+			}
+
 			relayEntrySubmittedChannel <- event.BlockNumber
 		},
 	)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/keep-network/keep-common/pkg/cache"
 	"math/big"
+	"strconv"
 	"sync"
 	"time"
 )
@@ -13,6 +14,9 @@ const (
 	// DKGSeedCachePeriod is the time period the cache maintains
 	// the DKG seed corresponding to a DKG instance.
 	DKGSeedCachePeriod = 7 * 24 * time.Hour
+	// DKGResultHashCachePeriod is the time period the cache maintains
+	// the given DKG result hash.
+	DKGResultHashCachePeriod = 7 * 24 * time.Hour
 )
 
 // Local chain interface to avoid import cycles.
@@ -33,11 +37,13 @@ type chain interface {
 //
 // Those events are supported:
 // - DKG started
+// - DKG result submitted
 // - relay entry requested
 type Deduplicator struct {
 	chain chain
 
-	dkgSeedCache *cache.TimeCache
+	dkgSeedCache       *cache.TimeCache
+	dkgResultHashCache *cache.TimeCache
 
 	relayEntryMutex             sync.Mutex
 	currentRequestStartBlock    uint64
@@ -47,8 +53,9 @@ type Deduplicator struct {
 // NewDeduplicator constructs a new Deduplicator instance.
 func NewDeduplicator(chain chain) *Deduplicator {
 	return &Deduplicator{
-		chain:        chain,
-		dkgSeedCache: cache.NewTimeCache(DKGSeedCachePeriod),
+		chain:              chain,
+		dkgSeedCache:       cache.NewTimeCache(DKGSeedCachePeriod),
+		dkgResultHashCache: cache.NewTimeCache(DKGResultHashCachePeriod),
 	}
 }
 
@@ -74,6 +81,32 @@ func (d *Deduplicator) NotifyDKGStarted(
 	return false
 }
 
+// NotifyDKGResultSubmitted notifies the client wants to start some actions
+// upon the DKG result submission. It returns boolean indicating whether the
+// client should proceed with the actions or ignore the event as a duplicate.
+func (d *Deduplicator) NotifyDKGResultSubmitted(
+	newDKGResultSeed *big.Int,
+	newDKGResultHash [32]byte,
+	newDKGResultBlock uint64,
+) bool {
+	d.dkgResultHashCache.Sweep()
+
+	cacheKey := newDKGResultSeed.Text(16) +
+		hex.EncodeToString(newDKGResultHash[:]) +
+		strconv.FormatUint(newDKGResultBlock, 10)
+
+	// If the key is not in the cache, that means the result was not handled
+	// yet and the client should proceed with the execution.
+	if !d.dkgResultHashCache.Has(cacheKey) {
+		d.dkgResultHashCache.Add(cacheKey)
+		return true
+	}
+
+	// Otherwise, the DKG result is a duplicate and the client should not
+	// proceed with the execution.
+	return false
+}
+
 // NotifyRelayEntryStarted notifies the client wants to start relay entry
 // generation upon receiving an event. It returns boolean indicating whether the
 // client should proceed with the execution or ignore the event as a duplicate.
@@ -8,7 +8,10 @@ import (
 	"time"
 )
 
-const testDKGSeedCachePeriod = 1 * time.Second
+const (
+	testDKGSeedCachePeriod       = 1 * time.Second
+	testDKGResultHashCachePeriod = 1 * time.Second
+)
 
 func TestNotifyDKGStarted(t *testing.T) {
 	chain := &testChain{
@@ -52,6 +55,65 @@ func TestNotifyDKGStarted(t *testing.T) {
 	}
 }
 
+func TestNotifyDKGResultSubmitted(t *testing.T) {
+	deduplicator := &Deduplicator{
+		dkgResultHashCache: cache.NewTimeCache(testDKGResultHashCachePeriod),
+	}
+
+	hash1Bytes, err := hex.DecodeString("92327ddff69a2b8c7ae787c5d590a2f14586089e6339e942d56e82aa42052cd9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hash1 [32]byte
+	copy(hash1[:], hash1Bytes)
+
+	hash2Bytes, err := hex.DecodeString("23c0062913c4614bdff07f94475ceb4c585df53f71611776c3521ed8f8785913")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hash2 [32]byte
+	copy(hash2[:], hash2Bytes)
+
+	// Add the original parameters.
+	canProcess := deduplicator.NotifyDKGResultSubmitted(big.NewInt(100), hash1, 500)
+	if !canProcess {
+		t.Fatal("should be allowed to process")
+	}
+
+	// Add with different seed.
+	canProcess = deduplicator.NotifyDKGResultSubmitted(big.NewInt(101), hash1, 500)
+	if !canProcess {
+		t.Fatal("should be allowed to process")
+	}
+
+	// Add with different result hash.
+	canProcess = deduplicator.NotifyDKGResultSubmitted(big.NewInt(100), hash2, 500)
+	if !canProcess {
+		t.Fatal("should be allowed to process")
+	}
+
+	// Add with different result block.
+	canProcess = deduplicator.NotifyDKGResultSubmitted(big.NewInt(100), hash1, 501)
+	if !canProcess {
+		t.Fatal("should be allowed to process")
+	}
+
+	// Add the original parameters before caching period elapses.
+	canProcess = deduplicator.NotifyDKGResultSubmitted(big.NewInt(100), hash1, 500)
+	if canProcess {
+		t.Fatal("should not be allowed to process")
+	}
+
+	// Wait until caching period elapses.
+	time.Sleep(testDKGResultHashCachePeriod)
+
+	// Add the original parameters again.
+	canProcess = deduplicator.NotifyDKGResultSubmitted(big.NewInt(100), hash1, 500)
+	if !canProcess {
+		t.Fatal("should be allowed to process")
+	}
+}
+
 func TestStartRelayEntry_NoPriorRelayEntries(t *testing.T) {
 	chain := &testChain{
 		currentRequestStartBlockValue:    nil,
@@ -8,19 +8,25 @@ import (
 )
 
 // RelayEntrySubmitted indicates that valid relay entry has been submitted to
-// the chain for the currently processed relay request. This event is intended
+// the chain for the relay request with the given ID. This event is intended
 // to be used by operators for tracking entry generation and submission progress.
-// TODO: Adjust to the v2 RandomBeacon contract.
 type RelayEntrySubmitted struct {
+	RequestID *big.Int
+	Entry     []byte
+
 	BlockNumber uint64
 }
 
 // RelayEntryRequested represents a request for an entry in the threshold relay.
-// TODO: Adjust to the v2 RandomBeacon contract.
+// The request is processed by the group with the given ID and public key
+// which should sign the previous entry.
 type RelayEntryRequested struct {
+	RequestID      *big.Int
+	GroupID        uint64
 	PreviousEntry  []byte
 	GroupPublicKey []byte
-	BlockNumber    uint64
+
+	BlockNumber uint64
 }
 
 // DKGStarted represents a DKG start event.
@@ -31,8 +37,9 @@ type DKGStarted struct {
 
 // GroupRegistration represents an event of registering a new group with the
 // given public key.
-// TODO: Adjust to the v2 RandomBeacon contract and rename to GroupRegistered.
+// TODO: Rename to GroupRegistered.
 type GroupRegistration struct {
+	GroupID        uint64
 	GroupPublicKey []byte
 
 	BlockNumber uint64
@@ -40,13 +47,40 @@ type GroupRegistration struct {
 
 // DKGResultSubmission represents a DKG result submission event. It is emitted
 // after a submitted DKG result is positively validated on the chain. It contains
-// the index of the member who submitted the result and a final public key of
-// the group.
-// TODO: Adjust to the v2 RandomBeacon contract and rename to DKGResultSubmitted.
+// the index of the member who submitted the result, a final public key of
+// the group, and the remaining parts of the submitted result which are
+// needed to challenge or approve it.
+// TODO: Rename to DKGResultSubmitted.
 type DKGResultSubmission struct {
-	MemberIndex    uint32
-	GroupPublicKey []byte
-	Misbehaved     []uint8
+	Seed       *big.Int
+	ResultHash [32]byte
+
+	MemberIndex           uint32
+	GroupPublicKey        []byte
+	Misbehaved            []uint8
+	Signatures            []byte
+	SigningMembersIndexes []uint8
+	Members               []uint32
+	MembersHash           [32]byte
+
+	BlockNumber uint64
+}
+
+// DKGResultChallenged represents a DKG result challenge event. It is emitted
+// after a submitted DKG result is challenged as an invalid result.
+type DKGResultChallenged struct {
+	ResultHash [32]byte
+	Challenger string
+	Reason     string
+
+	BlockNumber uint64
+}
+
+// DKGResultApproved represents a DKG result approval event. It is emitted
+// after a submitted DKG result is approved as a valid result.
+type DKGResultApproved struct {
+	ResultHash [32]byte
+	Approver   string
 
 	BlockNumber uint64
 }
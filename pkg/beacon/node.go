@@ -1,11 +1,15 @@
 package beacon
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"sort"
+	"sync"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/ipfs/go-log/v2"
 	"go.uber.org/zap"
 
 	"github.com/keep-network/keep-core/pkg/altbn128"
@@ -14,17 +18,55 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/entry"
 	"github.com/keep-network/keep-core/pkg/beacon/event"
 	"github.com/keep-network/keep-core/pkg/beacon/registry"
+	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/generator"
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
 )
 
+const (
+	// dkgResultApprovalDelayStepBlocks determines the delay step in blocks
+	// that is used to calculate the submission delay period that should be
+	// respected by the given member to avoid all members approving the same
+	// DKG result at the same time.
+	dkgResultApprovalDelayStepBlocks = 15
+	// dkgResultChallengeDelayStepBlocks determines the delay step in blocks
+	// that is used to calculate the challenge delay period that should be
+	// respected by the given member. The first member of the DKG group is
+	// the designated challenger and other members challenge only if the
+	// designated challenger did not manage to do it on time.
+	dkgResultChallengeDelayStepBlocks = 15
+	// dkgResultChallengeConfirmationBlocks determines the block length of
+	// the confirmation period that is preserved after a DKG result challenge
+	// submission. Once the period elapses, the DKG state is checked to confirm
+	// the challenge was accepted successfully.
+	dkgResultChallengeConfirmationBlocks = 20
+	// dkgResultChallengeMaxAttempts determines the maximum number of attempts
+	// to challenge an invalid DKG result.
+	dkgResultChallengeMaxAttempts = 3
+)
+
 // node represents the current state of a beacon node.
 type node struct {
 	beaconChain   beaconchain.Interface
 	netProvider   net.Provider
 	groupRegistry *registry.Groups
 	protocolLatch *generator.ProtocolLatch
+
+	localDKGMutex sync.Mutex
+	localDKG      *localDKG
+}
+
+// localDKG holds the details of the DKG the members controlled by this node
+// participated in. Only one DKG is executed on the chain at a time so only
+// the details of the most recent one are held.
+type localDKG struct {
+	seed          *big.Int
+	startBlock    uint64
+	memberIndexes []group.MemberIndex
+	// resultsHashes holds hashes of DKG results computed locally by members
+	// who completed the DKG protocol.
+	resultsHashes map[group.MemberIndex]beaconchain.DKGResultHash
 }
 
 // newNode returns an empty node with no group, zero group count, and a nil last
@@ -67,7 +109,7 @@ func (n *node) JoinDKGIfEligible(
 
 	dkgLogger.Info("checking eligibility for DKG")
 
-	selectedOperators, err := n.beaconChain.SelectGroup(dkgSeed)
+	selectedOperators, err := n.beaconChain.SelectGroup()
 	if err != nil {
 		// TODO: We should consider switching this log to Errorf when the
 		// Chaosnet 0 phase is completed and results are submitted to the chain.
@@ -104,13 +146,26 @@ func (n *node) JoinDKGIfEligible(
 	}
 
 	indexes := make([]uint8, 0)
+	memberIndexes := make([]group.MemberIndex, 0)
 	for index, selectedOperator := range selectedOperators {
 		// See if we are amongst those chosen
 		if selectedOperator == operatorAddress {
 			indexes = append(indexes, uint8(index))
+			// The group member index should be in range [1, groupSize] so
+			// we need to add 1.
+			memberIndexes = append(memberIndexes, group.MemberIndex(index+1))
 		}
 	}
 
+	n.localDKGMutex.Lock()
+	n.localDKG = &localDKG{
+		seed:          dkgSeed,
+		startBlock:    dkgStartBlockNumber,
+		memberIndexes: memberIndexes,
+		resultsHashes: make(map[group.MemberIndex]beaconchain.DKGResultHash),
+	}
+	n.localDKGMutex.Unlock()
+
 	// Create temporary broadcast channel name for DKG using the
 	// group selection seed with the protocol name as prefix.
 	channelName := fmt.Sprintf("%s-%s", ProtocolName, dkgSeed.Text(16))
@@ -151,7 +206,7 @@ func (n *node) JoinDKGIfEligible(
 				n.protocolLatch.Lock()
 				defer n.protocolLatch.Unlock()
 
-				signer, err := dkg.ExecuteDKG(
+				signer, resultHash, err := dkg.ExecuteDKG(
 					dkgLogger,
 					dkgSeed,
 					memberIndex,
@@ -166,6 +221,12 @@ func (n *node) JoinDKGIfEligible(
 					return
 				}
 
+				n.localDKGMutex.Lock()
+				if n.localDKG != nil && n.localDKG.seed.Cmp(dkgSeed) == 0 {
+					n.localDKG.resultsHashes[memberIndex] = resultHash
+				}
+				n.localDKGMutex.Unlock()
+
 				groupPublicKey := hex.EncodeToString(
 					signer.GroupPublicKeyBytesCompressed(),
 				)
@@ -194,6 +255,388 @@ func (n *node) JoinDKGIfEligible(
 	}
 }
 
+// ValidateDKGResult performs the submitted DKG result validation process.
+// If the result is not valid, this function submits an on-chain result
+// challenge on behalf of the designated challenger controlled by this node.
+// If the result is valid, this function schedules an on-chain approve that is
+// submitted once the challenge period elapses. Approvals are submitted only
+// on behalf of members controlled by this node whose local DKG result hash
+// matches the hash of the submitted result.
+func (n *node) ValidateDKGResult(result *event.DKGResultSubmission) {
+	dkgLogger := logger.With(
+		zap.String("seed", fmt.Sprintf("0x%x", result.Seed)),
+		zap.String("groupPublicKey", fmt.Sprintf("0x%x", result.GroupPublicKey)),
+		zap.String("resultHash", fmt.Sprintf("0x%x", result.ResultHash)),
+	)
+
+	dkgLogger.Infof("starting DKG result validation")
+
+	blockCounter, err := n.beaconChain.BlockCounter()
+	if err != nil {
+		dkgLogger.Errorf("failed to get block counter: [%v]", err)
+		return
+	}
+
+	parameters, err := n.beaconChain.DKGParameters()
+	if err != nil {
+		dkgLogger.Errorf("cannot get current DKG parameters: [%v]", err)
+		return
+	}
+
+	// The challenge period starts at the result submission block and lasts
+	// for challengePeriodBlocks.
+	challengePeriodEndBlock := result.BlockNumber + parameters.ChallengePeriodBlocks
+
+	isValid, err := n.beaconChain.IsDKGResultValid(result)
+	if err != nil {
+		dkgLogger.Errorf("cannot validate DKG result: [%v]", err)
+		return
+	}
+
+	if !isValid {
+		dkgLogger.Infof("DKG result is invalid")
+		n.challengeDKGResult(
+			dkgLogger,
+			blockCounter,
+			result,
+			challengePeriodEndBlock,
+		)
+		return
+	}
+
+	dkgLogger.Infof("DKG result is valid")
+
+	// The approval is possible one block after the challenge period end.
+	// The result submitter has precedence for approvePrecedencePeriodBlocks.
+	approvePrecedencePeriodStartBlock := challengePeriodEndBlock + 1
+	// Everyone else can approve once the precedence period ends.
+	approvePeriodStartBlock := approvePrecedencePeriodStartBlock +
+		parameters.ApprovePrecedencePeriodBlocks
+
+	// The result may be approved by someone else or challenged in the
+	// meantime. In both cases, there is nothing left to approve.
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	approvedSubscription := n.beaconChain.OnDKGResultApproved(
+		func(event *event.DKGResultApproved) {
+			cancelCtx()
+		},
+	)
+	defer approvedSubscription.Unsubscribe()
+
+	challengedSubscription := n.beaconChain.OnDKGResultChallenged(
+		func(event *event.DKGResultChallenged) {
+			if event.ResultHash == result.ResultHash {
+				cancelCtx()
+			}
+		},
+	)
+	defer challengedSubscription.Unsubscribe()
+
+	dkgLogger.Infof(
+		"waiting for block [%v] to determine DKG result approvers",
+		approvePrecedencePeriodStartBlock,
+	)
+
+	if !waitForBlockHeight(ctx, blockCounter, approvePrecedencePeriodStartBlock) {
+		dkgLogger.Infof("DKG result approved or challenged by someone else")
+		return
+	}
+
+	// Members controlled by this node approve the result only if it matches
+	// the result they computed locally. The check is done once the challenge
+	// period is over to give them enough time to complete the protocol.
+	memberIndexes, err := n.approvingMembersIndexes(result)
+	if err != nil {
+		dkgLogger.Errorf("cannot determine DKG result approvers: [%v]", err)
+		return
+	}
+
+	if len(memberIndexes) == 0 {
+		dkgLogger.Infof(
+			"not eligible for DKG result approval; no members " +
+				"controlled by this node computed the submitted result",
+		)
+		return
+	}
+
+	dkgLogger.Infof("scheduling DKG result approval")
+
+	var wg sync.WaitGroup
+	wg.Add(len(memberIndexes))
+
+	for _, currentMemberIndex := range memberIndexes {
+		go func(memberIndex group.MemberIndex) {
+			defer wg.Done()
+
+			var approveBlock uint64
+
+			if uint32(memberIndex) == result.MemberIndex {
+				// The submitter can approve earlier, during the precedence
+				// period.
+				approveBlock = approvePrecedencePeriodStartBlock
+			} else {
+				// Everyone else must approve after the precedence period ends.
+				// Each member preserves a delay according to their index
+				// to avoid simultaneous approval.
+				delayBlocks := uint64(memberIndex-1) * dkgResultApprovalDelayStepBlocks
+				approveBlock = approvePeriodStartBlock + delayBlocks
+			}
+
+			dkgLogger.Infof(
+				"[member:%v] waiting for block [%v] to approve DKG result",
+				memberIndex,
+				approveBlock,
+			)
+
+			if !waitForBlockHeight(ctx, blockCounter, approveBlock) {
+				dkgLogger.Infof(
+					"[member:%v] DKG result approved by someone else",
+					memberIndex,
+				)
+				return
+			}
+
+			err := n.beaconChain.ApproveDKGResult(result)
+			if err != nil {
+				dkgLogger.Errorf(
+					"[member:%v] cannot approve DKG result: [%v]",
+					memberIndex,
+					err,
+				)
+				return
+			}
+
+			dkgLogger.Infof("[member:%v] approving DKG result", memberIndex)
+		}(currentMemberIndex)
+	}
+
+	wg.Wait()
+}
+
+// approvingMembersIndexes returns indexes of members controlled by this node
+// that can approve the given DKG result. Only members whose local DKG result
+// hash matches the hash of the submitted result are eligible to approve it.
+func (n *node) approvingMembersIndexes(
+	result *event.DKGResultSubmission,
+) ([]group.MemberIndex, error) {
+	n.localDKGMutex.Lock()
+	defer n.localDKGMutex.Unlock()
+
+	if n.localDKG == nil || n.localDKG.seed.Cmp(result.Seed) != 0 {
+		return []group.MemberIndex{}, nil
+	}
+
+	// The hash emitted by the chain covers the entire submitted result so
+	// the hash of the result parts signed by members is calculated instead.
+	resultHash, err := n.beaconChain.CalculateDKGResultHash(
+		&beaconchain.DKGResult{
+			GroupPublicKey: result.GroupPublicKey,
+			Misbehaved:     result.Misbehaved,
+		},
+		n.localDKG.startBlock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot calculate submitted DKG result hash: [%v]",
+			err,
+		)
+	}
+
+	return matchingMembersIndexes(n.localDKG.resultsHashes, resultHash), nil
+}
+
+// matchingMembersIndexes returns indexes of members, in ascending order,
+// whose DKG result hash is the same as the given one.
+func matchingMembersIndexes(
+	resultsHashes map[group.MemberIndex]beaconchain.DKGResultHash,
+	resultHash beaconchain.DKGResultHash,
+) []group.MemberIndex {
+	memberIndexes := make([]group.MemberIndex, 0)
+	for memberIndex, memberResultHash := range resultsHashes {
+		if memberResultHash == resultHash {
+			memberIndexes = append(memberIndexes, memberIndex)
+		}
+	}
+
+	sort.Slice(memberIndexes, func(i, j int) bool {
+		return memberIndexes[i] < memberIndexes[j]
+	})
+
+	return memberIndexes
+}
+
+// challengeDKGResult submits an on-chain challenge of the given DKG result.
+// The first member of the DKG group is the designated challenger. Other
+// members challenge the result only if the designated challenger did not
+// do it before their delay, determined by their member index, elapses. This
+// node challenges on behalf of the member with the lowest index it controls.
+// Challenges are confirmed every dkgResultChallengeConfirmationBlocks and
+// re-submitted until the DKG state changes, at most
+// dkgResultChallengeMaxAttempts times and no later than the given challenge
+// period end block.
+func (n *node) challengeDKGResult(
+	dkgLogger log.StandardLogger,
+	blockCounter chain.BlockCounter,
+	result *event.DKGResultSubmission,
+	challengePeriodEndBlock uint64,
+) {
+	n.localDKGMutex.Lock()
+	var memberIndexes []group.MemberIndex
+	if n.localDKG != nil && n.localDKG.seed.Cmp(result.Seed) == 0 {
+		memberIndexes = n.localDKG.memberIndexes
+	}
+	n.localDKGMutex.Unlock()
+
+	if len(memberIndexes) == 0 {
+		dkgLogger.Infof(
+			"not eligible for DKG result challenge; no members " +
+				"controlled by this node participated in the DKG",
+		)
+		return
+	}
+
+	challengerIndex := memberIndexes[0]
+	for _, memberIndex := range memberIndexes[1:] {
+		if memberIndex < challengerIndex {
+			challengerIndex = memberIndex
+		}
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	challengedSubscription := n.beaconChain.OnDKGResultChallenged(
+		func(event *event.DKGResultChallenged) {
+			if event.ResultHash == result.ResultHash {
+				cancelCtx()
+			}
+		},
+	)
+	defer challengedSubscription.Unsubscribe()
+
+	challengeBlock := result.BlockNumber +
+		uint64(challengerIndex-1)*dkgResultChallengeDelayStepBlocks
+
+	dkgLogger.Infof(
+		"[member:%v] waiting for block [%v] to challenge DKG result",
+		challengerIndex,
+		challengeBlock,
+	)
+
+	if !waitForBlockHeight(ctx, blockCounter, challengeBlock) {
+		dkgLogger.Infof(
+			"[member:%v] invalid DKG result challenged by someone else",
+			challengerIndex,
+		)
+		return
+	}
+
+	// Challenges are done along with DKG state confirmations. This is
+	// needed to handle chain reorgs that may wipe out the block holding
+	// the challenge transaction. The state check done upon the confirmation
+	// block makes sure the submitted challenge changed the DKG state
+	// as expected. If the DKG state was not changed, the challenge is
+	// re-submitted.
+	for i := uint64(1); i <= dkgResultChallengeMaxAttempts; i++ {
+		currentBlock, err := blockCounter.CurrentBlock()
+		if err != nil {
+			dkgLogger.Errorf("cannot get current block: [%v]", err)
+			return
+		}
+
+		if currentBlock > challengePeriodEndBlock {
+			dkgLogger.Errorf(
+				"[member:%v] cannot challenge invalid DKG result; "+
+					"challenge period ended at block [%v]",
+				challengerIndex,
+				challengePeriodEndBlock,
+			)
+			return
+		}
+
+		err = n.beaconChain.ChallengeDKGResult(result)
+		if err != nil {
+			dkgLogger.Errorf(
+				"[member:%v] cannot challenge invalid DKG result: [%v]",
+				challengerIndex,
+				err,
+			)
+			return
+		}
+
+		confirmationBlock := challengeBlock +
+			(i * dkgResultChallengeConfirmationBlocks)
+
+		dkgLogger.Infof(
+			"[member:%v] challenging invalid DKG result; waiting for "+
+				"block [%v] to confirm DKG state",
+			challengerIndex,
+			confirmationBlock,
+		)
+
+		err = blockCounter.WaitForBlockHeight(confirmationBlock)
+		if err != nil {
+			dkgLogger.Errorf(
+				"error while waiting for challenge confirmation: [%v]",
+				err,
+			)
+			return
+		}
+
+		state, err := n.beaconChain.GetDKGState()
+		if err != nil {
+			dkgLogger.Errorf("cannot check DKG state: [%v]", err)
+			return
+		}
+
+		if state != beaconchain.Challenge {
+			dkgLogger.Infof(
+				"invalid DKG result challenged successfully",
+			)
+			return
+		}
+
+		dkgLogger.Infof(
+			"invalid DKG result still not challenged; retrying",
+		)
+	}
+
+	dkgLogger.Errorf(
+		"[member:%v] invalid DKG result still not challenged after [%v] "+
+			"attempts; giving up",
+		challengerIndex,
+		dkgResultChallengeMaxAttempts,
+	)
+}
+
+// waitForBlockHeight waits until the given block height is reached. Returns
+// true if the block height has been reached or false if the context has been
+// cancelled before or if the block counter failed.
+func waitForBlockHeight(
+	ctx context.Context,
+	blockCounter chain.BlockCounter,
+	blockHeight uint64,
+) bool {
+	waiter, err := blockCounter.BlockHeightWaiter(blockHeight)
+	if err != nil {
+		logger.Errorf(
+			"failed to wait for block height [%v]: [%v]",
+			blockHeight,
+			err,
+		)
+		return false
+	}
+
+	select {
+	case <-waiter:
+		return ctx.Err() == nil
+	case <-ctx.Done():
+		return false
+	}
+}
+
 // ForwardSignatureShares enables the ability to forward signature shares
 // messages to other nodes even if this node is not a part of the group which
 // signs the relay entry.
@@ -286,6 +729,12 @@ func (n *node) MonitorRelayEntry(
 
 	subscription := n.beaconChain.OnRelayEntrySubmitted(
 		func(event *event.RelayEntrySubmitted) {
+			// Entries submitted before the monitored request started belong
+			// to previous requests.
+			if event.BlockNumber < relayRequestBlockNumber {
+				return
+			}
+
 			onEntrySubmittedChannel <- event
 		},
 	)
@@ -3,9 +3,12 @@ package beacon
 import (
 	"fmt"
 	"math/big"
+	"reflect"
 	"testing"
 
+	beaconchain "github.com/keep-network/keep-core/pkg/beacon/chain"
 	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
 )
 
 var relayEntryTimeout = uint64(15)
@@ -109,3 +112,49 @@ func TestMonitorRelayEntryOnChain_EntryNotSubmitted(t *testing.T) {
 		)
 	}
 }
+
+func TestMatchingMembersIndexes(t *testing.T) {
+	resultHash := beaconchain.DKGResultHash{0x01}
+	otherResultHash := beaconchain.DKGResultHash{0x02}
+
+	var tests = map[string]struct {
+		resultsHashes         map[group.MemberIndex]beaconchain.DKGResultHash
+		expectedMemberIndexes []group.MemberIndex
+	}{
+		"no local results": {
+			resultsHashes:         map[group.MemberIndex]beaconchain.DKGResultHash{},
+			expectedMemberIndexes: []group.MemberIndex{},
+		},
+		"all local results match": {
+			resultsHashes: map[group.MemberIndex]beaconchain.DKGResultHash{
+				5: resultHash,
+				2: resultHash,
+			},
+			expectedMemberIndexes: []group.MemberIndex{2, 5},
+		},
+		"local result diverges": {
+			resultsHashes: map[group.MemberIndex]beaconchain.DKGResultHash{
+				2: resultHash,
+				5: otherResultHash,
+			},
+			expectedMemberIndexes: []group.MemberIndex{2},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			memberIndexes := matchingMembersIndexes(
+				test.resultsHashes,
+				resultHash,
+			)
+
+			if !reflect.DeepEqual(test.expectedMemberIndexes, memberIndexes) {
+				t.Errorf(
+					"unexpected member indexes\nexpected: %v\nactual:   %v",
+					test.expectedMemberIndexes,
+					memberIndexes,
+				)
+			}
+		})
+	}
+}
@@ -24,6 +24,10 @@ type Groups struct {
 	// key is group public key in uncompressed form
 	myGroups map[string][]*Membership
 
+	// key is group public key in uncompressed form; contains groups from
+	// myGroups that have been seen as expired on-chain but are not yet stale
+	expiredGroups map[string]bool
+
 	beaconChain beaconchain.GroupRegistrationInterface
 
 	storage storage
@@ -42,11 +46,12 @@ func NewGroupRegistry(
 	persistence persistence.ProtectedHandle,
 ) *Groups {
 	return &Groups{
-		logger:      logger,
-		myGroups:    make(map[string][]*Membership),
-		beaconChain: beaconChain,
-		storage:     newStorage(persistence),
-		mutex:       sync.Mutex{},
+		logger:        logger,
+		myGroups:      make(map[string][]*Membership),
+		expiredGroups: make(map[string]bool),
+		beaconChain:   beaconChain,
+		storage:       newStorage(persistence),
+		mutex:         sync.Mutex{},
 	}
 }
 
@@ -89,12 +94,19 @@ func (g *Groups) GetGroup(groupPublicKey []byte) []*Membership {
 // after the group expiration. This guarantees the group will not be selected to
 // a new operation and it cannot have an ongoing operation for which it could be
 // selected before it expired. Such a group can be safely removed from the registry
-// and archived in the underlying storage.
+// and archived in the underlying storage. Groups that are expired but not yet
+// stale are kept in the registry and tracked as expired. Groups are checked
+// against the chain without holding the registry lock so the chain calls do
+// not block other registry operations.
 func (g *Groups) UnregisterStaleGroups(latestGroupPublicKey []byte) {
 	g.mutex.Lock()
-	defer g.mutex.Unlock()
+	publicKeys := make([]string, 0, len(g.myGroups))
+	for publicKey := range g.myGroups {
+		publicKeys = append(publicKeys, publicKey)
+	}
+	g.mutex.Unlock()
 
-	for publicKey, memberships := range g.myGroups {
+	for _, publicKey := range publicKeys {
 		publicKeyBytes, err := groupKeyFromString(publicKey)
 		if err != nil {
 			g.logger.Errorf(
@@ -108,51 +120,115 @@ func (g *Groups) UnregisterStaleGroups(latestGroupPublicKey []byte) {
 		// It is also to avoid a scenario when there is a delay to sync with the
 		// recent state of the chain which might lead to loggin a false positive
 		// error: "Group does not exist".
-		if !bytes.Equal(latestGroupPublicKey, publicKeyBytes) {
-			isStaleGroup, err := g.beaconChain.IsStaleGroup(publicKeyBytes)
-			if err != nil {
-				g.logger.Errorf(
-					"failed to check if stale for group with public key [%s]: [%v]",
-					publicKey,
-					err,
-				)
-				continue
-			}
-
-			if isStaleGroup {
-				if len(memberships) == 0 {
-					g.logger.Errorf(
-						"inconsistent state; group with public key [%s] has no members",
-						publicKey,
-					)
-					continue
-				}
-
-				compressedPublicKey := memberships[0].Signer.GroupPublicKeyBytesCompressed()
-				err = g.storage.archive(compressedPublicKey)
-				if err != nil {
-					g.logger.Errorf("failed to archive group with compressed public key [%s]: [%v]",
-						hex.EncodeToString(compressedPublicKey),
-						err,
-					)
-					continue
-				}
-
-				g.logger.Infof(
-					"archived group with compressed public key [%s]",
-					hex.EncodeToString(compressedPublicKey),
-				)
-
-				delete(g.myGroups, publicKey)
-			}
+		if bytes.Equal(latestGroupPublicKey, publicKeyBytes) {
+			continue
 		}
+
+		groupState, err := g.beaconChain.GetGroupState(publicKeyBytes)
+		if err != nil {
+			g.logger.Errorf(
+				"failed to check state of group with public key [%s]: [%v]",
+				publicKey,
+				err,
+			)
+			continue
+		}
+
+		g.updateGroupState(publicKey, groupState)
 	}
 }
 
+// updateGroupState archives the group with the given public key if it is
+// stale or tracks whether it is expired otherwise.
+func (g *Groups) updateGroupState(
+	publicKey string,
+	groupState beaconchain.GroupState,
+) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	memberships, ok := g.myGroups[publicKey]
+	if !ok {
+		// The group was unregistered in the meantime.
+		return
+	}
+
+	if groupState == beaconchain.GroupStale {
+		if len(memberships) == 0 {
+			g.logger.Errorf(
+				"inconsistent state; group with public key [%s] has no members",
+				publicKey,
+			)
+			return
+		}
+
+		compressedPublicKey := memberships[0].Signer.GroupPublicKeyBytesCompressed()
+		err := g.storage.archive(compressedPublicKey)
+		if err != nil {
+			g.logger.Errorf("failed to archive group with compressed public key [%s]: [%v]",
+				hex.EncodeToString(compressedPublicKey),
+				err,
+			)
+			return
+		}
+
+		g.logger.Infof(
+			"archived group with compressed public key [%s]",
+			hex.EncodeToString(compressedPublicKey),
+		)
+
+		delete(g.myGroups, publicKey)
+		delete(g.expiredGroups, publicKey)
+		return
+	}
+
+	isExpiredGroup := groupState == beaconchain.GroupExpired
+
+	if isExpiredGroup && !g.expiredGroups[publicKey] {
+		g.logger.Infof(
+			"group with public key [%s] expired",
+			publicKey,
+		)
+	}
+
+	g.expiredGroups[publicKey] = isExpiredGroup
+}
+
+// ActiveGroupsCount returns the number of groups in the registry that have
+// not been seen as expired on-chain.
+func (g *Groups) ActiveGroupsCount() int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return len(g.myGroups) - g.expiredGroupsCount()
+}
+
+// ExpiredGroupsCount returns the number of groups in the registry that have
+// been seen as expired on-chain but are not stale yet, so they have not been
+// archived.
+func (g *Groups) ExpiredGroupsCount() int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.expiredGroupsCount()
+}
+
+func (g *Groups) expiredGroupsCount() int {
+	count := 0
+	for publicKey, isExpired := range g.expiredGroups {
+		if _, ok := g.myGroups[publicKey]; ok && isExpired {
+			count++
+		}
+	}
+
+	return count
+}
+
 // LoadExistingGroups iterates over all stored memberships on disk and loads them
 // into memory
 func (g *Groups) LoadExistingGroups() {
 	g.myGroups = make(map[string][]*Membership)
+	g.expiredGroups = make(map[string]bool)
 
 	membershipsChannel, errorsChannel := g.storage.readAll()
 
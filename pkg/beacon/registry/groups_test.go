@@ -15,6 +15,7 @@ import (
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 
 	"github.com/keep-network/keep-common/pkg/persistence"
+	beaconchain "github.com/keep-network/keep-core/pkg/beacon/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/event"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
@@ -177,23 +178,65 @@ func TestUnregisterStaleGroupsSkipLastGroupCheck(t *testing.T) {
 
 	group1PublicKeyString := groupKeyToString(signer1.GroupPublicKeyBytes())
 	if mockChain.groupsCheckedIfStale[group1PublicKeyString] != true {
-		t.Fatalf("IsStaleGroup() was expected to be called for the first group")
+		t.Fatalf("GetGroupState() was expected to be called for the first group")
 	}
 
 	group2PublicKeyString := groupKeyToString(signer2.GroupPublicKeyBytes())
 	if mockChain.groupsCheckedIfStale[group2PublicKeyString] != true {
-		t.Fatalf("IsStaleGroup() was expected to be called for the second group")
+		t.Fatalf("GetGroupState() was expected to be called for the second group")
 	}
 
 	group3PublicKeyString := groupKeyToString(signer3.GroupPublicKeyBytes())
 	if mockChain.groupsCheckedIfStale[group3PublicKeyString] != false {
-		t.Fatalf("IsStaleGroup() was expected to not be called for the third group")
+		t.Fatalf("GetGroupState() was expected to not be called for the third group")
 	}
 }
 
+func TestUnregisterStaleGroupsTrackExpiredGroups(t *testing.T) {
+	mockChain := &mockGroupRegistrationInterface{
+		groupsToRemove:       [][]byte{},
+		groupsCheckedIfStale: make(map[string]bool),
+	}
+
+	gr := NewGroupRegistry(&testutils.MockLogger{}, mockChain, &persistenceHandleMock{})
+
+	gr.RegisterGroup(signer1, channelName1)
+	gr.RegisterGroup(signer2, channelName1)
+	gr.RegisterGroup(signer3, channelName1)
+
+	testutils.AssertIntsEqual(t, "active groups count", 3, gr.ActiveGroupsCount())
+	testutils.AssertIntsEqual(t, "expired groups count", 0, gr.ExpiredGroupsCount())
+
+	mockChain.markAsExpired(signer1.GroupPublicKeyBytes())
+	mockChain.markAsExpired(signer2.GroupPublicKeyBytes())
+
+	gr.UnregisterStaleGroups(signer3.GroupPublicKeyBytes())
+
+	if gr.GetGroup(signer1.GroupPublicKeyBytes()) == nil {
+		t.Fatalf("expired group1 was expected to be kept in the registry")
+	}
+	testutils.AssertIntsEqual(t, "active groups count", 1, gr.ActiveGroupsCount())
+	testutils.AssertIntsEqual(t, "expired groups count", 2, gr.ExpiredGroupsCount())
+
+	mockChain.markAsStale(signer2.GroupPublicKeyBytes())
+
+	gr.UnregisterStaleGroups(signer3.GroupPublicKeyBytes())
+
+	if gr.GetGroup(signer2.GroupPublicKeyBytes()) != nil {
+		t.Fatalf("stale group2 was expected to be unregistered")
+	}
+	testutils.AssertIntsEqual(t, "active groups count", 1, gr.ActiveGroupsCount())
+	testutils.AssertIntsEqual(t, "expired groups count", 1, gr.ExpiredGroupsCount())
+}
+
 type mockGroupRegistrationInterface struct {
 	groupsToRemove       [][]byte
 	groupsCheckedIfStale map[string]bool
+	expiredGroups        [][]byte
+}
+
+func (mgri *mockGroupRegistrationInterface) markAsExpired(publicKey []byte) {
+	mgri.expiredGroups = append(mgri.expiredGroups, publicKey)
 }
 
 func (mgri *mockGroupRegistrationInterface) markAsStale(publicKey []byte) {
@@ -212,14 +255,21 @@ func (mgri *mockGroupRegistrationInterface) IsGroupRegistered(
 	panic("not implemented")
 }
 
-func (mgri *mockGroupRegistrationInterface) IsStaleGroup(groupPublicKey []byte) (bool, error) {
+func (mgri *mockGroupRegistrationInterface) GetGroupState(
+	groupPublicKey []byte,
+) (beaconchain.GroupState, error) {
 	mgri.groupsCheckedIfStale[groupKeyToString(groupPublicKey)] = true
 	for _, groupToRemove := range mgri.groupsToRemove {
 		if bytes.Equal(groupToRemove, groupPublicKey) {
-			return true, nil
+			return beaconchain.GroupStale, nil
+		}
+	}
+	for _, expiredGroup := range mgri.expiredGroups {
+		if bytes.Equal(expiredGroup, groupPublicKey) {
+			return beaconchain.GroupExpired, nil
 		}
 	}
-	return false, nil
+	return beaconchain.GroupActive, nil
 }
 
 type persistenceHandleMock struct {
@@ -0,0 +1,110 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// balanceMonitoringTick is the interval in which the balance of the operator
+// account is checked.
+const balanceMonitoringTick = 10 * time.Minute
+
+// balanceSource returns the current balance of the given account, in wei.
+type balanceSource func(
+	ctx context.Context,
+	address common.Address,
+) (*big.Int, error)
+
+// balanceMonitor periodically checks the balance of the given account and
+// logs a warning if the balance drops below the alert threshold. The most
+// recently observed balance is cached so it can be exposed by metrics without
+// additional calls to the Ethereum client.
+type balanceMonitor struct {
+	balanceSource  balanceSource
+	address        common.Address
+	alertThreshold *big.Int
+
+	lastBalanceMutex sync.RWMutex
+	lastBalance      *big.Int
+}
+
+func newBalanceMonitor(
+	balanceSource balanceSource,
+	address common.Address,
+	alertThreshold *big.Int,
+) *balanceMonitor {
+	return &balanceMonitor{
+		balanceSource:  balanceSource,
+		address:        address,
+		alertThreshold: alertThreshold,
+	}
+}
+
+// observe checks the balance immediately and then periodically, with the
+// given tick, until the context is done.
+func (bm *balanceMonitor) observe(ctx context.Context, tick time.Duration) {
+	bm.check(ctx)
+
+	go func() {
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				bm.check(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// check fetches the current balance, caches it and logs a warning if the
+// balance is below the alert threshold.
+func (bm *balanceMonitor) check(ctx context.Context) {
+	balance, err := bm.balanceSource(ctx, bm.address)
+	if err != nil {
+		logger.Errorf(
+			"cannot get balance of account [%s]: [%v]",
+			bm.address.Hex(),
+			err,
+		)
+		return
+	}
+
+	bm.lastBalanceMutex.Lock()
+	bm.lastBalance = balance
+	bm.lastBalanceMutex.Unlock()
+
+	if bm.alertThreshold != nil && balance.Cmp(bm.alertThreshold) == -1 {
+		logger.Warnf(
+			"balance of account [%s] is [%v] wei which is below the "+
+				"alert threshold of [%v] wei; please top up the account "+
+				"to make sure the client can submit transactions",
+			bm.address.Hex(),
+			balance,
+			bm.alertThreshold,
+		)
+	}
+}
+
+// balance returns the most recently observed balance, in wei.
+func (bm *balanceMonitor) balance() (*big.Int, error) {
+	bm.lastBalanceMutex.RLock()
+	defer bm.lastBalanceMutex.RUnlock()
+
+	if bm.lastBalance == nil {
+		return nil, fmt.Errorf(
+			"balance of account [%s] not observed yet",
+			bm.address.Hex(),
+		)
+	}
+
+	return new(big.Int).Set(bm.lastBalance), nil
+}
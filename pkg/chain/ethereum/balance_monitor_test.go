@@ -0,0 +1,65 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+func TestBalanceMonitor_Balance(t *testing.T) {
+	address := common.HexToAddress("0x3712C6fED51CECA83cA953f6FF3458f2339436b4")
+
+	var sourceBalance *big.Int
+	var sourceErr error
+
+	monitor := newBalanceMonitor(
+		func(ctx context.Context, a common.Address) (*big.Int, error) {
+			if a != address {
+				return nil, fmt.Errorf("unexpected address [%s]", a.Hex())
+			}
+			return sourceBalance, sourceErr
+		},
+		address,
+		big.NewInt(100),
+	)
+
+	_, err := monitor.balance()
+	if err == nil {
+		t.Fatal("expected error for balance not observed yet")
+	}
+
+	sourceBalance = big.NewInt(50)
+	monitor.check(context.Background())
+
+	balance, err := monitor.balance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBigIntsEqual(t, "balance", big.NewInt(50), balance)
+
+	// A failed check must not override the last observed balance.
+	sourceBalance = nil
+	sourceErr = fmt.Errorf("connection refused")
+	monitor.check(context.Background())
+
+	balance, err = monitor.balance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBigIntsEqual(t, "balance", big.NewInt(50), balance)
+
+	sourceBalance = big.NewInt(150)
+	sourceErr = nil
+	monitor.check(context.Background())
+
+	balance, err = monitor.balance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBigIntsEqual(t, "balance", big.NewInt(150), balance)
+}
@@ -1,17 +1,23 @@
 package ethereum
 
 import (
+	"encoding/hex"
 	"fmt"
 	"math/big"
+	"sort"
+	"strings"
+	"sync"
 
-	"github.com/ethereum/go-ethereum/crypto"
 	beaconchain "github.com/keep-network/keep-core/pkg/beacon/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/event"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
 	"github.com/keep-network/keep-core/pkg/subscription"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/chain"
+	beaconabi "github.com/keep-network/keep-core/pkg/chain/ethereum/beacon/gen/abi"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum/beacon/gen/contract"
 	"github.com/keep-network/keep-core/pkg/operator"
 )
@@ -21,7 +27,11 @@ const (
 	RandomBeaconContractName = "RandomBeacon"
 )
 
-var errNotImplemented = fmt.Errorf("not implemented")
+// unjustifiedChallengeReason is the revert reason used by the RandomBeacon
+// contract when a challenged DKG result turns out to be valid.
+const unjustifiedChallengeReason = "unjustified challenge"
+
+var errNoRelayEntryInProgress = fmt.Errorf("there is no relay entry in progress")
 
 // BeaconChain represents a beacon-specific chain handle.
 type BeaconChain struct {
@@ -29,6 +39,21 @@ type BeaconChain struct {
 
 	randomBeacon  *contract.RandomBeacon
 	sortitionPool *contract.BeaconSortitionPool
+
+	relayEntrySoftTimeout uint64
+	relayEntryHardTimeout uint64
+	groupLifetime         uint64
+
+	groupsMembersMutex sync.Mutex
+	groupsMembers      map[uint64]chain.OperatorIDs
+
+	// key is the group public key in hex; value is the block at which the
+	// group was first seen as nonexistent on-chain
+	nonexistentGroupsMutex sync.Mutex
+	nonexistentGroups      map[string]uint64
+
+	currentRelayRequestMutex sync.Mutex
+	currentRelayRequest      *relayRequest
 }
 
 // newBeaconChain construct a new instance of the beacon-specific Ethereum
@@ -90,26 +115,153 @@ func newBeaconChain(
 		)
 	}
 
-	return &BeaconChain{
-		baseChain:     baseChain,
-		randomBeacon:  randomBeacon,
-		sortitionPool: sortitionPool,
-	}, nil
+	relayEntryParameters, err := randomBeacon.RelayEntryParameters()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get relay entry parameters: [%v]",
+			err,
+		)
+	}
+
+	groupCreationParameters, err := randomBeacon.GroupCreationParameters()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get group creation parameters: [%v]",
+			err,
+		)
+	}
+
+	beaconChain := &BeaconChain{
+		baseChain:             baseChain,
+		randomBeacon:          randomBeacon,
+		sortitionPool:         sortitionPool,
+		relayEntrySoftTimeout: relayEntryParameters.RelayEntrySoftTimeout.Uint64(),
+		relayEntryHardTimeout: relayEntryParameters.RelayEntryHardTimeout.Uint64(),
+		groupLifetime:         groupCreationParameters.GroupLifetime.Uint64(),
+		groupsMembers:         make(map[uint64]chain.OperatorIDs),
+		nonexistentGroups:     make(map[string]uint64),
+	}
+
+	_ = randomBeacon.RelayEntryRequestedEvent(nil, nil).OnEvent(
+		func(
+			requestID *big.Int,
+			groupID uint64,
+			previousEntry []byte,
+			blockNumber uint64,
+		) {
+			beaconChain.cacheRelayRequest(&relayRequest{
+				requestID:     requestID,
+				groupID:       groupID,
+				previousEntry: previousEntry,
+				blockNumber:   blockNumber,
+			})
+		},
+	)
+
+	err = beaconChain.registerObsolescenceChecks(randomBeaconAddress)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to register transactions obsolescence checks: [%v]",
+			err,
+		)
+	}
+
+	return beaconChain, nil
+}
+
+// registerObsolescenceChecks makes the transaction manager cancel pending
+// DKG result submissions, challenges and approvals once the DKG is no longer
+// in the state they were submitted in, or once another result is submitted
+// in case of challenges and approvals, as well as pending relay entry
+// submissions once the relay entry is no longer in progress.
+func (bc *BeaconChain) registerObsolescenceChecks(
+	randomBeaconAddress common.Address,
+) error {
+	randomBeaconAbi, err := beaconabi.RandomBeaconMetaData.GetAbi()
+	if err != nil {
+		return fmt.Errorf("cannot get RandomBeacon ABI: [%v]", err)
+	}
+
+	latestResultHash := &submittedResultHash{}
+	_ = bc.randomBeacon.DkgResultSubmittedEvent(nil, nil, nil).OnEvent(
+		func(
+			resultHash [32]byte,
+			seed *big.Int,
+			result beaconabi.BeaconDkgResult,
+			blockNumber uint64,
+		) {
+			latestResultHash.update(resultHash, blockNumber)
+		},
+	)
+
+	obsoleteUnless := func(
+		expectedState beaconchain.DKGState,
+		sameResult bool,
+	) obsolescenceCheck {
+		return func(transaction *types.Transaction) (bool, error) {
+			state, err := bc.GetDKGState()
+			if err != nil {
+				return false, err
+			}
+
+			if state != expectedState {
+				return true, nil
+			}
+
+			// Challenges and approvals refer to the submitted result and
+			// are obsolete once another result is submitted.
+			if hash, ok := latestResultHash.get(); sameResult && ok {
+				return dkgResultHash(transaction) != hash, nil
+			}
+
+			return false, nil
+		}
+	}
+
+	for methodName, check := range map[string]obsolescenceCheck{
+		"submitDkgResult":    obsoleteUnless(beaconchain.AwaitingResult, false),
+		"challengeDkgResult": obsoleteUnless(beaconchain.Challenge, true),
+		"approveDkgResult":   obsoleteUnless(beaconchain.Challenge, true),
+	} {
+		err := bc.transactionManager.registerObsolescenceCheck(
+			randomBeaconAddress,
+			randomBeaconAbi,
+			methodName,
+			check,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return bc.transactionManager.registerObsolescenceCheck(
+		randomBeaconAddress,
+		randomBeaconAbi,
+		"submitRelayEntry",
+		func(transaction *types.Transaction) (bool, error) {
+			inProgress, err := bc.IsEntryInProgress()
+			if err != nil {
+				return false, err
+			}
+
+			return !inProgress, nil
+		},
+	)
 }
 
 // GetConfig returns the expected configuration of the random beacon.
-// TODO: Adjust to the random beacon v2 requirements.
+// Relay entry timeouts are taken from the RandomBeacon contract parameters
+// read when the chain handle was created.
 func (bc *BeaconChain) GetConfig() *beaconchain.Config {
 	groupSize := 64
 	honestThreshold := 33
 	resultPublicationBlockStep := 1
-	relayEntryTimeout := groupSize * resultPublicationBlockStep
 
 	return &beaconchain.Config{
 		GroupSize:                  groupSize,
 		HonestThreshold:            honestThreshold,
 		ResultPublicationBlockStep: uint64(resultPublicationBlockStep),
-		RelayEntryTimeout:          uint64(relayEntryTimeout),
+		RelayEntryTimeout:          bc.relayEntrySoftTimeout + bc.relayEntryHardTimeout,
 	}
 }
 
@@ -243,16 +395,13 @@ func (bc *BeaconChain) GetOperatorID(
 	)
 }
 
-// SelectGroup returns the group members for the group generated by
-// the given seed. This function can return an error if the beacon chain's
-// state does not allow for group selection at the moment.
-func (bc *BeaconChain) SelectGroup(seed *big.Int) (chain.Addresses, error) {
-	groupSize := big.NewInt(int64(bc.GetConfig().GroupSize))
-	seedBytes := [32]byte{}
-	seed.FillBytes(seedBytes[:])
-
-	// TODO: Replace with a call to the RandomBeacon.selectGroup function.
-	operatorsIDs, err := bc.sortitionPool.SelectGroup(groupSize, seedBytes)
+// SelectGroup returns the group members selected for the current group
+// selection. The group is selected by the RandomBeacon contract using the
+// seed of the DKG currently awaiting the result. This function can return an
+// error if the beacon chain's state does not allow for group selection at the
+// moment.
+func (bc *BeaconChain) SelectGroup() (chain.Addresses, error) {
+	operatorsIDs, err := bc.randomBeacon.SelectGroup()
 	if err != nil {
 		return nil, fmt.Errorf(
 			"cannot select group in the sortition pool: [%v]",
@@ -276,65 +425,578 @@ func (bc *BeaconChain) SelectGroup(seed *big.Int) (chain.Addresses, error) {
 	return result, nil
 }
 
-// TODO: Implement a real OnGroupRegistered function.
+// OnGroupRegistered registers a callback that is invoked when an on-chain
+// notification of a new, valid group being registered is seen. The
+// GroupRegistered event carries only the hash of the group public key so
+// the public key itself is fetched from the RandomBeacon contract.
 func (bc *BeaconChain) OnGroupRegistered(
 	handler func(groupRegistration *event.GroupRegistration),
 ) subscription.EventSubscription {
-	return subscription.NewEventSubscription(func() {})
+	onEvent := func(
+		groupID uint64,
+		groupPublicKeyHash common.Hash,
+		blockNumber uint64,
+	) {
+		group, err := bc.randomBeacon.GetGroup(groupID)
+		if err != nil {
+			logger.Errorf(
+				"cannot get group [%v] registered at block [%v]: [%v]",
+				groupID,
+				blockNumber,
+				err,
+			)
+			return
+		}
+
+		handler(&event.GroupRegistration{
+			GroupID:        groupID,
+			GroupPublicKey: group.GroupPubKey,
+			BlockNumber:    blockNumber,
+		})
+	}
+
+	return bc.randomBeacon.GroupRegisteredEvent(nil, nil, nil).OnEvent(onEvent)
 }
 
-// TODO: Implement a real IsGroupRegistered function.
+// IsGroupRegistered checks if group with the given public key is registered
+// on-chain.
 func (bc *BeaconChain) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
-	return false, errNotImplemented
+	group, err := bc.randomBeacon.GetGroup0(groupPublicKey)
+	if err != nil {
+		return false, fmt.Errorf("cannot get group: [%v]", err)
+	}
+
+	// The contract returns an empty group for unknown public keys.
+	return group.RegistrationBlockNumber != nil &&
+		group.RegistrationBlockNumber.Sign() > 0, nil
+}
+
+// GetGroupState returns the state of a group with the given public key from
+// the group expiry standpoint. Group expires once its lifetime passes or if it
+// was terminated. An expired group may have been selected for a relay request
+// right before its expiration so the relay entry soft and hard timeouts must
+// elapse before the group is considered stale. Terminated groups are never
+// selected for new relay requests so they are stale right away. A group that
+// does not exist on-chain for longer than the DKG result challenge period,
+// counting from the moment it was first seen as nonexistent, is considered
+// stale as well as the DKG result creating it was not approved. The group
+// lifetime is taken from the RandomBeacon group creation parameters read when
+// the chain handle was created.
+func (bc *BeaconChain) GetGroupState(
+	groupPublicKey []byte,
+) (beaconchain.GroupState, error) {
+	group, err := bc.randomBeacon.GetGroup0(groupPublicKey)
+	if err != nil {
+		return beaconchain.GroupActive, fmt.Errorf(
+			"cannot get group: [%v]",
+			err,
+		)
+	}
+
+	currentBlock, err := bc.blockCounter.CurrentBlock()
+	if err != nil {
+		return beaconchain.GroupActive, fmt.Errorf(
+			"cannot get current block: [%v]",
+			err,
+		)
+	}
+
+	// The contract returns an empty group for unknown public keys.
+	if group.RegistrationBlockNumber == nil ||
+		group.RegistrationBlockNumber.Sign() == 0 {
+		return bc.nonexistentGroupState(groupPublicKey, currentBlock)
+	}
+
+	bc.nonexistentGroupsMutex.Lock()
+	delete(bc.nonexistentGroups, hex.EncodeToString(groupPublicKey))
+	bc.nonexistentGroupsMutex.Unlock()
+
+	if group.Terminated {
+		return beaconchain.GroupStale, nil
+	}
+
+	expirationBlock := group.RegistrationBlockNumber.Uint64() + bc.groupLifetime
+
+	staleBlock := expirationBlock +
+		bc.relayEntrySoftTimeout +
+		bc.relayEntryHardTimeout
+
+	switch {
+	case currentBlock > staleBlock:
+		return beaconchain.GroupStale, nil
+	case currentBlock > expirationBlock:
+		return beaconchain.GroupExpired, nil
+	default:
+		return beaconchain.GroupActive, nil
+	}
 }
 
-// TODO: Implement a real IsStaleGroup function.
-func (bc *BeaconChain) IsStaleGroup(groupPublicKey []byte) (bool, error) {
-	return false, nil
+// nonexistentGroupState returns the state of a group with the given public
+// key that does not exist on-chain. The group may not be registered yet if
+// the DKG result creating it is still being challenged. It is considered
+// stale once the DKG result challenge period passes since the group was first
+// seen as nonexistent.
+func (bc *BeaconChain) nonexistentGroupState(
+	groupPublicKey []byte,
+	currentBlock uint64,
+) (beaconchain.GroupState, error) {
+	bc.nonexistentGroupsMutex.Lock()
+	defer bc.nonexistentGroupsMutex.Unlock()
+
+	key := hex.EncodeToString(groupPublicKey)
+
+	firstSeenBlock, ok := bc.nonexistentGroups[key]
+	if !ok {
+		bc.nonexistentGroups[key] = currentBlock
+		return beaconchain.GroupActive, nil
+	}
+
+	parameters, err := bc.DKGParameters()
+	if err != nil {
+		return beaconchain.GroupActive, fmt.Errorf(
+			"cannot get DKG parameters: [%v]",
+			err,
+		)
+	}
+
+	if currentBlock > firstSeenBlock+parameters.ChallengePeriodBlocks {
+		delete(bc.nonexistentGroups, key)
+		return beaconchain.GroupStale, nil
+	}
+
+	return beaconchain.GroupActive, nil
 }
 
-// TODO: Implement a real OnDKGStarted event subscription. The current
-// implementation generates a fake event every 500th block where the
-// seed is the keccak256 of the block number.
+// OnDKGStarted registers a callback that is invoked when an on-chain
+// notification of the DKG process start is seen.
 func (bc *BeaconChain) OnDKGStarted(
 	handler func(event *event.DKGStarted),
 ) subscription.EventSubscription {
-	return subscription.NewEventSubscription(func() {})
+	onEvent := func(
+		seed *big.Int,
+		blockNumber uint64,
+	) {
+		handler(&event.DKGStarted{
+			Seed:        seed,
+			BlockNumber: blockNumber,
+		})
+	}
+
+	return bc.randomBeacon.DkgStartedEvent(nil, nil).OnEvent(onEvent)
 }
 
-// TODO: Implement a real SubmitDKGResult action. The current implementation
-// just creates and pipes the DKG submission event to the handlers
-// registered in the dkgResultSubmissionHandlers map.
+// SubmitDKGResult sends DKG result to a chain, along with signatures over
+// result hash from group participants supporting the result. The result is
+// assembled according to the rules of the RandomBeacon contract. The members
+// of the group are taken from the group selection of the DKG currently
+// awaiting the result.
 func (bc *BeaconChain) SubmitDKGResult(
 	participantIndex beaconchain.GroupMemberIndex,
 	dkgResult *beaconchain.DKGResult,
 	signatures map[beaconchain.GroupMemberIndex][]byte,
 ) error {
-	return errNotImplemented
+	operatorsIDs, err := bc.randomBeacon.SelectGroup()
+	if err != nil {
+		return fmt.Errorf("cannot select group: [%v]", err)
+	}
+
+	result, err := assembleBeaconDkgResult(
+		participantIndex,
+		dkgResult,
+		signatures,
+		operatorsIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot assemble DKG result: [%v]", err)
+	}
+
+	_, err = bc.randomBeacon.SubmitDkgResult(result)
+
+	return err
+}
+
+// assembleBeaconDkgResult converts the given DKG result and signatures
+// supporting it to the format applicable for the RandomBeacon ABI. The
+// operatorsIDs argument must hold identifiers of all operators selected
+// to the group, in the order of group members.
+func assembleBeaconDkgResult(
+	submitterMemberIndex beaconchain.GroupMemberIndex,
+	dkgResult *beaconchain.DKGResult,
+	signatures map[beaconchain.GroupMemberIndex][]byte,
+	operatorsIDs chain.OperatorIDs,
+) (beaconabi.BeaconDkgResult, error) {
+	// Sort misbehaved members indexes in ascending order as expected by
+	// the on-chain contract.
+	misbehavedMembersIndexes := make([]uint8, len(dkgResult.Misbehaved))
+	copy(misbehavedMembersIndexes, dkgResult.Misbehaved)
+	sort.Slice(misbehavedMembersIndexes, func(i, j int) bool {
+		return misbehavedMembersIndexes[i] < misbehavedMembersIndexes[j]
+	})
+
+	membersSignatures := make(map[group.MemberIndex][]byte, len(signatures))
+	for memberIndex, signature := range signatures {
+		membersSignatures[group.MemberIndex(memberIndex)] = signature
+	}
+
+	signingMembersIndexes, signaturesBytes, err := convertSignaturesToChainFormat(
+		membersSignatures,
+	)
+	if err != nil {
+		return beaconabi.BeaconDkgResult{}, fmt.Errorf(
+			"could not convert signatures to chain format: [%v]",
+			err,
+		)
+	}
+
+	signingMembersIndices := make([]*big.Int, len(signingMembersIndexes))
+	for i, memberIndex := range signingMembersIndexes {
+		signingMembersIndices[i] = big.NewInt(int64(memberIndex))
+	}
+
+	// The members hash is computed over the operators IDs of members which
+	// did not misbehave, in the order of group members.
+	operatingOperatorsIDs, err := operatingMembersIDs(
+		operatorsIDs,
+		misbehavedMembersIndexes,
+	)
+	if err != nil {
+		return beaconabi.BeaconDkgResult{}, err
+	}
+
+	membersHash, err := computeOperatorsIDsHash(operatingOperatorsIDs)
+	if err != nil {
+		return beaconabi.BeaconDkgResult{}, fmt.Errorf(
+			"could not compute members hash: [%v]",
+			err,
+		)
+	}
+
+	return beaconabi.BeaconDkgResult{
+		SubmitterMemberIndex:     big.NewInt(int64(submitterMemberIndex)),
+		GroupPubKey:              dkgResult.GroupPublicKey,
+		MisbehavedMembersIndices: misbehavedMembersIndexes,
+		Signatures:               signaturesBytes,
+		SigningMembersIndices:    signingMembersIndices,
+		Members:                  operatorsIDs,
+		MembersHash:              membersHash,
+	}, nil
+}
+
+// operatingMembersIDs returns identifiers of operators selected to the group
+// which were not marked as misbehaved, in the order of group members.
+// Misbehaved members indexes are 1-based positions in operatorsIDs.
+func operatingMembersIDs(
+	operatorsIDs chain.OperatorIDs,
+	misbehavedMembersIndexes []uint8,
+) (chain.OperatorIDs, error) {
+	misbehaved := make(map[uint8]bool, len(misbehavedMembersIndexes))
+	for _, memberIndex := range misbehavedMembersIndexes {
+		if memberIndex == 0 || int(memberIndex) > len(operatorsIDs) {
+			return nil, fmt.Errorf(
+				"invalid misbehaved member index: [%v]",
+				memberIndex,
+			)
+		}
+
+		misbehaved[memberIndex] = true
+	}
+
+	operatingOperatorsIDs := make(chain.OperatorIDs, 0, len(operatorsIDs))
+	for i, operatorID := range operatorsIDs {
+		if !misbehaved[uint8(i+1)] {
+			operatingOperatorsIDs = append(operatingOperatorsIDs, operatorID)
+		}
+	}
+
+	return operatingOperatorsIDs, nil
 }
 
-// TODO: Implement a real OnDKGResultSubmitted event subscription. The current
-// implementation just pipes the DKG submission event generated within
-// SubmitDKGResult to the handlers registered in the
-// dkgResultSubmissionHandlers map.
+// OnDKGResultSubmitted registers a callback that is invoked when an on-chain
+// notification of a new, valid submitted result is seen.
 func (bc *BeaconChain) OnDKGResultSubmitted(
 	handler func(event *event.DKGResultSubmission),
 ) subscription.EventSubscription {
-	return subscription.NewEventSubscription(func() {})
+	onEvent := func(
+		resultHash [32]byte,
+		seed *big.Int,
+		result beaconabi.BeaconDkgResult,
+		blockNumber uint64,
+	) {
+		dkgResultSubmission, err := convertBeaconDkgResultFromAbiType(result)
+		if err != nil {
+			logger.Errorf(
+				"unexpected DKG result in DkgResultSubmitted event: [%v]",
+				err,
+			)
+			return
+		}
+
+		dkgResultSubmission.Seed = seed
+		dkgResultSubmission.ResultHash = resultHash
+		dkgResultSubmission.BlockNumber = blockNumber
+
+		handler(dkgResultSubmission)
+	}
+
+	return bc.randomBeacon.
+		DkgResultSubmittedEvent(nil, nil, nil).
+		OnEvent(onEvent)
+}
+
+// convertBeaconDkgResultFromAbiType converts the RandomBeacon-specific DKG
+// result to the DKG result submission event applicable for the random
+// beacon application.
+func convertBeaconDkgResultFromAbiType(
+	result beaconabi.BeaconDkgResult,
+) (*event.DKGResultSubmission, error) {
+	if err := validateMemberIndex(result.SubmitterMemberIndex); err != nil {
+		return nil, fmt.Errorf(
+			"unexpected submitter member index: [%v]",
+			err,
+		)
+	}
+
+	signingMembersIndexes := make([]uint8, len(result.SigningMembersIndices))
+	for i, memberIndex := range result.SigningMembersIndices {
+		if err := validateMemberIndex(memberIndex); err != nil {
+			return nil, fmt.Errorf(
+				"unexpected signing member index: [%v]",
+				err,
+			)
+		}
+
+		signingMembersIndexes[i] = uint8(memberIndex.Uint64())
+	}
+
+	return &event.DKGResultSubmission{
+		MemberIndex:           uint32(result.SubmitterMemberIndex.Uint64()),
+		GroupPublicKey:        result.GroupPubKey,
+		Misbehaved:            result.MisbehavedMembersIndices,
+		Signatures:            result.Signatures,
+		SigningMembersIndexes: signingMembersIndexes,
+		Members:               result.Members,
+		MembersHash:           result.MembersHash,
+	}, nil
+}
+
+// convertBeaconDkgResultToAbiType converts the DKG result submission event
+// to the format applicable for the RandomBeacon ABI.
+func convertBeaconDkgResultToAbiType(
+	result *event.DKGResultSubmission,
+) beaconabi.BeaconDkgResult {
+	signingMembersIndices := make([]*big.Int, len(result.SigningMembersIndexes))
+	for i, memberIndex := range result.SigningMembersIndexes {
+		signingMembersIndices[i] = big.NewInt(int64(memberIndex))
+	}
+
+	return beaconabi.BeaconDkgResult{
+		SubmitterMemberIndex:     big.NewInt(int64(result.MemberIndex)),
+		GroupPubKey:              result.GroupPublicKey,
+		MisbehavedMembersIndices: result.Misbehaved,
+		Signatures:               result.Signatures,
+		SigningMembersIndices:    signingMembersIndices,
+		Members:                  result.Members,
+		MembersHash:              result.MembersHash,
+	}
+}
+
+// OnDKGResultChallenged registers a callback that is invoked when an on-chain
+// notification of the DKG result challenge is seen.
+func (bc *BeaconChain) OnDKGResultChallenged(
+	handler func(event *event.DKGResultChallenged),
+) subscription.EventSubscription {
+	onEvent := func(
+		resultHash [32]byte,
+		challenger common.Address,
+		reason string,
+		blockNumber uint64,
+	) {
+		handler(&event.DKGResultChallenged{
+			ResultHash:  resultHash,
+			Challenger:  challenger.Hex(),
+			Reason:      reason,
+			BlockNumber: blockNumber,
+		})
+	}
+
+	return bc.randomBeacon.
+		DkgResultChallengedEvent(nil, nil, nil).
+		OnEvent(onEvent)
+}
+
+// OnDKGResultApproved registers a callback that is invoked when an on-chain
+// notification of the DKG result approval is seen.
+func (bc *BeaconChain) OnDKGResultApproved(
+	handler func(event *event.DKGResultApproved),
+) subscription.EventSubscription {
+	onEvent := func(
+		resultHash [32]byte,
+		approver common.Address,
+		blockNumber uint64,
+	) {
+		handler(&event.DKGResultApproved{
+			ResultHash:  resultHash,
+			Approver:    approver.Hex(),
+			BlockNumber: blockNumber,
+		})
+	}
+
+	return bc.randomBeacon.
+		DkgResultApprovedEvent(nil, nil, nil).
+		OnEvent(onEvent)
+}
+
+// GetDKGState returns the current state of the DKG procedure.
+func (bc *BeaconChain) GetDKGState() (beaconchain.DKGState, error) {
+	groupCreationState, err := bc.randomBeacon.GetGroupCreationState()
+	if err != nil {
+		return 0, err
+	}
+
+	var state beaconchain.DKGState
+
+	switch groupCreationState {
+	case 0:
+		state = beaconchain.Idle
+	case 1:
+		state = beaconchain.AwaitingSeed
+	case 2:
+		state = beaconchain.AwaitingResult
+	case 3:
+		state = beaconchain.Challenge
+	default:
+		err = fmt.Errorf(
+			"unexpected group creation state: [%v]",
+			groupCreationState,
+		)
+	}
+
+	return state, err
+}
+
+// IsDKGResultValid checks whether the submitted DKG result is valid from
+// the on-chain contract standpoint. The RandomBeacon contract does not expose
+// a result validation function so the validity is determined by simulating
+// a challenge of the result. The contract rejects challenges of valid results
+// with the unjustifiedChallengeReason revert reason.
+func (bc *BeaconChain) IsDKGResultValid(
+	dkgResult *event.DKGResultSubmission,
+) (bool, error) {
+	err := bc.randomBeacon.CallChallengeDkgResult(
+		convertBeaconDkgResultToAbiType(dkgResult),
+		nil,
+	)
+	if err == nil {
+		// The challenge would succeed so the result is invalid.
+		return false, nil
+	}
+
+	if strings.Contains(err.Error(), unjustifiedChallengeReason) {
+		return true, nil
+	}
+
+	return false, fmt.Errorf("cannot check result validity: [%v]", err)
+}
+
+// ChallengeDKGResult challenges the submitted DKG result.
+func (bc *BeaconChain) ChallengeDKGResult(
+	dkgResult *event.DKGResultSubmission,
+) error {
+	_, err := bc.randomBeacon.ChallengeDkgResult(
+		convertBeaconDkgResultToAbiType(dkgResult),
+	)
+
+	return err
 }
 
-// CalculateDKGResultHash calculates Keccak-256 hash of the DKG result. Operation
-// is performed off-chain.
+// ApproveDKGResult approves the submitted DKG result.
+func (bc *BeaconChain) ApproveDKGResult(
+	dkgResult *event.DKGResultSubmission,
+) error {
+	_, err := bc.randomBeacon.ApproveDkgResult(
+		convertBeaconDkgResultToAbiType(dkgResult),
+	)
+
+	return err
+}
+
+// DKGParameters gets the current value of DKG-specific control parameters.
+func (bc *BeaconChain) DKGParameters() (*beaconchain.DKGParameters, error) {
+	parameters, err := bc.randomBeacon.GroupCreationParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	return &beaconchain.DKGParameters{
+		SubmissionTimeoutBlocks:       parameters.DkgResultSubmissionTimeout.Uint64(),
+		ChallengePeriodBlocks:         parameters.DkgResultChallengePeriodLength.Uint64(),
+		ApprovePrecedencePeriodBlocks: parameters.DkgSubmitterPrecedencePeriodLength.Uint64(),
+	}, nil
+}
+
+// CalculateDKGResultHash calculates Keccak-256 hash of the DKG result signed
+// by the group members supporting the result. Operation is performed
+// off-chain.
 //
-// It first encodes the result using solidity ABI and then calculates Keccak-256
-// hash over it. This corresponds to the DKG result hash calculation on-chain.
-// Hashes calculated off-chain and on-chain must always match.
+// It first encodes the chain ID, the group public key, misbehaved members
+// indexes, and the DKG start block using solidity ABI and then calculates
+// Keccak-256 hash over it. This corresponds to the DKG result hash
+// calculation on-chain. Hashes calculated off-chain and on-chain must always
+// match.
 func (bc *BeaconChain) CalculateDKGResultHash(
 	dkgResult *beaconchain.DKGResult,
+	startBlock uint64,
 ) (beaconchain.DKGResultHash, error) {
-	// Encode DKG result to the format matched with Solidity keccak256(abi.encodePacked(...))
-	hash := crypto.Keccak256(dkgResult.GroupPublicKey, dkgResult.Misbehaved)
-	return beaconchain.DKGResultHashFromBytes(hash)
+	// Sort misbehaved members indexes in ascending order as expected by
+	// the on-chain contract.
+	misbehavedMembersIndexes := make([]uint8, len(dkgResult.Misbehaved))
+	copy(misbehavedMembersIndexes, dkgResult.Misbehaved)
+	sort.Slice(misbehavedMembersIndexes, func(i, j int) bool {
+		return misbehavedMembersIndexes[i] < misbehavedMembersIndexes[j]
+	})
+
+	return calculateBeaconDKGResultHash(
+		bc.chainID,
+		dkgResult.GroupPublicKey,
+		misbehavedMembersIndexes,
+		big.NewInt(int64(startBlock)),
+	)
+}
+
+// calculateBeaconDKGResultHash computes the keccak256 hash for the given
+// random beacon DKG result parameters. It expects that the groupPublicKey is
+// a 128-byte serialized G2 point and misbehavedMembersIndexes slice is sorted
+// in ascending order. Those expectations are forced by the contract.
+func calculateBeaconDKGResultHash(
+	chainID *big.Int,
+	groupPublicKey []byte,
+	misbehavedMembersIndexes []uint8,
+	startBlock *big.Int,
+) (beaconchain.DKGResultHash, error) {
+	publicKeySize := 128
+
+	if len(groupPublicKey) != publicKeySize {
+		return beaconchain.DKGResultHash{}, fmt.Errorf(
+			"wrong group public key length",
+		)
+	}
+
+	membersIndexes := make([]group.MemberIndex, len(misbehavedMembersIndexes))
+	for i, memberIndex := range misbehavedMembersIndexes {
+		membersIndexes[i] = group.MemberIndex(memberIndex)
+	}
+
+	hash, err := computeDKGResultSignatureHash(
+		chainID,
+		groupPublicKey,
+		membersIndexes,
+		startBlock,
+	)
+	if err != nil {
+		return beaconchain.DKGResultHash{}, err
+	}
+
+	return beaconchain.DKGResultHash(hash), nil
 }
 
 // IsRecognized checks whether the given operator is recognized by the BeaconChain
@@ -384,48 +1046,381 @@ func (bc *BeaconChain) IsRecognized(operatorPublicKey *operator.PublicKey) (bool
 	return true, nil
 }
 
-// TODO: Implement a real SubmitRelayEntry function.
+// SubmitRelayEntry submits a newly created relay entry for the current relay
+// request to the chain. Before the relay entry soft timeout, the entry is
+// submitted using the cheaper contract function that does not need the group
+// members. Once the soft timeout is exceeded, the operating members of the
+// group are passed along so the contract can slash them for the delay.
 func (bc *BeaconChain) SubmitRelayEntry(
 	entry []byte,
 ) error {
-	return errNotImplemented
+	request, err := bc.currentRequest()
+	if err != nil {
+		return fmt.Errorf("cannot get current request: [%v]", err)
+	}
+
+	if request == nil {
+		return errNoRelayEntryInProgress
+	}
+
+	currentBlock, err := bc.blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	if currentBlock < request.blockNumber+bc.relayEntrySoftTimeout {
+		_, err = bc.randomBeacon.SubmitRelayEntry0(entry)
+		return err
+	}
+
+	groupMembers, err := bc.groupMembers(request.groupID)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot get members of group [%v]: [%v]",
+			request.groupID,
+			err,
+		)
+	}
+
+	_, err = bc.randomBeacon.SubmitRelayEntry(entry, groupMembers)
+
+	return err
 }
 
-// TODO: Implement a real OnRelayEntrySubmitted function.
+// OnRelayEntrySubmitted registers a callback that is invoked when an on-chain
+// notification of a new, valid relay entry is seen.
 func (bc *BeaconChain) OnRelayEntrySubmitted(
 	handler func(entry *event.RelayEntrySubmitted),
 ) subscription.EventSubscription {
-	return subscription.NewEventSubscription(func() {})
+	onEvent := func(
+		requestID *big.Int,
+		submitter common.Address,
+		entry []byte,
+		blockNumber uint64,
+	) {
+		handler(&event.RelayEntrySubmitted{
+			RequestID:   requestID,
+			Entry:       entry,
+			BlockNumber: blockNumber,
+		})
+	}
+
+	return bc.randomBeacon.RelayEntrySubmittedEvent(nil, nil).OnEvent(onEvent)
 }
 
-// TODO: Implement a real OnRelayEntryRequested function.
+// OnRelayEntryRequested registers a callback that is invoked when an on-chain
+// notification of a new, valid relay request is seen. The RelayEntryRequested
+// event carries only the ID of the group selected to sign the previous entry
+// so the group public key is fetched from the RandomBeacon contract.
 func (bc *BeaconChain) OnRelayEntryRequested(
 	handler func(request *event.RelayEntryRequested),
 ) subscription.EventSubscription {
-	return subscription.NewEventSubscription(func() {})
+	onEvent := func(
+		requestID *big.Int,
+		groupID uint64,
+		previousEntry []byte,
+		blockNumber uint64,
+	) {
+		group, err := bc.randomBeacon.GetGroup(groupID)
+		if err != nil {
+			logger.Errorf(
+				"cannot get group [%v] selected for relay request [%v] "+
+					"at block [%v]: [%v]",
+				groupID,
+				requestID,
+				blockNumber,
+				err,
+			)
+			return
+		}
+
+		handler(&event.RelayEntryRequested{
+			RequestID:      requestID,
+			GroupID:        groupID,
+			PreviousEntry:  previousEntry,
+			GroupPublicKey: group.GroupPubKey,
+			BlockNumber:    blockNumber,
+		})
+	}
+
+	return bc.randomBeacon.RelayEntryRequestedEvent(nil, nil).OnEvent(onEvent)
 }
 
-// TODO: Implement a real ReportRelayEntryTimeout function.
+// ReportRelayEntryTimeout notifies the chain when a selected group which was
+// supposed to submit a relay entry, did not deliver it within the relay entry
+// hard timeout. The operating members of the group are passed along so the
+// contract can slash them.
 func (bc *BeaconChain) ReportRelayEntryTimeout() error {
-	return errNotImplemented
+	request, err := bc.currentRequest()
+	if err != nil {
+		return fmt.Errorf("cannot get current request: [%v]", err)
+	}
+
+	if request == nil {
+		return errNoRelayEntryInProgress
+	}
+
+	groupMembers, err := bc.groupMembers(request.groupID)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot get members of group [%v]: [%v]",
+			request.groupID,
+			err,
+		)
+	}
+
+	_, err = bc.randomBeacon.ReportRelayEntryTimeout(groupMembers)
+
+	return err
 }
 
-// TODO: Implement a real IsEntryInProgress function.
+// IsEntryInProgress checks if a new relay entry is currently in progress.
 func (bc *BeaconChain) IsEntryInProgress() (bool, error) {
-	return false, nil // no chain integration so not in progress
+	return bc.randomBeacon.IsRelayRequestInProgress()
 }
 
-// TODO: Implement a real CurrentRequestStartBlock function.
+// CurrentRequestStartBlock returns a start block of a current entry.
+// Returns zero if there is no relay entry in progress.
 func (bc *BeaconChain) CurrentRequestStartBlock() (*big.Int, error) {
-	return nil, errNotImplemented
+	request, err := bc.currentRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	if request == nil {
+		return big.NewInt(0), nil
+	}
+
+	return new(big.Int).SetUint64(request.blockNumber), nil
 }
 
-// TODO: Implement a real CurrentRequestPreviousEntry function.
+// CurrentRequestPreviousEntry returns previous entry of a current request.
 func (bc *BeaconChain) CurrentRequestPreviousEntry() ([]byte, error) {
-	return nil, errNotImplemented
+	request, err := bc.currentRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	if request == nil {
+		return nil, errNoRelayEntryInProgress
+	}
+
+	return request.previousEntry, nil
 }
 
-// TODO: Implement a real CurrentRequestGroupPublicKey function.
+// CurrentRequestGroupPublicKey returns group public key for the current request.
 func (bc *BeaconChain) CurrentRequestGroupPublicKey() ([]byte, error) {
-	return nil, errNotImplemented
+	request, err := bc.currentRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	if request == nil {
+		return nil, errNoRelayEntryInProgress
+	}
+
+	group, err := bc.randomBeacon.GetGroup(request.groupID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get group [%v]: [%v]",
+			request.groupID,
+			err,
+		)
+	}
+
+	return group.GroupPubKey, nil
+}
+
+// relayRequest holds the details of a relay request the RandomBeacon contract
+// does not expose on its own.
+type relayRequest struct {
+	requestID     *big.Int
+	groupID       uint64
+	previousEntry []byte
+	blockNumber   uint64
+}
+
+// cacheRelayRequest stores the given relay request as the current one unless
+// a more recent request is already cached.
+func (bc *BeaconChain) cacheRelayRequest(request *relayRequest) {
+	bc.currentRelayRequestMutex.Lock()
+	defer bc.currentRelayRequestMutex.Unlock()
+
+	if bc.currentRelayRequest != nil &&
+		bc.currentRelayRequest.requestID.Cmp(request.requestID) >= 0 {
+		return
+	}
+
+	bc.currentRelayRequest = request
+}
+
+// currentRequest returns the relay request currently in progress or nil if
+// there is no request in progress. The RandomBeacon contract does not expose
+// the current request so it is cached from the RelayEntryRequested events.
+// If the request was made before the events were observed, the request is
+// looked up in the past RelayEntryRequested events emitted during the last
+// two relay entry timeouts. This window is big enough to find the request
+// until its timeout is reported.
+func (bc *BeaconChain) currentRequest() (*relayRequest, error) {
+	inProgress, err := bc.IsEntryInProgress()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot check if relay entry is in progress: [%v]",
+			err,
+		)
+	}
+
+	if !inProgress {
+		return nil, nil
+	}
+
+	bc.currentRelayRequestMutex.Lock()
+	request := bc.currentRelayRequest
+	bc.currentRelayRequestMutex.Unlock()
+
+	if request != nil {
+		return request, nil
+	}
+
+	currentBlock, err := bc.blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	lookbackBlocks := 2 * (bc.relayEntrySoftTimeout + bc.relayEntryHardTimeout)
+
+	startBlock := uint64(0)
+	if currentBlock > lookbackBlocks {
+		startBlock = currentBlock - lookbackBlocks
+	}
+
+	events, err := bc.randomBeacon.PastRelayEntryRequestedEvents(
+		startBlock,
+		&currentBlock,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get past relay entry requested events: [%v]",
+			err,
+		)
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf(
+			"cannot find relay entry requested event for the relay entry "+
+				"in progress since block [%v]",
+			startBlock,
+		)
+	}
+
+	for _, event := range events {
+		bc.cacheRelayRequest(&relayRequest{
+			requestID:     event.RequestId,
+			groupID:       event.GroupId,
+			previousEntry: event.PreviousEntry,
+			blockNumber:   event.Raw.BlockNumber,
+		})
+	}
+
+	bc.currentRelayRequestMutex.Lock()
+	defer bc.currentRelayRequestMutex.Unlock()
+
+	return bc.currentRelayRequest, nil
+}
+
+// groupMembers returns identifiers of the operating members of the group with
+// the given ID, in the order of group members. The RandomBeacon contract
+// stores only the hash of the members so they are recovered from the DKG
+// result approved for the group. The returned members are verified against
+// the hash stored on-chain. Results are cached as group members never change.
+func (bc *BeaconChain) groupMembers(groupID uint64) (chain.OperatorIDs, error) {
+	bc.groupsMembersMutex.Lock()
+	defer bc.groupsMembersMutex.Unlock()
+
+	if members, ok := bc.groupsMembers[groupID]; ok {
+		return members, nil
+	}
+
+	group, err := bc.randomBeacon.GetGroup(groupID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get group: [%v]", err)
+	}
+
+	// The group is registered in the same transaction the DKG result
+	// creating it is approved in.
+	registrationBlock := group.RegistrationBlockNumber.Uint64()
+
+	approvedEvents, err := bc.randomBeacon.PastDkgResultApprovedEvents(
+		registrationBlock,
+		&registrationBlock,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get past DKG result approved events: [%v]",
+			err,
+		)
+	}
+
+	resultHashes := make([][32]byte, len(approvedEvents))
+	for i, approvedEvent := range approvedEvents {
+		resultHashes[i] = approvedEvent.ResultHash
+	}
+
+	if len(resultHashes) == 0 {
+		return nil, fmt.Errorf(
+			"cannot find DKG result approved at block [%v]",
+			registrationBlock,
+		)
+	}
+
+	submittedEvents, err := bc.randomBeacon.PastDkgResultSubmittedEvents(
+		0,
+		&registrationBlock,
+		resultHashes,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get past DKG result submitted events: [%v]",
+			err,
+		)
+	}
+
+	for _, submittedEvent := range submittedEvents {
+		members, err := operatingMembersIDs(
+			submittedEvent.Result.Members,
+			submittedEvent.Result.MisbehavedMembersIndices,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot get operating members of DKG result [0x%x]: [%v]",
+				submittedEvent.ResultHash,
+				err,
+			)
+		}
+
+		membersHash, err := computeOperatorsIDsHash(members)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot compute members hash: [%v]",
+				err,
+			)
+		}
+
+		if membersHash != group.MembersHash {
+			continue
+		}
+
+		bc.groupsMembers[groupID] = members
+
+		return members, nil
+	}
+
+	return nil, fmt.Errorf(
+		"cannot find DKG result matching members hash [0x%x]",
+		group.MembersHash,
+	)
 }
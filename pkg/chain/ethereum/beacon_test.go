@@ -0,0 +1,380 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	beaconchain "github.com/keep-network/keep-core/pkg/beacon/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+func TestAssembleBeaconDkgResult(t *testing.T) {
+	signature := func(b byte) []byte {
+		signature := make([]byte, 65)
+		signature[0] = b
+		return signature
+	}
+
+	groupPublicKey := []byte{1, 2, 3}
+	operatorsIDs := chain.OperatorIDs{11, 12, 13, 14, 15}
+
+	var tests = map[string]struct {
+		misbehaved            []byte
+		signatures            map[beaconchain.GroupMemberIndex][]byte
+		expectedMisbehaved    []uint8
+		expectedSigningIndex  []int64
+		expectedSignatures    []byte
+		expectedOperatingIDs  chain.OperatorIDs
+		expectedErrorContains string
+	}{
+		"no misbehaved members": {
+			misbehaved: []byte{},
+			signatures: map[beaconchain.GroupMemberIndex][]byte{
+				3: signature(3),
+				1: signature(1),
+			},
+			expectedMisbehaved:   []uint8{},
+			expectedSigningIndex: []int64{1, 3},
+			expectedSignatures:   append(signature(1), signature(3)...),
+			expectedOperatingIDs: chain.OperatorIDs{11, 12, 13, 14, 15},
+		},
+		"unsorted misbehaved members": {
+			misbehaved: []byte{5, 2},
+			signatures: map[beaconchain.GroupMemberIndex][]byte{
+				4: signature(4),
+			},
+			expectedMisbehaved:   []uint8{2, 5},
+			expectedSigningIndex: []int64{4},
+			expectedSignatures:   signature(4),
+			expectedOperatingIDs: chain.OperatorIDs{11, 13, 14},
+		},
+		"misbehaved member out of group": {
+			misbehaved: []byte{6},
+			signatures: map[beaconchain.GroupMemberIndex][]byte{
+				1: signature(1),
+			},
+			expectedErrorContains: "invalid misbehaved member index: [6]",
+		},
+		"invalid signature": {
+			misbehaved: []byte{},
+			signatures: map[beaconchain.GroupMemberIndex][]byte{
+				1: {1},
+			},
+			expectedErrorContains: "could not convert signatures to chain format",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			result, err := assembleBeaconDkgResult(
+				2,
+				&beaconchain.DKGResult{
+					GroupPublicKey: groupPublicKey,
+					Misbehaved:     test.misbehaved,
+				},
+				test.signatures,
+				operatorsIDs,
+			)
+
+			if test.expectedErrorContains != "" {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if !strings.Contains(err.Error(), test.expectedErrorContains) {
+					t.Errorf(
+						"unexpected error\nexpected to contain: %v\nactual: %v",
+						test.expectedErrorContains,
+						err,
+					)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBigIntsEqual(
+				t,
+				"submitter member index",
+				big.NewInt(2),
+				result.SubmitterMemberIndex,
+			)
+			testutils.AssertBytesEqual(t, groupPublicKey, result.GroupPubKey)
+			testutils.AssertBytesEqual(
+				t,
+				test.expectedMisbehaved,
+				result.MisbehavedMembersIndices,
+			)
+			testutils.AssertBytesEqual(
+				t,
+				test.expectedSignatures,
+				result.Signatures,
+			)
+
+			signingMembersIndices := make([]int64, len(result.SigningMembersIndices))
+			for i, memberIndex := range result.SigningMembersIndices {
+				signingMembersIndices[i] = memberIndex.Int64()
+			}
+			if !reflect.DeepEqual(
+				test.expectedSigningIndex,
+				signingMembersIndices,
+			) {
+				t.Errorf(
+					"unexpected signing members indices\n"+
+						"expected: %v\nactual:   %v",
+					test.expectedSigningIndex,
+					signingMembersIndices,
+				)
+			}
+
+			if !reflect.DeepEqual([]uint32(operatorsIDs), result.Members) {
+				t.Errorf("unexpected members: %v", result.Members)
+			}
+
+			expectedMembersHash, err := computeOperatorsIDsHash(
+				test.expectedOperatingIDs,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutils.AssertBytesEqual(
+				t,
+				expectedMembersHash[:],
+				result.MembersHash[:],
+			)
+		})
+	}
+}
+
+func TestConvertBeaconDkgResultFromAbiType(t *testing.T) {
+	signature := make([]byte, 65)
+
+	result, err := assembleBeaconDkgResult(
+		3,
+		&beaconchain.DKGResult{
+			GroupPublicKey: []byte{1, 2, 3},
+			Misbehaved:     []byte{2},
+		},
+		map[beaconchain.GroupMemberIndex][]byte{
+			1: signature,
+			3: signature,
+		},
+		chain.OperatorIDs{11, 12, 13},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgResultSubmission, err := convertBeaconDkgResultFromAbiType(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDkgResultSubmission := &event.DKGResultSubmission{
+		MemberIndex:           3,
+		GroupPublicKey:        []byte{1, 2, 3},
+		Misbehaved:            []uint8{2},
+		Signatures:            append(signature, signature...),
+		SigningMembersIndexes: []uint8{1, 3},
+		Members:               []uint32{11, 12, 13},
+		MembersHash:           result.MembersHash,
+	}
+
+	if !reflect.DeepEqual(expectedDkgResultSubmission, dkgResultSubmission) {
+		t.Errorf(
+			"unexpected DKG result submission\nexpected: %+v\nactual:   %+v",
+			expectedDkgResultSubmission,
+			dkgResultSubmission,
+		)
+	}
+
+	result.SubmitterMemberIndex = big.NewInt(256)
+
+	_, err = convertBeaconDkgResultFromAbiType(result)
+
+	expectedError := fmt.Errorf(
+		"unexpected submitter member index: [invalid member index value: [256]]",
+	)
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestConvertBeaconDkgResultToAbiType(t *testing.T) {
+	signature := make([]byte, 65)
+
+	result, err := assembleBeaconDkgResult(
+		2,
+		&beaconchain.DKGResult{
+			GroupPublicKey: []byte{4, 5, 6},
+			Misbehaved:     []byte{3},
+		},
+		map[beaconchain.GroupMemberIndex][]byte{
+			1: signature,
+			2: signature,
+		},
+		chain.OperatorIDs{21, 22, 23},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgResultSubmission, err := convertBeaconDkgResultFromAbiType(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	convertedResult := convertBeaconDkgResultToAbiType(dkgResultSubmission)
+
+	if !reflect.DeepEqual(result, convertedResult) {
+		t.Errorf(
+			"unexpected DKG result\nexpected: %+v\nactual:   %+v",
+			result,
+			convertedResult,
+		)
+	}
+}
+
+func TestCalculateBeaconDKGResultHash(t *testing.T) {
+	chainID := big.NewInt(1)
+
+	groupPublicKey, err := hex.DecodeString(
+		"1f1954b33144db2b5c90da089e8bde287ec7089d5d6433f3b6becaefdb678b1b" +
+			"2a9de38d14bef2cf9afc3c698a4211fa7ada7b4f036a2dfef0dc122b423259d0" +
+			"15ec5d16f03d3d0cc1c3b1ff7d7b2f4c2ae2c0bc8bfb62d6f5c4cf8a1ed3ec5e" +
+			"0b56b1d69dbcd1fa4cbc12f84e4e2e0ce3d3b51d9bd9c1a1c7a3bb0c4eb5c1c7",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	misbehavedMembersIndexes := []uint8{2, 55}
+
+	startBlock := big.NewInt(2000)
+
+	hash, err := calculateBeaconDKGResultHash(
+		chainID,
+		groupPublicKey,
+		misbehavedMembersIndexes,
+		startBlock,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedHash := "4063f219b1bb776ef73a65da8cda6d1ace0cb8d10978bfd20a664c183fae7117"
+
+	testutils.AssertStringsEqual(
+		t,
+		"hash",
+		expectedHash,
+		hex.EncodeToString(hash[:]),
+	)
+
+	_, err = calculateBeaconDKGResultHash(
+		chainID,
+		groupPublicKey[:64],
+		misbehavedMembersIndexes,
+		startBlock,
+	)
+	expectedError := fmt.Errorf("wrong group public key length")
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestOperatingMembersIDs(t *testing.T) {
+	operatorsIDs := chain.OperatorIDs{11, 12, 13, 14, 15}
+
+	var tests = map[string]struct {
+		misbehaved            []uint8
+		expectedOperatingIDs  chain.OperatorIDs
+		expectedErrorContains string
+	}{
+		"no misbehaved members": {
+			misbehaved:           []uint8{},
+			expectedOperatingIDs: chain.OperatorIDs{11, 12, 13, 14, 15},
+		},
+		"first and last members misbehaved": {
+			misbehaved:           []uint8{1, 5},
+			expectedOperatingIDs: chain.OperatorIDs{12, 13, 14},
+		},
+		"misbehaved member index zero": {
+			misbehaved:            []uint8{0},
+			expectedErrorContains: "invalid misbehaved member index: [0]",
+		},
+		"misbehaved member out of group": {
+			misbehaved:            []uint8{6},
+			expectedErrorContains: "invalid misbehaved member index: [6]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			operatingIDs, err := operatingMembersIDs(
+				operatorsIDs,
+				test.misbehaved,
+			)
+
+			if test.expectedErrorContains != "" {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if !strings.Contains(err.Error(), test.expectedErrorContains) {
+					t.Errorf(
+						"unexpected error\nexpected to contain: %v\nactual: %v",
+						test.expectedErrorContains,
+						err,
+					)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedOperatingIDs, operatingIDs) {
+				t.Errorf(
+					"unexpected operating members\nexpected: %v\nactual:   %v",
+					test.expectedOperatingIDs,
+					operatingIDs,
+				)
+			}
+		})
+	}
+}
+
+func TestCacheRelayRequest(t *testing.T) {
+	bc := &BeaconChain{}
+
+	bc.cacheRelayRequest(&relayRequest{requestID: big.NewInt(7), groupID: 1})
+	bc.cacheRelayRequest(&relayRequest{requestID: big.NewInt(9), groupID: 3})
+	bc.cacheRelayRequest(&relayRequest{requestID: big.NewInt(8), groupID: 2})
+
+	testutils.AssertBigIntsEqual(
+		t,
+		"request ID",
+		big.NewInt(9),
+		bc.currentRelayRequest.requestID,
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"group ID",
+		3,
+		int(bc.currentRelayRequest.groupID),
+	)
+}
@@ -5,15 +5,18 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ipfs/go-log"
 
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-common/pkg/rate"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum/threshold/gen/contract"
@@ -31,14 +34,30 @@ var logger = log.Logger("keep-ethereum")
 // provides the implementation of generic features like balance monitor,
 // block counter and similar.
 type baseChain struct {
-	key     *keystore.Key
-	client  ethutil.EthereumClient
-	chainID *big.Int
+	// key is the key used by contract bindings to sign transactions. It is
+	// the operator key unless the operator key is held by an external signer.
+	key            *keystore.Key
+	operatorSigner operatorSigner
+	// operatorKey is the operator key decrypted from the key file. It is nil
+	// if the operator key is held by an external signer.
+	operatorKey *keystore.Key
+	client      ethutil.EthereumClient
+	chainID     *big.Int
 
 	blockCounter *ethereum.BlockCounter
 	nonceManager *ethereum.NonceManager
 	miningWaiter *ethutil.MiningWaiter
 
+	balanceMonitor *balanceMonitor
+
+	transactionManager *transactionManager
+
+	endpoints []*Endpoint
+
+	// averageBlockTime is the average block time of the chain, determined
+	// by the chain ID. Zero for the Ethereum chain.
+	averageBlockTime time.Duration
+
 	// transactionMutex allows interested parties to forcibly serialize
 	// transaction submission.
 	//
@@ -56,10 +75,19 @@ type baseChain struct {
 	tokenStaking *contract.TokenStaking
 }
 
-// Connect creates Random Beacon and TBTC Ethereum chain handles.
+// Connect creates Random Beacon and TBTC Ethereum chain handles. If the
+// signer config URL is set, transactions and messages are signed by the
+// external signer, the operator key file is not used and the returned operator
+// private key is nil. In that case, the network identity of the client must
+// use a separate key attested by the operator. If the TBTC chain
+// config is set, the TBTC chain handle is connected to the separate EVM chain
+// from that config. The returned block counter is the one of the Ethereum
+// chain in all cases.
 func Connect(
 	ctx context.Context,
 	config ethereum.Config,
+	signerConfig SignerConfig,
+	tbtcChainConfig TbtcChainConfig,
 ) (
 	*BeaconChain,
 	*TbtcChain,
@@ -68,16 +96,15 @@ func Connect(
 	*operator.PrivateKey,
 	error,
 ) {
-	client, err := ethclient.Dial(config.URL)
+	client, err := connectEndpoints(ctx, config)
 	if err != nil {
 		return nil, nil, nil, nil, nil, fmt.Errorf(
-			"error Connecting to Ethereum Server: %s [%v]",
-			config.URL,
+			"error connecting to Ethereum endpoints: [%v]",
 			err,
 		)
 	}
 
-	baseChain, err := newBaseChain(ctx, config, client)
+	baseChain, err := newBaseChain(ctx, config, signerConfig, client)
 	if err != nil {
 		return nil, nil, nil, nil, nil, fmt.Errorf(
 			"could not create base chain handle: [%v]",
@@ -93,7 +120,26 @@ func Connect(
 		)
 	}
 
-	tbtcChain, err := newTbtcChain(config, baseChain)
+	tbtcConfig := config
+	tbtcBaseChain := baseChain
+	if tbtcChainConfig.IsSet() {
+		tbtcConfig = tbtcChainConfig.ethereumConfig(config)
+
+		tbtcBaseChain, err = connectTbtcBaseChain(
+			ctx,
+			tbtcConfig,
+			tbtcChainConfig,
+			baseChain,
+		)
+		if err != nil {
+			return nil, nil, nil, nil, nil, fmt.Errorf(
+				"could not create TBTC base chain handle: [%v]",
+				err,
+			)
+		}
+	}
+
+	tbtcChain, err := newTbtcChain(tbtcConfig, tbtcBaseChain)
 	if err != nil {
 		return nil, nil, nil, nil, nil, fmt.Errorf(
 			"could not create TBTC chain handle: [%v]",
@@ -109,7 +155,12 @@ func Connect(
 		)
 	}
 
-	if err := validateContractsAddresses(config, beaconChain, tbtcChain); err != nil {
+	if err := validateContractsAddresses(
+		config,
+		tbtcConfig,
+		beaconChain,
+		tbtcChain,
+	); err != nil {
 		return nil, nil, nil, nil, nil, fmt.Errorf(
 			"contracts addresses validation failed: [%w]", err,
 		)
@@ -123,24 +174,26 @@ func Connect(
 		nil
 }
 
-// ConnectBitcoinDifficulty creates Bitcoin difficulty chain handle.
+// ConnectBitcoinDifficulty creates Bitcoin difficulty chain handle. If the
+// signer config URL is set, transactions are signed by the external signer
+// and the operator key file is not used.
 func ConnectBitcoinDifficulty(
 	ctx context.Context,
 	config ethereum.Config,
+	signerConfig SignerConfig,
 ) (
 	*BitcoinDifficultyChain,
 	error,
 ) {
-	client, err := ethclient.Dial(config.URL)
+	client, err := connectEndpoints(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf(
-			"error Connecting to Ethereum Server: %s [%v]",
-			config.URL,
+			"error connecting to Ethereum endpoints: [%v]",
 			err,
 		)
 	}
 
-	baseChain, err := newBaseChain(ctx, config, client)
+	baseChain, err := newBaseChain(ctx, config, signerConfig, client)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"could not create base chain handle: [%v]",
@@ -159,8 +212,11 @@ func ConnectBitcoinDifficulty(
 	return bitcoinDifficultyChain, nil
 }
 
+// validateContractsAddresses checks if the Random Beacon and TBTC
+// applications use the TokenStaking contracts from the respective configs.
 func validateContractsAddresses(
 	config ethereum.Config,
+	tbtcConfig ethereum.Config,
 	beaconChain *BeaconChain,
 	tbtcChain *TbtcChain,
 ) error {
@@ -178,6 +234,17 @@ func validateContractsAddresses(
 		return fmt.Errorf("failed to get staking address for beacon: [%w]", err)
 	}
 
+	tbtcBaseStakingAddress, err := tbtcConfig.ContractAddress(
+		TokenStakingContractName,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to get %s address from tbtc config: [%w]",
+			TokenStakingContractName,
+			err,
+		)
+	}
+
 	tbtcStakingAddress, err := tbtcChain.Staking()
 	if err != nil {
 		return fmt.Errorf("failed to get staking address for tbtc: [%w]", err)
@@ -193,11 +260,11 @@ func validateContractsAddresses(
 		))
 	}
 
-	if tbtcStakingAddress.String() != baseStakingAddress.String() {
+	if tbtcStakingAddress.String() != tbtcBaseStakingAddress.String() {
 		result = multierror.Append(result, fmt.Errorf(
 			"staking address for tbtc [%s] doesn't match the base token staking address: [%s]",
 			tbtcStakingAddress,
-			baseStakingAddress,
+			tbtcBaseStakingAddress,
 		))
 	}
 
@@ -208,7 +275,8 @@ func validateContractsAddresses(
 func newBaseChain(
 	ctx context.Context,
 	config ethereum.Config,
-	client *ethclient.Client,
+	signerConfig SignerConfig,
+	client *failoverClient,
 ) (*baseChain, error) {
 	chainID, err := client.ChainID(ctx)
 	if err != nil {
@@ -230,15 +298,91 @@ func newBaseChain(
 		)
 	}
 
-	key, err := decryptKey(config)
+	operatorSigner, operatorKey, err := resolveOperatorSigner(
+		config,
+		signerConfig,
+	)
 	if err != nil {
-		return nil, fmt.Errorf(
+		return nil, err
+	}
+
+	return attachBaseChain(
+		ctx,
+		config,
+		client,
+		chainID,
+		operatorSigner,
+		operatorKey,
+		pendingTransactionsDirectory,
+	)
+}
+
+// resolveOperatorSigner returns the signer of the operator account. If the
+// signer config URL is set, the external signer is used and the returned
+// operator key is nil. Otherwise, the operator key file is decrypted.
+func resolveOperatorSigner(
+	config ethereum.Config,
+	signerConfig SignerConfig,
+) (operatorSigner, *keystore.Key, error) {
+	if signerConfig.URL != "" {
+		remoteSigner, err := connectRemoteSigner(signerConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"failed to connect to the external signer: [%v]",
+				err,
+			)
+		}
+
+		return remoteSigner, nil, nil
+	}
+
+	operatorKey, err := decryptKey(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
 			"failed to decrypt Ethereum key: [%v]",
 			err,
 		)
 	}
 
-	clientWithAddons := wrapClientAddons(config, client)
+	return &keyFileSigner{operatorKey}, operatorKey, nil
+}
+
+// attachBaseChain constructs the chain handle on top of the given client
+// connected to the chain with the given ID. Transactions are signed using the
+// given operator signer. Pending transactions are persisted in the given
+// work persistence directory, once the persistence is enabled.
+func attachBaseChain(
+	ctx context.Context,
+	config ethereum.Config,
+	client *failoverClient,
+	chainID *big.Int,
+	operatorSigner operatorSigner,
+	operatorKey *keystore.Key,
+	pendingTransactionsDirectory string,
+) (*baseChain, error) {
+	key, err := transactorKey(operatorSigner, operatorKey)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to create transactor key: [%v]",
+			err,
+		)
+	}
+
+	client.monitorEndpoints(ctx)
+
+	// Transactions are submitted through the transaction manager which
+	// tracks them until they are mined.
+	transactionManager := newTransactionManager(
+		client,
+		operatorSigner,
+		crypto.PubkeyToAddress(key.PrivateKey.PublicKey),
+		chainID,
+		config,
+	)
+	transactionManager.persistenceDirectory = pendingTransactionsDirectory
+	transactionManager.observe(ctx)
+
+	clientWithAddons := transactionManager.managedClient()
 
 	blockCounter, err := ethutil.NewBlockCounter(clientWithAddons)
 	if err != nil {
@@ -253,11 +397,27 @@ func newBaseChain(
 		key.Address,
 	)
 
-	miningWaiter := ethutil.NewMiningWaiter(clientWithAddons, config)
+	// Not mined transactions are re-submitted by the transaction manager so
+	// the mining waiter used by contract bindings must not do it on its own.
+	// The mining waiter never re-submits transactions with zero max gas fee
+	// cap as no gas price can be bumped below it.
+	miningWaiterConfig := config
+	miningWaiterConfig.MaxGasFeeCap = *ethereum.WrapWei(big.NewInt(0))
+	miningWaiter := ethutil.NewMiningWaiter(
+		clientWithAddons,
+		miningWaiterConfig,
+	)
 
 	transactionMutex := &sync.Mutex{}
 
-	// TODO: Consider adding the balance monitoring.
+	balanceMonitor := newBalanceMonitor(
+		func(ctx context.Context, address common.Address) (*big.Int, error) {
+			return clientWithAddons.BalanceAt(ctx, address, nil)
+		},
+		key.Address,
+		config.BalanceAlertThreshold.Int,
+	)
+	balanceMonitor.observe(ctx, balanceMonitoringTick)
 
 	tokenStakingAddress, err := config.ContractAddress(TokenStakingContractName)
 	if err != nil {
@@ -273,7 +433,7 @@ func newBaseChain(
 			tokenStakingAddress,
 			chainID,
 			key,
-			client,
+			clientWithAddons,
 			nonceManager,
 			miningWaiter,
 			blockCounter,
@@ -287,26 +447,42 @@ func newBaseChain(
 	}
 
 	return &baseChain{
-		key:              key,
-		client:           clientWithAddons,
-		chainID:          chainID,
-		blockCounter:     blockCounter,
-		nonceManager:     nonceManager,
-		miningWaiter:     miningWaiter,
-		transactionMutex: transactionMutex,
-		tokenStaking:     tokenStaking,
+		key:                key,
+		operatorSigner:     operatorSigner,
+		operatorKey:        operatorKey,
+		client:             clientWithAddons,
+		chainID:            chainID,
+		blockCounter:       blockCounter,
+		nonceManager:       nonceManager,
+		miningWaiter:       miningWaiter,
+		balanceMonitor:     balanceMonitor,
+		transactionManager: transactionManager,
+		endpoints:          client.endpoints,
+		transactionMutex:   transactionMutex,
+		tokenStaking:       tokenStaking,
 	}, nil
 }
 
 // OperatorKeyPair returns the key pair of the operator assigned to this
-// chain handle.
+// chain handle. If the operator key is held by the external signer, the
+// returned private key is nil.
 func (bc *baseChain) OperatorKeyPair() (
 	*operator.PrivateKey,
 	*operator.PublicKey,
 	error,
 ) {
+	if bc.operatorKey == nil {
+		operatorPublicKey := bc.operatorSigner.publicKey()
+
+		return nil, &operator.PublicKey{
+			Curve: operator.Secp256k1,
+			X:     operatorPublicKey.X,
+			Y:     operatorPublicKey.Y,
+		}, nil
+	}
+
 	privateKey, publicKey, err := ChainPrivateKeyToOperatorKeyPair(
-		bc.key.PrivateKey,
+		bc.operatorKey.PrivateKey,
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf(
@@ -318,6 +494,28 @@ func (bc *baseChain) OperatorKeyPair() (
 	return privateKey, publicKey, nil
 }
 
+// OperatorBalance returns the most recently observed balance of the operator
+// account, in wei. The balance is refreshed periodically by the balance
+// monitor so the returned value may be slightly outdated.
+func (bc *baseChain) OperatorBalance() (*big.Int, error) {
+	return bc.balanceMonitor.balance()
+}
+
+// PersistPendingTransactions makes pending transactions submitted by the
+// operator account survive a restart of the client. Transactions persisted
+// before the restart are recovered and tracked again.
+func (bc *baseChain) PersistPendingTransactions(
+	handle persistence.BasicHandle,
+) {
+	bc.transactionManager.enablePersistence(handle)
+}
+
+// Endpoints returns all Ethereum RPC endpoints the chain handle is
+// configured with.
+func (bc *baseChain) Endpoints() []*Endpoint {
+	return bc.endpoints
+}
+
 // wrapClientAddons wraps the client instance with add-ons like logging, rate
 // limiting and so on.
 func wrapClientAddons(
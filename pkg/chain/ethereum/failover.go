@@ -0,0 +1,735 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+)
+
+const (
+	// endpointsHealthCheckTick is the interval in which the health of
+	// Ethereum RPC endpoints is checked.
+	endpointsHealthCheckTick = 15 * time.Second
+	// endpointHealthCheckTimeout is the timeout of a single endpoint health
+	// check.
+	endpointHealthCheckTimeout = 10 * time.Second
+	// maxEndpointBlockLag is the maximum number of blocks an endpoint can lag
+	// behind the best block known across all endpoints to be considered
+	// healthy.
+	maxEndpointBlockLag = 5
+	// resubscribeBackoffMax is the maximum time between attempts to
+	// re-establish a failed subscription.
+	resubscribeBackoffMax = 30 * time.Second
+)
+
+// Endpoint is an Ethereum RPC endpoint the client is connected to.
+type Endpoint struct {
+	index int
+	url   string
+
+	mutex       sync.RWMutex
+	client      ethutil.EthereumClient
+	healthy     bool
+	latestBlock uint64
+	blockLag    uint64
+	latency     time.Duration
+	errorsCount uint64
+}
+
+// String returns the endpoint description. Only the host of the endpoint URL
+// is exposed as the rest of the URL often contains an API key.
+func (e *Endpoint) String() string {
+	host := "unknown"
+	if parsed, err := url.Parse(e.url); err == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+
+	return fmt.Sprintf("%v (%v)", e.index, host)
+}
+
+// Healthy returns true if the endpoint is reachable and does not lag behind
+// the best block known across all endpoints.
+func (e *Endpoint) Healthy() bool {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.healthy
+}
+
+// BlockLag returns the number of blocks the endpoint lags behind the best
+// block known across all endpoints.
+func (e *Endpoint) BlockLag() uint64 {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.blockLag
+}
+
+// Latency returns the latency of the latest endpoint health check.
+func (e *Endpoint) Latency() time.Duration {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.latency
+}
+
+// ErrorsCount returns the number of failed requests to the endpoint.
+func (e *Endpoint) ErrorsCount() uint64 {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.errorsCount
+}
+
+func (e *Endpoint) connectedClient() (ethutil.EthereumClient, bool) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.client, e.client != nil
+}
+
+// markFailed records a failed request and marks the endpoint as unhealthy
+// until the next successful health check.
+func (e *Endpoint) markFailed() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.errorsCount++
+	e.healthy = false
+}
+
+// failoverClient is an Ethereum client spreading requests over several RPC
+// endpoints. Requests go to the first healthy endpoint, in the configured
+// order. If the endpoint fails to handle the request, the request is retried
+// against the next endpoint. Endpoints are health-checked periodically and
+// the ones lagging behind the best known block are avoided.
+type failoverClient struct {
+	config    ethereum.Config
+	chainID   *big.Int
+	endpoints []*Endpoint
+}
+
+// connectEndpoints connects to all Ethereum RPC endpoints from the config.
+// The config URL can contain several comma-separated endpoint URLs. All
+// endpoints must serve the same chain. Endpoints that cannot be connected to
+// are retried during health checks, as long as at least one endpoint is
+// connected.
+func connectEndpoints(
+	ctx context.Context,
+	config ethereum.Config,
+) (*failoverClient, error) {
+	urls := endpointsURLs(config.URL)
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no Ethereum endpoint URL configured")
+	}
+
+	fc := &failoverClient{config: config}
+
+	for i, endpointURL := range urls {
+		endpoint := &Endpoint{index: i, url: endpointURL}
+		fc.endpoints = append(fc.endpoints, endpoint)
+
+		if err := fc.dial(ctx, endpoint); err != nil {
+			var chainIDErr *chainIDMismatchError
+			if errors.As(err, &chainIDErr) {
+				return nil, err
+			}
+
+			logger.Warnf(
+				"could not connect to Ethereum endpoint [%v]: [%v]",
+				endpoint,
+				err,
+			)
+		}
+	}
+
+	if fc.chainID == nil {
+		return nil, fmt.Errorf("could not connect to any Ethereum endpoint")
+	}
+
+	if len(fc.endpoints) > 1 {
+		logger.Infof(
+			"using [%v] Ethereum endpoints with failover",
+			len(fc.endpoints),
+		)
+	}
+
+	fc.checkEndpoints(ctx)
+
+	return fc, nil
+}
+
+// endpointsURLs splits the comma-separated list of endpoint URLs.
+func endpointsURLs(configURL string) []string {
+	urls := make([]string, 0)
+	for _, endpointURL := range strings.Split(configURL, ",") {
+		endpointURL = strings.TrimSpace(endpointURL)
+		if endpointURL != "" {
+			urls = append(urls, endpointURL)
+		}
+	}
+
+	return urls
+}
+
+// chainIDMismatchError is returned when an endpoint serves a different chain
+// than other endpoints.
+type chainIDMismatchError struct {
+	endpoint *Endpoint
+	chainID  *big.Int
+	expected *big.Int
+}
+
+func (e *chainIDMismatchError) Error() string {
+	return fmt.Sprintf(
+		"endpoint [%v] serves chain with id [%v] while other endpoints "+
+			"serve chain with id [%v]",
+		e.endpoint,
+		e.chainID,
+		e.expected,
+	)
+}
+
+// dial connects to the given endpoint and verifies the endpoint serves the
+// same chain as other endpoints.
+func (fc *failoverClient) dial(ctx context.Context, endpoint *Endpoint) error {
+	dialCtx, cancel := context.WithTimeout(ctx, endpointHealthCheckTimeout)
+	defer cancel()
+
+	client, err := ethclient.DialContext(dialCtx, endpoint.url)
+	if err != nil {
+		return err
+	}
+
+	chainID, err := client.ChainID(dialCtx)
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("could not get chain id: [%v]", err)
+	}
+
+	if fc.chainID == nil {
+		fc.chainID = chainID
+	} else if fc.chainID.Cmp(chainID) != 0 {
+		client.Close()
+		return &chainIDMismatchError{endpoint, chainID, fc.chainID}
+	}
+
+	endpoint.mutex.Lock()
+	endpoint.client = wrapClientAddons(fc.config, client)
+	endpoint.healthy = true
+	endpoint.mutex.Unlock()
+
+	return nil
+}
+
+// monitorEndpoints periodically checks the health of all endpoints until
+// the context is done.
+func (fc *failoverClient) monitorEndpoints(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(endpointsHealthCheckTick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				fc.checkEndpoints(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// checkEndpoints checks the health of all endpoints. Endpoints that are not
+// connected are dialed again. An endpoint is healthy if it returns the latest
+// block header and does not lag behind the best block known across all
+// endpoints by more than maxEndpointBlockLag blocks.
+func (fc *failoverClient) checkEndpoints(ctx context.Context) {
+	type checkResult struct {
+		latestBlock uint64
+		latency     time.Duration
+		err         error
+	}
+
+	results := make([]checkResult, len(fc.endpoints))
+	bestBlock := uint64(0)
+
+	for i, endpoint := range fc.endpoints {
+		if _, ok := endpoint.connectedClient(); !ok {
+			if err := fc.dial(ctx, endpoint); err != nil {
+				results[i].err = err
+				continue
+			}
+
+			logger.Infof("connected to Ethereum endpoint [%v]", endpoint)
+		}
+
+		client, _ := endpoint.connectedClient()
+
+		checkCtx, cancel := context.WithTimeout(ctx, endpointHealthCheckTimeout)
+		start := time.Now()
+		header, err := client.HeaderByNumber(checkCtx, nil)
+		results[i].latency = time.Since(start)
+		cancel()
+
+		if err != nil {
+			results[i].err = err
+			continue
+		}
+
+		results[i].latestBlock = header.Number.Uint64()
+		if results[i].latestBlock > bestBlock {
+			bestBlock = results[i].latestBlock
+		}
+	}
+
+	for i, endpoint := range fc.endpoints {
+		result := results[i]
+
+		endpoint.mutex.Lock()
+
+		wasHealthy := endpoint.healthy
+
+		if result.err != nil {
+			endpoint.errorsCount++
+			endpoint.healthy = false
+		} else {
+			endpoint.latestBlock = result.latestBlock
+			endpoint.blockLag = bestBlock - result.latestBlock
+			endpoint.latency = result.latency
+			endpoint.healthy = endpoint.blockLag <= maxEndpointBlockLag
+		}
+
+		isHealthy := endpoint.healthy
+		blockLag := endpoint.blockLag
+
+		endpoint.mutex.Unlock()
+
+		switch {
+		case wasHealthy && result.err != nil:
+			logger.Warnf(
+				"Ethereum endpoint [%v] is unhealthy: [%v]",
+				endpoint,
+				result.err,
+			)
+		case wasHealthy && !isHealthy:
+			logger.Warnf(
+				"Ethereum endpoint [%v] is lagging [%v] blocks behind "+
+					"the best known block [%v]",
+				endpoint,
+				blockLag,
+				bestBlock,
+			)
+		case !wasHealthy && isHealthy:
+			logger.Infof("Ethereum endpoint [%v] is healthy again", endpoint)
+		}
+	}
+}
+
+// orderedEndpoints returns connected endpoints in the order they should be
+// tried: healthy endpoints first, then the unhealthy ones, each group in
+// the configured order.
+func (fc *failoverClient) orderedEndpoints() []*Endpoint {
+	healthy := make([]*Endpoint, 0, len(fc.endpoints))
+	unhealthy := make([]*Endpoint, 0)
+
+	for _, endpoint := range fc.endpoints {
+		if _, ok := endpoint.connectedClient(); !ok {
+			continue
+		}
+
+		if endpoint.Healthy() {
+			healthy = append(healthy, endpoint)
+		} else {
+			unhealthy = append(unhealthy, endpoint)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// isFailoverError determines whether the request that failed with the given
+// error should be retried against another endpoint. Errors returned by the
+// Ethereum node itself, for example transaction reverts, are final as all
+// endpoints would return them as well.
+func isFailoverError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	if errors.Is(err, goethereum.NotFound) {
+		return false
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+
+	var dataErr rpc.DataError
+	return !errors.As(err, &dataErr)
+}
+
+// withFailover executes the given request against subsequent endpoints until
+// it succeeds or fails with an error that should not be retried.
+func withFailover[T any](
+	ctx context.Context,
+	fc *failoverClient,
+	request func(client ethutil.EthereumClient) (T, error),
+) (T, error) {
+	var result T
+	err := fmt.Errorf("no Ethereum endpoint connected")
+
+	for _, endpoint := range fc.orderedEndpoints() {
+		client, ok := endpoint.connectedClient()
+		if !ok {
+			continue
+		}
+
+		result, err = request(client)
+		if !isFailoverError(ctx, err) {
+			return result, err
+		}
+
+		endpoint.markFailed()
+
+		logger.Warnf(
+			"request to Ethereum endpoint [%v] failed; "+
+				"trying next endpoint: [%v]",
+			endpoint,
+			err,
+		)
+	}
+
+	return result, err
+}
+
+// subscribeWithFailover establishes the subscription through the first
+// endpoint accepting it and keeps it established. Once the subscription
+// fails, its endpoint is marked as failed and the subscription is
+// re-established through the next endpoint. Events emitted while the
+// subscription is being re-established are not delivered.
+func subscribeWithFailover(
+	ctx context.Context,
+	fc *failoverClient,
+	subscribe func(
+		ctx context.Context,
+		client ethutil.EthereumClient,
+	) (goethereum.Subscription, error),
+) (goethereum.Subscription, error) {
+	var endpoint *Endpoint
+
+	subscribeOnce := func(ctx context.Context) (goethereum.Subscription, error) {
+		return withFailover(ctx, fc, func(c ethutil.EthereumClient) (goethereum.Subscription, error) {
+			subscription, err := subscribe(ctx, c)
+			if err == nil {
+				endpoint = fc.endpointOf(c)
+			}
+			return subscription, err
+		})
+	}
+
+	// The first subscription is established synchronously so that the
+	// caller learns if no endpoint accepts it.
+	initial, err := subscribeOnce(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.ResubscribeErr(
+		resubscribeBackoffMax,
+		func(ctx context.Context, lastErr error) (event.Subscription, error) {
+			if initial != nil {
+				subscription := initial
+				initial = nil
+				return subscription, nil
+			}
+
+			if endpoint != nil {
+				endpoint.markFailed()
+
+				logger.Warnf(
+					"subscription through Ethereum endpoint [%v] failed; "+
+						"subscribing through the next endpoint: [%v]",
+					endpoint,
+					lastErr,
+				)
+			}
+
+			return subscribeOnce(ctx)
+		},
+	), nil
+}
+
+// endpointOf returns the endpoint connected with the given client.
+func (fc *failoverClient) endpointOf(client ethutil.EthereumClient) *Endpoint {
+	for _, endpoint := range fc.endpoints {
+		if connected, ok := endpoint.connectedClient(); ok && connected == client {
+			return endpoint
+		}
+	}
+
+	return nil
+}
+
+// isAlreadySent determines whether the given error returned by the client
+// when sending the given transaction means the transaction has already been
+// sent to the network. That is the case if the transaction is already known
+// or its nonce is too low because the transaction with the same hash has
+// already been mined.
+func isAlreadySent(
+	ctx context.Context,
+	client ethutil.EthereumClient,
+	transaction *types.Transaction,
+	err error,
+) bool {
+	if isAlreadyKnown(err) {
+		return true
+	}
+
+	if !isNonceTooLow(err) {
+		return false
+	}
+
+	known, _, lookupErr := client.TransactionByHash(ctx, transaction.Hash())
+	return lookupErr == nil && known != nil
+}
+
+// ChainID returns the id of the chain served by the endpoints.
+func (fc *failoverClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(fc.chainID), nil
+}
+
+func (fc *failoverClient) CodeAt(
+	ctx context.Context,
+	contract common.Address,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) ([]byte, error) {
+		return c.CodeAt(ctx, contract, blockNumber)
+	})
+}
+
+func (fc *failoverClient) CallContract(
+	ctx context.Context,
+	call goethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) ([]byte, error) {
+		return c.CallContract(ctx, call, blockNumber)
+	})
+}
+
+func (fc *failoverClient) PendingCodeAt(
+	ctx context.Context,
+	account common.Address,
+) ([]byte, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) ([]byte, error) {
+		return c.PendingCodeAt(ctx, account)
+	})
+}
+
+func (fc *failoverClient) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) (uint64, error) {
+		return c.PendingNonceAt(ctx, account)
+	})
+}
+
+func (fc *failoverClient) SuggestGasPrice(
+	ctx context.Context,
+) (*big.Int, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) (*big.Int, error) {
+		return c.SuggestGasPrice(ctx)
+	})
+}
+
+func (fc *failoverClient) SuggestGasTipCap(
+	ctx context.Context,
+) (*big.Int, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) (*big.Int, error) {
+		return c.SuggestGasTipCap(ctx)
+	})
+}
+
+func (fc *failoverClient) EstimateGas(
+	ctx context.Context,
+	call goethereum.CallMsg,
+) (uint64, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) (uint64, error) {
+		return c.EstimateGas(ctx, call)
+	})
+}
+
+// SendTransaction sends the transaction through the first endpoint accepting
+// it. Sending is idempotent: an endpoint that failed may have propagated the
+// transaction anyway so the next endpoint reporting the same transaction as
+// already known or already mined is considered a success.
+func (fc *failoverClient) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	_, err := withFailover(ctx, fc, func(c ethutil.EthereumClient) (struct{}, error) {
+		err := c.SendTransaction(ctx, transaction)
+		if err != nil && isAlreadySent(ctx, c, transaction, err) {
+			logger.Infof(
+				"transaction [%v] already sent to Ethereum endpoint [%v]",
+				transaction.Hash().TerminalString(),
+				fc.endpointOf(c),
+			)
+			return struct{}{}, nil
+		}
+		return struct{}{}, err
+	})
+	return err
+}
+
+func (fc *failoverClient) FilterLogs(
+	ctx context.Context,
+	query goethereum.FilterQuery,
+) ([]types.Log, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) ([]types.Log, error) {
+		return c.FilterLogs(ctx, query)
+	})
+}
+
+// SubscribeFilterLogs subscribes to logs through the first endpoint accepting
+// the subscription. The subscription moves to the next endpoint once the
+// endpoint fails.
+func (fc *failoverClient) SubscribeFilterLogs(
+	ctx context.Context,
+	query goethereum.FilterQuery,
+	ch chan<- types.Log,
+) (goethereum.Subscription, error) {
+	return subscribeWithFailover(
+		ctx,
+		fc,
+		func(ctx context.Context, c ethutil.EthereumClient) (goethereum.Subscription, error) {
+			return c.SubscribeFilterLogs(ctx, query, ch)
+		},
+	)
+}
+
+func (fc *failoverClient) BlockByHash(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Block, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) (*types.Block, error) {
+		return c.BlockByHash(ctx, hash)
+	})
+}
+
+func (fc *failoverClient) BlockByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Block, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) (*types.Block, error) {
+		return c.BlockByNumber(ctx, number)
+	})
+}
+
+func (fc *failoverClient) HeaderByHash(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Header, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) (*types.Header, error) {
+		return c.HeaderByHash(ctx, hash)
+	})
+}
+
+func (fc *failoverClient) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) (*types.Header, error) {
+		return c.HeaderByNumber(ctx, number)
+	})
+}
+
+func (fc *failoverClient) TransactionCount(
+	ctx context.Context,
+	blockHash common.Hash,
+) (uint, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) (uint, error) {
+		return c.TransactionCount(ctx, blockHash)
+	})
+}
+
+func (fc *failoverClient) TransactionInBlock(
+	ctx context.Context,
+	blockHash common.Hash,
+	index uint,
+) (*types.Transaction, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) (*types.Transaction, error) {
+		return c.TransactionInBlock(ctx, blockHash, index)
+	})
+}
+
+// SubscribeNewHead subscribes to new headers through the first endpoint
+// accepting the subscription. The subscription moves to the next endpoint
+// once the endpoint fails.
+func (fc *failoverClient) SubscribeNewHead(
+	ctx context.Context,
+	ch chan<- *types.Header,
+) (goethereum.Subscription, error) {
+	return subscribeWithFailover(
+		ctx,
+		fc,
+		func(ctx context.Context, c ethutil.EthereumClient) (goethereum.Subscription, error) {
+			return c.SubscribeNewHead(ctx, ch)
+		},
+	)
+}
+
+func (fc *failoverClient) TransactionByHash(
+	ctx context.Context,
+	txHash common.Hash,
+) (*types.Transaction, bool, error) {
+	type result struct {
+		transaction *types.Transaction
+		isPending   bool
+	}
+
+	r, err := withFailover(ctx, fc, func(c ethutil.EthereumClient) (result, error) {
+		transaction, isPending, err := c.TransactionByHash(ctx, txHash)
+		return result{transaction, isPending}, err
+	})
+
+	return r.transaction, r.isPending, err
+}
+
+func (fc *failoverClient) TransactionReceipt(
+	ctx context.Context,
+	txHash common.Hash,
+) (*types.Receipt, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) (*types.Receipt, error) {
+		return c.TransactionReceipt(ctx, txHash)
+	})
+}
+
+func (fc *failoverClient) BalanceAt(
+	ctx context.Context,
+	account common.Address,
+	blockNumber *big.Int,
+) (*big.Int, error) {
+	return withFailover(ctx, fc, func(c ethutil.EthereumClient) (*big.Int, error) {
+		return c.BalanceAt(ctx, account, blockNumber)
+	})
+}
@@ -0,0 +1,389 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+var testAccount = common.HexToAddress(
+	"0x7c6aE24D3Fd5c4a1AF4c9aC5c7b2C7d0E0cBc6e1",
+)
+
+func TestEndpointsURLs(t *testing.T) {
+	var tests = map[string]struct {
+		configURL    string
+		expectedURLs []string
+	}{
+		"single URL": {
+			configURL:    "ws://127.0.0.1:8546",
+			expectedURLs: []string{"ws://127.0.0.1:8546"},
+		},
+		"multiple URLs": {
+			configURL: "ws://127.0.0.1:8546, wss://provider.io/v3/key ",
+			expectedURLs: []string{
+				"ws://127.0.0.1:8546",
+				"wss://provider.io/v3/key",
+			},
+		},
+		"empty entries": {
+			configURL:    ",ws://127.0.0.1:8546,,",
+			expectedURLs: []string{"ws://127.0.0.1:8546"},
+		},
+		"no URL": {
+			configURL:    "",
+			expectedURLs: []string{},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			urls := endpointsURLs(test.configURL)
+
+			if !reflect.DeepEqual(test.expectedURLs, urls) {
+				t.Errorf(
+					"unexpected URLs\nexpected: %v\nactual:   %v",
+					test.expectedURLs,
+					urls,
+				)
+			}
+		})
+	}
+}
+
+func TestEndpoint_String(t *testing.T) {
+	endpoint := &Endpoint{index: 1, url: "wss://provider.io/v3/secret-key"}
+
+	testutils.AssertStringsEqual(
+		t,
+		"endpoint description",
+		"1 (provider.io)",
+		endpoint.String(),
+	)
+}
+
+func TestFailoverClient_FailsOverOnConnectionError(t *testing.T) {
+	first := &mockEndpointClient{balanceErr: fmt.Errorf("connection refused")}
+	second := &mockEndpointClient{balance: big.NewInt(100)}
+
+	fc := newTestFailoverClient(first, second)
+
+	balance, err := fc.BalanceAt(context.Background(), testAccount, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertBigIntsEqual(t, "balance", big.NewInt(100), balance)
+	testutils.AssertBoolsEqual(
+		t,
+		"first endpoint healthy",
+		false,
+		fc.endpoints[0].Healthy(),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"first endpoint errors count",
+		1,
+		int(fc.endpoints[0].ErrorsCount()),
+	)
+
+	// The unhealthy endpoint should be tried last from now on.
+	first.balanceErr = nil
+	first.balance = big.NewInt(50)
+
+	balance, err = fc.BalanceAt(context.Background(), testAccount, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertBigIntsEqual(t, "balance", big.NewInt(100), balance)
+}
+
+func TestFailoverClient_DoesNotFailOverOnRPCError(t *testing.T) {
+	rpcErr := &mockRPCError{code: -32000, message: "execution reverted"}
+
+	first := &mockEndpointClient{balanceErr: rpcErr}
+	second := &mockEndpointClient{balance: big.NewInt(100)}
+
+	fc := newTestFailoverClient(first, second)
+
+	_, err := fc.BalanceAt(context.Background(), testAccount, nil)
+	if err != rpcErr {
+		t.Fatalf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			rpcErr,
+			err,
+		)
+	}
+
+	testutils.AssertBoolsEqual(
+		t,
+		"first endpoint healthy",
+		true,
+		fc.endpoints[0].Healthy(),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"second endpoint calls",
+		0,
+		second.balanceCalls,
+	)
+}
+
+func TestFailoverClient_DetectsLaggingEndpoint(t *testing.T) {
+	first := &mockEndpointClient{latestBlock: 100}
+	second := &mockEndpointClient{latestBlock: 100 + maxEndpointBlockLag + 1}
+	third := &mockEndpointClient{latestBlock: 100 + maxEndpointBlockLag}
+
+	fc := newTestFailoverClient(first, second, third)
+
+	fc.checkEndpoints(context.Background())
+
+	var tests = map[int]struct {
+		expectedHealthy  bool
+		expectedBlockLag int
+	}{
+		0: {expectedHealthy: false, expectedBlockLag: maxEndpointBlockLag + 1},
+		1: {expectedHealthy: true, expectedBlockLag: 0},
+		2: {expectedHealthy: true, expectedBlockLag: 1},
+	}
+
+	for index, test := range tests {
+		endpoint := fc.endpoints[index]
+
+		testutils.AssertBoolsEqual(
+			t,
+			fmt.Sprintf("endpoint [%v] healthy", index),
+			test.expectedHealthy,
+			endpoint.Healthy(),
+		)
+		testutils.AssertIntsEqual(
+			t,
+			fmt.Sprintf("endpoint [%v] block lag", index),
+			test.expectedBlockLag,
+			int(endpoint.BlockLag()),
+		)
+	}
+
+	// Requests should go to the first healthy endpoint.
+	header, err := fc.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"latest block",
+		100+maxEndpointBlockLag+1,
+		int(header.Number.Int64()),
+	)
+}
+
+func TestFailoverClient_SendTransactionIsIdempotent(t *testing.T) {
+	transaction := types.NewTx(&types.LegacyTx{Nonce: 5})
+
+	var tests = map[string]struct {
+		sendErr       error
+		known         bool
+		expectedError bool
+	}{
+		"already known": {
+			sendErr: &mockRPCError{code: -32000, message: "already known"},
+		},
+		"nonce too low for the same transaction": {
+			sendErr: &mockRPCError{code: -32000, message: "nonce too low"},
+			known:   true,
+		},
+		"nonce too low for another transaction": {
+			sendErr:       &mockRPCError{code: -32000, message: "nonce too low"},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			// The first endpoint propagates the transaction before failing.
+			first := &mockEndpointClient{sendErr: fmt.Errorf("i/o timeout")}
+			second := &mockEndpointClient{sendErr: test.sendErr}
+			if test.known {
+				second.transactions = []*types.Transaction{transaction}
+			}
+
+			fc := newTestFailoverClient(first, second)
+
+			err := fc.SendTransaction(context.Background(), transaction)
+
+			testutils.AssertBoolsEqual(
+				t,
+				"error returned",
+				test.expectedError,
+				err != nil,
+			)
+		})
+	}
+}
+
+func TestFailoverClient_FailsOverSubscription(t *testing.T) {
+	first := &mockEndpointClient{}
+	second := &mockEndpointClient{}
+
+	fc := newTestFailoverClient(first, second)
+
+	subscription, err := fc.SubscribeNewHead(
+		context.Background(),
+		make(chan *types.Header),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Unsubscribe()
+
+	first.failSubscription(fmt.Errorf("connection reset"))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for second.subscriptionsCount() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscription not moved to the next endpoint")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	testutils.AssertBoolsEqual(
+		t,
+		"first endpoint healthy",
+		false,
+		fc.endpoints[0].Healthy(),
+	)
+}
+
+func newTestFailoverClient(clients ...ethutil.EthereumClient) *failoverClient {
+	fc := &failoverClient{chainID: testChainID}
+
+	for i, client := range clients {
+		fc.endpoints = append(fc.endpoints, &Endpoint{
+			index:   i,
+			url:     fmt.Sprintf("ws://endpoint-%v:8546", i),
+			client:  client,
+			healthy: true,
+		})
+	}
+
+	return fc
+}
+
+type mockEndpointClient struct {
+	ethutil.EthereumClient
+
+	latestBlock uint64
+
+	balance      *big.Int
+	balanceErr   error
+	balanceCalls int
+
+	sendErr      error
+	transactions []*types.Transaction
+
+	subscriptionsMutex sync.Mutex
+	subscriptions      []*mockSubscription
+}
+
+func (mec *mockEndpointClient) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	return mec.sendErr
+}
+
+func (mec *mockEndpointClient) TransactionByHash(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Transaction, bool, error) {
+	for _, transaction := range mec.transactions {
+		if transaction.Hash() == hash {
+			return transaction, true, nil
+		}
+	}
+
+	return nil, false, goethereum.NotFound
+}
+
+func (mec *mockEndpointClient) SubscribeNewHead(
+	ctx context.Context,
+	ch chan<- *types.Header,
+) (goethereum.Subscription, error) {
+	mec.subscriptionsMutex.Lock()
+	defer mec.subscriptionsMutex.Unlock()
+
+	subscription := &mockSubscription{err: make(chan error, 1)}
+	mec.subscriptions = append(mec.subscriptions, subscription)
+
+	return subscription, nil
+}
+
+func (mec *mockEndpointClient) subscriptionsCount() int {
+	mec.subscriptionsMutex.Lock()
+	defer mec.subscriptionsMutex.Unlock()
+
+	return len(mec.subscriptions)
+}
+
+func (mec *mockEndpointClient) failSubscription(err error) {
+	mec.subscriptionsMutex.Lock()
+	defer mec.subscriptionsMutex.Unlock()
+
+	for _, subscription := range mec.subscriptions {
+		subscription.err <- err
+	}
+}
+
+type mockSubscription struct {
+	err chan error
+}
+
+func (ms *mockSubscription) Unsubscribe() {}
+
+func (ms *mockSubscription) Err() <-chan error {
+	return ms.err
+}
+
+func (mec *mockEndpointClient) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	return &types.Header{
+		Number: new(big.Int).SetUint64(mec.latestBlock),
+	}, nil
+}
+
+func (mec *mockEndpointClient) BalanceAt(
+	ctx context.Context,
+	account common.Address,
+	blockNumber *big.Int,
+) (*big.Int, error) {
+	mec.balanceCalls++
+	return mec.balance, mec.balanceErr
+}
+
+type mockRPCError struct {
+	code    int
+	message string
+}
+
+func (mre *mockRPCError) Error() string {
+	return mre.message
+}
+
+func (mre *mockRPCError) ErrorCode() int {
+	return mre.code
+}
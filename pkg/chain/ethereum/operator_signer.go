@@ -0,0 +1,294 @@
+package ethereum
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+)
+
+// SignerConfig holds the configuration of an external signer holding the
+// operator key.
+type SignerConfig struct {
+	// URL of the external signer API. It can be a path of an IPC socket or
+	// an HTTP(S) or WS(S) URL. The signer must expose the Clef external API
+	// (account_list, account_signData, account_signTransaction). If empty,
+	// the operator key file is used for signing.
+	URL string
+
+	// Address of the operator account managed by the external signer. It must
+	// be set if the signer manages more than one account.
+	Address string
+}
+
+// publicKeyRecoveryMessage is signed by the external signer when the client
+// connects to it. The operator public key is recovered from the signature as
+// the external signer API exposes only addresses of managed accounts.
+const publicKeyRecoveryMessage = "keep-client operator public key recovery"
+
+// operatorSigner signs transactions and messages on behalf of the operator
+// account.
+type operatorSigner interface {
+	// address returns the operator account address.
+	address() common.Address
+
+	// publicKey returns the operator account public key.
+	publicKey() *ecdsa.PublicKey
+
+	// signTransaction signs the given transaction for the given chain.
+	signTransaction(
+		transaction *types.Transaction,
+		chainID *big.Int,
+	) (*types.Transaction, error)
+
+	// signMessage signs the given message using the Ethereum-specific format
+	// with the recovery id equal to 27 or 28.
+	signMessage(message []byte) ([]byte, error)
+}
+
+// keyFileSigner is an operator signer using the operator key decrypted from
+// the key file.
+type keyFileSigner struct {
+	key *keystore.Key
+}
+
+func (kfs *keyFileSigner) address() common.Address {
+	return kfs.key.Address
+}
+
+func (kfs *keyFileSigner) publicKey() *ecdsa.PublicKey {
+	return &kfs.key.PrivateKey.PublicKey
+}
+
+func (kfs *keyFileSigner) signTransaction(
+	transaction *types.Transaction,
+	chainID *big.Int,
+) (*types.Transaction, error) {
+	return types.SignTx(
+		transaction,
+		types.LatestSignerForChainID(chainID),
+		kfs.key.PrivateKey,
+	)
+}
+
+func (kfs *keyFileSigner) signMessage(message []byte) ([]byte, error) {
+	return ethutil.NewSigner(kfs.key.PrivateKey).Sign(message)
+}
+
+// remoteSigner is an operator signer delegating signing to an external
+// signer process. The operator key never leaves the external signer.
+type remoteSigner struct {
+	external        *external.ExternalSigner
+	account         accounts.Account
+	operatorAddress common.Address
+	operatorKey     *ecdsa.PublicKey
+}
+
+// connectRemoteSigner connects to the external signer from the config and
+// resolves the operator account it manages.
+func connectRemoteSigner(config SignerConfig) (*remoteSigner, error) {
+	externalSigner, err := external.NewExternalSigner(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot reach the signer API: [%v]",
+			err,
+		)
+	}
+
+	managedAccounts := externalSigner.Accounts()
+
+	var account accounts.Account
+	if config.Address != "" {
+		if !common.IsHexAddress(config.Address) {
+			return nil, fmt.Errorf(
+				"invalid operator address [%v]",
+				config.Address,
+			)
+		}
+
+		account = accounts.Account{
+			Address: common.HexToAddress(config.Address),
+		}
+
+		if !externalSigner.Contains(account) {
+			return nil, fmt.Errorf(
+				"operator account [%v] is not managed by the external signer",
+				account.Address.Hex(),
+			)
+		}
+	} else {
+		if len(managedAccounts) != 1 {
+			return nil, fmt.Errorf(
+				"external signer manages [%v] accounts; the operator "+
+					"address must be configured explicitly",
+				len(managedAccounts),
+			)
+		}
+
+		account = managedAccounts[0]
+	}
+
+	rs := &remoteSigner{
+		external:        externalSigner,
+		account:         account,
+		operatorAddress: account.Address,
+	}
+
+	signature, err := rs.signMessage([]byte(publicKeyRecoveryMessage))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot sign public key recovery message: [%v]",
+			err,
+		)
+	}
+
+	operatorKey, err := recoverPublicKey(
+		[]byte(publicKeyRecoveryMessage),
+		signature,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot recover operator public key: [%v]", err)
+	}
+
+	if crypto.PubkeyToAddress(*operatorKey) != account.Address {
+		return nil, fmt.Errorf(
+			"external signer signed the public key recovery message " +
+				"with a key of another account",
+		)
+	}
+
+	rs.operatorKey = operatorKey
+
+	logger.Infof(
+		"using external signer for operator account [%v]",
+		account.Address.Hex(),
+	)
+
+	return rs, nil
+}
+
+func (rs *remoteSigner) address() common.Address {
+	return rs.operatorAddress
+}
+
+func (rs *remoteSigner) publicKey() *ecdsa.PublicKey {
+	return rs.operatorKey
+}
+
+func (rs *remoteSigner) signTransaction(
+	transaction *types.Transaction,
+	chainID *big.Int,
+) (*types.Transaction, error) {
+	signedTransaction, err := rs.external.SignTx(
+		rs.account,
+		transaction,
+		chainID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The external signer returns the whole transaction so make sure it
+	// signed exactly the requested one, with the operator key.
+	signer := types.LatestSignerForChainID(chainID)
+
+	if signer.Hash(signedTransaction) != signer.Hash(transaction) {
+		return nil, fmt.Errorf(
+			"external signer returned a transaction different " +
+				"than the requested one",
+		)
+	}
+
+	sender, err := types.Sender(signer, signedTransaction)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot recover sender of the signed transaction: [%v]",
+			err,
+		)
+	}
+
+	if sender != rs.operatorAddress {
+		return nil, fmt.Errorf(
+			"external signer signed the transaction as [%v]",
+			sender.Hex(),
+		)
+	}
+
+	return signedTransaction, nil
+}
+
+func (rs *remoteSigner) signMessage(message []byte) ([]byte, error) {
+	signature, err := rs.external.SignText(rs.account, message)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(signature) != ethutil.SignatureSize {
+		return nil, fmt.Errorf(
+			"external signer returned signature of [%v] bytes",
+			len(signature),
+		)
+	}
+
+	// The external signer client normalizes the recovery id to 0 or 1 while
+	// the on-chain signature validation expects 27 or 28.
+	signature[len(signature)-1] += 27
+
+	return signature, nil
+}
+
+// recoverPublicKey recovers the public key from the given signature of the
+// given message, computed using the Ethereum-specific format.
+func recoverPublicKey(message []byte, signature []byte) (*ecdsa.PublicKey, error) {
+	if len(signature) != ethutil.SignatureSize {
+		return nil, fmt.Errorf(
+			"signature should have [%v] bytes; has: [%v]",
+			ethutil.SignatureSize,
+			len(signature),
+		)
+	}
+
+	normalizedSignature := make([]byte, len(signature))
+	copy(normalizedSignature, signature)
+	if normalizedSignature[len(signature)-1] >= 27 {
+		normalizedSignature[len(signature)-1] -= 27
+	}
+
+	return crypto.SigToPub(accounts.TextHash(message), normalizedSignature)
+}
+
+// transactorKey returns the key that should be used by contract bindings to
+// sign transactions. Contract bindings require a private key so if the
+// operator key is held by an external signer, a random, throwaway transactor
+// key is returned. Transactions signed with the throwaway key are signed
+// again by the transaction manager, using the external signer, before
+// being sent to the network.
+func transactorKey(
+	operatorSigner operatorSigner,
+	operatorKey *keystore.Key,
+) (*keystore.Key, error) {
+	if _, ok := operatorSigner.(*keyFileSigner); ok {
+		return operatorKey, nil
+	}
+
+	privateKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate transactor key: [%v]", err)
+	}
+
+	// The address is the operator address so that calls done by contract
+	// bindings are executed on behalf of the operator.
+	return &keystore.Key{
+		Address:    operatorSigner.address(),
+		PrivateKey: privateKey,
+	}, nil
+}
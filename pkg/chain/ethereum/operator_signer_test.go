@@ -0,0 +1,332 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+func TestRemoteSigner_SignMessage(t *testing.T) {
+	operatorKey := generateTestKey(t)
+	server := newStubExternalSigner(t, operatorKey)
+
+	remoteSigner, err := connectRemoteSigner(SignerConfig{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertStringsEqual(
+		t,
+		"operator address",
+		crypto.PubkeyToAddress(operatorKey.PublicKey).Hex(),
+		remoteSigner.address().Hex(),
+	)
+	testutils.AssertBytesEqual(
+		t,
+		crypto.FromECDSAPub(&operatorKey.PublicKey),
+		crypto.FromECDSAPub(remoteSigner.publicKey()),
+	)
+
+	message := []byte("hello")
+
+	signing := newSigner(remoteSigner)
+
+	signature, err := signing.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The signature must be the same as the one computed locally.
+	expectedSignature, err := newSigner(
+		&keyFileSigner{keyFromPrivateKey(operatorKey)},
+	).Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertBytesEqual(t, expectedSignature, signature)
+
+	ok, err := signing.Verify(message, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertBoolsEqual(t, "signature validity", true, ok)
+}
+
+func TestRemoteSigner_SignTransaction(t *testing.T) {
+	operatorKey := generateTestKey(t)
+	server := newStubExternalSigner(t, operatorKey)
+
+	remoteSigner, err := connectRemoteSigner(SignerConfig{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	to := testContractAddress
+	transaction := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   testChainID,
+		Nonce:     5,
+		GasTipCap: big.NewInt(10),
+		GasFeeCap: big.NewInt(100),
+		Gas:       100000,
+		To:        &to,
+		Data:      []byte{1, 2, 3},
+	})
+
+	signedTransaction, err := remoteSigner.signTransaction(
+		transaction,
+		testChainID,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender, err := types.Sender(
+		types.LatestSignerForChainID(testChainID),
+		signedTransaction,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertStringsEqual(
+		t,
+		"sender",
+		crypto.PubkeyToAddress(operatorKey.PublicKey).Hex(),
+		sender.Hex(),
+	)
+	testutils.AssertIntsEqual(t, "nonce", 5, int(signedTransaction.Nonce()))
+	testutils.AssertBytesEqual(t, []byte{1, 2, 3}, signedTransaction.Data())
+}
+
+func TestRemoteSigner_RejectsTamperedTransaction(t *testing.T) {
+	operatorKey := generateTestKey(t)
+	server := newStubExternalSigner(t, operatorKey)
+
+	remoteSigner, err := connectRemoteSigner(SignerConfig{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.api.tamper = true
+
+	to := testContractAddress
+	transaction := types.NewTx(&types.LegacyTx{
+		Nonce:    5,
+		GasPrice: big.NewInt(100),
+		Gas:      100000,
+		To:       &to,
+	})
+
+	_, err = remoteSigner.signTransaction(transaction, testChainID)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestConnectRemoteSigner_OperatorAddress(t *testing.T) {
+	operatorKey := generateTestKey(t)
+	otherKey := generateTestKey(t)
+
+	operatorAddress := crypto.PubkeyToAddress(operatorKey.PublicKey)
+
+	var tests = map[string]struct {
+		keys            []*ecdsa.PrivateKey
+		address         string
+		expectedAddress common.Address
+		expectedError   bool
+	}{
+		"single account": {
+			keys:            []*ecdsa.PrivateKey{operatorKey},
+			expectedAddress: operatorAddress,
+		},
+		"multiple accounts and configured address": {
+			keys:            []*ecdsa.PrivateKey{otherKey, operatorKey},
+			address:         operatorAddress.Hex(),
+			expectedAddress: operatorAddress,
+		},
+		"multiple accounts and no configured address": {
+			keys:          []*ecdsa.PrivateKey{otherKey, operatorKey},
+			expectedError: true,
+		},
+		"configured address not managed by the signer": {
+			keys:          []*ecdsa.PrivateKey{otherKey},
+			address:       operatorAddress.Hex(),
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			server := newStubExternalSigner(t, test.keys...)
+
+			remoteSigner, err := connectRemoteSigner(SignerConfig{
+				URL:     server.URL,
+				Address: test.address,
+			})
+
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertStringsEqual(
+				t,
+				"operator address",
+				test.expectedAddress.Hex(),
+				remoteSigner.address().Hex(),
+			)
+		})
+	}
+}
+
+func generateTestKey(t *testing.T) *ecdsa.PrivateKey {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return privateKey
+}
+
+func keyFromPrivateKey(privateKey *ecdsa.PrivateKey) *keystore.Key {
+	return &keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+}
+
+// stubExternalSigner is an HTTP server exposing the Clef external API for
+// the given keys.
+type stubExternalSigner struct {
+	*httptest.Server
+
+	api *stubExternalSignerAPI
+}
+
+func newStubExternalSigner(
+	t *testing.T,
+	keys ...*ecdsa.PrivateKey,
+) *stubExternalSigner {
+	api := &stubExternalSignerAPI{keys: make(map[common.Address]*ecdsa.PrivateKey)}
+	for _, key := range keys {
+		address := crypto.PubkeyToAddress(key.PublicKey)
+		api.keys[address] = key
+		api.addresses = append(api.addresses, address)
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", api); err != nil {
+		t.Fatal(err)
+	}
+
+	httpServer := httptest.NewServer(server)
+
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+
+	return &stubExternalSigner{httpServer, api}
+}
+
+type stubExternalSignerAPI struct {
+	keys      map[common.Address]*ecdsa.PrivateKey
+	addresses []common.Address
+
+	// tamper makes the signer modify transactions before signing them.
+	tamper bool
+}
+
+func (sesa *stubExternalSignerAPI) Version(ctx context.Context) (string, error) {
+	return "6.1.0", nil
+}
+
+func (sesa *stubExternalSignerAPI) List(
+	ctx context.Context,
+) ([]common.Address, error) {
+	return sesa.addresses, nil
+}
+
+func (sesa *stubExternalSignerAPI) SignData(
+	ctx context.Context,
+	contentType string,
+	address common.MixedcaseAddress,
+	data hexutil.Bytes,
+) (hexutil.Bytes, error) {
+	if contentType != accounts.MimetypeTextPlain {
+		return nil, fmt.Errorf("unsupported content type [%v]", contentType)
+	}
+
+	key, ok := sesa.keys[address.Address()]
+	if !ok {
+		return nil, fmt.Errorf("unknown account [%v]", address.Address())
+	}
+
+	signature, err := crypto.Sign(accounts.TextHash(data), key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Clef returns signatures with the recovery id equal to 27 or 28.
+	signature[len(signature)-1] += 27
+
+	return signature, nil
+}
+
+func (sesa *stubExternalSignerAPI) SignTransaction(
+	ctx context.Context,
+	args apitypes.SendTxArgs,
+	methodSelector *string,
+) (*stubSignTransactionResult, error) {
+	key, ok := sesa.keys[args.From.Address()]
+	if !ok {
+		return nil, fmt.Errorf("unknown account [%v]", args.From.Address())
+	}
+
+	if sesa.tamper {
+		args.Nonce++
+	}
+
+	transaction, err := types.SignTx(
+		args.ToTransaction(),
+		types.LatestSignerForChainID((*big.Int)(args.ChainID)),
+		key,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := transaction.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &stubSignTransactionResult{raw, transaction}, nil
+}
+
+type stubSignTransactionResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
@@ -1,9 +1,9 @@
 package ethereum
 
 import (
+	"crypto/ecdsa"
 	"fmt"
 
-	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
@@ -14,14 +14,28 @@ import (
 // TODO: Consider moving the `EthereumSigner` out of `keep-common` to this file.
 type signer struct {
 	*ethutil.EthereumSigner
+
+	operatorSigner operatorSigner
 }
 
-func newSigner(chainKey *keystore.Key) *signer {
+func newSigner(operatorSigner operatorSigner) *signer {
 	return &signer{
-		ethutil.NewSigner(chainKey.PrivateKey),
+		// The EthereumSigner is used only for signature verification and
+		// key conversions so it does not need the private key. Signing is
+		// delegated to the operator signer.
+		EthereumSigner: ethutil.NewSigner(
+			&ecdsa.PrivateKey{PublicKey: *operatorSigner.publicKey()},
+		),
+		operatorSigner: operatorSigner,
 	}
 }
 
+// Sign signs the provided message with the operator key using
+// Ethereum-specific format.
+func (s *signer) Sign(message []byte) ([]byte, error) {
+	return s.operatorSigner.signMessage(message)
+}
+
 // Address returns operator's address.
 func (s *signer) Address() chain.Address {
 	return s.PublicKeyBytesToAddress(s.PublicKey())
@@ -50,5 +64,5 @@ func (s *signer) PublicKeyBytesToAddress(publicKey []byte) chain.Address {
 }
 
 func (bc *baseChain) Signing() chain.Signing {
-	return newSigner(bc.key)
+	return newSigner(bc.operatorSigner)
 }
@@ -32,7 +32,7 @@ func TestSigner_PublicKeyToAddress(t *testing.T) {
 	}
 
 	// The chain key is not relevant in this scenario.
-	signer := newSigner(keystore.NewKeyForDirectICAP(rand.Reader))
+	signer := newSigner(&keyFileSigner{keystore.NewKeyForDirectICAP(rand.Reader)})
 
 	address, err := signer.PublicKeyToAddress(operatorPublicKey)
 	if err != nil {
@@ -10,9 +10,11 @@ import (
 	"math/big"
 	"reflect"
 	"sort"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
@@ -132,12 +134,98 @@ func newTbtcChain(
 		)
 	}
 
-	return &TbtcChain{
+	tbtcChain := &TbtcChain{
 		baseChain:      baseChain,
 		bridge:         bridge,
 		walletRegistry: walletRegistry,
 		sortitionPool:  sortitionPool,
-	}, nil
+	}
+
+	err = tbtcChain.registerObsolescenceChecks(walletRegistryAddress)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to register transactions obsolescence checks: [%v]",
+			err,
+		)
+	}
+
+	return tbtcChain, nil
+}
+
+// registerObsolescenceChecks makes the transaction manager cancel pending
+// DKG result submissions, challenges and approvals once the DKG is no longer
+// in the state they were submitted in, or once another result is submitted
+// in case of challenges and approvals. This happens, for example, when
+// another operator approved the result first.
+func (tc *TbtcChain) registerObsolescenceChecks(
+	walletRegistryAddress common.Address,
+) error {
+	walletRegistryAbi, err := ecdsaabi.WalletRegistryMetaData.GetAbi()
+	if err != nil {
+		return fmt.Errorf("cannot get WalletRegistry ABI: [%v]", err)
+	}
+
+	latestResultHash := &submittedResultHash{}
+	_ = tc.walletRegistry.DkgResultSubmittedEvent(nil, nil, nil).OnEvent(
+		func(
+			resultHash [32]byte,
+			seed *big.Int,
+			result ecdsaabi.EcdsaDkgResult,
+			blockNumber uint64,
+		) {
+			latestResultHash.update(resultHash, blockNumber)
+		},
+	)
+
+	obsoleteUnless := func(
+		expectedState tbtc.DKGState,
+		sameResult bool,
+	) obsolescenceCheck {
+		return func(transaction *types.Transaction) (bool, error) {
+			state, err := tc.GetDKGState()
+			if err != nil {
+				return false, err
+			}
+
+			if state != expectedState {
+				return true, nil
+			}
+
+			// Challenges and approvals refer to the submitted result and
+			// are obsolete once another result is submitted.
+			if hash, ok := latestResultHash.get(); sameResult && ok {
+				return dkgResultHash(transaction) != hash, nil
+			}
+
+			return false, nil
+		}
+	}
+
+	for methodName, check := range map[string]obsolescenceCheck{
+		"submitDkgResult":    obsoleteUnless(tbtc.AwaitingResult, false),
+		"challengeDkgResult": obsoleteUnless(tbtc.Challenge, true),
+		"approveDkgResult":   obsoleteUnless(tbtc.Challenge, true),
+	} {
+		err := tc.transactionManager.registerObsolescenceCheck(
+			walletRegistryAddress,
+			walletRegistryAbi,
+			methodName,
+			check,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AverageBlockTime returns the average block time of the chain the TBTC
+// application runs on, determined by the chain ID. It is zero unless the TBTC
+// application runs on a separate chain so block durations are not scaled on
+// Ethereum.
+func (tc *TbtcChain) AverageBlockTime() time.Duration {
+	return tc.averageBlockTime
 }
 
 // Staking returns address of the TokenStaking contract the WalletRegistry is
@@ -207,11 +295,22 @@ func (tc *TbtcChain) IsRecognized(operatorPublicKey *operator.PublicKey) (bool,
 // false. If the staking provider has been registered, the address is not
 // empty and the boolean flag indicates true.
 func (tc *TbtcChain) OperatorToStakingProvider() (chain.Address, bool, error) {
-	stakingProvider, err := tc.walletRegistry.OperatorToStakingProvider(tc.key.Address)
+	return tc.StakingProviderOf(chain.Address(tc.key.Address.Hex()))
+}
+
+// StakingProviderOf returns the staking provider of the given operator.
+// The boolean returned value is false if the operator is not registered
+// for any staking provider.
+func (tc *TbtcChain) StakingProviderOf(
+	operator chain.Address,
+) (chain.Address, bool, error) {
+	stakingProvider, err := tc.walletRegistry.OperatorToStakingProvider(
+		common.HexToAddress(operator.String()),
+	)
 	if err != nil {
 		return "", false, fmt.Errorf(
 			"failed to map operator [%v] to a staking provider: [%v]",
-			tc.key.Address,
+			operator,
 			err,
 		)
 	}
@@ -587,6 +686,45 @@ func (tc *TbtcChain) SubmitDKGResult(
 	return err
 }
 
+// HasFundsForDKGResultSubmission checks whether the operator account balance
+// covers the estimated fee of the given DKG result submission. The fee is
+// estimated as the estimated gas of the submission multiplied by the
+// currently suggested gas price.
+func (tc *TbtcChain) HasFundsForDKGResultSubmission(
+	dkgResult *tbtc.DKGChainResult,
+) (bool, error) {
+	gasEstimate, err := tc.walletRegistry.SubmitDkgResultGasEstimate(
+		convertDkgResultToAbiType(dkgResult),
+	)
+	if err != nil {
+		return false, fmt.Errorf(
+			"cannot estimate gas of DKG result submission: [%v]",
+			err,
+		)
+	}
+
+	gasPrice, err := tc.client.SuggestGasPrice(context.Background())
+	if err != nil {
+		return false, fmt.Errorf("cannot get suggested gas price: [%v]", err)
+	}
+
+	balance, err := tc.client.BalanceAt(
+		context.Background(),
+		tc.key.Address,
+		nil,
+	)
+	if err != nil {
+		return false, fmt.Errorf("cannot get operator balance: [%v]", err)
+	}
+
+	estimatedFee := new(big.Int).Mul(
+		new(big.Int).SetUint64(gasEstimate),
+		gasPrice,
+	)
+
+	return balance.Cmp(estimatedFee) >= 0, nil
+}
+
 // computeOperatorsIDsHash computes the keccak256 hash for the given list
 // of operators IDs.
 func computeOperatorsIDsHash(operatorsIDs chain.OperatorIDs) ([32]byte, error) {
@@ -744,17 +882,39 @@ func calculateDKGResultSignatureHash(
 		)
 	}
 
-	uint256Type, err := abi.NewType("uint256", "uint256", nil)
+	hash, err := computeDKGResultSignatureHash(
+		chainID,
+		groupPublicKey,
+		misbehavedMembersIndexes,
+		startBlock,
+	)
 	if err != nil {
 		return dkg.ResultSignatureHash{}, err
 	}
+
+	return dkg.ResultSignatureHash(hash), nil
+}
+
+// computeDKGResultSignatureHash computes the keccak256 hash over the ABI
+// encoding of the given DKG result parameters. This is the hash signed by
+// DKG group members of both the WalletRegistry and RandomBeacon contracts.
+func computeDKGResultSignatureHash(
+	chainID *big.Int,
+	groupPublicKey []byte,
+	misbehavedMembersIndexes []group.MemberIndex,
+	startBlock *big.Int,
+) ([32]byte, error) {
+	uint256Type, err := abi.NewType("uint256", "uint256", nil)
+	if err != nil {
+		return [32]byte{}, err
+	}
 	bytesType, err := abi.NewType("bytes", "bytes", nil)
 	if err != nil {
-		return dkg.ResultSignatureHash{}, err
+		return [32]byte{}, err
 	}
 	uint8SliceType, err := abi.NewType("uint8[]", "uint8[]", nil)
 	if err != nil {
-		return dkg.ResultSignatureHash{}, err
+		return [32]byte{}, err
 	}
 
 	bytes, err := abi.Arguments{
@@ -769,10 +929,10 @@ func calculateDKGResultSignatureHash(
 		startBlock,
 	)
 	if err != nil {
-		return dkg.ResultSignatureHash{}, err
+		return [32]byte{}, err
 	}
 
-	return dkg.ResultSignatureHash(crypto.Keccak256Hash(bytes)), nil
+	return crypto.Keccak256Hash(bytes), nil
 }
 
 func (tc *TbtcChain) IsDKGResultValid(
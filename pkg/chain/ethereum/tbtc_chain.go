@@ -0,0 +1,160 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+)
+
+// TbtcChainConfig holds the configuration of a separate EVM chain the TBTC
+// application runs on. If the URL is empty, the TBTC application runs on the
+// same Ethereum chain as the Random Beacon application.
+type TbtcChainConfig struct {
+	// URL of the chain RPC API. Multiple comma-separated URLs enable
+	// failover between them.
+	URL string
+
+	// ChainID is the expected ID of the chain. It must be different from
+	// the ID of the Ethereum chain and one of the supported TBTC chains.
+	ChainID uint64
+
+	// BridgeAddress is the address of the Bridge contract deployed on
+	// the chain.
+	BridgeAddress string
+
+	// TokenStakingAddress is the address of the TokenStaking contract
+	// deployed on the chain.
+	TokenStakingAddress string
+}
+
+// tbtcChainBlockTimes holds average block times of chains supported as
+// separate TBTC application chains, by chain ID. Block durations of the TBTC
+// protocol phases are scaled by the average block time so the phases last
+// roughly as long as on Ethereum mainnet. The block times are fixed per chain
+// rather than configured as all operators of the chain must scale the block
+// durations exactly the same way to agree on the protocol phase boundaries.
+//
+// Arbitrum chains are not supported. The block.number seen by Arbitrum
+// contracts is the approximate number of the L1 block, not the number of
+// the L2 block reported by the chain API, so the client and the contracts
+// would not agree on the protocol phase boundaries.
+var tbtcChainBlockTimes = map[uint64]time.Duration{
+	10:       2 * time.Second, // OP Mainnet
+	137:      2 * time.Second, // Polygon PoS
+	8453:     2 * time.Second, // Base
+	80002:    2 * time.Second, // Polygon Amoy
+	84532:    2 * time.Second, // Base Sepolia
+	11155420: 2 * time.Second, // OP Sepolia
+}
+
+// tbtcChainAverageBlockTime returns the average block time of the chain with
+// the given ID. An error is returned if the chain is not supported as the
+// TBTC application chain.
+func tbtcChainAverageBlockTime(chainID uint64) (time.Duration, error) {
+	averageBlockTime, ok := tbtcChainBlockTimes[chainID]
+	if !ok {
+		return 0, fmt.Errorf("TBTC chain id [%d] is not supported", chainID)
+	}
+
+	return averageBlockTime, nil
+}
+
+// IsSet returns true if a separate chain is configured for the TBTC
+// application.
+func (tcc TbtcChainConfig) IsSet() bool {
+	return tcc.URL != ""
+}
+
+// ethereumConfig returns the config of the TBTC application chain derived
+// from the given Ethereum chain config. The operator account, gas and rate
+// limiting settings are shared by both chains.
+func (tcc TbtcChainConfig) ethereumConfig(
+	config ethereum.Config,
+) ethereum.Config {
+	tbtcConfig := config
+	tbtcConfig.URL = tcc.URL
+	tbtcConfig.ContractAddresses = make(map[string]string)
+	tbtcConfig.SetContractAddress(BridgeContractName, tcc.BridgeAddress)
+	tbtcConfig.SetContractAddress(
+		TokenStakingContractName,
+		tcc.TokenStakingAddress,
+	)
+
+	return tbtcConfig
+}
+
+// connectTbtcBaseChain connects to the separate chain of the TBTC application
+// and constructs its base chain handle. The handle uses the operator signer
+// of the given Ethereum base chain but has its own block counter, nonce
+// manager and transaction manager.
+func connectTbtcBaseChain(
+	ctx context.Context,
+	config ethereum.Config,
+	tbtcChainConfig TbtcChainConfig,
+	ethereumChain *baseChain,
+) (*baseChain, error) {
+	client, err := connectEndpoints(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error connecting to TBTC chain endpoints: [%v]",
+			err,
+		)
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to resolve TBTC chain id: [%v]",
+			err,
+		)
+	}
+
+	if new(big.Int).SetUint64(tbtcChainConfig.ChainID).Cmp(chainID) != 0 {
+		return nil, fmt.Errorf(
+			"chain id returned from TBTC chain api [%s] doesn't match "+
+				"the expected chain id [%d]; please verify the configured "+
+				"tbtcChain.url and tbtcChain.chainId",
+			chainID.String(),
+			tbtcChainConfig.ChainID,
+		)
+	}
+
+	// Both chains are used by the same operator account so sharing the chain
+	// would make two nonce managers compete for the same nonces.
+	if chainID.Cmp(ethereumChain.chainID) == 0 {
+		return nil, fmt.Errorf(
+			"TBTC chain id [%s] is the same as the Ethereum chain id",
+			chainID.String(),
+		)
+	}
+
+	averageBlockTime, err := tbtcChainAverageBlockTime(tbtcChainConfig.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	tbtcBaseChain, err := attachBaseChain(
+		ctx,
+		config,
+		client,
+		chainID,
+		ethereumChain.operatorSigner,
+		ethereumChain.operatorKey,
+		fmt.Sprintf("%s_%s", pendingTransactionsDirectory, chainID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tbtcBaseChain.averageBlockTime = averageBlockTime
+
+	logger.Infof(
+		"TBTC application runs on a separate chain with id [%s]",
+		chainID.String(),
+	)
+
+	return tbtcBaseChain, nil
+}
@@ -0,0 +1,140 @@
+package ethereum
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+func TestTbtcChainConfig_EthereumConfig(t *testing.T) {
+	config := ethereum.Config{
+		Network:                ethereum.Mainnet,
+		URL:                    "wss://mainnet.provider.io",
+		RequestsPerSecondLimit: 10,
+		ContractAddresses: map[string]string{
+			"randombeacon": "0x5499f54b4A1CB4816eefCf78962040461be3D80b",
+			"bridge":       "0x5e4861a80B55f035D899f66772117F00FA0E8e7B",
+			"tokenstaking": "0x01B67b1194C75264d06F808A921228a95C765dd7",
+		},
+	}
+
+	tbtcChainConfig := TbtcChainConfig{
+		URL:                 "wss://l2.provider.io",
+		ChainID:             8453,
+		BridgeAddress:       "0x8d14a6fb7fd4c7c9d2a4eb1e5b0d5f1b4c6a2e10",
+		TokenStakingAddress: "0x2b8f4c3a5e1d7c9b0a6f8e4d2c1b3a5f7e9d0c12",
+	}
+
+	tbtcConfig := tbtcChainConfig.ethereumConfig(config)
+
+	testutils.AssertStringsEqual(t, "URL", tbtcChainConfig.URL, tbtcConfig.URL)
+	testutils.AssertIntsEqual(
+		t,
+		"requests per second limit",
+		config.RequestsPerSecondLimit,
+		tbtcConfig.RequestsPerSecondLimit,
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"contract addresses count",
+		2,
+		len(tbtcConfig.ContractAddresses),
+	)
+
+	var tests = map[string]struct {
+		contractName    string
+		expectedAddress string
+	}{
+		"bridge": {
+			contractName:    BridgeContractName,
+			expectedAddress: tbtcChainConfig.BridgeAddress,
+		},
+		"token staking": {
+			contractName:    TokenStakingContractName,
+			expectedAddress: tbtcChainConfig.TokenStakingAddress,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			address, err := tbtcConfig.ContractAddress(test.contractName)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertStringsEqual(
+				t,
+				"address",
+				strings.ToLower(test.expectedAddress),
+				strings.ToLower(address.Hex()),
+			)
+		})
+	}
+
+	// The Ethereum config must not be affected.
+	testutils.AssertIntsEqual(
+		t,
+		"Ethereum contract addresses count",
+		3,
+		len(config.ContractAddresses),
+	)
+}
+
+func TestTbtcChainAverageBlockTime(t *testing.T) {
+	var tests = map[string]struct {
+		chainID                  uint64
+		expectedAverageBlockTime time.Duration
+		expectedError            error
+	}{
+		"OP Mainnet": {
+			chainID:                  10,
+			expectedAverageBlockTime: 2 * time.Second,
+		},
+		"Base Sepolia": {
+			chainID:                  84532,
+			expectedAverageBlockTime: 2 * time.Second,
+		},
+		"Ethereum mainnet": {
+			chainID:       1,
+			expectedError: fmt.Errorf("TBTC chain id [1] is not supported"),
+		},
+		// Arbitrum contracts see the L1 block number so block durations
+		// cannot be scaled by the L2 block time.
+		"Arbitrum One": {
+			chainID:       42161,
+			expectedError: fmt.Errorf("TBTC chain id [42161] is not supported"),
+		},
+		"Arbitrum Sepolia": {
+			chainID:       421614,
+			expectedError: fmt.Errorf("TBTC chain id [421614] is not supported"),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			averageBlockTime, err := tbtcChainAverageBlockTime(test.chainID)
+
+			if !reflect.DeepEqual(test.expectedError, err) {
+				t.Errorf(
+					"unexpected error\nexpected: %v\nactual:   %v",
+					test.expectedError,
+					err,
+				)
+			}
+
+			if test.expectedAverageBlockTime != averageBlockTime {
+				t.Errorf(
+					"unexpected average block time\nexpected: %v\nactual:   %v",
+					test.expectedAverageBlockTime,
+					averageBlockTime,
+				)
+			}
+		})
+	}
+}
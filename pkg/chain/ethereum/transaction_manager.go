@@ -0,0 +1,1130 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+// pendingTransactionsDirectory is the name of the work persistence directory
+// holding transactions submitted by the operator account that were not mined
+// yet.
+const pendingTransactionsDirectory = "pending_transactions"
+
+// maxTransactionsInFlight is the maximum number of transactions sent to the
+// network and not mined yet. Further transactions are queued and sent, from
+// the lowest nonce, once transactions in flight get mined.
+const maxTransactionsInFlight = 8
+
+// obsolescenceCheck determines whether the given pending transaction no
+// longer needs to be mined, for example because another operator already did
+// the same action on-chain.
+type obsolescenceCheck func(transaction *types.Transaction) (bool, error)
+
+// obsolescenceCheckKey identifies a contract method whose pending
+// transactions are subject to an obsolescence check.
+type obsolescenceCheckKey struct {
+	contract common.Address
+	method   [4]byte
+}
+
+// pendingTransaction is a transaction submitted by the operator account that
+// was not mined yet.
+type pendingTransaction struct {
+	// versions contains all versions of the transaction, from the oldest.
+	// Any of them can be mined. The last one is the version sent to the
+	// network most recently.
+	versions []*types.Transaction
+	// submittedAt is the time the most recent version of the transaction was
+	// sent to the network. It is zero for queued transactions.
+	submittedAt time.Time
+}
+
+// transaction returns the most recent version of the pending transaction.
+func (pt *pendingTransaction) transaction() *types.Transaction {
+	return pt.versions[len(pt.versions)-1]
+}
+
+// transactionManager keeps track of transactions submitted by the operator
+// account, by their nonces, until they are mined. At most
+// maxTransactionsInFlight transactions are sent to the network at a time;
+// further transactions are queued until earlier ones get mined. Transactions
+// not mined within the check interval are re-submitted with bumped gas fees,
+// up to the max gas fee cap. Transactions that became obsolete before being
+// mined are cancelled by replacing them with an empty transfer to the
+// operator account. Pending and queued transactions, with all their versions,
+// can be persisted so they are recovered after a restart of the client.
+//
+// The transaction manager takes over the responsibility of the mining waiter
+// used by the generated contract bindings. The mining waiter must be
+// configured not to re-submit transactions on its own.
+//
+// No lock is held while communicating with the network so that a slow
+// endpoint does not block submission of other transactions.
+//
+// All transactions are signed with the operator signer. Contract bindings
+// require a private key so if the operator key is held by an external signer,
+// the bindings sign transactions with a throwaway transactor key. Such
+// transactions are signed again by the operator signer before being sent and
+// their original hashes are aliased to the hashes of transactions actually
+// sent so that the bindings can still wait for them to be mined.
+type transactionManager struct {
+	client            ethutil.EthereumClient
+	operatorSigner    operatorSigner
+	transactorAddress common.Address
+	chainID           *big.Int
+	signer            types.Signer
+
+	checkInterval time.Duration
+	maxGasFeeCap  *big.Int
+
+	obsolescenceChecksMutex sync.RWMutex
+	obsolescenceChecks      map[obsolescenceCheckKey]obsolescenceCheck
+
+	pendingMutex sync.Mutex
+	// pending holds transactions sent to the network, by their nonces.
+	pending map[uint64]*pendingTransaction
+	// queued holds transactions waiting to be sent to the network, by their
+	// nonces.
+	queued      map[uint64]*pendingTransaction
+	persistence persistence.BasicHandle
+	// persistenceDirectory is the name of the work persistence directory
+	// holding pending transactions. Transaction managers of different chains
+	// must use different directories as transactions are persisted under
+	// their nonces.
+	persistenceDirectory string
+
+	// aliases maps hashes of transactions signed by the transactor to hashes
+	// of transactions signed by the operator and sent to the network. They are
+	// kept for the lifetime of the client as contract bindings may wait for
+	// the transaction after the manager stopped tracking it.
+	aliasesMutex sync.RWMutex
+	aliases      map[common.Hash]common.Hash
+}
+
+func newTransactionManager(
+	client ethutil.EthereumClient,
+	operatorSigner operatorSigner,
+	transactorAddress common.Address,
+	chainID *big.Int,
+	config ethereum.Config,
+) *transactionManager {
+	checkInterval := ethutil.DefaultMiningCheckInterval
+	if config.MiningCheckInterval != 0 {
+		checkInterval = config.MiningCheckInterval
+	}
+
+	maxGasFeeCap := ethutil.DefaultMaxGasFeeCap.Int
+	if config.MaxGasFeeCap.Int != nil {
+		maxGasFeeCap = config.MaxGasFeeCap.Int
+	}
+
+	return &transactionManager{
+		client:             client,
+		operatorSigner:     operatorSigner,
+		transactorAddress:  transactorAddress,
+		chainID:            chainID,
+		signer:             types.LatestSignerForChainID(chainID),
+		checkInterval:      checkInterval,
+		maxGasFeeCap:       maxGasFeeCap,
+		obsolescenceChecks: make(map[obsolescenceCheckKey]obsolescenceCheck),
+		pending:            make(map[uint64]*pendingTransaction),
+		queued:             make(map[uint64]*pendingTransaction),
+		aliases:            make(map[common.Hash]common.Hash),
+
+		persistenceDirectory: pendingTransactionsDirectory,
+	}
+}
+
+// managedClient is an Ethereum client submitting transactions through the
+// transaction manager.
+type managedClient struct {
+	ethutil.EthereumClient
+
+	manager *transactionManager
+}
+
+// SendTransaction sends the transaction to the network and starts tracking
+// it in the transaction manager.
+func (mc *managedClient) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	return mc.manager.submit(ctx, transaction)
+}
+
+// PendingNonceAt returns the next nonce of the operator account if the nonce
+// of the transactor is requested. The nonce accounts for transactions queued
+// in the transaction manager and not sent to the network yet.
+func (mc *managedClient) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	account = mc.manager.operatorAccount(account)
+
+	nonce, err := mc.EthereumClient.PendingNonceAt(ctx, account)
+	if err != nil {
+		return 0, err
+	}
+
+	if account != mc.manager.operatorSigner.address() {
+		return nonce, nil
+	}
+
+	return mc.manager.nextNonce(nonce), nil
+}
+
+// CallContract executes the call on behalf of the operator account if the
+// call is done on behalf of the transactor.
+func (mc *managedClient) CallContract(
+	ctx context.Context,
+	call goethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	return mc.EthereumClient.CallContract(
+		ctx,
+		mc.manager.onBehalfOfOperator(call),
+		blockNumber,
+	)
+}
+
+// EstimateGas estimates the gas on behalf of the operator account if the
+// estimation is done on behalf of the transactor.
+func (mc *managedClient) EstimateGas(
+	ctx context.Context,
+	call goethereum.CallMsg,
+) (uint64, error) {
+	return mc.EthereumClient.EstimateGas(
+		ctx,
+		mc.manager.onBehalfOfOperator(call),
+	)
+}
+
+// TransactionReceipt returns the receipt of the transaction with the given
+// hash, resolving hashes of transactions signed again before being sent.
+func (mc *managedClient) TransactionReceipt(
+	ctx context.Context,
+	txHash common.Hash,
+) (*types.Receipt, error) {
+	return mc.EthereumClient.TransactionReceipt(
+		ctx,
+		mc.manager.resolveAlias(txHash),
+	)
+}
+
+// TransactionByHash returns the transaction with the given hash, resolving
+// hashes of transactions signed again before being sent.
+func (mc *managedClient) TransactionByHash(
+	ctx context.Context,
+	txHash common.Hash,
+) (*types.Transaction, bool, error) {
+	return mc.EthereumClient.TransactionByHash(
+		ctx,
+		mc.manager.resolveAlias(txHash),
+	)
+}
+
+// managedClient returns a client submitting transactions through the
+// transaction manager.
+func (tm *transactionManager) managedClient() ethutil.EthereumClient {
+	return &managedClient{tm.client, tm}
+}
+
+// registerObsolescenceCheck registers a check determining whether pending
+// transactions calling the given method of the given contract are obsolete.
+// Obsolete transactions are cancelled instead of being re-submitted.
+func (tm *transactionManager) registerObsolescenceCheck(
+	contract common.Address,
+	contractAbi *abi.ABI,
+	methodName string,
+	check obsolescenceCheck,
+) error {
+	method, ok := contractAbi.Methods[methodName]
+	if !ok {
+		return fmt.Errorf("method [%v] not found in the ABI", methodName)
+	}
+
+	tm.obsolescenceChecksMutex.Lock()
+	defer tm.obsolescenceChecksMutex.Unlock()
+
+	key := obsolescenceCheckKey{contract: contract}
+	copy(key.method[:], method.ID)
+
+	tm.obsolescenceChecks[key] = check
+
+	return nil
+}
+
+// submit sends the given transaction to the network and tracks it until it
+// is mined. If the maximum number of transactions is already in flight, the
+// transaction is queued and sent once earlier transactions get mined.
+func (tm *transactionManager) submit(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	transaction, err := tm.signedByOperator(transaction)
+	if err != nil {
+		return err
+	}
+
+	nonce := transaction.Nonce()
+	pending := &pendingTransaction{
+		versions: []*types.Transaction{transaction},
+	}
+
+	tm.pendingMutex.Lock()
+
+	if tm.isManaged(nonce) {
+		tm.pendingMutex.Unlock()
+		return fmt.Errorf(
+			"transaction with nonce [%v] is already managed by the "+
+				"transaction manager",
+			nonce,
+		)
+	}
+
+	if len(tm.pending) >= maxTransactionsInFlight || len(tm.queued) > 0 {
+		tm.queued[nonce] = pending
+		tm.persist(pending)
+		tm.pendingMutex.Unlock()
+
+		logger.Infof(
+			"queued transaction [%v] with nonce [%v]",
+			transaction.Hash().TerminalString(),
+			nonce,
+		)
+
+		return nil
+	}
+
+	// The nonce is reserved before the transaction is sent so that
+	// the pending transactions mutex is not held during the call.
+	pending.submittedAt = time.Now()
+	tm.pending[nonce] = pending
+	tm.persist(pending)
+
+	tm.pendingMutex.Unlock()
+
+	if err := tm.client.SendTransaction(ctx, transaction); err != nil {
+		tm.pendingMutex.Lock()
+		if tm.pending[nonce] == pending {
+			tm.untrack(nonce)
+		}
+		tm.pendingMutex.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// observe periodically checks pending transactions until the context is done.
+func (tm *transactionManager) observe(ctx context.Context) {
+	logger.Infof(
+		"using [%v] transaction check interval and [%v] wei max gas fee cap",
+		tm.checkInterval,
+		tm.maxGasFeeCap,
+	)
+
+	go func() {
+		ticker := time.NewTicker(tm.checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				tm.checkPendingTransactions(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// checkPendingTransactions checks all pending transactions, from the lowest
+// nonce. Mined transactions stop being tracked. Obsolete transactions are
+// cancelled and transactions not mined within the check interval are
+// re-submitted with bumped gas fees. Queued transactions are sent afterwards
+// if there is room for them.
+func (tm *transactionManager) checkPendingTransactions(ctx context.Context) {
+	tm.pendingMutex.Lock()
+	pendings := make([]*pendingTransaction, 0, len(tm.pending))
+	for _, nonce := range sortedNonces(tm.pending) {
+		pendings = append(pendings, tm.pending[nonce])
+	}
+	tm.pendingMutex.Unlock()
+
+	for _, pending := range pendings {
+		tm.checkPendingTransaction(ctx, pending)
+	}
+
+	tm.sendQueuedTransactions(ctx)
+}
+
+func (tm *transactionManager) checkPendingTransaction(
+	ctx context.Context,
+	pending *pendingTransaction,
+) {
+	tm.pendingMutex.Lock()
+	versions := append([]*types.Transaction{}, pending.versions...)
+	submittedAt := pending.submittedAt
+	tm.pendingMutex.Unlock()
+
+	transaction := versions[len(versions)-1]
+	nonce := transaction.Nonce()
+
+	for _, version := range versions {
+		receipt, _ := tm.client.TransactionReceipt(ctx, version.Hash())
+		if receipt != nil {
+			logger.Infof(
+				"transaction [%v] with nonce [%v] mined with status [%v] "+
+					"at block [%v]",
+				version.Hash().TerminalString(),
+				nonce,
+				receipt.Status,
+				receipt.BlockNumber,
+			)
+			tm.untrackIfPending(nonce, pending)
+			return
+		}
+	}
+
+	if time.Since(submittedAt) < tm.checkInterval {
+		return
+	}
+
+	cancel := tm.isCancellation(transaction)
+	if !cancel {
+		obsolete, err := tm.isObsolete(transaction)
+		if err != nil {
+			logger.Warnf(
+				"cannot check if transaction [%v] with nonce [%v] "+
+					"is obsolete: [%v]",
+				transaction.Hash().TerminalString(),
+				nonce,
+				err,
+			)
+		}
+		cancel = obsolete
+	}
+
+	replacement, err := tm.replacement(ctx, transaction, cancel)
+	if err != nil {
+		logger.Warnf(
+			"cannot replace transaction [%v] with nonce [%v]: [%v]",
+			transaction.Hash().TerminalString(),
+			nonce,
+			err,
+		)
+		return
+	}
+
+	if err := tm.client.SendTransaction(ctx, replacement); err != nil {
+		if isNonceTooLow(err) {
+			// Another transaction with the same nonce has been mined.
+			// It can be a transaction sent outside of the client.
+			logger.Infof(
+				"nonce [%v] of transaction [%v] already used",
+				nonce,
+				transaction.Hash().TerminalString(),
+			)
+			tm.untrackIfPending(nonce, pending)
+			return
+		}
+
+		logger.Warnf(
+			"cannot send replacement of transaction [%v] "+
+				"with nonce [%v]: [%v]",
+			transaction.Hash().TerminalString(),
+			nonce,
+			err,
+		)
+		return
+	}
+
+	if cancel {
+		logger.Infof(
+			"cancelling obsolete transaction [%v] with nonce [%v] "+
+				"with transaction [%v]",
+			transaction.Hash().TerminalString(),
+			nonce,
+			replacement.Hash().TerminalString(),
+		)
+	} else {
+		logger.Infof(
+			"resubmitted transaction [%v] with nonce [%v] "+
+				"with bumped gas fees as transaction [%v]",
+			transaction.Hash().TerminalString(),
+			nonce,
+			replacement.Hash().TerminalString(),
+		)
+	}
+
+	tm.pendingMutex.Lock()
+	defer tm.pendingMutex.Unlock()
+
+	if tm.pending[nonce] != pending {
+		// The transaction stopped being tracked in the meantime.
+		return
+	}
+
+	pending.versions = append(pending.versions, replacement)
+	pending.submittedAt = time.Now()
+	tm.persist(pending)
+}
+
+// sendQueuedTransactions sends queued transactions to the network, from the
+// lowest nonce, as long as the maximum number of transactions in flight is
+// not reached. Queued transactions whose nonces are already used are
+// dropped. Sending stops on the first failure and the failed transaction
+// stays queued until the next check.
+func (tm *transactionManager) sendQueuedTransactions(ctx context.Context) {
+	for {
+		tm.pendingMutex.Lock()
+
+		if len(tm.queued) == 0 || len(tm.pending) >= maxTransactionsInFlight {
+			tm.pendingMutex.Unlock()
+			return
+		}
+
+		nonce := sortedNonces(tm.queued)[0]
+		pending := tm.queued[nonce]
+		delete(tm.queued, nonce)
+		pending.submittedAt = time.Now()
+		tm.pending[nonce] = pending
+		transaction := pending.transaction()
+
+		tm.pendingMutex.Unlock()
+
+		err := tm.client.SendTransaction(ctx, transaction)
+		if err == nil || isAlreadyKnown(err) {
+			logger.Infof(
+				"sent queued transaction [%v] with nonce [%v]",
+				transaction.Hash().TerminalString(),
+				nonce,
+			)
+			continue
+		}
+
+		if isNonceTooLow(err) {
+			// The queued transaction may have been mined before a restart of
+			// the client. The nonce can be used by another transaction as
+			// well. Receipts of all versions are checked to know which.
+			logger.Infof(
+				"nonce [%v] of queued transaction [%v] already used",
+				nonce,
+				transaction.Hash().TerminalString(),
+			)
+			tm.untrackIfPending(nonce, pending)
+			continue
+		}
+
+		logger.Warnf(
+			"cannot send queued transaction [%v] with nonce [%v]: [%v]",
+			transaction.Hash().TerminalString(),
+			nonce,
+			err,
+		)
+
+		tm.pendingMutex.Lock()
+		if tm.pending[nonce] == pending {
+			delete(tm.pending, nonce)
+			pending.submittedAt = time.Time{}
+			tm.queued[nonce] = pending
+		}
+		tm.pendingMutex.Unlock()
+
+		return
+	}
+}
+
+// isCancellation determines whether the given transaction is a cancellation,
+// that is an empty transfer to the operator account.
+func (tm *transactionManager) isCancellation(
+	transaction *types.Transaction,
+) bool {
+	return transaction.To() != nil &&
+		*transaction.To() == tm.operatorSigner.address() &&
+		len(transaction.Data()) == 0
+}
+
+// isObsolete runs the obsolescence check registered for the contract method
+// called by the given transaction. Transactions without a registered check
+// are never obsolete.
+func (tm *transactionManager) isObsolete(
+	transaction *types.Transaction,
+) (bool, error) {
+	if transaction.To() == nil || len(transaction.Data()) < 4 {
+		return false, nil
+	}
+
+	key := obsolescenceCheckKey{contract: *transaction.To()}
+	copy(key.method[:], transaction.Data()[:4])
+
+	tm.obsolescenceChecksMutex.RLock()
+	check, ok := tm.obsolescenceChecks[key]
+	tm.obsolescenceChecksMutex.RUnlock()
+
+	if !ok {
+		return false, nil
+	}
+
+	return check(transaction)
+}
+
+// replacement creates a signed transaction with the same nonce as the given
+// one and bumped gas fees. If cancel is true, the replacement is an empty
+// transfer to the operator account.
+func (tm *transactionManager) replacement(
+	ctx context.Context,
+	original *types.Transaction,
+	cancel bool,
+) (*types.Transaction, error) {
+	var latestBaseFee *big.Int
+	if original.Type() == types.DynamicFeeTxType {
+		header, err := tm.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get latest header: [%v]", err)
+		}
+		latestBaseFee = header.BaseFee
+	}
+
+	gasTipCap, gasFeeCap, err := bumpGasFees(
+		original,
+		latestBaseFee,
+		tm.maxGasFeeCap,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	to := original.To()
+	value := original.Value()
+	data := original.Data()
+	gas := original.Gas()
+	if cancel {
+		operatorAddress := tm.operatorSigner.address()
+		to = &operatorAddress
+		value = big.NewInt(0)
+		data = nil
+		gas = params.TxGas
+	}
+
+	var inner types.TxData
+	switch original.Type() {
+	case types.LegacyTxType:
+		inner = &types.LegacyTx{
+			Nonce:    original.Nonce(),
+			GasPrice: gasFeeCap,
+			Gas:      gas,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		}
+	case types.AccessListTxType:
+		inner = &types.AccessListTx{
+			ChainID:    original.ChainId(),
+			Nonce:      original.Nonce(),
+			GasPrice:   gasFeeCap,
+			Gas:        gas,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: original.AccessList(),
+		}
+	case types.DynamicFeeTxType:
+		inner = &types.DynamicFeeTx{
+			ChainID:    original.ChainId(),
+			Nonce:      original.Nonce(),
+			GasTipCap:  gasTipCap,
+			GasFeeCap:  gasFeeCap,
+			Gas:        gas,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: original.AccessList(),
+		}
+	default:
+		return nil, fmt.Errorf(
+			"unsupported transaction type [%v]",
+			original.Type(),
+		)
+	}
+
+	return tm.operatorSigner.signTransaction(types.NewTx(inner), tm.chainID)
+}
+
+// signedByOperator makes sure the given transaction is signed by the operator
+// account. Transactions signed by the transactor are signed again with the
+// operator signer and the hash of the original transaction becomes an alias
+// of the hash of the transaction signed by the operator.
+func (tm *transactionManager) signedByOperator(
+	transaction *types.Transaction,
+) (*types.Transaction, error) {
+	if tm.transactorAddress == tm.operatorSigner.address() {
+		return transaction, nil
+	}
+
+	sender, err := types.Sender(tm.signer, transaction)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot recover transaction sender: [%v]",
+			err,
+		)
+	}
+
+	if sender != tm.transactorAddress {
+		return transaction, nil
+	}
+
+	signedTransaction, err := tm.operatorSigner.signTransaction(
+		transaction,
+		tm.chainID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot sign transaction with operator signer: [%v]",
+			err,
+		)
+	}
+
+	tm.aliasesMutex.Lock()
+	tm.aliases[transaction.Hash()] = signedTransaction.Hash()
+	tm.aliasesMutex.Unlock()
+
+	return signedTransaction, nil
+}
+
+// resolveAlias returns the hash of the transaction sent to the network for
+// the given transaction hash.
+func (tm *transactionManager) resolveAlias(hash common.Hash) common.Hash {
+	tm.aliasesMutex.RLock()
+	defer tm.aliasesMutex.RUnlock()
+
+	if alias, ok := tm.aliases[hash]; ok {
+		return alias
+	}
+
+	return hash
+}
+
+// onBehalfOfOperator returns the given call executed on behalf of the
+// operator account if it is executed on behalf of the transactor.
+func (tm *transactionManager) onBehalfOfOperator(
+	call goethereum.CallMsg,
+) goethereum.CallMsg {
+	call.From = tm.operatorAccount(call.From)
+	return call
+}
+
+// operatorAccount returns the operator account if the given account is the
+// transactor. Otherwise, the given account is returned.
+func (tm *transactionManager) operatorAccount(
+	account common.Address,
+) common.Address {
+	if account == tm.transactorAddress {
+		return tm.operatorSigner.address()
+	}
+
+	return account
+}
+
+// nextNonce returns the nonce of the next transaction of the operator
+// account given the pending nonce reported by the network. Nonces of
+// transactions tracked by the manager, including queued ones, are never
+// returned.
+func (tm *transactionManager) nextNonce(networkNonce uint64) uint64 {
+	tm.pendingMutex.Lock()
+	defer tm.pendingMutex.Unlock()
+
+	nonce := networkNonce
+	for _, transactions := range []map[uint64]*pendingTransaction{
+		tm.pending,
+		tm.queued,
+	} {
+		for managedNonce := range transactions {
+			if managedNonce >= nonce {
+				nonce = managedNonce + 1
+			}
+		}
+	}
+
+	return nonce
+}
+
+// bumpGasFees computes gas fees of a replacement of the given transaction.
+// Fees are bumped by 20% but never exceed the max gas fee cap. Fees of
+// dynamic fee transactions also follow the latest base fee. An error is
+// returned if the fees cannot be bumped by at least 10%, which is the minimum
+// required by nodes to accept the replacement. For legacy transactions both
+// returned values are equal to the gas price.
+func bumpGasFees(
+	transaction *types.Transaction,
+	latestBaseFee *big.Int,
+	maxGasFeeCap *big.Int,
+) (*big.Int, *big.Int, error) {
+	bump := func(value *big.Int) *big.Int {
+		return new(big.Int).Add(value, new(big.Int).Div(value, big.NewInt(5)))
+	}
+	threshold := func(value *big.Int) *big.Int {
+		return new(big.Int).Add(value, new(big.Int).Div(value, big.NewInt(10)))
+	}
+	min := func(a, b *big.Int) *big.Int {
+		if a.Cmp(b) < 0 {
+			return a
+		}
+		return b
+	}
+
+	switch transaction.Type() {
+	case types.LegacyTxType, types.AccessListTxType:
+		gasPrice := min(bump(transaction.GasPrice()), maxGasFeeCap)
+		if gasPrice.Cmp(threshold(transaction.GasPrice())) < 0 {
+			return nil, nil, fmt.Errorf(
+				"gas price [%v] cannot be bumped as the max gas fee cap "+
+					"[%v] would be exceeded",
+				transaction.GasPrice(),
+				maxGasFeeCap,
+			)
+		}
+
+		return gasPrice, gasPrice, nil
+	case types.DynamicFeeTxType:
+		gasTipCap := bump(transaction.GasTipCap())
+
+		gasFeeCap := bump(transaction.GasFeeCap())
+		if latestBaseFee != nil {
+			baseFeeBased := new(big.Int).Add(
+				new(big.Int).Mul(latestBaseFee, big.NewInt(2)),
+				gasTipCap,
+			)
+			if baseFeeBased.Cmp(gasFeeCap) > 0 {
+				gasFeeCap = baseFeeBased
+			}
+		}
+		gasFeeCap = min(gasFeeCap, maxGasFeeCap)
+		gasTipCap = min(gasTipCap, gasFeeCap)
+
+		if gasFeeCap.Cmp(threshold(transaction.GasFeeCap())) < 0 ||
+			gasTipCap.Cmp(threshold(transaction.GasTipCap())) < 0 {
+			return nil, nil, fmt.Errorf(
+				"gas fee cap [%v] and gas tip cap [%v] cannot be bumped "+
+					"as the max gas fee cap [%v] would be exceeded",
+				transaction.GasFeeCap(),
+				transaction.GasTipCap(),
+				maxGasFeeCap,
+			)
+		}
+
+		return gasTipCap, gasFeeCap, nil
+	default:
+		return nil, nil, fmt.Errorf(
+			"unsupported transaction type [%v]",
+			transaction.Type(),
+		)
+	}
+}
+
+// isManaged determines whether a transaction with the given nonce is tracked
+// by the manager, either as a pending or a queued one.
+// Must be called with the pending transactions mutex held.
+func (tm *transactionManager) isManaged(nonce uint64) bool {
+	_, isPending := tm.pending[nonce]
+	_, isQueued := tm.queued[nonce]
+	return isPending || isQueued
+}
+
+// untrackIfPending stops tracking the transaction with the given nonce if it
+// is still the given pending transaction.
+func (tm *transactionManager) untrackIfPending(
+	nonce uint64,
+	pending *pendingTransaction,
+) {
+	tm.pendingMutex.Lock()
+	defer tm.pendingMutex.Unlock()
+
+	if tm.pending[nonce] == pending {
+		tm.untrack(nonce)
+	}
+}
+
+// untrack stops tracking the transaction with the given nonce.
+// Must be called with the pending transactions mutex held.
+func (tm *transactionManager) untrack(nonce uint64) {
+	delete(tm.pending, nonce)
+	delete(tm.queued, nonce)
+
+	if tm.persistence == nil {
+		return
+	}
+
+	err := tm.persistence.Delete(
+		tm.persistenceDirectory,
+		strconv.FormatUint(nonce, 10),
+	)
+	if err != nil {
+		logger.Warnf(
+			"cannot delete persisted transaction with nonce [%v]: [%v]",
+			nonce,
+			err,
+		)
+	}
+}
+
+// persist saves all versions of the given pending transaction.
+// Must be called with the pending transactions mutex held.
+func (tm *transactionManager) persist(pending *pendingTransaction) {
+	if tm.persistence == nil {
+		return
+	}
+
+	nonce := pending.transaction().Nonce()
+
+	versionsBytes, err := marshalVersions(pending.versions)
+	if err != nil {
+		logger.Warnf(
+			"cannot marshal transaction with nonce [%v]: [%v]",
+			nonce,
+			err,
+		)
+		return
+	}
+
+	err = tm.persistence.Save(
+		versionsBytes,
+		tm.persistenceDirectory,
+		strconv.FormatUint(nonce, 10),
+	)
+	if err != nil {
+		logger.Warnf(
+			"transaction with nonce [%v] will not be recovered after "+
+				"restart: [%v]",
+			nonce,
+			err,
+		)
+	}
+}
+
+// enablePersistence starts persisting pending transactions using the given
+// handle. Transactions persisted before a restart of the client are
+// recovered with all their versions and queued. The network may have dropped
+// them so they are broadcast again, once there is room for them, unless
+// their nonces have been used in the meantime. Persistence can be enabled
+// only once; subsequent calls have no effect.
+func (tm *transactionManager) enablePersistence(
+	handle persistence.BasicHandle,
+) {
+	tm.pendingMutex.Lock()
+
+	if tm.persistence != nil {
+		tm.pendingMutex.Unlock()
+		return
+	}
+
+	tm.persistence = handle
+
+	descriptorsChan, errorsChan := handle.ReadAll()
+
+	// Descriptors and errors channels are read by two goroutines at the
+	// same time as the channels do not have to be buffered.
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for descriptor := range descriptorsChan {
+			if descriptor.Directory() != tm.persistenceDirectory {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"could not read transaction from file [%s]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+				continue
+			}
+
+			versions, err := unmarshalVersions(content)
+			if err != nil {
+				logger.Errorf(
+					"could not unmarshal transaction from file [%s]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+				continue
+			}
+
+			pending := &pendingTransaction{versions: versions}
+			transaction := pending.transaction()
+
+			if tm.isManaged(transaction.Nonce()) {
+				continue
+			}
+
+			logger.Infof(
+				"recovered pending transaction [%v] with nonce [%v] "+
+					"and [%v] versions",
+				transaction.Hash().TerminalString(),
+				transaction.Nonce(),
+				len(versions),
+			)
+
+			tm.queued[transaction.Nonce()] = pending
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for err := range errorsChan {
+			logger.Errorf(
+				"could not load pending transactions from disk: [%v]",
+				err,
+			)
+		}
+	}()
+
+	wg.Wait()
+
+	// Transactions submitted before the persistence was enabled are
+	// persisted now.
+	for _, transactions := range []map[uint64]*pendingTransaction{
+		tm.pending,
+		tm.queued,
+	} {
+		for _, pending := range transactions {
+			tm.persist(pending)
+		}
+	}
+
+	tm.pendingMutex.Unlock()
+
+	tm.sendQueuedTransactions(context.Background())
+}
+
+// sortedNonces returns nonces of the given transactions in ascending order.
+func sortedNonces(transactions map[uint64]*pendingTransaction) []uint64 {
+	nonces := make([]uint64, 0, len(transactions))
+	for nonce := range transactions {
+		nonces = append(nonces, nonce)
+	}
+	sort.Slice(nonces, func(i, j int) bool {
+		return nonces[i] < nonces[j]
+	})
+
+	return nonces
+}
+
+// isNonceTooLow determines whether the given error returned by the network
+// means the nonce of the sent transaction has already been used.
+func isNonceTooLow(err error) bool {
+	return strings.Contains(err.Error(), "nonce too low")
+}
+
+// isAlreadyKnown determines whether the given error returned by the network
+// means the sent transaction is already in the transaction pool.
+func isAlreadyKnown(err error) bool {
+	return strings.Contains(err.Error(), "already known") ||
+		strings.Contains(err.Error(), "known transaction")
+}
+
+// marshalVersions encodes the given versions of a transaction as an RLP list
+// of transactions in their binary form.
+func marshalVersions(versions []*types.Transaction) ([]byte, error) {
+	versionsBytes := make([][]byte, len(versions))
+	for i, version := range versions {
+		versionBytes, err := version.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		versionsBytes[i] = versionBytes
+	}
+
+	return rlp.EncodeToBytes(versionsBytes)
+}
+
+// unmarshalVersions decodes versions of a transaction encoded with
+// marshalVersions.
+func unmarshalVersions(content []byte) ([]*types.Transaction, error) {
+	var versionsBytes [][]byte
+	if err := rlp.DecodeBytes(content, &versionsBytes); err != nil {
+		return nil, err
+	}
+
+	if len(versionsBytes) == 0 {
+		return nil, fmt.Errorf("no transaction versions")
+	}
+
+	versions := make([]*types.Transaction, len(versionsBytes))
+	for i, versionBytes := range versionsBytes {
+		version := &types.Transaction{}
+		if err := version.UnmarshalBinary(versionBytes); err != nil {
+			return nil, err
+		}
+		versions[i] = version
+	}
+
+	return versions, nil
+}
+
+// submittedResultHash holds the hash of the most recently submitted DKG
+// result, as seen in DKG result submission events.
+type submittedResultHash struct {
+	mutex       sync.RWMutex
+	hash        common.Hash
+	blockNumber uint64
+	known       bool
+}
+
+// update records the hash of a DKG result submitted at the given block,
+// unless a more recent submission has already been recorded.
+func (srh *submittedResultHash) update(hash [32]byte, blockNumber uint64) {
+	srh.mutex.Lock()
+	defer srh.mutex.Unlock()
+
+	if srh.known && blockNumber < srh.blockNumber {
+		return
+	}
+
+	srh.hash = hash
+	srh.blockNumber = blockNumber
+	srh.known = true
+}
+
+// get returns the hash of the most recently submitted DKG result. The
+// boolean is false if no submission has been recorded yet.
+func (srh *submittedResultHash) get() (common.Hash, bool) {
+	srh.mutex.RLock()
+	defer srh.mutex.RUnlock()
+
+	return srh.hash, srh.known
+}
+
+// dkgResultHash returns the hash of the DKG result passed to the given
+// transaction. The called contract method must take the DKG result as its
+// only argument, so the hash is computed the same way the contract does,
+// over the ABI-encoded result.
+func dkgResultHash(transaction *types.Transaction) common.Hash {
+	return crypto.Keccak256Hash(transaction.Data()[4:])
+}
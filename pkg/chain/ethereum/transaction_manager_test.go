@@ -0,0 +1,712 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+var (
+	testChainID         = big.NewInt(1101)
+	testContractAddress = common.HexToAddress(
+		"0x2363cc10b7680000C02E4a7067A68d1788ffc86F",
+	)
+	testContractAbi = &abi.ABI{
+		Methods: map[string]abi.Method{
+			"approveDkgResult": abi.NewMethod(
+				"approveDkgResult",
+				"approveDkgResult",
+				abi.Function,
+				"nonpayable",
+				false,
+				false,
+				nil,
+				nil,
+			),
+		},
+	}
+)
+
+func TestBumpGasFees(t *testing.T) {
+	var tests = map[string]struct {
+		transaction       types.TxData
+		latestBaseFee     *big.Int
+		maxGasFeeCap      *big.Int
+		expectedGasTipCap *big.Int
+		expectedGasFeeCap *big.Int
+		expectedError     bool
+	}{
+		"legacy transaction": {
+			transaction:       &types.LegacyTx{GasPrice: big.NewInt(100)},
+			maxGasFeeCap:      big.NewInt(1000),
+			expectedGasTipCap: big.NewInt(120),
+			expectedGasFeeCap: big.NewInt(120),
+		},
+		"legacy transaction capped by max gas fee cap": {
+			transaction:       &types.LegacyTx{GasPrice: big.NewInt(100)},
+			maxGasFeeCap:      big.NewInt(115),
+			expectedGasTipCap: big.NewInt(115),
+			expectedGasFeeCap: big.NewInt(115),
+		},
+		"legacy transaction at max gas fee cap": {
+			transaction:   &types.LegacyTx{GasPrice: big.NewInt(100)},
+			maxGasFeeCap:  big.NewInt(105),
+			expectedError: true,
+		},
+		"dynamic fee transaction with low base fee": {
+			transaction: &types.DynamicFeeTx{
+				GasTipCap: big.NewInt(10),
+				GasFeeCap: big.NewInt(100),
+			},
+			latestBaseFee:     big.NewInt(20),
+			maxGasFeeCap:      big.NewInt(1000),
+			expectedGasTipCap: big.NewInt(12),
+			expectedGasFeeCap: big.NewInt(120),
+		},
+		"dynamic fee transaction with high base fee": {
+			transaction: &types.DynamicFeeTx{
+				GasTipCap: big.NewInt(10),
+				GasFeeCap: big.NewInt(100),
+			},
+			latestBaseFee:     big.NewInt(200),
+			maxGasFeeCap:      big.NewInt(1000),
+			expectedGasTipCap: big.NewInt(12),
+			expectedGasFeeCap: big.NewInt(412),
+		},
+		"dynamic fee transaction capped by max gas fee cap": {
+			transaction: &types.DynamicFeeTx{
+				GasTipCap: big.NewInt(10),
+				GasFeeCap: big.NewInt(100),
+			},
+			latestBaseFee:     big.NewInt(200),
+			maxGasFeeCap:      big.NewInt(300),
+			expectedGasTipCap: big.NewInt(12),
+			expectedGasFeeCap: big.NewInt(300),
+		},
+		"dynamic fee transaction at max gas fee cap": {
+			transaction: &types.DynamicFeeTx{
+				GasTipCap: big.NewInt(10),
+				GasFeeCap: big.NewInt(100),
+			},
+			latestBaseFee: big.NewInt(20),
+			maxGasFeeCap:  big.NewInt(100),
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			gasTipCap, gasFeeCap, err := bumpGasFees(
+				types.NewTx(test.transaction),
+				test.latestBaseFee,
+				test.maxGasFeeCap,
+			)
+
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBigIntsEqual(
+				t,
+				"gas tip cap",
+				test.expectedGasTipCap,
+				gasTipCap,
+			)
+			testutils.AssertBigIntsEqual(
+				t,
+				"gas fee cap",
+				test.expectedGasFeeCap,
+				gasFeeCap,
+			)
+		})
+	}
+}
+
+func TestTransactionManager_ResubmitsNotMinedTransaction(t *testing.T) {
+	client := newMockEthereumClient()
+	manager, key := newTestTransactionManager(t, client)
+
+	transaction := signTestTransaction(t, key, 5, testContractAddress, []byte{1, 2, 3, 4, 5})
+
+	err := manager.managedClient().SendTransaction(context.Background(), transaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Transactions are not re-submitted before the check interval elapses.
+	manager.checkPendingTransactions(context.Background())
+	testutils.AssertIntsEqual(t, "sent transactions", 1, len(client.sent))
+
+	manager.pending[5].submittedAt = time.Now().Add(-manager.checkInterval)
+	manager.checkPendingTransactions(context.Background())
+	testutils.AssertIntsEqual(t, "sent transactions", 2, len(client.sent))
+	testutils.AssertIntsEqual(t, "versions", 2, len(manager.pending[5].versions))
+
+	replacement := client.sent[1]
+	testutils.AssertIntsEqual(t, "nonce", 5, int(replacement.Nonce()))
+	testutils.AssertBytesEqual(t, transaction.Data(), replacement.Data())
+	testutils.AssertBigIntsEqual(t, "gas tip cap", big.NewInt(12), replacement.GasTipCap())
+	testutils.AssertBigIntsEqual(t, "gas fee cap", big.NewInt(120), replacement.GasFeeCap())
+
+	// Any version of the transaction can be mined.
+	client.mine(transaction.Hash())
+	manager.checkPendingTransactions(context.Background())
+	testutils.AssertIntsEqual(t, "pending transactions", 0, len(manager.pending))
+}
+
+func TestTransactionManager_ReplacesAccessListTransaction(t *testing.T) {
+	client := newMockEthereumClient()
+	manager, key := newTestTransactionManager(t, client)
+
+	accessList := types.AccessList{
+		{Address: testContractAddress, StorageKeys: []common.Hash{{1}}},
+	}
+
+	transaction, err := types.SignTx(
+		types.NewTx(&types.AccessListTx{
+			ChainID:    testChainID,
+			Nonce:      5,
+			GasPrice:   big.NewInt(100),
+			Gas:        100000,
+			To:         &testContractAddress,
+			Data:       []byte{1, 2, 3},
+			AccessList: accessList,
+		}),
+		manager.signer,
+		key.PrivateKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replacement, err := manager.replacement(
+		context.Background(),
+		transaction,
+		false,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"transaction type",
+		types.AccessListTxType,
+		int(replacement.Type()),
+	)
+	testutils.AssertBigIntsEqual(t, "gas price", big.NewInt(120), replacement.GasPrice())
+	testutils.AssertIntsEqual(t, "access list length", 1, len(replacement.AccessList()))
+	testutils.AssertStringsEqual(
+		t,
+		"access list address",
+		testContractAddress.Hex(),
+		replacement.AccessList()[0].Address.Hex(),
+	)
+}
+
+func TestTransactionManager_QueuesTransactionsAboveInFlightLimit(t *testing.T) {
+	client := newMockEthereumClient()
+	manager, key := newTestTransactionManager(t, client)
+
+	for nonce := uint64(0); nonce < maxTransactionsInFlight+2; nonce++ {
+		err := manager.managedClient().SendTransaction(
+			context.Background(),
+			signTestTransaction(t, key, nonce, testContractAddress, nil),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testutils.AssertIntsEqual(t, "sent transactions", maxTransactionsInFlight, len(client.sent))
+	testutils.AssertIntsEqual(t, "queued transactions", 2, len(manager.queued))
+
+	// Nonces of queued transactions are not reused.
+	nonce, err := manager.managedClient().PendingNonceAt(
+		context.Background(),
+		key.Address,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertIntsEqual(t, "pending nonce", maxTransactionsInFlight+2, int(nonce))
+
+	// Queued transactions are sent once transactions in flight are mined.
+	client.mine(client.sent[0].Hash())
+	manager.checkPendingTransactions(context.Background())
+
+	testutils.AssertIntsEqual(t, "sent transactions", maxTransactionsInFlight+1, len(client.sent))
+	testutils.AssertIntsEqual(
+		t,
+		"sent nonce",
+		maxTransactionsInFlight,
+		int(client.sent[maxTransactionsInFlight].Nonce()),
+	)
+	testutils.AssertIntsEqual(t, "queued transactions", 1, len(manager.queued))
+}
+
+func TestTransactionManager_CancelsObsoleteTransaction(t *testing.T) {
+	client := newMockEthereumClient()
+	manager, key := newTestTransactionManager(t, client)
+
+	obsolete := false
+	err := manager.registerObsolescenceCheck(
+		testContractAddress,
+		testContractAbi,
+		"approveDkgResult",
+		func(transaction *types.Transaction) (bool, error) {
+			return obsolete, nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := append(testContractAbi.Methods["approveDkgResult"].ID, 1, 2, 3)
+	transaction := signTestTransaction(t, key, 5, testContractAddress, data)
+
+	err = manager.managedClient().SendTransaction(context.Background(), transaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager.pending[5].submittedAt = time.Now().Add(-manager.checkInterval)
+	manager.checkPendingTransactions(context.Background())
+	testutils.AssertBytesEqual(t, data, client.sent[1].Data())
+
+	obsolete = true
+	manager.pending[5].submittedAt = time.Now().Add(-manager.checkInterval)
+	manager.checkPendingTransactions(context.Background())
+
+	cancellation := client.sent[2]
+	testutils.AssertIntsEqual(t, "nonce", 5, int(cancellation.Nonce()))
+	testutils.AssertStringsEqual(
+		t,
+		"recipient",
+		key.Address.Hex(),
+		cancellation.To().Hex(),
+	)
+	testutils.AssertIntsEqual(t, "data length", 0, len(cancellation.Data()))
+	testutils.AssertIntsEqual(t, "gas", int(params.TxGas), int(cancellation.Gas()))
+	testutils.AssertBoolsEqual(
+		t,
+		"is cancellation",
+		true,
+		manager.isCancellation(cancellation),
+	)
+}
+
+func TestTransactionManager_RejectsTransactionWithManagedNonce(t *testing.T) {
+	client := newMockEthereumClient()
+	manager, key := newTestTransactionManager(t, client)
+
+	err := manager.managedClient().SendTransaction(
+		context.Background(),
+		signTestTransaction(t, key, 5, testContractAddress, nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = manager.managedClient().SendTransaction(
+		context.Background(),
+		signTestTransaction(t, key, 5, testContractAddress, []byte{1}),
+	)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	testutils.AssertIntsEqual(t, "sent transactions", 1, len(client.sent))
+}
+
+func TestTransactionManager_RecoversPersistedTransactions(t *testing.T) {
+	handle := newMockPersistenceHandle()
+
+	client := newMockEthereumClient()
+	manager, key := newTestTransactionManager(t, client)
+	manager.enablePersistence(handle)
+
+	transaction := signTestTransaction(t, key, 5, testContractAddress, nil)
+
+	err := manager.managedClient().SendTransaction(context.Background(), transaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "persisted transactions", 1, len(handle.saved))
+
+	manager.pending[5].submittedAt = time.Now().Add(-manager.checkInterval)
+	manager.checkPendingTransactions(context.Background())
+	testutils.AssertIntsEqual(t, "sent transactions", 2, len(client.sent))
+
+	// Restart the client. The transaction is recovered with all its versions
+	// and the most recent one is broadcast again.
+	restartedClient := newMockEthereumClient()
+	restartedManager := newTransactionManager(
+		restartedClient,
+		&keyFileSigner{key},
+		key.Address,
+		testChainID,
+		ethereum.Config{},
+	)
+	restartedManager.enablePersistence(handle)
+
+	testutils.AssertIntsEqual(t, "pending transactions", 1, len(restartedManager.pending))
+	testutils.AssertIntsEqual(t, "versions", 2, len(restartedManager.pending[5].versions))
+	testutils.AssertIntsEqual(t, "sent transactions", 1, len(restartedClient.sent))
+	testutils.AssertStringsEqual(
+		t,
+		"hash",
+		client.sent[1].Hash().Hex(),
+		restartedClient.sent[0].Hash().Hex(),
+	)
+
+	// Any version of the recovered transaction can be mined.
+	restartedClient.mine(transaction.Hash())
+	restartedManager.checkPendingTransactions(context.Background())
+
+	testutils.AssertIntsEqual(t, "pending transactions", 0, len(restartedManager.pending))
+	testutils.AssertIntsEqual(t, "persisted transactions", 0, len(handle.saved))
+}
+
+func TestTransactionManager_SeparatesPersistedTransactionsOfChains(t *testing.T) {
+	handle := newMockPersistenceHandle()
+
+	client := newMockEthereumClient()
+	manager, key := newTestTransactionManager(t, client)
+	manager.enablePersistence(handle)
+
+	otherChainClient := newMockEthereumClient()
+	otherChainManager, _ := newTestTransactionManager(t, otherChainClient)
+	otherChainManager.persistenceDirectory = "other_chain_transactions"
+	otherChainManager.enablePersistence(handle)
+
+	err := manager.managedClient().SendTransaction(
+		context.Background(),
+		signTestTransaction(t, key, 5, testContractAddress, nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = otherChainManager.managedClient().SendTransaction(
+		context.Background(),
+		signTestTransaction(t, key, 5, testContractAddress, []byte{1}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "persisted transactions", 2, len(handle.saved))
+
+	// Restart the client. Each manager recovers only its own transaction.
+	restartedClient := newMockEthereumClient()
+	restartedManager := newTransactionManager(
+		restartedClient,
+		&keyFileSigner{key},
+		key.Address,
+		testChainID,
+		ethereum.Config{},
+	)
+	restartedManager.enablePersistence(handle)
+
+	testutils.AssertIntsEqual(t, "pending transactions", 1, len(restartedManager.pending))
+	testutils.AssertIntsEqual(
+		t,
+		"data length",
+		0,
+		len(restartedManager.pending[5].transaction().Data()),
+	)
+
+	// Persistence can be enabled only once.
+	restartedManager.enablePersistence(newMockPersistenceHandle())
+	testutils.AssertIntsEqual(t, "pending transactions", 1, len(restartedManager.pending))
+	testutils.AssertIntsEqual(t, "sent transactions", 1, len(restartedClient.sent))
+}
+
+func TestTransactionManager_SignsTransactorTransactionsWithOperatorSigner(t *testing.T) {
+	client := newMockEthereumClient()
+
+	operatorKey := keyFromPrivateKey(generateTestKey(t))
+	transactorKey := keyFromPrivateKey(generateTestKey(t))
+
+	manager := newTransactionManager(
+		client,
+		&keyFileSigner{operatorKey},
+		transactorKey.Address,
+		testChainID,
+		ethereum.Config{},
+	)
+
+	transaction := signTestTransaction(t, transactorKey, 5, testContractAddress, nil)
+
+	err := manager.managedClient().SendTransaction(context.Background(), transaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent := client.sent[0]
+
+	sender, err := types.Sender(manager.signer, sent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertStringsEqual(
+		t,
+		"sender",
+		operatorKey.Address.Hex(),
+		sender.Hex(),
+	)
+
+	// The hash of the transaction signed by the transactor resolves to the
+	// hash of the transaction sent to the network.
+	client.mine(sent.Hash())
+
+	receipt, err := manager.managedClient().TransactionReceipt(
+		context.Background(),
+		transaction.Hash(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertBoolsEqual(t, "receipt found", true, receipt != nil)
+
+	call := manager.onBehalfOfOperator(goethereum.CallMsg{
+		From: transactorKey.Address,
+	})
+	testutils.AssertStringsEqual(
+		t,
+		"call sender",
+		operatorKey.Address.Hex(),
+		call.From.Hex(),
+	)
+}
+
+func TestSubmittedResultHash(t *testing.T) {
+	latestResultHash := &submittedResultHash{}
+
+	_, ok := latestResultHash.get()
+	testutils.AssertBoolsEqual(t, "known", false, ok)
+
+	result := []byte{1, 2, 3}
+	latestResultHash.update(crypto.Keccak256Hash(result), 10)
+
+	// Submissions older than the recorded one are ignored.
+	latestResultHash.update(crypto.Keccak256Hash([]byte{4}), 9)
+
+	data := append(testContractAbi.Methods["approveDkgResult"].ID, result...)
+	transaction := signTestTransaction(t, keyFromPrivateKey(generateTestKey(t)), 5, testContractAddress, data)
+
+	hash, ok := latestResultHash.get()
+	testutils.AssertBoolsEqual(t, "known", true, ok)
+	testutils.AssertStringsEqual(
+		t,
+		"result hash",
+		hash.Hex(),
+		dkgResultHash(transaction).Hex(),
+	)
+}
+
+func newTestTransactionManager(
+	t *testing.T,
+	client ethutil.EthereumClient,
+) (*transactionManager, *keystore.Key) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := &keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+
+	return newTransactionManager(
+		client,
+		&keyFileSigner{key},
+		key.Address,
+		testChainID,
+		ethereum.Config{},
+	), key
+}
+
+func signTestTransaction(
+	t *testing.T,
+	key *keystore.Key,
+	nonce uint64,
+	to common.Address,
+	data []byte,
+) *types.Transaction {
+	transaction, err := types.SignTx(
+		types.NewTx(&types.DynamicFeeTx{
+			ChainID:   testChainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(10),
+			GasFeeCap: big.NewInt(100),
+			Gas:       100000,
+			To:        &to,
+			Data:      data,
+		}),
+		types.LatestSignerForChainID(testChainID),
+		key.PrivateKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return transaction
+}
+
+type mockEthereumClient struct {
+	ethutil.EthereumClient
+
+	sent  []*types.Transaction
+	mined map[common.Hash]bool
+}
+
+func newMockEthereumClient() *mockEthereumClient {
+	return &mockEthereumClient{
+		mined: make(map[common.Hash]bool),
+	}
+}
+
+func (mec *mockEthereumClient) mine(hash common.Hash) {
+	mec.mined[hash] = true
+}
+
+func (mec *mockEthereumClient) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	mec.sent = append(mec.sent, transaction)
+	return nil
+}
+
+func (mec *mockEthereumClient) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	return 0, nil
+}
+
+func (mec *mockEthereumClient) TransactionReceipt(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Receipt, error) {
+	if !mec.mined[hash] {
+		return nil, fmt.Errorf("not found")
+	}
+
+	return &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      hash,
+		BlockNumber: big.NewInt(1),
+	}, nil
+}
+
+func (mec *mockEthereumClient) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	return &types.Header{BaseFee: big.NewInt(20)}, nil
+}
+
+type mockPersistenceHandle struct {
+	mutex sync.Mutex
+	saved map[mockDescriptor][]byte
+}
+
+func newMockPersistenceHandle() *mockPersistenceHandle {
+	return &mockPersistenceHandle{
+		saved: make(map[mockDescriptor][]byte),
+	}
+}
+
+func (mph *mockPersistenceHandle) Save(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	mph.mutex.Lock()
+	defer mph.mutex.Unlock()
+
+	mph.saved[mockDescriptor{directory: directory, name: name}] = data
+
+	return nil
+}
+
+func (mph *mockPersistenceHandle) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	mph.mutex.Lock()
+	defer mph.mutex.Unlock()
+
+	outputData := make(chan persistence.DataDescriptor, len(mph.saved))
+	outputErrors := make(chan error)
+
+	for descriptor, content := range mph.saved {
+		outputData <- &mockDescriptor{
+			directory: descriptor.directory,
+			name:      descriptor.name,
+			content:   string(content),
+		}
+	}
+
+	close(outputData)
+	close(outputErrors)
+
+	return outputData, outputErrors
+}
+
+func (mph *mockPersistenceHandle) Delete(directory string, name string) error {
+	mph.mutex.Lock()
+	defer mph.mutex.Unlock()
+
+	delete(mph.saved, mockDescriptor{directory: directory, name: name})
+
+	return nil
+}
+
+type mockDescriptor struct {
+	directory string
+	name      string
+	content   string
+}
+
+func (md *mockDescriptor) Name() string {
+	return md.name
+}
+
+func (md *mockDescriptor) Directory() string {
+	return md.directory
+}
+
+func (md *mockDescriptor) Content() ([]byte, error) {
+	return []byte(md.content), nil
+}
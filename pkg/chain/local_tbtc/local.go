@@ -0,0 +1,1038 @@
+// Package local_tbtc provides a local, in-memory implementation of the chain
+// interface expected by the tbtc package. The chain state is shared by all
+// operators connected to the same Chain instance so several clients can run
+// DKG and signing against each other without any Ethereum node.
+package local_tbtc
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/subscription"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+	"github.com/keep-network/keep-core/pkg/tecdsa/dkg"
+)
+
+var logger = log.Logger("keep-chainlocal-tbtc")
+
+const (
+	// GroupSize is the number of members selected to each group. It must
+	// match the group size expected by the tbtc package.
+	GroupSize = 100
+	// GroupQuorum is the minimum number of members that must sign a DKG
+	// result for it to be valid. It must match the group quorum expected by
+	// the tbtc package.
+	GroupQuorum = 90
+)
+
+// DefaultDKGParameters are the DKG parameters used by the local chain if no
+// other parameters are given. They are much shorter than the ones used on
+// Ethereum so local DKG rounds complete quickly.
+var DefaultDKGParameters = tbtc.DKGParameters{
+	SubmissionTimeoutBlocks:       500,
+	ChallengePeriodBlocks:         20,
+	ApprovePrecedencePeriodBlocks: 10,
+}
+
+// eligibleStake is the stake of every operator connected to the local chain.
+// All operators have the same weight in the local sortition pool.
+var eligibleStake = big.NewInt(1000000)
+
+// Chain is the state of the local chain shared by all operators connected to
+// it. It keeps the sortition pool, drives the DKG state machine according to
+// the local block counter and emits the tbtc events.
+type Chain struct {
+	blockCounter  chain.BlockCounter
+	dkgParameters tbtc.DKGParameters
+
+	poolMutex sync.Mutex
+	// Operators that joined the sortition pool, in the order of joining.
+	// The operator ID is the position in this slice plus one.
+	poolOperators []chain.Address
+
+	dkgMutex                 sync.Mutex
+	dkgState                 tbtc.DKGState
+	dkgSeed                  *big.Int
+	dkgStartBlock            uint64
+	dkgSubmissionStartBlock  uint64
+	groupSelectionResult     *tbtc.GroupSelectionResult
+	dkgResult                *tbtc.DKGChainResult
+	dkgResultSubmissionBlock uint64
+	wallets                  [][]byte
+
+	handlersMutex              sync.Mutex
+	dkgStartedHandlers         map[int]func(event *tbtc.DKGStartedEvent)
+	dkgResultSubmittedHandlers map[int]func(event *tbtc.DKGResultSubmittedEvent)
+	dkgResultChallengeHandlers map[int]func(event *tbtc.DKGResultChallengedEvent)
+	dkgResultApprovedHandlers  map[int]func(event *tbtc.DKGResultApprovedEvent)
+	heartbeatHandlers          map[int]func(event *tbtc.HeartbeatRequestedEvent)
+}
+
+// NewChain creates the local chain state using the given block counter and
+// DKG parameters. The chain watches blocks until the given context is done
+// to time out DKG results that were not submitted on time.
+func NewChain(
+	ctx context.Context,
+	blockCounter chain.BlockCounter,
+	dkgParameters tbtc.DKGParameters,
+) *Chain {
+	c := &Chain{
+		blockCounter:  blockCounter,
+		dkgParameters: dkgParameters,
+		dkgState:      tbtc.Idle,
+		dkgStartedHandlers: make(
+			map[int]func(event *tbtc.DKGStartedEvent),
+		),
+		dkgResultSubmittedHandlers: make(
+			map[int]func(event *tbtc.DKGResultSubmittedEvent),
+		),
+		dkgResultChallengeHandlers: make(
+			map[int]func(event *tbtc.DKGResultChallengedEvent),
+		),
+		dkgResultApprovedHandlers: make(
+			map[int]func(event *tbtc.DKGResultApprovedEvent),
+		),
+		heartbeatHandlers: make(
+			map[int]func(event *tbtc.HeartbeatRequestedEvent),
+		),
+	}
+
+	go c.monitorDKGTimeout(ctx)
+
+	return c
+}
+
+// Connect returns a handle of the local chain for the operator with the given
+// private key. The operator is considered as staked but has to join the
+// sortition pool to be selected to groups.
+func (c *Chain) Connect(operatorPrivateKey *operator.PrivateKey) *localChain {
+	return &localChain{
+		Chain:              c,
+		operatorPrivateKey: operatorPrivateKey,
+		signing:            local_v1.NewSigner(operatorPrivateKey),
+	}
+}
+
+// RequestNewWallet starts a new DKG with a random seed. The sortition pool is
+// locked until the DKG result is approved or the DKG times out. Returns an
+// error if another DKG is in progress or the sortition pool is empty.
+func (c *Chain) RequestNewWallet() error {
+	c.poolMutex.Lock()
+	poolSize := len(c.poolOperators)
+	c.poolMutex.Unlock()
+
+	if poolSize == 0 {
+		return fmt.Errorf("sortition pool is empty")
+	}
+
+	c.dkgMutex.Lock()
+
+	if c.dkgState != tbtc.Idle {
+		c.dkgMutex.Unlock()
+		return fmt.Errorf("DKG is already in progress")
+	}
+
+	blockNumber, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		c.dkgMutex.Unlock()
+		return fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	// #nosec G404 (insecure random number source (rand))
+	// Local chain implementation doesn't require secure randomness.
+	seed := new(big.Int).SetUint64(rand.Uint64())
+
+	c.dkgState = tbtc.AwaitingResult
+	c.dkgSeed = seed
+	c.dkgStartBlock = blockNumber
+	c.dkgSubmissionStartBlock = blockNumber
+	c.groupSelectionResult = c.selectGroup(seed)
+
+	c.dkgMutex.Unlock()
+
+	logger.Infof(
+		"DKG started with seed [0x%x] at block [%v]",
+		seed,
+		blockNumber,
+	)
+
+	c.handlersMutex.Lock()
+	defer c.handlersMutex.Unlock()
+
+	for _, handler := range c.dkgStartedHandlers {
+		go handler(&tbtc.DKGStartedEvent{
+			Seed:        seed,
+			BlockNumber: blockNumber,
+		})
+	}
+
+	return nil
+}
+
+// RequestHeartbeat requests a heartbeat signature of the given messages from
+// the wallet with the given public key. Returns an error if the wallet has
+// not been created on the local chain.
+func (c *Chain) RequestHeartbeat(
+	walletPublicKey []byte,
+	messages []*big.Int,
+) error {
+	if !c.isWalletRegistered(walletPublicKey) {
+		return fmt.Errorf("unknown wallet")
+	}
+
+	blockNumber, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	c.handlersMutex.Lock()
+	defer c.handlersMutex.Unlock()
+
+	for _, handler := range c.heartbeatHandlers {
+		go handler(&tbtc.HeartbeatRequestedEvent{
+			WalletPublicKey: walletPublicKey,
+			Messages:        messages,
+			BlockNumber:     blockNumber,
+		})
+	}
+
+	return nil
+}
+
+// ActiveWalletPublicKey returns the public key of the most recently created
+// wallet. The boolean flag is false if no wallet has been created yet.
+func (c *Chain) ActiveWalletPublicKey() ([]byte, bool) {
+	c.dkgMutex.Lock()
+	defer c.dkgMutex.Unlock()
+
+	if len(c.wallets) == 0 {
+		return nil, false
+	}
+
+	return c.wallets[len(c.wallets)-1], true
+}
+
+func (c *Chain) isWalletRegistered(walletPublicKey []byte) bool {
+	c.dkgMutex.Lock()
+	defer c.dkgMutex.Unlock()
+
+	for _, wallet := range c.wallets {
+		if bytes.Equal(wallet, walletPublicKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selectGroup selects GroupSize members from the sortition pool using the
+// given seed. Operators can be selected multiple times, as it happens with
+// the sortition pool on Ethereum. The DKG mutex must be held by the caller.
+func (c *Chain) selectGroup(seed *big.Int) *tbtc.GroupSelectionResult {
+	c.poolMutex.Lock()
+	defer c.poolMutex.Unlock()
+
+	// #nosec G404 (insecure random number source (rand))
+	// Local chain implementation doesn't require secure randomness.
+	random := rand.New(rand.NewSource(int64(seed.Uint64())))
+
+	result := &tbtc.GroupSelectionResult{
+		OperatorsIDs:       make(chain.OperatorIDs, GroupSize),
+		OperatorsAddresses: make(chain.Addresses, GroupSize),
+	}
+
+	for i := 0; i < GroupSize; i++ {
+		position := random.Intn(len(c.poolOperators))
+		result.OperatorsIDs[i] = chain.OperatorID(position + 1)
+		result.OperatorsAddresses[i] = c.poolOperators[position]
+	}
+
+	return result
+}
+
+// monitorDKGTimeout resets the DKG state if the DKG result was not submitted
+// within the submission timeout. This is what notifyDkgTimeout does on
+// Ethereum.
+func (c *Chain) monitorDKGTimeout(ctx context.Context) {
+	blocksChan := c.blockCounter.WatchBlocks(ctx)
+
+	for {
+		select {
+		case block := <-blocksChan:
+			c.dkgMutex.Lock()
+			if c.dkgState == tbtc.AwaitingResult &&
+				block > c.dkgSubmissionStartBlock+
+					c.dkgParameters.SubmissionTimeoutBlocks {
+				logger.Warnf(
+					"DKG with seed [0x%x] timed out at block [%v]",
+					c.dkgSeed,
+					block,
+				)
+				c.resetDKG()
+			}
+			c.dkgMutex.Unlock()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// resetDKG moves the DKG back to the idle state and unlocks the sortition
+// pool. The DKG mutex must be held by the caller.
+func (c *Chain) resetDKG() {
+	c.dkgState = tbtc.Idle
+	c.dkgSeed = nil
+	c.groupSelectionResult = nil
+	c.dkgResult = nil
+}
+
+// validateDKGResult checks the given DKG result against the DKG in progress.
+// Signatures of the result are verified using the given signing. The DKG
+// mutex must be held by the caller.
+func (c *Chain) validateDKGResult(
+	result *tbtc.DKGChainResult,
+	signing chain.Signing,
+) error {
+	if c.groupSelectionResult == nil {
+		return fmt.Errorf("group not selected")
+	}
+
+	if len(result.GroupPublicKey) != 65 {
+		return fmt.Errorf("malformed group public key")
+	}
+
+	if result.SubmitterMemberIndex < 1 ||
+		int(result.SubmitterMemberIndex) > GroupSize {
+		return fmt.Errorf("invalid submitter member index")
+	}
+
+	if len(result.Members) != GroupSize {
+		return fmt.Errorf("invalid number of members")
+	}
+
+	for i, operatorID := range result.Members {
+		if operatorID != c.groupSelectionResult.OperatorsIDs[i] {
+			return fmt.Errorf("members do not match the selected group")
+		}
+	}
+
+	misbehaved := make(map[group.MemberIndex]bool)
+	for i, memberIndex := range result.MisbehavedMembersIndexes {
+		if memberIndex < 1 || int(memberIndex) > GroupSize {
+			return fmt.Errorf("invalid misbehaved member index")
+		}
+		if i > 0 && memberIndex <= result.MisbehavedMembersIndexes[i-1] {
+			return fmt.Errorf("misbehaved members indexes not sorted")
+		}
+		misbehaved[memberIndex] = true
+	}
+
+	operatingMembersIndexes := make([]group.MemberIndex, 0)
+	for i := 1; i <= GroupSize; i++ {
+		if !misbehaved[group.MemberIndex(i)] {
+			operatingMembersIndexes = append(
+				operatingMembersIndexes,
+				group.MemberIndex(i),
+			)
+		}
+	}
+
+	if result.MembersHash != computeMembersHash(
+		operatingMembersIndexes,
+		c.groupSelectionResult,
+	) {
+		return fmt.Errorf("invalid members hash")
+	}
+
+	if len(result.SigningMembersIndexes) < GroupQuorum {
+		return fmt.Errorf("too few signatures")
+	}
+
+	signatures, err := splitSignatures(result.Signatures)
+	if err != nil {
+		return fmt.Errorf("malformed signatures: [%v]", err)
+	}
+
+	if len(signatures) != len(result.SigningMembersIndexes) {
+		return fmt.Errorf("number of signatures does not match signers")
+	}
+
+	signatureHash := computeDKGResultSignatureHash(
+		result.GroupPublicKey,
+		result.MisbehavedMembersIndexes,
+		c.dkgStartBlock,
+	)
+
+	for i, memberIndex := range result.SigningMembersIndexes {
+		if memberIndex < 1 || int(memberIndex) > GroupSize {
+			return fmt.Errorf("invalid signing member index")
+		}
+		if i > 0 && memberIndex <= result.SigningMembersIndexes[i-1] {
+			return fmt.Errorf("signing members indexes not sorted")
+		}
+
+		// Local chain addresses are the serialized operator public keys.
+		publicKey, err := hex.DecodeString(
+			c.groupSelectionResult.OperatorsAddresses[memberIndex-1].String(),
+		)
+		if err != nil {
+			return fmt.Errorf("cannot decode operator public key: [%v]", err)
+		}
+
+		ok, err := signing.VerifyWithPublicKey(
+			signatureHash[:],
+			signatures[i],
+			publicKey,
+		)
+		if err != nil || !ok {
+			return fmt.Errorf(
+				"invalid signature of member [%v]",
+				memberIndex,
+			)
+		}
+	}
+
+	return nil
+}
+
+// localChain is a handle of the local chain for a single operator. It
+// implements the tbtc.Chain interface.
+type localChain struct {
+	*Chain
+
+	operatorPrivateKey *operator.PrivateKey
+	signing            chain.Signing
+}
+
+func (lc *localChain) BlockCounter() (chain.BlockCounter, error) {
+	return lc.blockCounter, nil
+}
+
+// AverageBlockTime returns zero so protocol block durations are not scaled
+// on the local chain.
+func (lc *localChain) AverageBlockTime() time.Duration {
+	return 0
+}
+
+func (lc *localChain) Signing() chain.Signing {
+	return lc.signing
+}
+
+func (lc *localChain) OperatorKeyPair() (
+	*operator.PrivateKey,
+	*operator.PublicKey,
+	error,
+) {
+	return lc.operatorPrivateKey, &lc.operatorPrivateKey.PublicKey, nil
+}
+
+// OperatorToStakingProvider returns the operator address as the staking
+// provider. All operators are staked on the local chain.
+func (lc *localChain) OperatorToStakingProvider() (chain.Address, bool, error) {
+	return lc.signing.Address(), true, nil
+}
+
+func (lc *localChain) EligibleStake(
+	stakingProvider chain.Address,
+) (*big.Int, error) {
+	return eligibleStake, nil
+}
+
+func (lc *localChain) IsPoolLocked() (bool, error) {
+	lc.dkgMutex.Lock()
+	defer lc.dkgMutex.Unlock()
+
+	return lc.dkgState != tbtc.Idle, nil
+}
+
+func (lc *localChain) IsOperatorInPool() (bool, error) {
+	lc.poolMutex.Lock()
+	defer lc.poolMutex.Unlock()
+
+	return lc.operatorPosition(lc.signing.Address()) >= 0, nil
+}
+
+func (lc *localChain) IsOperatorUpToDate() (bool, error) {
+	// The operator's weight never changes so the operator is up to date
+	// once it is in the pool.
+	return lc.IsOperatorInPool()
+}
+
+func (lc *localChain) JoinSortitionPool() error {
+	isLocked, err := lc.IsPoolLocked()
+	if err != nil {
+		return err
+	}
+
+	if isLocked {
+		return fmt.Errorf("sortition pool is locked")
+	}
+
+	lc.poolMutex.Lock()
+	defer lc.poolMutex.Unlock()
+
+	address := lc.signing.Address()
+
+	if lc.operatorPosition(address) >= 0 {
+		return fmt.Errorf("operator is already in the sortition pool")
+	}
+
+	lc.poolOperators = append(lc.poolOperators, address)
+
+	logger.Infof("operator [%v] joined the sortition pool", address)
+
+	return nil
+}
+
+func (lc *localChain) UpdateOperatorStatus() error {
+	isLocked, err := lc.IsPoolLocked()
+	if err != nil {
+		return err
+	}
+
+	if isLocked {
+		return fmt.Errorf("sortition pool is locked")
+	}
+
+	return nil
+}
+
+func (lc *localChain) IsEligibleForRewards() (bool, error) {
+	return true, nil
+}
+
+func (lc *localChain) CanRestoreRewardEligibility() (bool, error) {
+	return false, nil
+}
+
+func (lc *localChain) RestoreRewardEligibility() error {
+	return fmt.Errorf("operator is eligible for rewards")
+}
+
+func (lc *localChain) IsChaosnetActive() (bool, error) {
+	return false, nil
+}
+
+func (lc *localChain) IsBetaOperator() (bool, error) {
+	return false, nil
+}
+
+// GetOperatorID returns the ID of the operator with the given address. As on
+// Ethereum, zero is returned for operators that never joined the pool.
+func (lc *localChain) GetOperatorID(
+	operatorAddress chain.Address,
+) (chain.OperatorID, error) {
+	lc.poolMutex.Lock()
+	defer lc.poolMutex.Unlock()
+
+	return chain.OperatorID(lc.operatorPosition(operatorAddress) + 1), nil
+}
+
+// operatorPosition returns the position of the operator in the sortition pool
+// or -1 if the operator is not in the pool. The pool mutex must be held by the
+// caller.
+func (lc *localChain) operatorPosition(operatorAddress chain.Address) int {
+	for i, address := range lc.poolOperators {
+		if address == operatorAddress {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (lc *localChain) SelectGroup() (*tbtc.GroupSelectionResult, error) {
+	lc.dkgMutex.Lock()
+	defer lc.dkgMutex.Unlock()
+
+	if lc.dkgState == tbtc.Idle || lc.groupSelectionResult == nil {
+		return nil, fmt.Errorf("group selection is not in progress")
+	}
+
+	return lc.groupSelectionResult, nil
+}
+
+func (lc *localChain) OnDKGStarted(
+	handler func(event *tbtc.DKGStartedEvent),
+) subscription.EventSubscription {
+	lc.handlersMutex.Lock()
+	defer lc.handlersMutex.Unlock()
+
+	handlerID := local_v1.GenerateHandlerID()
+	lc.dkgStartedHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		lc.handlersMutex.Lock()
+		defer lc.handlersMutex.Unlock()
+
+		delete(lc.dkgStartedHandlers, handlerID)
+	})
+}
+
+func (lc *localChain) OnDKGResultSubmitted(
+	handler func(event *tbtc.DKGResultSubmittedEvent),
+) subscription.EventSubscription {
+	lc.handlersMutex.Lock()
+	defer lc.handlersMutex.Unlock()
+
+	handlerID := local_v1.GenerateHandlerID()
+	lc.dkgResultSubmittedHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		lc.handlersMutex.Lock()
+		defer lc.handlersMutex.Unlock()
+
+		delete(lc.dkgResultSubmittedHandlers, handlerID)
+	})
+}
+
+func (lc *localChain) OnDKGResultChallenged(
+	handler func(event *tbtc.DKGResultChallengedEvent),
+) subscription.EventSubscription {
+	lc.handlersMutex.Lock()
+	defer lc.handlersMutex.Unlock()
+
+	handlerID := local_v1.GenerateHandlerID()
+	lc.dkgResultChallengeHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		lc.handlersMutex.Lock()
+		defer lc.handlersMutex.Unlock()
+
+		delete(lc.dkgResultChallengeHandlers, handlerID)
+	})
+}
+
+func (lc *localChain) OnDKGResultApproved(
+	handler func(event *tbtc.DKGResultApprovedEvent),
+) subscription.EventSubscription {
+	lc.handlersMutex.Lock()
+	defer lc.handlersMutex.Unlock()
+
+	handlerID := local_v1.GenerateHandlerID()
+	lc.dkgResultApprovedHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		lc.handlersMutex.Lock()
+		defer lc.handlersMutex.Unlock()
+
+		delete(lc.dkgResultApprovedHandlers, handlerID)
+	})
+}
+
+func (lc *localChain) AssembleDKGResult(
+	submitterMemberIndex group.MemberIndex,
+	groupPublicKey *ecdsa.PublicKey,
+	operatingMembersIndexes []group.MemberIndex,
+	misbehavedMembersIndexes []group.MemberIndex,
+	signatures map[group.MemberIndex][]byte,
+	groupSelectionResult *tbtc.GroupSelectionResult,
+) (*tbtc.DKGChainResult, error) {
+	groupPublicKeyBytes := elliptic.Marshal(
+		groupPublicKey.Curve,
+		groupPublicKey.X,
+		groupPublicKey.Y,
+	)
+
+	signingMembersIndexes := make([]group.MemberIndex, 0, len(signatures))
+	for memberIndex := range signatures {
+		signingMembersIndexes = append(signingMembersIndexes, memberIndex)
+	}
+	sort.Slice(signingMembersIndexes, func(i, j int) bool {
+		return signingMembersIndexes[i] < signingMembersIndexes[j]
+	})
+
+	// Local signatures are ASN.1 encoded so they can be split back after
+	// concatenation.
+	signaturesConcatenation := make([]byte, 0)
+	for _, memberIndex := range signingMembersIndexes {
+		signaturesConcatenation = append(
+			signaturesConcatenation,
+			signatures[memberIndex]...,
+		)
+	}
+
+	return &tbtc.DKGChainResult{
+		SubmitterMemberIndex:     submitterMemberIndex,
+		GroupPublicKey:           groupPublicKeyBytes,
+		MisbehavedMembersIndexes: misbehavedMembersIndexes,
+		Signatures:               signaturesConcatenation,
+		SigningMembersIndexes:    signingMembersIndexes,
+		Members:                  groupSelectionResult.OperatorsIDs,
+		MembersHash: computeMembersHash(
+			operatingMembersIndexes,
+			groupSelectionResult,
+		),
+	}, nil
+}
+
+// HasFundsForDKGResultSubmission always returns true as submitting
+// transactions to the local chain is free.
+func (lc *localChain) HasFundsForDKGResultSubmission(
+	dkgResult *tbtc.DKGChainResult,
+) (bool, error) {
+	return true, nil
+}
+
+// SubmitDKGResult submits the DKG result. The result must be submitted by
+// the operator controlling the submitter member and is accepted even if it
+// is invalid so it can be challenged later.
+func (lc *localChain) SubmitDKGResult(dkgResult *tbtc.DKGChainResult) error {
+	lc.dkgMutex.Lock()
+
+	if lc.dkgState != tbtc.AwaitingResult {
+		lc.dkgMutex.Unlock()
+		return fmt.Errorf("not awaiting DKG result")
+	}
+
+	if !lc.isMemberOperator(dkgResult.SubmitterMemberIndex) {
+		lc.dkgMutex.Unlock()
+		return fmt.Errorf("submitter is not the operator of the given member")
+	}
+
+	blockNumber, err := lc.blockCounter.CurrentBlock()
+	if err != nil {
+		lc.dkgMutex.Unlock()
+		return fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	lc.dkgState = tbtc.Challenge
+	lc.dkgResult = dkgResult
+	lc.dkgResultSubmissionBlock = blockNumber
+	seed := lc.dkgSeed
+
+	lc.dkgMutex.Unlock()
+
+	lc.handlersMutex.Lock()
+	defer lc.handlersMutex.Unlock()
+
+	for _, handler := range lc.dkgResultSubmittedHandlers {
+		go handler(&tbtc.DKGResultSubmittedEvent{
+			Seed:        seed,
+			ResultHash:  computeDKGChainResultHash(dkgResult),
+			Result:      dkgResult,
+			BlockNumber: blockNumber,
+		})
+	}
+
+	return nil
+}
+
+func (lc *localChain) GetDKGState() (tbtc.DKGState, error) {
+	lc.dkgMutex.Lock()
+	defer lc.dkgMutex.Unlock()
+
+	return lc.dkgState, nil
+}
+
+func (lc *localChain) CalculateDKGResultSignatureHash(
+	groupPublicKey *ecdsa.PublicKey,
+	misbehavedMembersIndexes []group.MemberIndex,
+	startBlock uint64,
+) (dkg.ResultSignatureHash, error) {
+	if groupPublicKey == nil {
+		return dkg.ResultSignatureHash{}, fmt.Errorf("group public key is nil")
+	}
+
+	return computeDKGResultSignatureHash(
+		elliptic.Marshal(groupPublicKey.Curve, groupPublicKey.X, groupPublicKey.Y),
+		misbehavedMembersIndexes,
+		startBlock,
+	), nil
+}
+
+func (lc *localChain) IsDKGResultValid(
+	dkgResult *tbtc.DKGChainResult,
+) (bool, error) {
+	lc.dkgMutex.Lock()
+	defer lc.dkgMutex.Unlock()
+
+	if err := lc.checkSubmittedResult(dkgResult); err != nil {
+		return false, err
+	}
+
+	if err := lc.validateDKGResult(dkgResult, lc.signing); err != nil {
+		logger.Infof("DKG result is invalid: [%v]", err)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// ChallengeDKGResult challenges the submitted DKG result. The challenge is
+// rejected if the result is valid. A successful challenge restarts the DKG
+// result submission.
+func (lc *localChain) ChallengeDKGResult(dkgResult *tbtc.DKGChainResult) error {
+	lc.dkgMutex.Lock()
+
+	if err := lc.checkSubmittedResult(dkgResult); err != nil {
+		lc.dkgMutex.Unlock()
+		return err
+	}
+
+	validationErr := lc.validateDKGResult(dkgResult, lc.signing)
+	if validationErr == nil {
+		lc.dkgMutex.Unlock()
+		return fmt.Errorf("unjustified challenge")
+	}
+
+	blockNumber, err := lc.blockCounter.CurrentBlock()
+	if err != nil {
+		lc.dkgMutex.Unlock()
+		return fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	lc.dkgState = tbtc.AwaitingResult
+	lc.dkgResult = nil
+	lc.dkgSubmissionStartBlock = blockNumber
+
+	lc.dkgMutex.Unlock()
+
+	lc.handlersMutex.Lock()
+	defer lc.handlersMutex.Unlock()
+
+	for _, handler := range lc.dkgResultChallengeHandlers {
+		go handler(&tbtc.DKGResultChallengedEvent{
+			ResultHash:  computeDKGChainResultHash(dkgResult),
+			Challenger:  lc.signing.Address(),
+			Reason:      validationErr.Error(),
+			BlockNumber: blockNumber,
+		})
+	}
+
+	return nil
+}
+
+// ApproveDKGResult approves the submitted DKG result once the challenge
+// period is over. During the approve precedence period only the result
+// submitter can approve it. The approved group becomes a new wallet and the
+// sortition pool is unlocked.
+func (lc *localChain) ApproveDKGResult(dkgResult *tbtc.DKGChainResult) error {
+	lc.dkgMutex.Lock()
+
+	if err := lc.checkSubmittedResult(dkgResult); err != nil {
+		lc.dkgMutex.Unlock()
+		return err
+	}
+
+	blockNumber, err := lc.blockCounter.CurrentBlock()
+	if err != nil {
+		lc.dkgMutex.Unlock()
+		return fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	challengePeriodEnd := lc.dkgResultSubmissionBlock +
+		lc.dkgParameters.ChallengePeriodBlocks
+	if blockNumber <= challengePeriodEnd {
+		lc.dkgMutex.Unlock()
+		return fmt.Errorf("challenge period has not passed yet")
+	}
+
+	if blockNumber <= challengePeriodEnd+
+		lc.dkgParameters.ApprovePrecedencePeriodBlocks &&
+		!lc.isMemberOperator(dkgResult.SubmitterMemberIndex) {
+		lc.dkgMutex.Unlock()
+		return fmt.Errorf("only the DKG result submitter can approve the result now")
+	}
+
+	if err := lc.validateDKGResult(dkgResult, lc.signing); err != nil {
+		lc.dkgMutex.Unlock()
+		return fmt.Errorf("DKG result is invalid: [%v]", err)
+	}
+
+	lc.wallets = append(lc.wallets, dkgResult.GroupPublicKey)
+	lc.resetDKG()
+
+	lc.dkgMutex.Unlock()
+
+	logger.Infof(
+		"wallet [0x%x] created at block [%v]",
+		dkgResult.GroupPublicKey,
+		blockNumber,
+	)
+
+	lc.handlersMutex.Lock()
+	defer lc.handlersMutex.Unlock()
+
+	for _, handler := range lc.dkgResultApprovedHandlers {
+		go handler(&tbtc.DKGResultApprovedEvent{
+			ResultHash:  computeDKGChainResultHash(dkgResult),
+			Approver:    lc.signing.Address(),
+			BlockNumber: blockNumber,
+		})
+	}
+
+	return nil
+}
+
+func (lc *localChain) DKGParameters() (*tbtc.DKGParameters, error) {
+	parameters := lc.dkgParameters
+	return &parameters, nil
+}
+
+func (lc *localChain) OnHeartbeatRequested(
+	handler func(event *tbtc.HeartbeatRequestedEvent),
+) subscription.EventSubscription {
+	lc.handlersMutex.Lock()
+	defer lc.handlersMutex.Unlock()
+
+	handlerID := local_v1.GenerateHandlerID()
+	lc.heartbeatHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		lc.handlersMutex.Lock()
+		defer lc.handlersMutex.Unlock()
+
+		delete(lc.heartbeatHandlers, handlerID)
+	})
+}
+
+// checkSubmittedResult ensures the DKG is in the challenge period and the
+// given result is the submitted one. The DKG mutex must be held by the caller.
+func (lc *localChain) checkSubmittedResult(
+	dkgResult *tbtc.DKGChainResult,
+) error {
+	if lc.dkgState != tbtc.Challenge {
+		return fmt.Errorf("not in DKG result challenge period")
+	}
+
+	if computeDKGChainResultHash(dkgResult) !=
+		computeDKGChainResultHash(lc.dkgResult) {
+		return fmt.Errorf("result does not match the submitted one")
+	}
+
+	return nil
+}
+
+// isMemberOperator checks whether this operator controls the group member
+// with the given index. The DKG mutex must be held by the caller.
+func (lc *localChain) isMemberOperator(memberIndex group.MemberIndex) bool {
+	if lc.groupSelectionResult == nil ||
+		memberIndex < 1 ||
+		int(memberIndex) > len(lc.groupSelectionResult.OperatorsAddresses) {
+		return false
+	}
+
+	return lc.groupSelectionResult.OperatorsAddresses[memberIndex-1] ==
+		lc.signing.Address()
+}
+
+// computeMembersHash computes the hash of IDs of the operators controlling
+// the given operating members.
+func computeMembersHash(
+	operatingMembersIndexes []group.MemberIndex,
+	groupSelectionResult *tbtc.GroupSelectionResult,
+) [32]byte {
+	operatingOperatorsIDsBytes := make([]byte, 0)
+	for _, memberIndex := range operatingMembersIndexes {
+		operatorIDBytes := make([]byte, 4)
+		operatorID := groupSelectionResult.OperatorsIDs[memberIndex-1]
+		binary.BigEndian.PutUint32(operatorIDBytes, operatorID)
+
+		operatingOperatorsIDsBytes = append(
+			operatingOperatorsIDsBytes,
+			operatorIDBytes...,
+		)
+	}
+
+	return sha3.Sum256(operatingOperatorsIDsBytes)
+}
+
+// computeDKGResultSignatureHash computes the hash DKG result signers sign
+// to support the given group public key.
+func computeDKGResultSignatureHash(
+	groupPublicKey []byte,
+	misbehavedMembersIndexes []group.MemberIndex,
+	startBlock uint64,
+) dkg.ResultSignatureHash {
+	startBlockBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(startBlockBytes, startBlock)
+
+	preimage := make([]byte, 0)
+	preimage = append(preimage, groupPublicKey...)
+	for _, memberIndex := range misbehavedMembersIndexes {
+		preimage = append(preimage, byte(memberIndex))
+	}
+	preimage = append(preimage, startBlockBytes...)
+
+	return sha3.Sum256(preimage)
+}
+
+// computeDKGChainResultHash computes the hash identifying the DKG result.
+func computeDKGChainResultHash(
+	result *tbtc.DKGChainResult,
+) tbtc.DKGChainResultHash {
+	if result == nil {
+		return tbtc.DKGChainResultHash{}
+	}
+
+	return sha3.Sum256([]byte(fmt.Sprint(
+		result.SubmitterMemberIndex,
+		result.GroupPublicKey,
+		result.MisbehavedMembersIndexes,
+		result.Signatures,
+		result.SigningMembersIndexes,
+		result.Members,
+		result.MembersHash,
+	)))
+}
+
+// splitSignatures splits the concatenation of ASN.1 encoded signatures.
+func splitSignatures(concatenation []byte) ([][]byte, error) {
+	signatures := make([][]byte, 0)
+
+	rest := concatenation
+	for len(rest) > 0 {
+		var signature asn1.RawValue
+		next, err := asn1.Unmarshal(rest, &signature)
+		if err != nil {
+			return nil, err
+		}
+
+		signatures = append(signatures, signature.FullBytes)
+		rest = next
+	}
+
+	return signatures, nil
+}
+
+// HeartbeatMessages returns messages to be signed by a wallet heartbeat
+// requested at the given block. The messages are built in the same way as
+// the ones of heartbeats requested on Ethereum.
+func HeartbeatMessages(block uint64) []*big.Int {
+	prefixBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(prefixBytes, 0xffffffffffffffff)
+
+	messages := make([]*big.Int, 5)
+	for i := range messages {
+		suffixBytes := make([]byte, 8)
+		binary.BigEndian.PutUint64(suffixBytes, block+uint64(i))
+
+		preimage := append(prefixBytes, suffixBytes...)
+		preimageSha256 := sha256.Sum256(preimage)
+		message := sha256.Sum256(preimageSha256[:])
+
+		messages[i] = new(big.Int).SetBytes(message[:])
+	}
+
+	return messages
+}
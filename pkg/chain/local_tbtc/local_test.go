@@ -0,0 +1,419 @@
+package local_tbtc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+var testDKGParameters = tbtc.DKGParameters{
+	SubmissionTimeoutBlocks:       6,
+	ChallengePeriodBlocks:         2,
+	ApprovePrecedencePeriodBlocks: 2,
+}
+
+func TestSortitionPool(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain, operators := connectOperators(ctx, t, 1, false)
+	operatorChain := operators[0]
+
+	err := localChain.RequestNewWallet()
+	if err == nil {
+		t.Fatal("expected error for empty sortition pool")
+	}
+
+	isInPool, err := operatorChain.IsOperatorInPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "operator in pool", false, isInPool)
+
+	operatorID, err := operatorChain.GetOperatorID(
+		operatorChain.Signing().Address(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertIntsEqual(t, "operator ID", 0, int(operatorID))
+
+	if err := operatorChain.JoinSortitionPool(); err != nil {
+		t.Fatal(err)
+	}
+
+	isInPool, err = operatorChain.IsOperatorInPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "operator in pool", true, isInPool)
+
+	operatorID, err = operatorChain.GetOperatorID(
+		operatorChain.Signing().Address(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertIntsEqual(t, "operator ID", 1, int(operatorID))
+
+	if err := localChain.RequestNewWallet(); err != nil {
+		t.Fatal(err)
+	}
+
+	isLocked, err := operatorChain.IsPoolLocked()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "pool locked", true, isLocked)
+
+	if err := localChain.RequestNewWallet(); err == nil {
+		t.Fatal("expected error for DKG already in progress")
+	}
+}
+
+func TestDKG(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain, operators := connectOperators(ctx, t, 2, true)
+
+	dkgStartedChan := make(chan *tbtc.DKGStartedEvent, 1)
+	operators[0].OnDKGStarted(func(event *tbtc.DKGStartedEvent) {
+		dkgStartedChan <- event
+	})
+	dkgResultSubmittedChan := make(chan *tbtc.DKGResultSubmittedEvent, 1)
+	operators[0].OnDKGResultSubmitted(func(event *tbtc.DKGResultSubmittedEvent) {
+		dkgResultSubmittedChan <- event
+	})
+	dkgResultApprovedChan := make(chan *tbtc.DKGResultApprovedEvent, 1)
+	operators[0].OnDKGResultApproved(func(event *tbtc.DKGResultApprovedEvent) {
+		dkgResultApprovedChan <- event
+	})
+
+	if err := localChain.RequestNewWallet(); err != nil {
+		t.Fatal(err)
+	}
+
+	startedEvent := receive(t, dkgStartedChan)
+
+	groupSelectionResult, err := operators[0].SelectGroup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertIntsEqual(
+		t,
+		"selected members",
+		GroupSize,
+		len(groupSelectionResult.OperatorsAddresses),
+	)
+
+	groupPrivateKey, err := ecdsa.GenerateKey(tecdsa.Curve, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := assembleResult(
+		t,
+		operators,
+		groupSelectionResult,
+		&groupPrivateKey.PublicKey,
+		startedEvent.BlockNumber,
+	)
+
+	submitter := operatorOf(operators, groupSelectionResult, 1)
+	nonSubmitter := operators[0]
+	if nonSubmitter == submitter {
+		nonSubmitter = operators[1]
+	}
+
+	if err := submitter.SubmitDKGResult(result); err != nil {
+		t.Fatal(err)
+	}
+
+	submittedEvent := receive(t, dkgResultSubmittedChan)
+	testutils.AssertBigIntsEqual(t, "seed", startedEvent.Seed, submittedEvent.Seed)
+
+	isValid, err := nonSubmitter.IsDKGResultValid(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "result validity", true, isValid)
+
+	if err := nonSubmitter.ChallengeDKGResult(result); err == nil {
+		t.Fatal("expected error for challenge of a valid result")
+	}
+
+	if err := submitter.ApproveDKGResult(result); err == nil {
+		t.Fatal("expected error for approval in the challenge period")
+	}
+
+	blockCounter, _ := submitter.BlockCounter()
+	err = blockCounter.WaitForBlockHeight(
+		submittedEvent.BlockNumber + testDKGParameters.ChallengePeriodBlocks + 1,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if nonSubmitter.Signing().Address() != submitter.Signing().Address() {
+		if err := nonSubmitter.ApproveDKGResult(result); err == nil {
+			t.Fatal("expected error for approval in the precedence period")
+		}
+	}
+
+	if err := submitter.ApproveDKGResult(result); err != nil {
+		t.Fatal(err)
+	}
+
+	receive(t, dkgResultApprovedChan)
+
+	walletPublicKey, ok := localChain.ActiveWalletPublicKey()
+	if !ok {
+		t.Fatal("expected active wallet")
+	}
+	testutils.AssertBytesEqual(t, result.GroupPublicKey, walletPublicKey)
+
+	isLocked, err := submitter.IsPoolLocked()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "pool locked", false, isLocked)
+}
+
+func TestDKG_ChallengeInvalidResult(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain, operators := connectOperators(ctx, t, 1, true)
+
+	dkgStartedChan := make(chan *tbtc.DKGStartedEvent, 1)
+	operators[0].OnDKGStarted(func(event *tbtc.DKGStartedEvent) {
+		dkgStartedChan <- event
+	})
+	dkgResultChallengedChan := make(chan *tbtc.DKGResultChallengedEvent, 1)
+	operators[0].OnDKGResultChallenged(func(event *tbtc.DKGResultChallengedEvent) {
+		dkgResultChallengedChan <- event
+	})
+
+	if err := localChain.RequestNewWallet(); err != nil {
+		t.Fatal(err)
+	}
+
+	startedEvent := receive(t, dkgStartedChan)
+
+	groupSelectionResult, err := operators[0].SelectGroup()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupPrivateKey, err := ecdsa.GenerateKey(tecdsa.Curve, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := assembleResult(
+		t,
+		operators,
+		groupSelectionResult,
+		&groupPrivateKey.PublicKey,
+		// Signatures over a wrong start block make the result invalid.
+		startedEvent.BlockNumber+1,
+	)
+
+	if err := operators[0].SubmitDKGResult(result); err != nil {
+		t.Fatal(err)
+	}
+
+	isValid, err := operators[0].IsDKGResultValid(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "result validity", false, isValid)
+
+	if err := operators[0].ChallengeDKGResult(result); err != nil {
+		t.Fatal(err)
+	}
+
+	receive(t, dkgResultChallengedChan)
+
+	state, err := operators[0].GetDKGState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertIntsEqual(t, "DKG state", int(tbtc.AwaitingResult), int(state))
+}
+
+func TestDKG_Timeout(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain, operators := connectOperators(ctx, t, 1, true)
+
+	if err := localChain.RequestNewWallet(); err != nil {
+		t.Fatal(err)
+	}
+
+	blockCounter, _ := operators[0].BlockCounter()
+	currentBlock, _ := blockCounter.CurrentBlock()
+	err := blockCounter.WaitForBlockHeight(
+		currentBlock + testDKGParameters.SubmissionTimeoutBlocks + 2,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := operators[0].GetDKGState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertIntsEqual(t, "DKG state", int(tbtc.Idle), int(state))
+}
+
+func TestRequestHeartbeat(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain, operators := connectOperators(ctx, t, 1, true)
+
+	heartbeatChan := make(chan *tbtc.HeartbeatRequestedEvent, 1)
+	operators[0].OnHeartbeatRequested(func(event *tbtc.HeartbeatRequestedEvent) {
+		heartbeatChan <- event
+	})
+
+	walletPublicKey := []byte{0x04, 0x01}
+	messages := HeartbeatMessages(100)
+
+	if err := localChain.RequestHeartbeat(walletPublicKey, messages); err == nil {
+		t.Fatal("expected error for unknown wallet")
+	}
+
+	localChain.wallets = append(localChain.wallets, walletPublicKey)
+
+	if err := localChain.RequestHeartbeat(walletPublicKey, messages); err != nil {
+		t.Fatal(err)
+	}
+
+	event := receive(t, heartbeatChan)
+	testutils.AssertBytesEqual(t, walletPublicKey, event.WalletPublicKey)
+	testutils.AssertIntsEqual(t, "messages count", 5, len(event.Messages))
+}
+
+func connectOperators(
+	ctx context.Context,
+	t *testing.T,
+	count int,
+	joinPool bool,
+) (*Chain, []*localChain) {
+	blockCounter, err := local_v1.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sharedChain := NewChain(ctx, blockCounter, testDKGParameters)
+
+	operators := make([]*localChain, count)
+	for i := range operators {
+		operatorPrivateKey, _, err := operator.GenerateKeyPair(
+			local_v1.DefaultCurve,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		operators[i] = sharedChain.Connect(operatorPrivateKey)
+	}
+
+	if joinPool {
+		for _, operatorChain := range operators {
+			if err := operatorChain.JoinSortitionPool(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	return sharedChain, operators
+}
+
+func assembleResult(
+	t *testing.T,
+	operators []*localChain,
+	groupSelectionResult *tbtc.GroupSelectionResult,
+	groupPublicKey *ecdsa.PublicKey,
+	startBlock uint64,
+) *tbtc.DKGChainResult {
+	operatingMembersIndexes := make([]group.MemberIndex, GroupSize)
+	signatures := make(map[group.MemberIndex][]byte)
+	for i := range operatingMembersIndexes {
+		memberIndex := group.MemberIndex(i + 1)
+		operatingMembersIndexes[i] = memberIndex
+
+		memberOperator := operatorOf(operators, groupSelectionResult, memberIndex)
+
+		signatureHash, err := memberOperator.CalculateDKGResultSignatureHash(
+			groupPublicKey,
+			[]group.MemberIndex{},
+			startBlock,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signature, err := memberOperator.Signing().Sign(signatureHash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signatures[memberIndex] = signature
+	}
+
+	submitter := operatorOf(operators, groupSelectionResult, 1)
+
+	result, err := submitter.AssembleDKGResult(
+		1,
+		groupPublicKey,
+		operatingMembersIndexes,
+		[]group.MemberIndex{},
+		signatures,
+		groupSelectionResult,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func operatorOf(
+	operators []*localChain,
+	groupSelectionResult *tbtc.GroupSelectionResult,
+	memberIndex group.MemberIndex,
+) *localChain {
+	address := groupSelectionResult.OperatorsAddresses[memberIndex-1]
+	for _, operatorChain := range operators {
+		if operatorChain.Signing().Address() == address {
+			return operatorChain
+		}
+	}
+
+	panic("member operator not found")
+}
+
+func receive[T any](t *testing.T, eventChan <-chan T) T {
+	select {
+	case event := <-eventChan:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not received")
+	}
+
+	panic("unreachable")
+}
@@ -43,6 +43,8 @@ type localChain struct {
 	groupRegisteredHandlers  map[int]func(groupRegistration *event.GroupRegistration)
 	dkgStartedHandlers       map[int]func(submission *event.DKGStarted)
 	resultSubmissionHandlers map[int]func(submission *event.DKGResultSubmission)
+	resultChallengeHandlers  map[int]func(challenge *event.DKGResultChallenged)
+	resultApprovalHandlers   map[int]func(approval *event.DKGResultApproved)
 
 	simulatedHeight uint64
 	blockCounter    chain.BlockCounter
@@ -76,6 +78,7 @@ func (c *localChain) SubmitRelayEntry(newEntry []byte) error {
 	}
 
 	entry := &event.RelayEntrySubmitted{
+		Entry:       newEntry,
 		BlockNumber: currentBlock,
 	}
 
@@ -130,7 +133,7 @@ func (c *localChain) OnRelayEntryRequested(
 	})
 }
 
-func (c *localChain) SelectGroup(seed *big.Int) (chain.Addresses, error) {
+func (c *localChain) SelectGroup() (chain.Addresses, error) {
 	panic("not implemented")
 }
 
@@ -195,6 +198,8 @@ func ConnectWithKey(
 		groupRegisteredHandlers:  make(map[int]func(groupRegistration *event.GroupRegistration)),
 		dkgStartedHandlers:       make(map[int]func(submission *event.DKGStarted)),
 		resultSubmissionHandlers: make(map[int]func(submission *event.DKGResultSubmission)),
+		resultChallengeHandlers:  make(map[int]func(challenge *event.DKGResultChallenged)),
+		resultApprovalHandlers:   make(map[int]func(approval *event.DKGResultApproved)),
 		blockCounter:             bc,
 		groups:                   []localGroup{group},
 		operatorPrivateKey:       operatorPrivateKey,
@@ -235,6 +240,42 @@ func (c *localChain) IsStaleGroup(groupPublicKey []byte) (bool, error) {
 	return true, nil
 }
 
+func (c *localChain) GetGroupState(
+	groupPublicKey []byte,
+) (beaconchain.GroupState, error) {
+	isStale, err := c.IsStaleGroup(groupPublicKey)
+	if err != nil {
+		return beaconchain.GroupActive, err
+	}
+
+	if isStale {
+		return beaconchain.GroupStale, nil
+	}
+
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	currentBlock, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		return beaconchain.GroupActive, fmt.Errorf(
+			"could not determine current block: [%v]",
+			err,
+		)
+	}
+
+	for _, group := range c.groups {
+		if bytes.Equal(group.groupPublicKey, groupPublicKey) {
+			if group.registrationBlockHeight+groupActiveTime < currentBlock {
+				return beaconchain.GroupExpired, nil
+			}
+
+			return beaconchain.GroupActive, nil
+		}
+	}
+
+	return beaconchain.GroupStale, nil
+}
+
 func (c *localChain) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
 	for _, group := range c.groups {
 		if bytes.Equal(group.groupPublicKey, groupPublicKey) {
@@ -334,6 +375,110 @@ func (c *localChain) OnDKGResultSubmitted(
 	})
 }
 
+func (c *localChain) OnDKGResultChallenged(
+	handler func(challenge *event.DKGResultChallenged),
+) subscription.EventSubscription {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	handlerID := GenerateHandlerID()
+	c.resultChallengeHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		c.handlerMutex.Lock()
+		defer c.handlerMutex.Unlock()
+
+		delete(c.resultChallengeHandlers, handlerID)
+	})
+}
+
+func (c *localChain) OnDKGResultApproved(
+	handler func(approval *event.DKGResultApproved),
+) subscription.EventSubscription {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	handlerID := GenerateHandlerID()
+	c.resultApprovalHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		c.handlerMutex.Lock()
+		defer c.handlerMutex.Unlock()
+
+		delete(c.resultApprovalHandlers, handlerID)
+	})
+}
+
+// GetDKGState returns the current state of the DKG procedure. The local chain
+// registers groups as soon as DKG results are submitted so it is always idle.
+func (c *localChain) GetDKGState() (beaconchain.DKGState, error) {
+	return beaconchain.Idle, nil
+}
+
+// IsDKGResultValid checks whether the submitted DKG result is valid. The local
+// chain considers all submitted results as valid.
+func (c *localChain) IsDKGResultValid(
+	dkgResult *event.DKGResultSubmission,
+) (bool, error) {
+	return true, nil
+}
+
+func (c *localChain) ChallengeDKGResult(
+	dkgResult *event.DKGResultSubmission,
+) error {
+	currentBlock, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("cannot read current block: [%v]", err)
+	}
+
+	challengeEvent := &event.DKGResultChallenged{
+		ResultHash:  dkgResult.ResultHash,
+		BlockNumber: currentBlock,
+	}
+
+	c.handlerMutex.Lock()
+	for _, handler := range c.resultChallengeHandlers {
+		go func(handler func(*event.DKGResultChallenged)) {
+			handler(challengeEvent)
+		}(handler)
+	}
+	c.handlerMutex.Unlock()
+
+	return nil
+}
+
+func (c *localChain) ApproveDKGResult(
+	dkgResult *event.DKGResultSubmission,
+) error {
+	currentBlock, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("cannot read current block: [%v]", err)
+	}
+
+	approvalEvent := &event.DKGResultApproved{
+		ResultHash:  dkgResult.ResultHash,
+		BlockNumber: currentBlock,
+	}
+
+	c.handlerMutex.Lock()
+	for _, handler := range c.resultApprovalHandlers {
+		go func(handler func(*event.DKGResultApproved)) {
+			handler(approvalEvent)
+		}(handler)
+	}
+	c.handlerMutex.Unlock()
+
+	return nil
+}
+
+func (c *localChain) DKGParameters() (*beaconchain.DKGParameters, error) {
+	return &beaconchain.DKGParameters{
+		SubmissionTimeoutBlocks:       c.relayConfig.ResultPublicationBlockStep * uint64(c.relayConfig.GroupSize),
+		ChallengePeriodBlocks:         10,
+		ApprovePrecedencePeriodBlocks: 5,
+	}, nil
+}
+
 func (c *localChain) GetLastDKGResult() (
 	*beaconchain.DKGResult,
 	map[beaconchain.GroupMemberIndex][]byte,
@@ -374,9 +519,11 @@ func (c *localChain) GetRelayEntryTimeoutReports() []uint64 {
 	return c.relayEntryTimeoutReports
 }
 
-// CalculateDKGResultHash calculates a 256-bit hash of the DKG result.
+// CalculateDKGResultHash calculates a 256-bit hash of the DKG result. The
+// local chain does not take the DKG start block into account.
 func (c *localChain) CalculateDKGResultHash(
 	dkgResult *beaconchain.DKGResult,
+	startBlock uint64,
 ) (beaconchain.DKGResultHash, error) {
 	encodedDKGResult := fmt.Sprint(dkgResult)
 	dkgResultHash := beaconchain.DKGResultHash(
@@ -597,7 +597,7 @@ func TestCalculateDKGResultHash(t *testing.T) {
 	}
 	expectedHashString := "97a94a3b11a0f780c9510df852ac7f77072085d5bda4b07e5d198396dd4f68e5"
 
-	actualHash, err := localChain.CalculateDKGResultHash(dkgResult)
+	actualHash, err := localChain.CalculateDKGResultHash(dkgResult, 100)
 	if err != nil {
 		t.Fatal(err)
 	}
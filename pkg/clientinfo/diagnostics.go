@@ -20,6 +20,7 @@ type Client struct {
 	NetworkID    string `json:"network_id"`
 	Version      string `json:"version"`
 	Revision     string `json:"revision"`
+	Reachability string `json:"reachability"`
 }
 
 // Peer describes data structure of peer information.
@@ -106,6 +107,7 @@ func (r *Registry) RegisterClientInfoSource(
 			ChainAddress: clientChainAddress.String(),
 			Version:      clientVersion,
 			Revision:     clientRevision,
+			Reachability: connectionManager.Reachability(),
 		}
 
 		bytes, err := json.Marshal(clientInfo)
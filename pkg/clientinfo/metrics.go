@@ -2,6 +2,7 @@ package clientinfo
 
 import (
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/keep-network/keep-common/pkg/clientinfo"
@@ -13,10 +14,13 @@ type Source func() float64
 
 // Names under which metrics are exposed.
 const (
-	ConnectedPeersCountMetricName     = "connected_peers_count"
-	ConnectedBootstrapCountMetricName = "connected_bootstrap_count"
-	EthConnectivityMetricName         = "eth_connectivity"
-	ClientInfoMetricName              = "client_info"
+	ConnectedPeersCountMetricName      = "connected_peers_count"
+	ConnectedBootstrapCountMetricName  = "connected_bootstrap_count"
+	EthConnectivityMetricName          = "eth_connectivity"
+	TbtcChainConnectivityMetricName    = "tbtc_chain_connectivity"
+	OperatorBalanceMetricName          = "operator_balance_eth"
+	TbtcChainOperatorBalanceMetricName = "operator_balance_tbtc_chain"
+	ClientInfoMetricName               = "client_info"
 )
 
 const (
@@ -98,6 +102,140 @@ func (r *Registry) ObserveEthConnectivity(
 	)
 }
 
+// EthEndpointSource provides the state of a single Ethereum RPC endpoint.
+type EthEndpointSource interface {
+	// Healthy returns true if the endpoint is reachable and up to date.
+	Healthy() bool
+	// BlockLag returns the number of blocks the endpoint lags behind the
+	// best known block.
+	BlockLag() uint64
+	// Latency returns the latency of the latest endpoint health check.
+	Latency() time.Duration
+	// ErrorsCount returns the number of failed requests to the endpoint.
+	ErrorsCount() uint64
+}
+
+// ObserveEthEndpoints triggers an observation process of per-endpoint
+// metrics from the eth_connectivity family. For each endpoint, the
+// eth_connectivity_endpoint_<index>_healthy, _block_lag, _latency_seconds
+// and _errors_count metrics are exposed.
+func (r *Registry) ObserveEthEndpoints(
+	endpoints []EthEndpointSource,
+	tick time.Duration,
+) {
+	r.observeEndpoints(EthConnectivityMetricName, endpoints, tick)
+}
+
+// ObserveTbtcChainEndpoints triggers an observation process of per-endpoint
+// metrics from the tbtc_chain_connectivity family for the separate EVM chain
+// the TBTC chain handle is connected to. The exposed metrics are the same as
+// for ObserveEthEndpoints.
+func (r *Registry) ObserveTbtcChainEndpoints(
+	endpoints []EthEndpointSource,
+	tick time.Duration,
+) {
+	r.observeEndpoints(TbtcChainConnectivityMetricName, endpoints, tick)
+}
+
+func (r *Registry) observeEndpoints(
+	metricName string,
+	endpoints []EthEndpointSource,
+	tick time.Duration,
+) {
+	tick = validateTick(tick, DefaultEthereumMetricsTick)
+
+	for i, endpoint := range endpoints {
+		endpoint := endpoint
+		prefix := fmt.Sprintf("%s_endpoint_%d", metricName, i)
+
+		r.observe(
+			prefix+"_healthy",
+			func() float64 {
+				if endpoint.Healthy() {
+					return 1
+				}
+
+				return 0
+			},
+			tick,
+		)
+
+		r.observe(
+			prefix+"_block_lag",
+			func() float64 {
+				return float64(endpoint.BlockLag())
+			},
+			tick,
+		)
+
+		r.observe(
+			prefix+"_latency_seconds",
+			func() float64 {
+				return endpoint.Latency().Seconds()
+			},
+			tick,
+		)
+
+		r.observe(
+			prefix+"_errors_count",
+			func() float64 {
+				return float64(endpoint.ErrorsCount())
+			},
+			tick,
+		)
+	}
+}
+
+// OperatorBalanceSource provides the balance of the operator account.
+type OperatorBalanceSource interface {
+	// OperatorBalance returns the balance of the operator account, in wei.
+	OperatorBalance() (*big.Int, error)
+}
+
+// ObserveOperatorBalance triggers an observation process of the
+// operator_balance_eth metric.
+func (r *Registry) ObserveOperatorBalance(
+	balanceSource OperatorBalanceSource,
+	tick time.Duration,
+) {
+	r.observeOperatorBalance(OperatorBalanceMetricName, balanceSource, tick)
+}
+
+// ObserveTbtcChainOperatorBalance triggers an observation process of the
+// operator_balance_tbtc_chain metric exposing the operator balance on the
+// separate EVM chain the TBTC chain handle is connected to.
+func (r *Registry) ObserveTbtcChainOperatorBalance(
+	balanceSource OperatorBalanceSource,
+	tick time.Duration,
+) {
+	r.observeOperatorBalance(
+		TbtcChainOperatorBalanceMetricName,
+		balanceSource,
+		tick,
+	)
+}
+
+func (r *Registry) observeOperatorBalance(
+	metricName string,
+	balanceSource OperatorBalanceSource,
+	tick time.Duration,
+) {
+	input := func() float64 {
+		balance, err := balanceSource.OperatorBalance()
+		if err != nil {
+			return 0
+		}
+
+		return weiToEther(balance)
+	}
+
+	r.observe(
+		metricName,
+		input,
+		validateTick(tick, DefaultEthereumMetricsTick),
+	)
+}
+
 // ObserveApplicationSource triggers an observation process of
 // application-specific metrics.
 func (r *Registry) ObserveApplicationSource(
@@ -149,3 +287,12 @@ func validateTick(tick time.Duration, defaultTick time.Duration) time.Duration {
 
 	return defaultTick
 }
+
+func weiToEther(wei *big.Int) float64 {
+	ether, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(wei),
+		big.NewFloat(1e18),
+	).Float64()
+
+	return ether
+}
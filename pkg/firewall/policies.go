@@ -0,0 +1,372 @@
+package firewall
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/keep-network/keep-common/pkg/cache"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+// Config holds the configuration of the firewall policies.
+type Config struct {
+	// DenyList is a list of operator addresses the client never connects to,
+	// even if they are recognized by applications or allowlisted.
+	DenyList []string
+	// MinimumStake is the minimum eligible stake, in T, the staking provider
+	// of an operator must have to let the operator join the network.
+	// Zero disables the check.
+	MinimumStake uint64
+	// AllowSigningGroupMembers determines whether operators being members of
+	// any signing group of wallets controlled by the client can join the
+	// network regardless of their stake.
+	AllowSigningGroupMembers bool
+}
+
+// MinimumStakeInBaseUnits returns the configured minimum stake in T token
+// base units, the same as used by the staking contract.
+func (c Config) MinimumStakeInBaseUnits() *big.Int {
+	return new(big.Int).Mul(
+		new(big.Int).SetUint64(c.MinimumStake),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil),
+	)
+}
+
+// AddressResolver converts operator public keys to chain addresses.
+type AddressResolver interface {
+	// PublicKeyToAddress converts operator's public key to an address
+	// associated with the given chain.
+	PublicKeyToAddress(publicKey *operator.PublicKey) (chain.Address, error)
+}
+
+// StakingApplication defines functionalities for operator stake verification
+// in the firewall.
+type StakingApplication interface {
+	// StakingProviderOf returns the staking provider of the given operator.
+	// The boolean returned value is false if the operator is not registered
+	// for any staking provider.
+	StakingProviderOf(operator chain.Address) (chain.Address, bool, error)
+	// EligibleStake returns the current value of the staking provider's
+	// eligible stake.
+	EligibleStake(stakingProvider chain.Address) (*big.Int, error)
+}
+
+// SigningGroups defines functionalities for checking membership of operators
+// in signing groups of wallets controlled by the client.
+type SigningGroups interface {
+	// IsSigningGroupOperator returns true if the given operator is a member
+	// of any signing group of wallets controlled by the client.
+	IsSigningGroupOperator(operator chain.Address) bool
+}
+
+// MinimumStakeCachePeriod is the time period the cache maintains the results
+// of the last minimum stake checks. We use the cache to minimize calls to
+// the on-chain client.
+const MinimumStakeCachePeriod = 1 * time.Hour
+
+var (
+	errDenied         = fmt.Errorf("remote peer is on the deny list")
+	errNotAllowed     = fmt.Errorf("remote peer is not on the allowlist")
+	errNotGroupMember = fmt.Errorf("remote peer is not a signing group member")
+	errNotEnoughStake = fmt.Errorf("remote peer does not have enough stake")
+)
+
+// DenyList represents a list of operator addresses that are never valid
+// peers, no matter what the other firewall rules say.
+type DenyList struct {
+	deniedAddresses map[string]bool
+}
+
+// NewDenyList creates a new firewall's deny list based on the given operator
+// address list. Addresses are compared case-insensitively.
+func NewDenyList(operatorAddresses []chain.Address) *DenyList {
+	deniedAddresses := make(map[string]bool, len(operatorAddresses))
+
+	for _, operatorAddress := range operatorAddresses {
+		deniedAddresses[strings.ToLower(operatorAddress.String())] = true
+	}
+
+	return &DenyList{deniedAddresses}
+}
+
+func (dl *DenyList) Contains(operatorAddress chain.Address) bool {
+	return dl.deniedAddresses[strings.ToLower(operatorAddress.String())]
+}
+
+// DenyListPolicy returns a firewall rejecting operators on the given deny
+// list and accepting all the other operators. It is meant to be combined
+// with other policies using AllOf.
+func DenyListPolicy(
+	addressResolver AddressResolver,
+	denyList *DenyList,
+) net.Firewall {
+	return &denyListPolicy{
+		addressResolver: addressResolver,
+		denyList:        denyList,
+	}
+}
+
+type denyListPolicy struct {
+	addressResolver AddressResolver
+	denyList        *DenyList
+}
+
+func (dlp *denyListPolicy) Validate(
+	remotePeerPublicKey *operator.PublicKey,
+) error {
+	operatorAddress, err := dlp.addressResolver.PublicKeyToAddress(
+		remotePeerPublicKey,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot convert from operator key to chain address: [%v]",
+			err,
+		)
+	}
+
+	if dlp.denyList.Contains(operatorAddress) {
+		return errDenied
+	}
+
+	return nil
+}
+
+// AllowListPolicy returns a firewall accepting only operators on the given
+// allowlist. It is meant to be combined with other policies using AnyOf.
+func AllowListPolicy(allowList *AllowList) net.Firewall {
+	return &allowListPolicy{allowList}
+}
+
+type allowListPolicy struct {
+	allowList *AllowList
+}
+
+func (alp *allowListPolicy) Validate(
+	remotePeerPublicKey *operator.PublicKey,
+) error {
+	if alp.allowList.Contains(remotePeerPublicKey) {
+		return nil
+	}
+
+	return errNotAllowed
+}
+
+// MinimumStakePolicy returns a firewall accepting only operators whose
+// staking providers have the eligible stake equal to or greater than the
+// given minimum stake. Due to performance reasons, the results of
+// validations are stored in a cache for a certain amount of time.
+func MinimumStakePolicy(
+	addressResolver AddressResolver,
+	stakingApplication StakingApplication,
+	minimumStake *big.Int,
+) net.Firewall {
+	return &minimumStakePolicy{
+		addressResolver:     addressResolver,
+		stakingApplication:  stakingApplication,
+		minimumStake:        minimumStake,
+		positiveResultCache: cache.NewTimeCache(MinimumStakeCachePeriod),
+		negativeResultCache: cache.NewTimeCache(MinimumStakeCachePeriod),
+	}
+}
+
+type minimumStakePolicy struct {
+	addressResolver     AddressResolver
+	stakingApplication  StakingApplication
+	minimumStake        *big.Int
+	positiveResultCache *cache.TimeCache
+	negativeResultCache *cache.TimeCache
+}
+
+func (msp *minimumStakePolicy) Validate(
+	remotePeerPublicKey *operator.PublicKey,
+) error {
+	msp.positiveResultCache.Sweep()
+	msp.negativeResultCache.Sweep()
+
+	remotePeerPublicKeyHex := remotePeerPublicKey.String()
+
+	if msp.positiveResultCache.Has(remotePeerPublicKeyHex) {
+		return nil
+	}
+
+	if msp.negativeResultCache.Has(remotePeerPublicKeyHex) {
+		return errNotEnoughStake
+	}
+
+	operatorAddress, err := msp.addressResolver.PublicKeyToAddress(
+		remotePeerPublicKey,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot convert from operator key to chain address: [%v]",
+			err,
+		)
+	}
+
+	stakingProvider, ok, err := msp.stakingApplication.StakingProviderOf(
+		operatorAddress,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"could not get staking provider of operator [%v]: [%w]",
+			operatorAddress,
+			err,
+		)
+	}
+	if !ok {
+		// An operator with no staking provider has no stake at all.
+		msp.negativeResultCache.Add(remotePeerPublicKeyHex)
+		return errNotEnoughStake
+	}
+
+	eligibleStake, err := msp.stakingApplication.EligibleStake(stakingProvider)
+	if err != nil {
+		return fmt.Errorf(
+			"could not get eligible stake of staking provider [%v]: [%w]",
+			stakingProvider,
+			err,
+		)
+	}
+
+	if eligibleStake.Cmp(msp.minimumStake) < 0 {
+		msp.negativeResultCache.Add(remotePeerPublicKeyHex)
+		return errNotEnoughStake
+	}
+
+	msp.positiveResultCache.Add(remotePeerPublicKeyHex)
+
+	return nil
+}
+
+// SigningGroupMembersPolicy returns a firewall accepting only operators
+// being members of any signing group of wallets controlled by the client.
+// It is meant to be combined with other policies using AnyOf.
+func SigningGroupMembersPolicy(
+	addressResolver AddressResolver,
+	signingGroups SigningGroups,
+) net.Firewall {
+	return &signingGroupMembersPolicy{
+		addressResolver: addressResolver,
+		signingGroups:   signingGroups,
+	}
+}
+
+type signingGroupMembersPolicy struct {
+	addressResolver AddressResolver
+	signingGroups   SigningGroups
+}
+
+func (sgmp *signingGroupMembersPolicy) Validate(
+	remotePeerPublicKey *operator.PublicKey,
+) error {
+	operatorAddress, err := sgmp.addressResolver.PublicKeyToAddress(
+		remotePeerPublicKey,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot convert from operator key to chain address: [%v]",
+			err,
+		)
+	}
+
+	if sgmp.signingGroups.IsSigningGroupOperator(operatorAddress) {
+		return nil
+	}
+
+	return errNotGroupMember
+}
+
+// AllOf returns a firewall accepting operators accepted by all the given
+// policies. Policies are evaluated in order and the first rejection is
+// returned. If no policies are given, all operators are accepted.
+func AllOf(policies ...net.Firewall) net.Firewall {
+	return &allOfPolicy{policies}
+}
+
+type allOfPolicy struct {
+	policies []net.Firewall
+}
+
+func (aop *allOfPolicy) Validate(
+	remotePeerPublicKey *operator.PublicKey,
+) error {
+	for _, policy := range aop.policies {
+		if err := policy.Validate(remotePeerPublicKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AnyOf returns a firewall accepting operators accepted by at least one of
+// the given policies. Policies are evaluated in order until the first one
+// accepts the operator. If all policies reject the operator, the returned
+// error contains all the rejection reasons. If no policies are given, all
+// operators are rejected.
+func AnyOf(policies ...net.Firewall) net.Firewall {
+	return &anyOfPolicy{policies}
+}
+
+type anyOfPolicy struct {
+	policies []net.Firewall
+}
+
+func (aop *anyOfPolicy) Validate(
+	remotePeerPublicKey *operator.PublicKey,
+) error {
+	if len(aop.policies) == 0 {
+		return fmt.Errorf("remote peer has not been accepted by any policy")
+	}
+
+	var result *multierror.Error
+
+	for _, policy := range aop.policies {
+		err := policy.Validate(remotePeerPublicKey)
+		if err == nil {
+			return nil
+		}
+
+		result = multierror.Append(result, err)
+	}
+
+	return result.ErrorOrNil()
+}
+
+// Reloadable is a firewall delegating validation to the wrapped firewall
+// that can be replaced at runtime, without reconnecting to the network.
+// Reloadable is safe for concurrent use.
+type Reloadable struct {
+	mutex    sync.RWMutex
+	firewall net.Firewall
+}
+
+// NewReloadable creates a new reloadable firewall initially delegating
+// validation to the given firewall.
+func NewReloadable(firewall net.Firewall) *Reloadable {
+	return &Reloadable{firewall: firewall}
+}
+
+// Reload replaces the wrapped firewall with the given one. All subsequent
+// validations are delegated to the new firewall.
+func (r *Reloadable) Reload(firewall net.Firewall) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.firewall = firewall
+}
+
+func (r *Reloadable) Validate(remotePeerPublicKey *operator.PublicKey) error {
+	r.mutex.RLock()
+	firewall := r.firewall
+	r.mutex.RUnlock()
+
+	return firewall.Validate(remotePeerPublicKey)
+}
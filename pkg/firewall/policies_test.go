@@ -0,0 +1,360 @@
+package firewall
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestDenyListPolicy(t *testing.T) {
+	deniedPublicKey := generatePublicKey(t)
+	otherPublicKey := generatePublicKey(t)
+
+	addressResolver := &mockAddressResolver{}
+
+	policy := DenyListPolicy(
+		addressResolver,
+		NewDenyList([]chain.Address{
+			// Deny list addresses are case-insensitive.
+			chain.Address(
+				strings.ToUpper(addressResolver.address(deniedPublicKey).String()),
+			),
+		}),
+	)
+
+	err := policy.Validate(deniedPublicKey)
+	testutils.AssertErrorsSame(t, errDenied, err)
+
+	err = policy.Validate(otherPublicKey)
+	if err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+}
+
+func TestAllowListPolicy(t *testing.T) {
+	allowedPublicKey := generatePublicKey(t)
+	otherPublicKey := generatePublicKey(t)
+
+	policy := AllowListPolicy(
+		NewAllowList([]*operator.PublicKey{allowedPublicKey}),
+	)
+
+	err := policy.Validate(allowedPublicKey)
+	if err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+
+	err = policy.Validate(otherPublicKey)
+	testutils.AssertErrorsSame(t, errNotAllowed, err)
+}
+
+func TestMinimumStakePolicy(t *testing.T) {
+	minimumStake := big.NewInt(1000)
+
+	addressResolver := &mockAddressResolver{}
+
+	var tests = map[string]struct {
+		hasStakingProvider bool
+		eligibleStake      *big.Int
+		expectedError      error
+	}{
+		"no staking provider": {
+			hasStakingProvider: false,
+			expectedError:      errNotEnoughStake,
+		},
+		"stake below the minimum": {
+			hasStakingProvider: true,
+			eligibleStake:      big.NewInt(999),
+			expectedError:      errNotEnoughStake,
+		},
+		"stake equal to the minimum": {
+			hasStakingProvider: true,
+			eligibleStake:      big.NewInt(1000),
+			expectedError:      nil,
+		},
+		"stake above the minimum": {
+			hasStakingProvider: true,
+			eligibleStake:      big.NewInt(1001),
+			expectedError:      nil,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			operatorPublicKey := generatePublicKey(t)
+			operatorAddress := addressResolver.address(operatorPublicKey)
+
+			stakingApplication := newMockStakingApplication()
+			if test.hasStakingProvider {
+				stakingApplication.setStake(operatorAddress, test.eligibleStake)
+			}
+
+			policy := MinimumStakePolicy(
+				addressResolver,
+				stakingApplication,
+				minimumStake,
+			)
+
+			err := policy.Validate(operatorPublicKey)
+			testutils.AssertErrorsSame(t, test.expectedError, err)
+
+			// The result should be cached and the application should not
+			// be asked again.
+			stakingApplication.setStake(operatorAddress, nil)
+			stakingApplication.err = fmt.Errorf("unexpected call")
+
+			err = policy.Validate(operatorPublicKey)
+			testutils.AssertErrorsSame(t, test.expectedError, err)
+		})
+	}
+}
+
+func TestMinimumStakePolicy_ApplicationError(t *testing.T) {
+	operatorPublicKey := generatePublicKey(t)
+
+	stakingApplication := newMockStakingApplication()
+	stakingApplication.err = fmt.Errorf("chain is down")
+
+	policy := MinimumStakePolicy(
+		&mockAddressResolver{},
+		stakingApplication,
+		big.NewInt(1000),
+	)
+
+	err := policy.Validate(operatorPublicKey)
+	testutils.AssertAnyErrorInChainMatchesTarget(t, stakingApplication.err, err)
+}
+
+func TestSigningGroupMembersPolicy(t *testing.T) {
+	memberPublicKey := generatePublicKey(t)
+	otherPublicKey := generatePublicKey(t)
+
+	addressResolver := &mockAddressResolver{}
+
+	policy := SigningGroupMembersPolicy(
+		addressResolver,
+		mockSigningGroups{
+			addressResolver.address(memberPublicKey): true,
+		},
+	)
+
+	err := policy.Validate(memberPublicKey)
+	if err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+
+	err = policy.Validate(otherPublicKey)
+	testutils.AssertErrorsSame(t, errNotGroupMember, err)
+}
+
+func TestAllOf(t *testing.T) {
+	operatorPublicKey := generatePublicKey(t)
+
+	errFirst := fmt.Errorf("first")
+	errSecond := fmt.Errorf("second")
+
+	var tests = map[string]struct {
+		policies      []net.Firewall
+		expectedError error
+	}{
+		"no policies": {
+			policies:      []net.Firewall{},
+			expectedError: nil,
+		},
+		"all policies accept": {
+			policies:      []net.Firewall{Disabled, Disabled},
+			expectedError: nil,
+		},
+		"one policy rejects": {
+			policies:      []net.Firewall{Disabled, mockPolicy{errSecond}},
+			expectedError: errSecond,
+		},
+		"all policies reject": {
+			policies: []net.Firewall{
+				mockPolicy{errFirst},
+				mockPolicy{errSecond},
+			},
+			expectedError: errFirst,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := AllOf(test.policies...).Validate(operatorPublicKey)
+			testutils.AssertErrorsSame(t, test.expectedError, err)
+		})
+	}
+}
+
+func TestAnyOf(t *testing.T) {
+	operatorPublicKey := generatePublicKey(t)
+
+	errFirst := fmt.Errorf("first")
+	errSecond := fmt.Errorf("second")
+
+	var tests = map[string]struct {
+		policies       []net.Firewall
+		expectedErrors []error
+	}{
+		"one policy accepts": {
+			policies:       []net.Firewall{mockPolicy{errFirst}, Disabled},
+			expectedErrors: nil,
+		},
+		"all policies reject": {
+			policies: []net.Firewall{
+				mockPolicy{errFirst},
+				mockPolicy{errSecond},
+			},
+			expectedErrors: []error{errFirst, errSecond},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := AnyOf(test.policies...).Validate(operatorPublicKey)
+			if test.expectedErrors == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: [%v]", err)
+				}
+				return
+			}
+
+			for _, expectedError := range test.expectedErrors {
+				testutils.AssertAnyErrorInChainMatchesTarget(
+					t,
+					expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestAnyOf_NoPolicies(t *testing.T) {
+	err := AnyOf().Validate(generatePublicKey(t))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestReloadable(t *testing.T) {
+	operatorPublicKey := generatePublicKey(t)
+
+	errRejected := fmt.Errorf("rejected")
+
+	reloadable := NewReloadable(Disabled)
+
+	err := reloadable.Validate(operatorPublicKey)
+	if err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+
+	reloadable.Reload(mockPolicy{errRejected})
+
+	err = reloadable.Validate(operatorPublicKey)
+	testutils.AssertErrorsSame(t, errRejected, err)
+}
+
+func TestConfig_MinimumStakeInBaseUnits(t *testing.T) {
+	config := Config{MinimumStake: 40000}
+
+	expected, _ := new(big.Int).SetString("40000000000000000000000", 10)
+
+	testutils.AssertBigIntsEqual(
+		t,
+		"minimum stake",
+		expected,
+		config.MinimumStakeInBaseUnits(),
+	)
+}
+
+func generatePublicKey(t *testing.T) *operator.PublicKey {
+	_, operatorPublicKey, err := operator.GenerateKeyPair(
+		local_v1.DefaultCurve,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return operatorPublicKey
+}
+
+type mockAddressResolver struct{}
+
+func (mar *mockAddressResolver) address(
+	publicKey *operator.PublicKey,
+) chain.Address {
+	return chain.Address(fmt.Sprintf("0x%x", publicKey.X.Bytes()[:20]))
+}
+
+func (mar *mockAddressResolver) PublicKeyToAddress(
+	publicKey *operator.PublicKey,
+) (chain.Address, error) {
+	return mar.address(publicKey), nil
+}
+
+type mockStakingApplication struct {
+	stakes map[chain.Address]*big.Int
+	err    error
+}
+
+func newMockStakingApplication() *mockStakingApplication {
+	return &mockStakingApplication{
+		stakes: make(map[chain.Address]*big.Int),
+	}
+}
+
+func (msa *mockStakingApplication) setStake(
+	operator chain.Address,
+	eligibleStake *big.Int,
+) {
+	if eligibleStake == nil {
+		delete(msa.stakes, operator)
+		return
+	}
+
+	msa.stakes[operator] = eligibleStake
+}
+
+func (msa *mockStakingApplication) StakingProviderOf(
+	operator chain.Address,
+) (chain.Address, bool, error) {
+	if msa.err != nil {
+		return "", false, msa.err
+	}
+
+	// For simplicity, the operator is its own staking provider.
+	_, ok := msa.stakes[operator]
+	return operator, ok, nil
+}
+
+func (msa *mockStakingApplication) EligibleStake(
+	stakingProvider chain.Address,
+) (*big.Int, error) {
+	if msa.err != nil {
+		return nil, msa.err
+	}
+
+	return msa.stakes[stakingProvider], nil
+}
+
+type mockSigningGroups map[chain.Address]bool
+
+func (msg mockSigningGroups) IsSigningGroupOperator(operator chain.Address) bool {
+	return msg[operator]
+}
+
+type mockPolicy struct {
+	err error
+}
+
+func (mp mockPolicy) Validate(remotePeerPublicKey *operator.PublicKey) error {
+	return mp.err
+}
@@ -3,6 +3,7 @@ package generator
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ipfs/go-log/v2"
@@ -36,8 +37,13 @@ type Persisted[S any] struct {
 // parameter automatically. The pool submits the work to the provided scheduler
 // instance and can be controlled by the scheduler.
 type ParameterPool[T any] struct {
+	logger      log.StandardLogger
 	persistence Persistence[T]
 	pool        chan *Persisted[T]
+
+	// poolMutex ensures parameters are not pulled from the pool while the
+	// pool is being pruned.
+	poolMutex sync.Mutex
 }
 
 // NewParameterPool creates a new instance of ParameterPool.
@@ -116,6 +122,7 @@ func NewParameterPool[T any](
 	})
 
 	return &ParameterPool[T]{
+		logger:      logger,
 		persistence: persistence,
 		pool:        pool,
 	}
@@ -124,6 +131,9 @@ func NewParameterPool[T any](
 // GetNow returns a new parameter from the pool. Returns ErrEmptyPool when the
 // pool is empty.
 func (pp *ParameterPool[T]) GetNow() (*T, error) {
+	pp.poolMutex.Lock()
+	defer pp.poolMutex.Unlock()
+
 	select {
 	case generated := <-pp.pool:
 		err := pp.persistence.Delete(generated)
@@ -142,5 +152,56 @@ func (pp *ParameterPool[T]) GetNow() (*T, error) {
 
 // ParametersCount returns the number of parameters in the pool.
 func (pp *ParameterPool[T]) ParametersCount() int {
+	pp.poolMutex.Lock()
+	defer pp.poolMutex.Unlock()
+
 	return len(pp.pool)
 }
+
+// Prune removes from the pool all parameters for which the isStale function
+// returns true and deletes them from the persistence layer. The isStale
+// function is called for every parameter in the pool. Parameters that are not
+// stale stay in the pool and keep their relative order. Returns the number of
+// removed parameters.
+func (pp *ParameterPool[T]) Prune(isStale func(*T) bool) int {
+	pp.poolMutex.Lock()
+	defer pp.poolMutex.Unlock()
+
+	count := len(pp.pool)
+
+	kept := make([]*Persisted[T], 0, count)
+	pruned := 0
+
+	for i := 0; i < count; i++ {
+		parameter := <-pp.pool
+
+		if !isStale(&parameter.Data) {
+			kept = append(kept, parameter)
+			continue
+		}
+
+		if err := pp.persistence.Delete(parameter); err != nil {
+			pp.logger.Errorf(
+				"could not delete pruned persisted parameter: [%v]",
+				err,
+			)
+		}
+
+		pruned++
+	}
+
+	for _, parameter := range kept {
+		select {
+		case pp.pool <- parameter:
+		default:
+			// The generator filled the slots released by the stale parameters
+			// in the meantime. The parameter stays persisted and is loaded
+			// into the pool on the next start.
+			pp.logger.Warnf(
+				"pool is full; parameter will be loaded on the next start",
+			)
+		}
+	}
+
+	return pruned
+}
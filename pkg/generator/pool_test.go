@@ -213,6 +213,57 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+// TestPrune ensures stale parameters are removed from the pool and deleted
+// from the persistence layer while other parameters stay in the pool in the
+// same order.
+func TestPrune(t *testing.T) {
+	persistence := &mockPersistence{storage: map[string]*big.Int{
+		"100": big.NewInt(100),
+		"200": big.NewInt(200),
+		"300": big.NewInt(300),
+		"400": big.NewInt(400),
+	}}
+
+	pool, scheduler := newTestPoolWithPersistence(
+		4,
+		persistence,
+		func(ctx context.Context) *big.Int {
+			<-ctx.Done()
+			return nil
+		},
+	)
+	defer scheduler.stop()
+
+	var checked []int64
+	pruned := pool.Prune(func(parameter *big.Int) bool {
+		checked = append(checked, parameter.Int64())
+		return parameter.Int64()%200 == 0
+	})
+
+	testutils.AssertIntsEqual(t, "number of pruned parameters", 2, pruned)
+	testutils.AssertIntsEqual(t, "number of checked parameters", 4, len(checked))
+	testutils.AssertIntsEqual(
+		t,
+		"number of parameters in the pool",
+		2,
+		pool.ParametersCount(),
+	)
+
+	for _, pruned := range []*big.Int{big.NewInt(200), big.NewInt(400)} {
+		if persistence.isPresent(pruned) {
+			t.Errorf("element should be deleted from persistence: [%v]", pruned)
+		}
+	}
+
+	for _, expected := range []*big.Int{big.NewInt(100), big.NewInt(300)} {
+		e, err := pool.GetNow()
+		if err != nil {
+			t.Fatalf("unexpected error: [%v]", err)
+		}
+		testutils.AssertBigIntsEqual(t, "parameter value", expected, e)
+	}
+}
+
 func newTestPool(
 	targetSize int,
 	optionalGenerateFn ...func(context.Context) *big.Int,
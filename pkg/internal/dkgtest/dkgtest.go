@@ -159,7 +159,7 @@ func executeDKG(
 	for i := 0; i < beaconConfig.GroupSize; i++ {
 		memberIndex := group.MemberIndex(i + 1) // capture for goroutine
 		go func() {
-			signer, err := dkg.ExecuteDKG(
+			signer, _, err := dkg.ExecuteDKG(
 				&testutils.MockLogger{},
 				seed,
 				memberIndex,
@@ -0,0 +1,166 @@
+package tbtcsim
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+// AssertDKGMembers checks which particular members completed the DKG and
+// which failed it, comparing them against the expected ones.
+func AssertDKGMembers(
+	t *testing.T,
+	result *DKGResult,
+	expectedSuccessfulMembers []group.MemberIndex,
+	expectedFailedMembers []group.MemberIndex,
+) {
+	actualSuccessfulMembers := make([]group.MemberIndex, 0)
+	for memberIndex := range result.Results {
+		actualSuccessfulMembers = append(actualSuccessfulMembers, memberIndex)
+	}
+
+	actualFailedMembers := make([]group.MemberIndex, 0)
+	for memberIndex := range result.Failures {
+		actualFailedMembers = append(actualFailedMembers, memberIndex)
+	}
+
+	assertMembers(
+		t,
+		"successful DKG members",
+		expectedSuccessfulMembers,
+		actualSuccessfulMembers,
+	)
+	assertMembers(
+		t,
+		"failed DKG members",
+		expectedFailedMembers,
+		actualFailedMembers,
+	)
+}
+
+// AssertSamePublicKey checks if all members who completed the DKG generated
+// the same group public key and returns it.
+func AssertSamePublicKey(t *testing.T, result *DKGResult) *ecdsa.PublicKey {
+	var publicKey *ecdsa.PublicKey
+
+	for memberIndex, memberResult := range result.Results {
+		memberPublicKey := memberResult.PrivateKeyShare.PublicKey()
+
+		if publicKey == nil {
+			publicKey = memberPublicKey
+			continue
+		}
+
+		if !publicKey.Equal(memberPublicKey) {
+			t.Errorf(
+				"member [%v] generated a different group public key",
+				memberIndex,
+			)
+		}
+	}
+
+	if publicKey == nil {
+		t.Fatal("no member generated the group public key")
+	}
+
+	return publicKey
+}
+
+// AssertSigningMembers checks which particular members completed the signing
+// and which failed it, comparing them against the expected ones.
+func AssertSigningMembers(
+	t *testing.T,
+	result *SigningResult,
+	expectedSuccessfulMembers []group.MemberIndex,
+	expectedFailedMembers []group.MemberIndex,
+) {
+	actualSuccessfulMembers := make([]group.MemberIndex, 0)
+	for memberIndex := range result.Signatures {
+		actualSuccessfulMembers = append(actualSuccessfulMembers, memberIndex)
+	}
+
+	actualFailedMembers := make([]group.MemberIndex, 0)
+	for memberIndex := range result.Failures {
+		actualFailedMembers = append(actualFailedMembers, memberIndex)
+	}
+
+	assertMembers(
+		t,
+		"successful signing members",
+		expectedSuccessfulMembers,
+		actualSuccessfulMembers,
+	)
+	assertMembers(
+		t,
+		"failed signing members",
+		expectedFailedMembers,
+		actualFailedMembers,
+	)
+}
+
+// AssertValidSignatures checks if all members who completed the signing
+// produced the same signature and if the signature is valid for the given
+// message and group public key.
+func AssertValidSignatures(
+	t *testing.T,
+	result *SigningResult,
+	publicKey *ecdsa.PublicKey,
+	message *big.Int,
+) {
+	if len(result.Signatures) == 0 {
+		t.Fatal("no member produced a signature")
+	}
+
+	var firstSignature *tecdsa.Signature
+	for memberIndex, signature := range result.Signatures {
+		if firstSignature == nil {
+			firstSignature = signature
+		} else if !firstSignature.Equals(signature) {
+			t.Errorf("member [%v] produced a different signature", memberIndex)
+		}
+
+		if !ecdsa.Verify(publicKey, message.Bytes(), signature.R, signature.S) {
+			t.Errorf(
+				"member [%v] produced an invalid signature [%v]",
+				memberIndex,
+				signature,
+			)
+		}
+	}
+}
+
+func assertMembers(
+	t *testing.T,
+	description string,
+	expected []group.MemberIndex,
+	actual []group.MemberIndex,
+) {
+	sortMembers := func(members []group.MemberIndex) []group.MemberIndex {
+		sorted := append([]group.MemberIndex{}, members...)
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i] < sorted[j]
+		})
+		return sorted
+	}
+
+	expected = sortMembers(expected)
+	actual = sortMembers(actual)
+
+	mismatch := len(expected) != len(actual)
+	for i := 0; !mismatch && i < len(expected); i++ {
+		mismatch = expected[i] != actual[i]
+	}
+
+	if mismatch {
+		t.Errorf(
+			"unexpected %v\nexpected: [%v]\nactual:   [%v]",
+			description,
+			expected,
+			actual,
+		)
+	}
+}
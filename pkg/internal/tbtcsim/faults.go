@@ -0,0 +1,70 @@
+package tbtcsim
+
+import (
+	"math/rand"
+	"time"
+
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// Faults describes the faults injected during the simulation. Rates are
+// probabilities in range [0, 1] applied independently to each transmission
+// of a message on the link between two members. Messages sent by a member to
+// itself are never affected. Network faults are injected by the fault
+// injector of the local network provider.
+type Faults struct {
+	// DropRate is the probability of dropping a message.
+	DropRate float64
+	// DelayRate is the probability of delaying a message.
+	DelayRate float64
+	// MaxDelay is the maximum delay of a delayed message. The actual delay
+	// is drawn uniformly from range [0, MaxDelay).
+	MaxDelay time.Duration
+	// CorruptionRate is the probability of corrupting a message. Corrupted
+	// messages have one bit of their serialized form flipped. Messages that
+	// cannot be deserialized after the corruption are dropped.
+	CorruptionRate float64
+	// Crashes holds the number of messages the given member sends in each
+	// protocol run before it crashes. Crashed members stop sending and
+	// receiving messages and abort the protocol. Members crashing after zero
+	// messages do not start the protocol at all.
+	Crashes map[group.MemberIndex]int
+}
+
+// linkConditions returns the conditions of links between simulated members
+// injecting the network faults.
+func (f Faults) linkConditions() netLocal.LinkConditions {
+	return netLocal.LinkConditions{
+		Latency: &delayDistribution{
+			rate:     f.DelayRate,
+			maxDelay: f.MaxDelay,
+		},
+		LossRate:       f.DropRate,
+		CorruptionRate: f.CorruptionRate,
+	}
+}
+
+// crashLimit returns the number of messages the given member sends before
+// it crashes. The second returned value is false if the member never
+// crashes.
+func (f Faults) crashLimit(memberIndex group.MemberIndex) (int, bool) {
+	limit, ok := f.Crashes[memberIndex]
+	return limit, ok
+}
+
+// delayDistribution is a latency distribution delaying messages with the
+// given probability by a duration drawn uniformly from range [0, maxDelay).
+// Messages that are not delayed are delivered instantly.
+type delayDistribution struct {
+	rate     float64
+	maxDelay time.Duration
+}
+
+func (dd *delayDistribution) Sample(random *rand.Rand) time.Duration {
+	if dd.maxDelay <= 0 || random.Float64() >= dd.rate {
+		return 0
+	}
+
+	return time.Duration(random.Int63n(int64(dd.maxDelay)))
+}
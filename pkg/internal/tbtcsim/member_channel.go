@@ -0,0 +1,97 @@
+package tbtcsim
+
+import (
+	"context"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// memberChannel is the broadcast channel of a single simulated member that
+// crashes after sending the given number of messages. Network faults are
+// injected by the delegate channel.
+type memberChannel struct {
+	delegate net.BroadcastChannel
+	// crashLimit is the number of messages the member sends before it
+	// crashes. Negative if the member never crashes.
+	crashLimit int
+	crashFn    context.CancelFunc
+
+	stateMutex sync.Mutex
+	sentCount  int
+	crashed    bool
+}
+
+func newMemberChannel(
+	delegate net.BroadcastChannel,
+	faults Faults,
+	memberIndex group.MemberIndex,
+	crashFn context.CancelFunc,
+) *memberChannel {
+	crashLimit, ok := faults.crashLimit(memberIndex)
+	if !ok {
+		crashLimit = -1
+	}
+
+	return &memberChannel{
+		delegate:   delegate,
+		crashLimit: crashLimit,
+		crashFn:    crashFn,
+		crashed:    crashLimit == 0,
+	}
+}
+
+func (mc *memberChannel) Name() string {
+	return mc.delegate.Name()
+}
+
+func (mc *memberChannel) Send(
+	ctx context.Context,
+	message net.TaggedMarshaler,
+	retransmissionStrategy ...net.RetransmissionStrategy,
+) error {
+	mc.stateMutex.Lock()
+	if mc.crashed {
+		mc.stateMutex.Unlock()
+		return nil
+	}
+
+	mc.sentCount++
+	if mc.crashLimit >= 0 && mc.sentCount >= mc.crashLimit {
+		// The member crashes right after sending the message.
+		mc.crashed = true
+		defer mc.crashFn()
+	}
+	mc.stateMutex.Unlock()
+
+	return mc.delegate.Send(ctx, message, retransmissionStrategy...)
+}
+
+func (mc *memberChannel) Recv(ctx context.Context, handler func(m net.Message)) {
+	mc.delegate.Recv(ctx, func(message net.Message) {
+		if mc.isCrashed() {
+			return
+		}
+
+		handler(message)
+	})
+}
+
+func (mc *memberChannel) SetUnmarshaler(
+	unmarshaler func() net.TaggedUnmarshaler,
+) {
+	mc.delegate.SetUnmarshaler(unmarshaler)
+}
+
+func (mc *memberChannel) SetFilter(filter net.BroadcastChannelFilter) error {
+	return mc.delegate.SetFilter(filter)
+}
+
+// isCrashed returns true if the member has crashed.
+func (mc *memberChannel) isCrashed() bool {
+	mc.stateMutex.Lock()
+	defer mc.stateMutex.Unlock()
+
+	return mc.crashed
+}
@@ -0,0 +1,288 @@
+package tbtcsim
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/ecdsa/keygen"
+	"google.golang.org/protobuf/proto"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/generator"
+	"github.com/keep-network/keep-core/pkg/internal/tecdsatest"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa/dkg"
+	"github.com/keep-network/keep-core/pkg/tecdsa/dkg/gen/pb"
+)
+
+// basePreParamsCount is the number of pre-parameters test fixtures used as
+// a base for pre-parameters of simulated members.
+const basePreParamsCount = 5
+
+// derivePreParams derives TSS pre-parameters for the given number of members
+// from the pre-parameters test fixtures. Generating pre-parameters takes
+// minutes so it is not feasible to generate them for each simulated member.
+// Instead, members share the Paillier keys and the NTilde modulus of the test
+// fixtures but each member gets unique h1 and h2 values, as required by the
+// DKG protocol. Pre-parameters derived this way must never be used outside
+// of simulations. The derivation is determined by the seed.
+func derivePreParams(
+	membersCount int,
+	seed int64,
+) (map[group.MemberIndex]*keygen.LocalPreParams, error) {
+	fixtures, err := tecdsatest.LoadPrivateKeyShareTestFixtures(
+		basePreParamsCount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load test fixtures: [%v]", err)
+	}
+
+	// #nosec G404 (insecure random number source (rand))
+	// Deterministic randomness is needed to make simulations reproducible.
+	// Derived pre-parameters are never used outside of simulations.
+	random := rand.New(rand.NewSource(seed))
+
+	preParams := make(map[group.MemberIndex]*keygen.LocalPreParams, membersCount)
+
+	for i := 0; i < membersCount; i++ {
+		base := fixtures[i%len(fixtures)].LocalPreParams
+
+		derived, err := derivePreParamsFrom(&base, random)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot derive pre-parameters for member [%v]: [%v]",
+				i+1,
+				err,
+			)
+		}
+
+		preParams[group.MemberIndex(i+1)] = derived
+	}
+
+	return preParams, nil
+}
+
+// derivePreParamsFrom derives new pre-parameters from the given ones the same
+// way tss-lib generates h1, h2, alpha, and beta values.
+func derivePreParamsFrom(
+	base *keygen.LocalPreParams,
+	random *rand.Rand,
+) (*keygen.LocalPreParams, error) {
+	one := big.NewInt(1)
+
+	randomRelativelyPrime := func(modulus *big.Int) *big.Int {
+		for {
+			value := new(big.Int).Rand(random, modulus)
+			if value.Sign() > 0 &&
+				new(big.Int).GCD(nil, nil, value, modulus).Cmp(one) == 0 {
+				return value
+			}
+		}
+	}
+
+	pq := new(big.Int).Mul(base.P, base.Q)
+
+	f1 := randomRelativelyPrime(base.NTildei)
+	h1 := new(big.Int).Exp(f1, big.NewInt(2), base.NTildei)
+
+	var alpha, beta *big.Int
+	for beta == nil {
+		alpha = randomRelativelyPrime(base.NTildei)
+		beta = new(big.Int).ModInverse(alpha, pq)
+	}
+
+	h2 := new(big.Int).Exp(h1, alpha, base.NTildei)
+
+	if h1.Cmp(h2) == 0 {
+		return nil, fmt.Errorf("h1 and h2 are equal")
+	}
+
+	return &keygen.LocalPreParams{
+		PaillierSK: base.PaillierSK,
+		NTildei:    base.NTildei,
+		H1i:        h1,
+		H2i:        h2,
+		Alpha:      alpha,
+		Beta:       beta,
+		P:          base.P,
+		Q:          base.Q,
+	}, nil
+}
+
+// newDkgExecutor creates a DKG executor whose pre-parameters pool holds only
+// the given pre-parameters. The pre-parameters are imported into an in-memory
+// persistence the pool is loaded from. The given scheduler must never run
+// computations so the pool does not generate new pre-parameters.
+func newDkgExecutor(
+	ctx context.Context,
+	scheduler *generator.Scheduler,
+	preParams *keygen.LocalPreParams,
+) (*dkg.Executor, error) {
+	preParamsBytes, err := proto.Marshal(&pb.PreParams{
+		Data: &pb.PreParams_LocalPreParams{
+			PaillierSK: &pb.PreParams_PrivateKey{
+				PublicKey: &pb.PreParams_PublicKey{
+					N: preParams.PaillierSK.N.Bytes(),
+				},
+				LambdaN: preParams.PaillierSK.LambdaN.Bytes(),
+				PhiN:    preParams.PaillierSK.PhiN.Bytes(),
+			},
+			NTilde: preParams.NTildei.Bytes(),
+			H1I:    preParams.H1i.Bytes(),
+			H2I:    preParams.H2i.Bytes(),
+			Alpha:  preParams.Alpha.Bytes(),
+			Beta:   preParams.Beta.Bytes(),
+			P:      preParams.P.Bytes(),
+			Q:      preParams.Q.Bytes(),
+		},
+		CreationTimestamp: timestamppb.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal pre-parameters: [%v]", err)
+	}
+
+	dkgPreParams := &dkg.PreParams{}
+	if err := dkgPreParams.Unmarshal(preParamsBytes); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal pre-parameters: [%v]", err)
+	}
+
+	handle := &memoryPersistence{}
+
+	imported, err := dkg.ImportPreParams(
+		handle,
+		&testutils.MockLogger{},
+		[]*dkg.PreParams{dkgPreParams},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot import pre-parameters: [%v]", err)
+	}
+	if imported != 1 {
+		return nil, fmt.Errorf("pre-parameters failed validation")
+	}
+
+	return dkg.NewExecutor(
+		ctx,
+		&testutils.MockLogger{},
+		scheduler,
+		handle,
+		1,
+		time.Minute,
+		0,
+		1,
+		0,
+		1,
+	), nil
+}
+
+// idleProtocol is a protocol that is always executing. It is used to keep
+// the scheduler of simulated members stopped.
+type idleProtocol struct {
+	checks chan struct{}
+}
+
+func (ip *idleProtocol) IsExecuting() bool {
+	select {
+	case ip.checks <- struct{}{}:
+	default:
+	}
+
+	return true
+}
+
+// newIdleScheduler creates a scheduler that never runs computations so
+// pre-parameters pools of simulated members do not generate pre-parameters.
+// The scheduler stops computations once it checks the registered protocol.
+// Checks happen one after another so the scheduler is stopped for sure once
+// the protocol is checked for the second time.
+func newIdleScheduler() *generator.Scheduler {
+	scheduler := generator.StartScheduler()
+
+	protocol := &idleProtocol{checks: make(chan struct{})}
+	scheduler.RegisterProtocol(protocol)
+
+	<-protocol.checks
+	<-protocol.checks
+
+	return scheduler
+}
+
+// memoryPersistence is an in-memory persistence handle holding pre-parameters
+// of a simulated member.
+type memoryPersistence struct {
+	mutex sync.Mutex
+	data  map[string]*memoryDescriptor
+}
+
+func (mp *memoryPersistence) Save(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+
+	if mp.data == nil {
+		mp.data = make(map[string]*memoryDescriptor)
+	}
+
+	mp.data[directory+"/"+name] = &memoryDescriptor{
+		name:      name,
+		directory: directory,
+		content:   data,
+	}
+
+	return nil
+}
+
+func (mp *memoryPersistence) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+
+	descriptors := make(chan persistence.DataDescriptor, len(mp.data))
+	errors := make(chan error)
+
+	for _, descriptor := range mp.data {
+		descriptors <- descriptor
+	}
+
+	close(descriptors)
+	close(errors)
+
+	return descriptors, errors
+}
+
+func (mp *memoryPersistence) Delete(directory string, name string) error {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+
+	delete(mp.data, directory+"/"+name)
+
+	return nil
+}
+
+type memoryDescriptor struct {
+	name      string
+	directory string
+	content   []byte
+}
+
+func (md *memoryDescriptor) Name() string {
+	return md.name
+}
+
+func (md *memoryDescriptor) Directory() string {
+	return md.directory
+}
+
+func (md *memoryDescriptor) Content() ([]byte, error) {
+	return md.content, nil
+}
@@ -0,0 +1,448 @@
+// Package tbtcsim provides a simulation harness running the full tECDSA
+// distributed key generation and signing protocols used by tBTC with many
+// simulated operators in one process. Members communicate over the local
+// broadcast channel and time is tracked by the local block counter. Network
+// faults such as dropped, delayed, and corrupted messages are injected by
+// the fault injector of the local network provider; crashed members are
+// simulated as well. Fault decisions are drawn from a source seeded with the
+// simulation seed so they are reproducible as long as members send messages
+// in the same order.
+package tbtcsim
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bnb-chain/tss-lib/ecdsa/keygen"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/generator"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/net"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+	"github.com/keep-network/keep-core/pkg/tecdsa/dkg"
+	"github.com/keep-network/keep-core/pkg/tecdsa/signing"
+)
+
+const (
+	// DefaultDKGTimeoutBlocks is the default number of blocks after which
+	// a simulated DKG times out.
+	DefaultDKGTimeoutBlocks = 1200
+	// DefaultSigningTimeoutBlocks is the default number of blocks after which
+	// a simulated signing times out.
+	DefaultSigningTimeoutBlocks = 600
+	// startDelayBlocks is the number of blocks the simulation waits before
+	// starting the protocol to make sure all members are up.
+	startDelayBlocks = 3
+)
+
+// simulationsCounter is used to give broadcast channels of simulations
+// executed in parallel unique names, even if they use the same seed.
+var simulationsCounter uint64
+
+// Config holds the parameters of the simulation.
+type Config struct {
+	// GroupSize is the number of simulated group members.
+	GroupSize int
+	// HonestThreshold is the minimum number of members needed to produce
+	// a signature.
+	HonestThreshold int
+	// Seed drives all random decisions of the simulation, including injected
+	// faults and members' pre-parameters.
+	Seed int64
+	// Faults are the faults injected during the simulation.
+	Faults Faults
+	// DKGTimeoutBlocks is the number of blocks after which the DKG times out.
+	// DefaultDKGTimeoutBlocks is used if not set.
+	DKGTimeoutBlocks uint64
+	// SigningTimeoutBlocks is the number of blocks after which the signing
+	// times out. DefaultSigningTimeoutBlocks is used if not set.
+	SigningTimeoutBlocks uint64
+}
+
+// DKGResult is the result of a simulated DKG.
+type DKGResult struct {
+	// Results holds the results of members who completed the DKG.
+	Results map[group.MemberIndex]*dkg.Result
+	// Failures holds the errors of members who failed the DKG.
+	Failures map[group.MemberIndex]error
+}
+
+// SigningResult is the result of a simulated signing.
+type SigningResult struct {
+	// Signatures holds the signatures produced by members who completed
+	// the signing.
+	Signatures map[group.MemberIndex]*tecdsa.Signature
+	// Failures holds the errors of members who failed the signing.
+	Failures map[group.MemberIndex]error
+}
+
+// Simulation runs the tECDSA protocols with simulated group members. DKG and
+// signing runs of the given simulation must be executed sequentially.
+type Simulation struct {
+	config Config
+	id     uint64
+
+	operatorPublicKey   *operator.PublicKey
+	blockCounter        chain.BlockCounter
+	membershipValidator *group.MembershipValidator
+	faultInjector       *netLocal.FaultInjector
+
+	preParams map[group.MemberIndex]*keygen.LocalPreParams
+	// scheduler is shared by DKG executors of all simulated members. It never
+	// runs computations so pre-parameters are not generated.
+	scheduler *generator.Scheduler
+
+	// runsCount is the number of protocol runs executed so far. It is used
+	// to build deterministic session identifiers.
+	runsCount int
+	// privateKeyShares holds the private key shares generated by the last
+	// DKG run.
+	privateKeyShares map[group.MemberIndex]*tecdsa.PrivateKeyShare
+}
+
+// NewSimulation creates a new simulation with the given configuration.
+func NewSimulation(config Config) (*Simulation, error) {
+	if config.GroupSize <= 0 {
+		return nil, fmt.Errorf("group size must be greater than zero")
+	}
+	if config.HonestThreshold <= 0 || config.HonestThreshold > config.GroupSize {
+		return nil, fmt.Errorf(
+			"honest threshold must be in range [1, %d]",
+			config.GroupSize,
+		)
+	}
+	for name, rate := range map[string]float64{
+		"drop rate":       config.Faults.DropRate,
+		"delay rate":      config.Faults.DelayRate,
+		"corruption rate": config.Faults.CorruptionRate,
+	} {
+		if rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("%s must be in range [0, 1]", name)
+		}
+	}
+	if config.DKGTimeoutBlocks == 0 {
+		config.DKGTimeoutBlocks = DefaultDKGTimeoutBlocks
+	}
+	if config.SigningTimeoutBlocks == 0 {
+		config.SigningTimeoutBlocks = DefaultSigningTimeoutBlocks
+	}
+
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair(
+		local_v1.DefaultCurve,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate operator key pair: [%v]", err)
+	}
+
+	localChain := local_v1.ConnectWithKey(
+		config.GroupSize,
+		config.HonestThreshold,
+		operatorPrivateKey,
+	)
+
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get block counter: [%v]", err)
+	}
+
+	address, err := localChain.Signing().PublicKeyToAddress(operatorPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot convert operator public key to chain address: [%v]",
+			err,
+		)
+	}
+
+	// All simulated members are controlled by the same operator.
+	operators := make([]chain.Address, config.GroupSize)
+	for i := range operators {
+		operators[i] = address
+	}
+
+	faultInjector := netLocal.NewFaultInjector(config.Seed)
+	faultInjector.SetDefaultConditions(config.Faults.linkConditions())
+
+	preParams, err := derivePreParams(config.GroupSize, config.Seed)
+	if err != nil {
+		return nil, fmt.Errorf("cannot derive pre-parameters: [%v]", err)
+	}
+
+	return &Simulation{
+		config:            config,
+		id:                atomic.AddUint64(&simulationsCounter, 1),
+		operatorPublicKey: operatorPublicKey,
+		blockCounter:      blockCounter,
+		membershipValidator: group.NewMembershipValidator(
+			&testutils.MockLogger{},
+			operators,
+			localChain.Signing(),
+		),
+		faultInjector: faultInjector,
+		preParams:     preParams,
+		scheduler:     newIdleScheduler(),
+	}, nil
+}
+
+// RunDKG runs the DKG with all group members except the excluded ones. Once
+// the DKG completes, private key shares of members who completed it are
+// used by subsequent signing runs.
+func (s *Simulation) RunDKG(
+	excludedMembersIndexes ...group.MemberIndex,
+) (*DKGResult, error) {
+	sessionID := s.nextSessionID("dkg")
+
+	results := make(map[group.MemberIndex]*dkg.Result)
+
+	failures, err := s.run(
+		sessionID,
+		s.config.DKGTimeoutBlocks,
+		excludedMembersIndexes,
+		dkg.RegisterUnmarshallers,
+		func(
+			ctx context.Context,
+			memberIndex group.MemberIndex,
+			channel *memberChannel,
+		) (func(), error) {
+			executor, err := newDkgExecutor(
+				ctx,
+				s.scheduler,
+				s.preParams[memberIndex],
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			result, err := executor.Execute(
+				ctx,
+				&testutils.MockLogger{},
+				big.NewInt(s.config.Seed),
+				sessionID,
+				memberIndex,
+				s.config.GroupSize,
+				s.dishonestThreshold(),
+				excludedMembersIndexes,
+				channel,
+				s.membershipValidator,
+				nil,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return func() { results[memberIndex] = result }, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.privateKeyShares = make(map[group.MemberIndex]*tecdsa.PrivateKeyShare)
+	for memberIndex, result := range results {
+		s.privateKeyShares[memberIndex] = result.PrivateKeyShare
+	}
+
+	return &DKGResult{
+		Results:  results,
+		Failures: failures,
+	}, nil
+}
+
+// RunSigning runs the signing of the given message with private key shares
+// generated by the last DKG run. All members holding a private key share
+// participate in the signing except the excluded ones. The signing is
+// successful only if exactly the honest threshold of members participates.
+func (s *Simulation) RunSigning(
+	message *big.Int,
+	excludedMembersIndexes ...group.MemberIndex,
+) (*SigningResult, error) {
+	if len(s.privateKeyShares) == 0 {
+		return nil, fmt.Errorf("no private key shares; run the DKG first")
+	}
+
+	excluded := make(map[group.MemberIndex]bool)
+	for _, memberIndex := range excludedMembersIndexes {
+		excluded[memberIndex] = true
+	}
+
+	// Members who did not complete the DKG cannot take part in the signing.
+	for memberIndex := group.MemberIndex(1); int(memberIndex) <= s.config.GroupSize; memberIndex++ {
+		if _, ok := s.privateKeyShares[memberIndex]; !ok && !excluded[memberIndex] {
+			excluded[memberIndex] = true
+			excludedMembersIndexes = append(excludedMembersIndexes, memberIndex)
+		}
+	}
+
+	sessionID := s.nextSessionID("signing")
+
+	signatures := make(map[group.MemberIndex]*tecdsa.Signature)
+
+	failures, err := s.run(
+		sessionID,
+		s.config.SigningTimeoutBlocks,
+		excludedMembersIndexes,
+		signing.RegisterUnmarshallers,
+		func(
+			ctx context.Context,
+			memberIndex group.MemberIndex,
+			channel *memberChannel,
+		) (func(), error) {
+			result, err := signing.Execute(
+				ctx,
+				&testutils.MockLogger{},
+				message,
+				sessionID,
+				memberIndex,
+				s.privateKeyShares[memberIndex],
+				s.config.GroupSize,
+				s.dishonestThreshold(),
+				excludedMembersIndexes,
+				channel,
+				s.membershipValidator,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return func() { signatures[memberIndex] = result.Signature }, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SigningResult{
+		Signatures: signatures,
+		Failures:   failures,
+	}, nil
+}
+
+// run executes the given protocol function for all group members except
+// the excluded ones. The protocol function returns a function recording
+// the member's result; it is called with the results lock held. The protocol
+// starts a few blocks after this function is called and times out after
+// the given number of blocks. Returns the failures of members.
+func (s *Simulation) run(
+	sessionID string,
+	timeoutBlocks uint64,
+	excludedMembersIndexes []group.MemberIndex,
+	registerUnmarshallersFn func(channel net.BroadcastChannel),
+	protocolFn func(
+		ctx context.Context,
+		memberIndex group.MemberIndex,
+		channel *memberChannel,
+	) (func(), error),
+) (map[group.MemberIndex]error, error) {
+	excluded := make(map[group.MemberIndex]bool)
+	for _, memberIndex := range excludedMembersIndexes {
+		excluded[memberIndex] = true
+	}
+
+	currentBlock, err := s.blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	startBlock := currentBlock + startDelayBlocks
+	timeoutBlock := startBlock + timeoutBlocks
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	timeoutWaiter, err := s.blockCounter.BlockHeightWaiter(timeoutBlock)
+	if err != nil {
+		return nil, fmt.Errorf("cannot wait for timeout block: [%v]", err)
+	}
+
+	go func() {
+		select {
+		case <-timeoutWaiter:
+			cancelCtx()
+		case <-ctx.Done():
+		}
+	}()
+
+	channelName := fmt.Sprintf("tbtcsim-%v-%v", s.id, sessionID)
+
+	failures := make(map[group.MemberIndex]error)
+
+	var resultsMutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for memberIndex := group.MemberIndex(1); int(memberIndex) <= s.config.GroupSize; memberIndex++ {
+		if excluded[memberIndex] {
+			continue
+		}
+
+		memberCtx, cancelMemberCtx := context.WithCancel(ctx)
+
+		// Each member has its own provider so the fault injector applies
+		// the faults to links between members.
+		provider := netLocal.ConnectWithFaults(
+			s.operatorPublicKey,
+			s.faultInjector,
+		)
+
+		broadcastChannel, err := provider.BroadcastChannelFor(channelName)
+		if err != nil {
+			cancelMemberCtx()
+			return nil, fmt.Errorf("cannot get broadcast channel: [%v]", err)
+		}
+
+		channel := newMemberChannel(
+			broadcastChannel,
+			s.config.Faults,
+			memberIndex,
+			cancelMemberCtx,
+		)
+		registerUnmarshallersFn(channel)
+
+		if channel.isCrashed() {
+			cancelMemberCtx()
+			failures[memberIndex] = fmt.Errorf("member crashed before start")
+			continue
+		}
+
+		wg.Add(1)
+		go func(memberIndex group.MemberIndex) {
+			defer wg.Done()
+			defer cancelMemberCtx()
+
+			err := s.blockCounter.WaitForBlockHeight(startBlock)
+			if err == nil {
+				var recordResultFn func()
+				recordResultFn, err = protocolFn(memberCtx, memberIndex, channel)
+				if err == nil {
+					resultsMutex.Lock()
+					recordResultFn()
+					resultsMutex.Unlock()
+					return
+				}
+			}
+
+			resultsMutex.Lock()
+			failures[memberIndex] = err
+			resultsMutex.Unlock()
+		}(memberIndex)
+	}
+
+	wg.Wait()
+
+	return failures, nil
+}
+
+func (s *Simulation) dishonestThreshold() int {
+	return s.config.GroupSize - s.config.HonestThreshold
+}
+
+func (s *Simulation) nextSessionID(protocol string) string {
+	s.runsCount++
+	return fmt.Sprintf("%s-%v-%v", protocol, s.config.Seed, s.runsCount)
+}
@@ -0,0 +1,120 @@
+//go:build integration
+// +build integration
+
+package tbtcsim
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// Simulations of the full-size tBTC group take a lot of time and CPU so they
+// are executed only with the integration build tag.
+
+const (
+	groupSize       = 100
+	honestThreshold = 51
+)
+
+func TestSimulation_FullGroup(t *testing.T) {
+	simulation, err := NewSimulation(Config{
+		GroupSize:       groupSize,
+		HonestThreshold: honestThreshold,
+		Seed:            100,
+		Faults: Faults{
+			DelayRate: 0.1,
+			MaxDelay:  2 * time.Second,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgResult, err := simulation.RunDKG()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertDKGMembers(t, dkgResult, membersRange(1, groupSize), nil)
+	publicKey := AssertSamePublicKey(t, dkgResult)
+
+	message := big.NewInt(100)
+
+	signingResult, err := simulation.RunSigning(
+		message,
+		membersRange(honestThreshold+1, groupSize)...,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertSigningMembers(
+		t,
+		signingResult,
+		membersRange(1, honestThreshold),
+		nil,
+	)
+	AssertValidSignatures(t, signingResult, publicKey, message)
+}
+
+func TestSimulation_FullGroupWithExcludedMembers(t *testing.T) {
+	crashed := membersRange(groupSize-4, groupSize)
+
+	crashes := make(map[group.MemberIndex]int)
+	for _, memberIndex := range crashed {
+		crashes[memberIndex] = 0
+	}
+
+	simulation, err := NewSimulation(Config{
+		GroupSize:       groupSize,
+		HonestThreshold: honestThreshold,
+		Seed:            101,
+		Faults: Faults{
+			DelayRate: 0.1,
+			MaxDelay:  2 * time.Second,
+			Crashes:   crashes,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Crashed members are excluded from the DKG the same way the client
+	// excludes inactive members in DKG retries.
+	dkgResult, err := simulation.RunDKG(crashed...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertDKGMembers(t, dkgResult, membersRange(1, groupSize-5), nil)
+	publicKey := AssertSamePublicKey(t, dkgResult)
+
+	message := big.NewInt(200)
+
+	signingResult, err := simulation.RunSigning(
+		message,
+		membersRange(honestThreshold+1, groupSize-5)...,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertSigningMembers(
+		t,
+		signingResult,
+		membersRange(1, honestThreshold),
+		nil,
+	)
+	AssertValidSignatures(t, signingResult, publicKey, message)
+}
+
+func membersRange(first, last int) []group.MemberIndex {
+	members := make([]group.MemberIndex, 0)
+	for i := first; i <= last; i++ {
+		members = append(members, group.MemberIndex(i))
+	}
+	return members
+}
@@ -0,0 +1,177 @@
+package tbtcsim
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+func TestSimulation_DKGAndSigning(t *testing.T) {
+	simulation, err := NewSimulation(Config{
+		GroupSize:       3,
+		HonestThreshold: 2,
+		Seed:            1,
+		Faults: Faults{
+			DelayRate: 0.5,
+			MaxDelay:  200 * time.Millisecond,
+		},
+		DKGTimeoutBlocks:     120,
+		SigningTimeoutBlocks: 60,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgResult, err := simulation.RunDKG()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertDKGMembers(t, dkgResult, []group.MemberIndex{1, 2, 3}, nil)
+	publicKey := AssertSamePublicKey(t, dkgResult)
+
+	message := big.NewInt(100)
+
+	signingResult, err := simulation.RunSigning(message, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertSigningMembers(t, signingResult, []group.MemberIndex{1, 2}, nil)
+	AssertValidSignatures(t, signingResult, publicKey, message)
+}
+
+func TestSimulation_CrashedMember(t *testing.T) {
+	simulation, err := NewSimulation(Config{
+		GroupSize:       3,
+		HonestThreshold: 2,
+		Seed:            2,
+		Faults: Faults{
+			Crashes: map[group.MemberIndex]int{3: 0},
+		},
+		DKGTimeoutBlocks: 20,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The DKG cannot complete without the crashed member.
+	dkgResult, err := simulation.RunDKG()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertDKGMembers(t, dkgResult, nil, []group.MemberIndex{1, 2, 3})
+
+	_, err = simulation.RunSigning(big.NewInt(100))
+	if err == nil {
+		t.Errorf("expected signing error when no DKG completed")
+	}
+}
+
+func TestSimulation_DroppedMessages(t *testing.T) {
+	simulation, err := NewSimulation(Config{
+		GroupSize:       3,
+		HonestThreshold: 2,
+		Seed:            3,
+		Faults: Faults{
+			DropRate: 1,
+		},
+		DKGTimeoutBlocks: 20,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgResult, err := simulation.RunDKG()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertDKGMembers(t, dkgResult, nil, []group.MemberIndex{1, 2, 3})
+}
+
+func TestNewSimulation_InvalidConfig(t *testing.T) {
+	var tests = map[string]Config{
+		"zero group size": {
+			GroupSize:       0,
+			HonestThreshold: 0,
+		},
+		"honest threshold greater than group size": {
+			GroupSize:       3,
+			HonestThreshold: 4,
+		},
+		"drop rate out of range": {
+			GroupSize:       3,
+			HonestThreshold: 2,
+			Faults:          Faults{DropRate: 1.5},
+		},
+	}
+
+	for testName, config := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := NewSimulation(config)
+			if err == nil {
+				t.Errorf("expected configuration error")
+			}
+		})
+	}
+}
+
+func TestDerivePreParams(t *testing.T) {
+	preParams, err := derivePreParams(12, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "pre-parameters count", 12, len(preParams))
+
+	otherPreParams, err := derivePreParams(12, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seenH := make(map[string]bool)
+	for memberIndex, pp := range preParams {
+		testutils.AssertBigIntsEqual(
+			t,
+			"h1",
+			otherPreParams[memberIndex].H1i,
+			pp.H1i,
+		)
+
+		if !pp.ValidateWithProof() {
+			t.Errorf("invalid pre-parameters of member [%v]", memberIndex)
+		}
+
+		pq := new(big.Int).Mul(pp.P, pp.Q)
+		testutils.AssertBigIntsEqual(
+			t,
+			"h2",
+			new(big.Int).Exp(pp.H1i, pp.Alpha, pp.NTildei),
+			pp.H2i,
+		)
+		testutils.AssertBigIntsEqual(
+			t,
+			"h1",
+			new(big.Int).Exp(pp.H2i, pp.Beta, pp.NTildei),
+			pp.H1i,
+		)
+		testutils.AssertBigIntsEqual(
+			t,
+			"alpha * beta mod pq",
+			big.NewInt(1),
+			new(big.Int).Mod(new(big.Int).Mul(pp.Alpha, pp.Beta), pq),
+		)
+
+		for _, h := range []*big.Int{pp.H1i, pp.H2i} {
+			if seenH[h.String()] {
+				t.Errorf("duplicated h value of member [%v]", memberIndex)
+			}
+			seenH[h.String()] = true
+		}
+	}
+}
@@ -36,6 +36,9 @@ type BroadcastNetworkMessage struct {
 	// Sequence number of the message. Retransmissions have the same sequence
 	// number as the original message.
 	SequenceNumber uint64 `protobuf:"varint,4,opt,name=sequenceNumber,proto3" json:"sequenceNumber,omitempty"`
+	// Indicates whether the payload is compressed. Receivers must decompress
+	// the payload before unmarshaling it.
+	Compressed bool `protobuf:"varint,5,opt,name=compressed,proto3" json:"compressed,omitempty"`
 }
 
 func (x *BroadcastNetworkMessage) Reset() {
@@ -98,12 +101,22 @@ func (x *BroadcastNetworkMessage) GetSequenceNumber() uint64 {
 	return 0
 }
 
+func (x *BroadcastNetworkMessage) GetCompressed() bool {
+	if x != nil {
+		return x.Compressed
+	}
+	return false
+}
+
 type Identity struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	PubKey []byte `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	// Marshaled OperatorAttestation of the network key. Empty if the network
+	// key is the operator key.
+	OperatorAttestation []byte `protobuf:"bytes,2,opt,name=operator_attestation,json=operatorAttestation,proto3" json:"operator_attestation,omitempty"`
 }
 
 func (x *Identity) Reset() {
@@ -145,12 +158,89 @@ func (x *Identity) GetPubKey() []byte {
 	return nil
 }
 
+func (x *Identity) GetOperatorAttestation() []byte {
+	if x != nil {
+		return x.OperatorAttestation
+	}
+	return nil
+}
+
+// OperatorAttestation binds a network key to the operator whose key is held
+// outside of the client, for example by an external signer.
+type OperatorAttestation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Uncompressed public key of the operator.
+	OperatorPubKey []byte `protobuf:"bytes,1,opt,name=operator_pub_key,json=operatorPubKey,proto3" json:"operator_pub_key,omitempty"`
+	// Signature of the network key attestation message done with the
+	// operator key.
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// Unix timestamp in seconds after which the attestation is no longer
+	// valid. The timestamp is part of the signed attestation message.
+	ExpiresAt uint64 `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *OperatorAttestation) Reset() {
+	*x = OperatorAttestation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_net_gen_pb_message_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperatorAttestation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorAttestation) ProtoMessage() {}
+
+func (x *OperatorAttestation) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_net_gen_pb_message_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorAttestation.ProtoReflect.Descriptor instead.
+func (*OperatorAttestation) Descriptor() ([]byte, []int) {
+	return file_pkg_net_gen_pb_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OperatorAttestation) GetOperatorPubKey() []byte {
+	if x != nil {
+		return x.OperatorPubKey
+	}
+	return nil
+}
+
+func (x *OperatorAttestation) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *OperatorAttestation) GetExpiresAt() uint64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
 var File_pkg_net_gen_pb_message_proto protoreflect.FileDescriptor
 
 var file_pkg_net_gen_pb_message_proto_rawDesc = []byte{
 	0x0a, 0x1c, 0x70, 0x6b, 0x67, 0x2f, 0x6e, 0x65, 0x74, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x62,
 	0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03,
-	0x6e, 0x65, 0x74, 0x22, 0x87, 0x01, 0x0a, 0x17, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73,
+	0x6e, 0x65, 0x74, 0x22, 0xa7, 0x01, 0x0a, 0x17, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73,
 	0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
 	0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
 	0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f,
@@ -158,11 +248,24 @@ var file_pkg_net_gen_pb_message_proto_rawDesc = []byte{
 	0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
 	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
 	0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x73,
-	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x23, 0x0a,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x22, 0x56, 0x0a,
 	0x08, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x75, 0x62,
 	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b,
-	0x65, 0x79, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x65, 0x79, 0x12, 0x31, 0x0a, 0x14, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x61,
+	0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x13, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x7c, 0x0a, 0x13, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x10,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f,
+	0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x41, 0x74, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -177,10 +280,11 @@ func file_pkg_net_gen_pb_message_proto_rawDescGZIP() []byte {
 	return file_pkg_net_gen_pb_message_proto_rawDescData
 }
 
-var file_pkg_net_gen_pb_message_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pkg_net_gen_pb_message_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_pkg_net_gen_pb_message_proto_goTypes = []interface{}{
 	(*BroadcastNetworkMessage)(nil), // 0: net.BroadcastNetworkMessage
 	(*Identity)(nil),                // 1: net.Identity
+	(*OperatorAttestation)(nil),     // 2: net.OperatorAttestation
 }
 var file_pkg_net_gen_pb_message_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -220,6 +324,18 @@ func file_pkg_net_gen_pb_message_proto_init() {
 				return nil
 			}
 		}
+		file_pkg_net_gen_pb_message_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperatorAttestation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -227,7 +343,7 @@ func file_pkg_net_gen_pb_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_net_gen_pb_message_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,406 @@
+package libp2p
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net/gen/pb"
+	"github.com/keep-network/keep-core/pkg/operator"
+
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+	libp2pnet "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	// operatorAttestationValidity is the period for which a newly created
+	// operator attestation is valid.
+	operatorAttestationValidity = 24 * time.Hour
+	// operatorAttestationRenewalPeriod is the period after which the client
+	// renews its own operator attestation. The attestation is renewed long
+	// before it expires so messages and handshakes carrying the previous
+	// attestation are still accepted by peers.
+	operatorAttestationRenewalPeriod = operatorAttestationValidity / 2
+	// operatorAttestationClockSkew is the tolerated difference between
+	// clocks of the attesting client and the verifying client.
+	operatorAttestationClockSkew = 5 * time.Minute
+)
+
+// operatorAttestationMessage returns the message the operator signs to attest
+// the given network key is used by the operator's client until the given
+// expiry time. The message is bound to the network key so the attestation
+// cannot be reused for another network key and to the expiry time so the
+// attestation cannot be used once the operator stops renewing it.
+func operatorAttestationMessage(
+	networkPublicKey libp2pcrypto.PubKey,
+	expiresAt uint64,
+) ([]byte, error) {
+	networkPublicKeyBytes, err := networkPublicKey.Raw()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get raw network public key: [%v]", err)
+	}
+
+	return []byte(
+		fmt.Sprintf(
+			"keep-client network key 0x%x expires at %d",
+			networkPublicKeyBytes,
+			expiresAt,
+		),
+	), nil
+}
+
+// operatorAttestations creates and verifies attestations binding network keys
+// to operators. An attestation is needed when the client's network key is
+// not the operator key, for example, when the operator key is held by an
+// external signer. Peers without an attestation are identified by the
+// operator key derived from their network key.
+//
+// Attestations are valid for a limited time. The client renews its own
+// attestation periodically and peers whose attestation expired must
+// reconnect with a renewed one.
+//
+// Operator keys resolved from attestations received during connection
+// handshakes are kept for connected peers, until the attestation expires,
+// so they can be used to validate firewall rules.
+type operatorAttestations struct {
+	signing chain.Signing
+
+	operatorsMutex sync.RWMutex
+	operators      map[peer.ID]*registeredOperator
+}
+
+// registeredOperator is the operator attested by a connected peer.
+type registeredOperator struct {
+	publicKey *operator.PublicKey
+	expiresAt time.Time
+}
+
+func newOperatorAttestations(signing chain.Signing) *operatorAttestations {
+	return &operatorAttestations{
+		signing:   signing,
+		operators: make(map[peer.ID]*registeredOperator),
+	}
+}
+
+// attest returns the marshaled operator attestation of the given network key,
+// valid for the operatorAttestationValidity period. If the network key is the
+// operator key, no attestation is needed and nil is returned.
+func (oa *operatorAttestations) attest(
+	networkPublicKey libp2pcrypto.PubKey,
+) ([]byte, error) {
+	return oa.attestUntil(
+		networkPublicKey,
+		time.Now().Add(operatorAttestationValidity),
+	)
+}
+
+// attestUntil returns the marshaled operator attestation of the given network
+// key, valid until the given expiry time. If the network key is the operator
+// key, no attestation is needed and nil is returned.
+func (oa *operatorAttestations) attestUntil(
+	networkPublicKey libp2pcrypto.PubKey,
+	expiresAt time.Time,
+) ([]byte, error) {
+	if oa == nil || oa.signing == nil {
+		return nil, nil
+	}
+
+	derivedOperatorPublicKey, err := networkPublicKeyToOperatorPublicKey(
+		networkPublicKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	operatorPublicKeyBytes := oa.signing.PublicKey()
+	if bytes.Equal(
+		operatorPublicKeyBytes,
+		operator.MarshalUncompressed(derivedOperatorPublicKey),
+	) {
+		return nil, nil
+	}
+
+	expiresAtUnix := uint64(expiresAt.Unix())
+
+	message, err := operatorAttestationMessage(networkPublicKey, expiresAtUnix)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := oa.signing.Sign(message)
+	if err != nil {
+		return nil, fmt.Errorf("cannot sign network key attestation: [%v]", err)
+	}
+
+	return proto.Marshal(&pb.OperatorAttestation{
+		OperatorPubKey: operatorPublicKeyBytes,
+		Signature:      signature,
+		ExpiresAt:      expiresAtUnix,
+	})
+}
+
+// attestIdentity attests the network key of the given local identity and
+// sets the attestation on the identity. The local peer is registered as
+// attested by the operator. If the network key is the operator key, no
+// attestation is needed and nil is returned.
+func (oa *operatorAttestations) attestIdentity(
+	identity *identity,
+) (*operator.PublicKey, error) {
+	attestation, err := oa.attest(identity.pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot attest network key: [%v]", err)
+	}
+
+	if len(attestation) == 0 {
+		return nil, nil
+	}
+
+	operatorPublicKey, expiresAt, err := oa.resolve(
+		identity.pubKey,
+		attestation,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot verify network key attestation: [%v]",
+			err,
+		)
+	}
+
+	identity.setOperatorAttestation(attestation)
+	oa.register(identity.id, operatorPublicKey, expiresAt)
+
+	return operatorPublicKey, nil
+}
+
+// renewIdentityAttestation periodically renews the operator attestation of
+// the given local identity until the passed context is done. Renewal errors
+// are logged and the renewal is retried in the next period; the previous
+// attestation remains in use until it expires.
+func (oa *operatorAttestations) renewIdentityAttestation(
+	ctx context.Context,
+	identity *identity,
+) {
+	ticker := time.NewTicker(operatorAttestationRenewalPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := oa.attestIdentity(identity); err != nil {
+				logger.Errorf(
+					"cannot renew network key attestation: [%v]",
+					err,
+				)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// resolve returns the operator public key of the given network key. If the
+// attestation is empty, the operator key is derived from the network key and
+// the returned expiry time is zero. Otherwise, the attestation is verified and
+// the attested operator key is returned along with the attestation expiry
+// time. Attestations that expired or whose expiry time exceeds the maximum
+// validity period are rejected.
+func (oa *operatorAttestations) resolve(
+	networkPublicKey libp2pcrypto.PubKey,
+	attestation []byte,
+) (*operator.PublicKey, time.Time, error) {
+	if len(attestation) == 0 {
+		operatorPublicKey, err := networkPublicKeyToOperatorPublicKey(
+			networkPublicKey,
+		)
+		return operatorPublicKey, time.Time{}, err
+	}
+
+	if oa == nil || oa.signing == nil {
+		return nil, time.Time{}, fmt.Errorf(
+			"operator attestations are not supported",
+		)
+	}
+
+	var pbAttestation pb.OperatorAttestation
+	if err := proto.Unmarshal(attestation, &pbAttestation); err != nil {
+		return nil, time.Time{}, fmt.Errorf(
+			"cannot unmarshal operator attestation: [%v]",
+			err,
+		)
+	}
+
+	now := time.Now()
+	expiresAt := time.Unix(int64(pbAttestation.ExpiresAt), 0)
+	if !expiresAt.After(now) {
+		return nil, time.Time{}, fmt.Errorf(
+			"operator attestation expired at [%v]",
+			expiresAt,
+		)
+	}
+	if expiresAt.After(
+		now.Add(operatorAttestationValidity + operatorAttestationClockSkew),
+	) {
+		return nil, time.Time{}, fmt.Errorf(
+			"operator attestation expiry time [%v] exceeds the "+
+				"maximum validity period",
+			expiresAt,
+		)
+	}
+
+	message, err := operatorAttestationMessage(
+		networkPublicKey,
+		pbAttestation.ExpiresAt,
+	)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	ok, err := oa.signing.VerifyWithPublicKey(
+		message,
+		pbAttestation.Signature,
+		pbAttestation.OperatorPubKey,
+	)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf(
+			"cannot verify operator attestation: [%v]",
+			err,
+		)
+	}
+	if !ok {
+		return nil, time.Time{}, fmt.Errorf(
+			"invalid operator attestation signature",
+		)
+	}
+
+	operatorNetworkPublicKey, err := libp2pcrypto.UnmarshalSecp256k1PublicKey(
+		pbAttestation.OperatorPubKey,
+	)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf(
+			"cannot unmarshal attested operator public key: [%v]",
+			err,
+		)
+	}
+
+	operatorPublicKey, err := networkPublicKeyToOperatorPublicKey(
+		operatorNetworkPublicKey,
+	)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return operatorPublicKey, expiresAt, nil
+}
+
+// register stores the operator public key of the given peer, attested until
+// the given expiry time.
+func (oa *operatorAttestations) register(
+	peerID peer.ID,
+	operatorPublicKey *operator.PublicKey,
+	expiresAt time.Time,
+) {
+	oa.operatorsMutex.Lock()
+	defer oa.operatorsMutex.Unlock()
+
+	oa.operators[peerID] = &registeredOperator{
+		publicKey: operatorPublicKey,
+		expiresAt: expiresAt,
+	}
+}
+
+// unregister removes the stored operator public key of the given peer.
+func (oa *operatorAttestations) unregister(peerID peer.ID) {
+	oa.operatorsMutex.Lock()
+	defer oa.operatorsMutex.Unlock()
+
+	delete(oa.operators, peerID)
+}
+
+// notifiee returns a notifiee removing stored operator public keys of peers
+// that are no longer connected.
+func (oa *operatorAttestations) notifiee() libp2pnet.Notifiee {
+	notifyBundle := &libp2pnet.NotifyBundle{}
+
+	notifyBundle.DisconnectedF = func(
+		network libp2pnet.Network,
+		connection libp2pnet.Conn,
+	) {
+		remotePeer := connection.RemotePeer()
+		if network.Connectedness(remotePeer) != libp2pnet.Connected {
+			oa.unregister(remotePeer)
+		}
+	}
+
+	return notifyBundle
+}
+
+// operatorPublicKey returns the operator public key of the given peer. The
+// registered operator key is returned if it exists. An error is returned if
+// the attestation of the registered operator key expired. Otherwise, the
+// operator key is derived from the peer ID.
+func (oa *operatorAttestations) operatorPublicKey(
+	peerID peer.ID,
+) (*operator.PublicKey, error) {
+	oa.operatorsMutex.RLock()
+	registered, ok := oa.operators[peerID]
+	oa.operatorsMutex.RUnlock()
+
+	if ok {
+		if !registered.expiresAt.After(time.Now()) {
+			return nil, fmt.Errorf(
+				"operator attestation of peer [%v] expired at [%v]",
+				peerID,
+				registered.expiresAt,
+			)
+		}
+
+		return registered.publicKey, nil
+	}
+
+	networkPublicKey, err := peerID.ExtractPublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return networkPublicKeyToOperatorPublicKey(networkPublicKey)
+}
+
+// peerIDs returns IDs of all peers identified by the given operator public
+// key. That is the peer ID derived from the operator key and IDs of all
+// registered peers attested by the operator.
+func (oa *operatorAttestations) peerIDs(
+	operatorPublicKey *operator.PublicKey,
+) ([]peer.ID, error) {
+	networkPublicKey, err := operatorPublicKeyToNetworkPublicKey(
+		operatorPublicKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	derivedPeerID, err := peer.IDFromPublicKey(networkPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	peerIDs := []peer.ID{derivedPeerID}
+
+	oa.operatorsMutex.RLock()
+	defer oa.operatorsMutex.RUnlock()
+
+	for peerID, registered := range oa.operators {
+		if peerID != derivedPeerID &&
+			registered.publicKey.X.Cmp(operatorPublicKey.X) == 0 &&
+			registered.publicKey.Y.Cmp(operatorPublicKey.Y) == 0 {
+			peerIDs = append(peerIDs, peerID)
+		}
+	}
+
+	return peerIDs, nil
+}
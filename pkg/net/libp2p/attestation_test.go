@@ -0,0 +1,270 @@
+package libp2p
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/operator"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+func TestOperatorAttestations_AttestOperatorKey(t *testing.T) {
+	operatorPrivateKey, _, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, networkPublicKey, err := operatorPrivateKeyToNetworkKeyPair(
+		operatorPrivateKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attestations := newOperatorAttestations(
+		local_v1.NewSigner(operatorPrivateKey),
+	)
+
+	attestation, err := attestations.attest(networkPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(attestation) != 0 {
+		t.Errorf("expected no attestation for the operator key")
+	}
+}
+
+func TestOperatorAttestations_Resolve(t *testing.T) {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair(
+		DefaultCurve,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkPrivateKey, _, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, networkPublicKey, err := operatorPrivateKeyToNetworkKeyPair(
+		networkPrivateKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherNetworkPrivateKey, otherNetworkOperatorPublicKey, err :=
+		operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, otherNetworkPublicKey, err := operatorPrivateKeyToNetworkKeyPair(
+		otherNetworkPrivateKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attestations := newOperatorAttestations(
+		local_v1.NewSigner(operatorPrivateKey),
+	)
+
+	attestation, err := attestations.attest(networkPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiredAttestation, err := attestations.attestUntil(
+		networkPublicKey,
+		time.Now().Add(-time.Minute),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	longLivedAttestation, err := attestations.attestUntil(
+		networkPublicKey,
+		time.Now().Add(2*operatorAttestationValidity),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		attestations        *operatorAttestations
+		networkPublicKey    libp2pcrypto.PubKey
+		attestation         []byte
+		expectedOperatorKey *operator.PublicKey
+		expectError         bool
+	}{
+		"attested network key": {
+			attestations:        attestations,
+			networkPublicKey:    networkPublicKey,
+			attestation:         attestation,
+			expectedOperatorKey: operatorPublicKey,
+		},
+		"network key without attestation": {
+			attestations:        attestations,
+			networkPublicKey:    otherNetworkPublicKey,
+			expectedOperatorKey: otherNetworkOperatorPublicKey,
+		},
+		"attestation of another network key": {
+			attestations:     attestations,
+			networkPublicKey: otherNetworkPublicKey,
+			attestation:      attestation,
+			expectError:      true,
+		},
+		"malformed attestation": {
+			attestations:     attestations,
+			networkPublicKey: networkPublicKey,
+			attestation:      []byte{0x01, 0x02},
+			expectError:      true,
+		},
+		"expired attestation": {
+			attestations:     attestations,
+			networkPublicKey: networkPublicKey,
+			attestation:      expiredAttestation,
+			expectError:      true,
+		},
+		"attestation exceeding the maximum validity period": {
+			attestations:     attestations,
+			networkPublicKey: networkPublicKey,
+			attestation:      longLivedAttestation,
+			expectError:      true,
+		},
+		"attestations not supported": {
+			attestations:     nil,
+			networkPublicKey: networkPublicKey,
+			attestation:      attestation,
+			expectError:      true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			operatorKey, _, err := test.attestations.resolve(
+				test.networkPublicKey,
+				test.attestation,
+			)
+
+			if test.expectError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedOperatorKey, operatorKey) {
+				t.Errorf(
+					"unexpected operator key\nexpected: %v\nactual:   %v",
+					test.expectedOperatorKey,
+					operatorKey,
+				)
+			}
+		})
+	}
+}
+
+func TestOperatorAttestations_PeerIDs(t *testing.T) {
+	_, operatorPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operatorNetworkPublicKey, err := operatorPublicKeyToNetworkPublicKey(
+		operatorPublicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operatorPeerID, err := peer.IDFromPublicKey(operatorNetworkPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attestedPeerID := generatePeerID(t)
+	otherPeerID := generatePeerID(t)
+
+	_, otherOperatorPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	attestations := newOperatorAttestations(nil)
+	attestations.register(attestedPeerID, operatorPublicKey, expiresAt)
+	attestations.register(otherPeerID, otherOperatorPublicKey, expiresAt)
+
+	peerIDs, err := attestations.peerIDs(operatorPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPeerIDs := []peer.ID{operatorPeerID, attestedPeerID}
+	if !reflect.DeepEqual(expectedPeerIDs, peerIDs) {
+		t.Errorf(
+			"unexpected peer IDs\nexpected: %v\nactual:   %v",
+			expectedPeerIDs,
+			peerIDs,
+		)
+	}
+
+	registeredOperatorPublicKey, err := attestations.operatorPublicKey(
+		attestedPeerID,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(operatorPublicKey, registeredOperatorPublicKey) {
+		t.Errorf("unexpected operator key of the attested peer")
+	}
+
+	attestations.unregister(attestedPeerID)
+
+	peerIDs, err = attestations.peerIDs(operatorPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPeerIDs = []peer.ID{operatorPeerID}
+	if !reflect.DeepEqual(expectedPeerIDs, peerIDs) {
+		t.Errorf(
+			"unexpected peer IDs after unregistering\nexpected: %v\nactual:   %v",
+			expectedPeerIDs,
+			peerIDs,
+		)
+	}
+}
+
+func TestOperatorAttestations_OperatorPublicKeyExpired(t *testing.T) {
+	_, operatorPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attestedPeerID := generatePeerID(t)
+
+	attestations := newOperatorAttestations(nil)
+	attestations.register(
+		attestedPeerID,
+		operatorPublicKey,
+		time.Now().Add(-time.Minute),
+	)
+
+	_, err = attestations.operatorPublicKey(attestedPeerID)
+	if err == nil {
+		t.Fatal("expected error for the expired attestation")
+	}
+}
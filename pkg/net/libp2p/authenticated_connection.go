@@ -29,13 +29,16 @@ const maxFrameSize = 1024
 type authenticatedConnection struct {
 	net.Conn
 
-	localPeerID         peer.ID
-	localPeerPrivateKey libp2pcrypto.PrivKey
+	localPeerID              peer.ID
+	localPeerPrivateKey      libp2pcrypto.PrivKey
+	localOperatorAttestation []byte
 
-	remotePeerID        peer.ID
-	remotePeerPublicKey libp2pcrypto.PubKey
+	remotePeerID              peer.ID
+	remotePeerPublicKey       libp2pcrypto.PubKey
+	remoteOperatorAttestation []byte
 
-	firewall keepNet.Firewall
+	firewall             keepNet.Firewall
+	operatorAttestations *operatorAttestations
 
 	protocol string
 
@@ -83,15 +86,19 @@ func newAuthenticatedInboundConnection(
 	unauthenticatedConn net.Conn,
 	localPeerID peer.ID,
 	privateKey libp2pcrypto.PrivKey,
+	operatorAttestation []byte,
 	firewall keepNet.Firewall,
+	operatorAttestations *operatorAttestations,
 	protocol string,
 ) (*authenticatedConnection, error) {
 	ac := &authenticatedConnection{
-		Conn:                unauthenticatedConn,
-		localPeerID:         localPeerID,
-		localPeerPrivateKey: privateKey,
-		firewall:            firewall,
-		protocol:            protocol,
+		Conn:                     unauthenticatedConn,
+		localPeerID:              localPeerID,
+		localPeerPrivateKey:      privateKey,
+		localOperatorAttestation: operatorAttestation,
+		firewall:                 firewall,
+		operatorAttestations:     operatorAttestations,
+		protocol:                 protocol,
 	}
 
 	ac.initializePipe()
@@ -126,8 +133,10 @@ func newAuthenticatedOutboundConnection(
 	unauthenticatedConn net.Conn,
 	localPeerID peer.ID,
 	privateKey libp2pcrypto.PrivKey,
+	operatorAttestation []byte,
 	remotePeerID peer.ID,
 	firewall keepNet.Firewall,
+	operatorAttestations *operatorAttestations,
 	protocol string,
 ) (*authenticatedConnection, error) {
 	remotePublicKey, err := remotePeerID.ExtractPublicKey()
@@ -139,13 +148,15 @@ func newAuthenticatedOutboundConnection(
 	}
 
 	ac := &authenticatedConnection{
-		Conn:                unauthenticatedConn,
-		localPeerID:         localPeerID,
-		localPeerPrivateKey: privateKey,
-		remotePeerID:        remotePeerID,
-		remotePeerPublicKey: remotePublicKey,
-		firewall:            firewall,
-		protocol:            protocol,
+		Conn:                     unauthenticatedConn,
+		localPeerID:              localPeerID,
+		localPeerPrivateKey:      privateKey,
+		localOperatorAttestation: operatorAttestation,
+		remotePeerID:             remotePeerID,
+		remotePeerPublicKey:      remotePublicKey,
+		firewall:                 firewall,
+		operatorAttestations:     operatorAttestations,
+		protocol:                 protocol,
 	}
 
 	ac.initializePipe()
@@ -169,16 +180,35 @@ func newAuthenticatedOutboundConnection(
 	return ac, nil
 }
 
+// checkFirewallRules validates firewall rules against the remote peer's
+// operator. If the remote peer's network key is attested by an operator, the
+// attested operator is validated and remembered for later firewall checks of
+// the connected peer.
 func (ac *authenticatedConnection) checkFirewallRules() error {
-	operatorPublicKey, err := networkPublicKeyToOperatorPublicKey(ac.remotePeerPublicKey)
+	operatorPublicKey, expiresAt, err := ac.operatorAttestations.resolve(
+		ac.remotePeerPublicKey,
+		ac.remoteOperatorAttestation,
+	)
 	if err != nil {
 		return fmt.Errorf(
-			"cannot convert libp2p public key to operator public key: [%v]",
+			"cannot resolve remote peer operator public key: [%v]",
 			err,
 		)
 	}
 
-	return ac.firewall.Validate(operatorPublicKey)
+	if err := ac.firewall.Validate(operatorPublicKey); err != nil {
+		return err
+	}
+
+	if len(ac.remoteOperatorAttestation) > 0 {
+		ac.operatorAttestations.register(
+			ac.remotePeerID,
+			operatorPublicKey,
+			expiresAt,
+		)
+	}
+
+	return nil
 }
 
 func (ac *authenticatedConnection) runHandshakeAsInitiator() error {
@@ -244,9 +274,10 @@ func (ac *authenticatedConnection) initiatorSendAct1(act1WireMessage []byte) err
 	}
 
 	act1Envelope := &pb.HandshakeEnvelope{
-		Message:   act1WireMessage,
-		PeerID:    []byte(ac.localPeerID),
-		Signature: signedAct1Message,
+		Message:             act1WireMessage,
+		PeerID:              []byte(ac.localPeerID),
+		Signature:           signedAct1Message,
+		OperatorAttestation: ac.localOperatorAttestation,
 	}
 
 	return ac.pipe.send(act1Envelope)
@@ -278,6 +309,8 @@ func (ac *authenticatedConnection) initiatorReceiveAct2() (*handshake.Act2Messag
 		return nil, err
 	}
 
+	ac.remoteOperatorAttestation = act2Envelope.GetOperatorAttestation()
+
 	return act2Message, nil
 }
 
@@ -383,6 +416,8 @@ func (ac *authenticatedConnection) responderReceiveAct1() (*handshake.Act1Messag
 		return nil, err
 	}
 
+	ac.remoteOperatorAttestation = act1Envelope.GetOperatorAttestation()
+
 	return act1Message, nil
 }
 
@@ -396,9 +431,10 @@ func (ac *authenticatedConnection) responderSendAct2(act2WireMessage []byte) err
 	}
 
 	act2Envelope := &pb.HandshakeEnvelope{
-		Message:   act2WireMessage,
-		PeerID:    []byte(ac.localPeerID),
-		Signature: signedAct2Message,
+		Message:             act2WireMessage,
+		PeerID:              []byte(ac.localPeerID),
+		Signature:           signedAct2Message,
+		OperatorAttestation: ac.localOperatorAttestation,
 	}
 
 	return ac.pipe.send(act2Envelope)
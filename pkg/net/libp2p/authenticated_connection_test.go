@@ -12,6 +12,7 @@ import (
 	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
 	keepNet "github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/gen/pb"
 	"github.com/keep-network/keep-core/pkg/net/security/handshake"
@@ -60,7 +61,9 @@ func TestPinnedAndMessageKeyMismatch(t *testing.T) {
 		responderConn,
 		responder.peerID,
 		responder.networkPrivateKey,
+		responder.operatorAttestation,
 		firewall,
+		responder.operatorAttestations,
 		protocolKeep,
 	)
 	if err == nil {
@@ -167,6 +170,249 @@ func TestHandshake(t *testing.T) {
 	}
 }
 
+func TestHandshakeWithAttestedNetworkKeys(t *testing.T) {
+	initiator := createAttestedTestConnectionConfig(t)
+	responder := createAttestedTestConnectionConfig(t)
+
+	firewall := newMockFirewall()
+
+	err := firewall.updateOperator(initiator.operatorPublicKey, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = firewall.updateOperator(responder.operatorPublicKey, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, outboundError, inboundError :=
+		connectInitiatorAndResponder(initiator, responder, firewall, t)
+	if inboundError != nil {
+		t.Fatal(inboundError)
+	}
+	if outboundError != nil {
+		t.Fatal(outboundError)
+	}
+
+	initiatorOperator, err := responder.operatorAttestations.operatorPublicKey(
+		initiator.peerID,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(initiator.operatorPublicKey, initiatorOperator) {
+		t.Errorf(
+			"unexpected initiator operator\nexpected: %v\nactual:   %v",
+			initiator.operatorPublicKey,
+			initiatorOperator,
+		)
+	}
+
+	responderOperator, err := initiator.operatorAttestations.operatorPublicKey(
+		responder.peerID,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(responder.operatorPublicKey, responderOperator) {
+		t.Errorf(
+			"unexpected responder operator\nexpected: %v\nactual:   %v",
+			responder.operatorPublicKey,
+			responderOperator,
+		)
+	}
+}
+
+func TestHandshakeAttestedInitiatorBlockedByFirewallRules(t *testing.T) {
+	initiator := createAttestedTestConnectionConfig(t)
+	responder := createTestConnectionConfig(t)
+
+	firewall := newMockFirewall()
+
+	// The initiator network key meets firewall rules but the operator
+	// attesting it does not.
+	err := firewall.updatePeer(initiator.networkPublicKey, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = firewall.updatePeer(responder.networkPublicKey, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, outboundError, inboundError :=
+		connectInitiatorAndResponder(initiator, responder, firewall, t)
+
+	// The responder has no operator signing so it does not accept
+	// attestations at all.
+	expectedInboundError := fmt.Errorf(
+		"connection handshake failed: [cannot resolve remote peer operator " +
+			"public key: [operator attestations are not supported]]",
+	)
+	if !reflect.DeepEqual(expectedInboundError, inboundError) {
+		t.Fatalf(
+			"unexpected inbound connection error\nexpected: %v\nactual: %v",
+			expectedInboundError,
+			inboundError,
+		)
+	}
+
+	if outboundError != nil {
+		t.Fatal(outboundError)
+	}
+
+	// Once the responder accepts attestations, the firewall rules are
+	// validated against the attesting operator.
+	responderOperatorPrivateKey, _, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	responder.operatorAttestations = newOperatorAttestations(
+		local_v1.NewSigner(responderOperatorPrivateKey),
+	)
+
+	_, _, outboundError, inboundError =
+		connectInitiatorAndResponder(initiator, responder, firewall, t)
+
+	expectedInboundError = fmt.Errorf(
+		"connection handshake failed: [remote peer does not meet firewall criteria]",
+	)
+	if !reflect.DeepEqual(expectedInboundError, inboundError) {
+		t.Fatalf(
+			"unexpected inbound connection error\nexpected: %v\nactual: %v",
+			expectedInboundError,
+			inboundError,
+		)
+	}
+
+	if outboundError != nil {
+		t.Fatal(outboundError)
+	}
+}
+
+func TestHandshakeAttestedInitiatorOverLegacyTransport(t *testing.T) {
+	initiator := createAttestedTestConnectionConfig(t)
+	responder := createAttestedTestConnectionConfig(t)
+
+	firewall := newMockFirewall()
+
+	// Only the operator attesting the initiator network key meets firewall
+	// rules, the initiator network key itself does not.
+	err := firewall.updateOperator(initiator.operatorPublicKey, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The responder meets firewall rules no matter if it is identified by
+	// the network key or by the attesting operator.
+	err = firewall.updateOperator(responder.operatorPublicKey, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = firewall.updatePeer(responder.networkPublicKey, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		newTransport         func(config *testConnectionConfig) (*transport, error)
+		expectedInboundError error
+	}{
+		"current handshake version": {
+			newTransport: func(config *testConnectionConfig) (*transport, error) {
+				return newEncryptedAuthenticatedTransport(
+					createTestIdentity(config),
+					protocolKeep,
+					firewall,
+					config.operatorAttestations,
+				)
+			},
+		},
+		"legacy handshake version": {
+			newTransport: func(config *testConnectionConfig) (*transport, error) {
+				return newLegacyEncryptedAuthenticatedTransport(
+					createTestIdentity(config),
+					protocolKeep,
+					firewall,
+				)
+			},
+			// The initiator does not send its attestation over the legacy
+			// handshake version so the responder validates firewall rules
+			// against the operator key derived from the network key.
+			expectedInboundError: fmt.Errorf(
+				"connection handshake failed: " +
+					"[remote peer does not meet firewall criteria]",
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			initiatorTransport, err := test.newTransport(initiator)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			responderTransport, err := test.newTransport(responder)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			initiatorConn, responderConn := newBufferedConnPair(t)
+			defer initiatorConn.Close()
+			defer responderConn.Close()
+
+			ctx, cancelCtx := context.WithTimeout(
+				context.Background(),
+				10*time.Second,
+			)
+			defer cancelCtx()
+
+			outboundErrorChan := make(chan error, 1)
+			go func() {
+				_, err := initiatorTransport.SecureOutbound(
+					ctx,
+					initiatorConn,
+					responder.peerID,
+				)
+				if err != nil {
+					// Unblock the responder waiting for the handshake.
+					initiatorConn.Close()
+				}
+				outboundErrorChan <- err
+			}()
+
+			_, inboundError := responderTransport.SecureInbound(
+				ctx,
+				responderConn,
+				"",
+			)
+			if inboundError != nil {
+				// Unblock the initiator waiting for the handshake.
+				responderConn.Close()
+			}
+			outboundError := <-outboundErrorChan
+
+			if !reflect.DeepEqual(test.expectedInboundError, inboundError) {
+				t.Fatalf(
+					"unexpected inbound connection error\n"+
+						"expected: %v\nactual: %v",
+					test.expectedInboundError,
+					inboundError,
+				)
+			}
+
+			if test.expectedInboundError == nil && outboundError != nil {
+				t.Fatal(outboundError)
+			}
+		})
+	}
+}
+
 func TestHandshakeInitiatorBlockedByFirewallRules(t *testing.T) {
 	_, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
@@ -245,26 +491,29 @@ func connectInitiatorAndResponder(
 
 	go func(
 		initiatorConn net.Conn,
-		initiatorPeerID peer.ID,
-		initiatorPrivKey libp2pcrypto.PrivKey,
+		initiator *testConnectionConfig,
 		responderPeerID peer.ID,
 	) {
 		authnOutboundConn, outboundError = newAuthenticatedOutboundConnection(
 			initiatorConn,
-			initiatorPeerID,
-			initiatorPrivKey,
+			initiator.peerID,
+			initiator.networkPrivateKey,
+			initiator.operatorAttestation,
 			responderPeerID,
 			firewall,
+			initiator.operatorAttestations,
 			protocolKeep,
 		)
 		done <- struct{}{}
-	}(initiatorConn, initiator.peerID, initiator.networkPrivateKey, responder.peerID)
+	}(initiatorConn, initiator, responder.peerID)
 
 	authnInboundConn, inboundError = newAuthenticatedInboundConnection(
 		responderConn,
 		responder.peerID,
 		responder.networkPrivateKey,
+		responder.operatorAttestation,
 		firewall,
+		responder.operatorAttestations,
 		protocolKeep,
 	)
 
@@ -274,13 +523,16 @@ func connectInitiatorAndResponder(
 }
 
 type testConnectionConfig struct {
-	networkPrivateKey *libp2pcrypto.Secp256k1PrivateKey
-	networkPublicKey  *libp2pcrypto.Secp256k1PublicKey
-	peerID            peer.ID
+	networkPrivateKey    *libp2pcrypto.Secp256k1PrivateKey
+	networkPublicKey     *libp2pcrypto.Secp256k1PublicKey
+	peerID               peer.ID
+	operatorPublicKey    *operator.PublicKey
+	operatorAttestation  []byte
+	operatorAttestations *operatorAttestations
 }
 
 func createTestConnectionConfig(t *testing.T) *testConnectionConfig {
-	operatorPrivateKey, _, err := operator.GenerateKeyPair(DefaultCurve)
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -296,18 +548,80 @@ func createTestConnectionConfig(t *testing.T) *testConnectionConfig {
 	}
 
 	return &testConnectionConfig{
-		networkPrivateKey,
-		networkPublicKey,
-		peerID,
+		networkPrivateKey:    networkPrivateKey,
+		networkPublicKey:     networkPublicKey,
+		peerID:               peerID,
+		operatorPublicKey:    operatorPublicKey,
+		operatorAttestations: newOperatorAttestations(nil),
 	}
 }
 
+// createAttestedTestConnectionConfig creates a connection config with
+// a network key separate from the operator key and attested by the operator.
+func createAttestedTestConnectionConfig(t *testing.T) *testConnectionConfig {
+	config := createTestConnectionConfig(t)
+
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.operatorPublicKey = operatorPublicKey
+	config.operatorAttestations = newOperatorAttestations(
+		local_v1.NewSigner(operatorPrivateKey),
+	)
+
+	config.operatorAttestation, err = config.operatorAttestations.attest(
+		config.networkPublicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return config
+}
+
+// createTestIdentity creates a local identity of the given connection config.
+func createTestIdentity(config *testConnectionConfig) *identity {
+	identity := &identity{
+		id:      config.peerID,
+		pubKey:  config.networkPublicKey,
+		privKey: config.networkPrivateKey,
+	}
+	identity.setOperatorAttestation(config.operatorAttestation)
+
+	return identity
+}
+
 // Connect an initiator and responder via a full duplex network connection (reads
 // on one end should be matched with writes on the other).
 func newConnPair() (net.Conn, net.Conn) {
 	return net.Pipe()
 }
 
+// Connect an initiator and responder via a buffered loopback network
+// connection so that closing one end does not block until the other end
+// reads pending data.
+func newBufferedConnPair(t *testing.T) (net.Conn, net.Conn) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	initiatorConn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	responderConn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return initiatorConn, responderConn
+}
+
 func newMockFirewall() *mockFirewall {
 	return &mockFirewall{
 		meetsCriteria: make(map[uint64]bool),
@@ -325,6 +639,20 @@ func (mf *mockFirewall) Validate(remotePeerOperatorPublicKey *operator.PublicKey
 	return nil
 }
 
+func (mf *mockFirewall) updateOperator(
+	remotePeerOperatorPublicKey *operator.PublicKey,
+	meetsCriteria bool,
+) error {
+	networkPublicKey, err := operatorPublicKeyToNetworkPublicKey(
+		remotePeerOperatorPublicKey,
+	)
+	if err != nil {
+		return err
+	}
+
+	return mf.updatePeer(networkPublicKey, meetsCriteria)
+}
+
 func (mf *mockFirewall) updatePeer(
 	remotePeerNetworkPublicKey *libp2pcrypto.Secp256k1PublicKey,
 	meetsCriteria bool,
@@ -2,6 +2,7 @@ package libp2p
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"sync"
@@ -26,6 +27,10 @@ var (
 	messageWorkers      = runtime.NumCPU()
 )
 
+// errUnknownMessageType is returned when no unmarshaler is registered for
+// the type of the received message.
+var errUnknownMessageType = errors.New("unknown message type")
+
 const (
 	incomingMessageThrottle = 4096
 	messageHandlerThrottle  = 512
@@ -73,6 +78,13 @@ type channel struct {
 	unmarshalersByType map[string]func() net.TaggedUnmarshaler
 
 	retransmissionTicker *retransmission.Ticker
+
+	peerScorer *peerScorer
+
+	operatorAttestations *operatorAttestations
+
+	maxMessageSize int
+	compression    bool
 }
 
 type messageHandler struct {
@@ -100,6 +112,10 @@ func (c *channel) Send(
 
 	messageProto.SequenceNumber = c.nextSeqno()
 
+	if err := c.validateMessageSize(proto.Size(messageProto)); err != nil {
+		return fmt.Errorf("cannot send message: [%v]", err)
+	}
+
 	doSend := func() error {
 		return c.publish(messageProto)
 	}
@@ -218,18 +234,47 @@ func (c *channel) messageProto(
 		return nil, err
 	}
 
+	// The receiver rejects payloads exceeding the maximum message size
+	// after decompression so there is no point in sending them.
+	if err := c.validateMessageSize(len(payloadBytes)); err != nil {
+		return nil, fmt.Errorf("invalid payload: [%v]", err)
+	}
+
+	compressed := false
+	if c.compression {
+		payloadBytes, compressed, err = compressPayload(payloadBytes)
+		if err != nil {
+			return nil, fmt.Errorf("could not compress payload: [%v]", err)
+		}
+	}
+
 	senderIdentityBytes, err := c.clientIdentity.Marshal()
 	if err != nil {
 		return nil, err
 	}
 
 	return &pb.BroadcastNetworkMessage{
-		Payload: payloadBytes,
-		Sender:  senderIdentityBytes,
-		Type:    []byte(message.Type()),
+		Payload:    payloadBytes,
+		Sender:     senderIdentityBytes,
+		Type:       []byte(message.Type()),
+		Compressed: compressed,
 	}, nil
 }
 
+// validateMessageSize checks whether the given message size does not exceed
+// the maximum message size of the channel.
+func (c *channel) validateMessageSize(size int) error {
+	if size > c.maxMessageSize {
+		return fmt.Errorf(
+			"message size [%v] exceeds the maximum message size [%v]",
+			size,
+			c.maxMessageSize,
+		)
+	}
+
+	return nil
+}
+
 func (c *channel) publish(message *pb.BroadcastNetworkMessage) error {
 	messageBytes, err := proto.Marshal(message)
 	if err != nil {
@@ -284,12 +329,23 @@ func (c *channel) incomingMessageWorker(ctx context.Context) {
 		case msg := <-c.incomingMessageQueue:
 			if err := c.processPubsubMessage(msg); err != nil {
 				logger.Error(err)
+
+				// A missing unmarshaler is not the sender's fault. It can
+				// happen, for example, when the message arrives before the
+				// protocol registers the unmarshaler for its type.
+				if !errors.Is(err, errUnknownMessageType) {
+					c.peerScorer.penalize(msg.GetFrom(), invalidMessagePenalty)
+				}
 			}
 		}
 	}
 }
 
 func (c *channel) processPubsubMessage(pubsubMessage *pubsub.Message) error {
+	if err := c.validateMessageSize(len(pubsubMessage.Data)); err != nil {
+		return err
+	}
+
 	var messageProto pb.BroadcastNetworkMessage
 	if err := proto.Unmarshal(pubsubMessage.Data, &messageProto); err != nil {
 		return err
@@ -309,7 +365,15 @@ func (c *channel) processContainerMessage(
 		return err
 	}
 
-	if err := unmarshaled.Unmarshal(message.GetPayload()); err != nil {
+	payload := message.GetPayload()
+	if message.GetCompressed() {
+		payload, err = decompressPayload(payload, c.maxMessageSize)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := unmarshaled.Unmarshal(payload); err != nil {
 		return err
 	}
 
@@ -330,12 +394,15 @@ func (c *channel) processContainerMessage(
 		)
 	}
 
-	operatorPublicKey, err := networkPublicKeyToOperatorPublicKey(senderIdentifier.pubKey)
+	operatorPublicKey, _, err := c.operatorAttestations.resolve(
+		senderIdentifier.pubKey,
+		senderIdentifier.getOperatorAttestation(),
+	)
 	if err != nil {
 		return fmt.Errorf(
-			"sender [%v] with key [%v] is not of correct type",
+			"cannot resolve operator of sender [%v]: [%v]",
 			senderIdentifier.id,
-			senderIdentifier.pubKey,
+			err,
 		)
 	}
 
@@ -361,8 +428,9 @@ func (c *channel) getUnmarshalingContainerByType(messageType string) (net.Tagged
 	unmarshaler, found := c.unmarshalersByType[messageType]
 	if !found {
 		return nil, fmt.Errorf(
-			"couldn't find unmarshaler for type [%s]",
+			"couldn't find unmarshaler for type [%s]: [%w]",
 			messageType,
+			errUnknownMessageType,
 		)
 	}
 
@@ -399,12 +467,64 @@ func (c *channel) SetFilter(filter net.BroadcastChannelFilter) error {
 		)
 	}
 
-	return c.validator.RegisterTopicValidator(c.name, createTopicValidator(filter))
+	return c.validator.RegisterTopicValidator(
+		c.name,
+		c.createMisbehaviorValidator(
+			createTopicValidator(filter, c.operatorAttestations),
+		),
+	)
+}
+
+// createMisbehaviorValidator creates a topic validator rejecting messages
+// from banned peers, oversized messages, and messages exceeding the peer's
+// rate limit in the channel's topic. Messages passing these checks are validated by the
+// wrapped validator, if provided. Messages published by the client itself
+// are not subject to misbehavior checks.
+func (c *channel) createMisbehaviorValidator(
+	wrapped pubsub.Validator,
+) pubsub.Validator {
+	return func(
+		ctx context.Context,
+		receivedFrom peer.ID,
+		message *pubsub.Message,
+	) bool {
+		author := message.GetFrom()
+
+		if author != c.clientIdentity.id {
+			if c.peerScorer.isBanned(author) {
+				return false
+			}
+
+			if err := c.validateMessageSize(len(message.Data)); err != nil {
+				logger.Warnf(
+					"rejecting message from peer [%v] in topic [%v]: [%v]",
+					author,
+					c.name,
+					err,
+				)
+				c.peerScorer.penalize(author, invalidMessagePenalty)
+				return false
+			}
+
+			if !c.peerScorer.allowMessage(c.name, author) {
+				return false
+			}
+		}
+
+		if wrapped == nil {
+			return true
+		}
+
+		return wrapped(ctx, receivedFrom, message)
+	}
 }
 
-func createTopicValidator(filter net.BroadcastChannelFilter) pubsub.Validator {
+func createTopicValidator(
+	filter net.BroadcastChannelFilter,
+	attestations *operatorAttestations,
+) pubsub.Validator {
 	return func(_ context.Context, _ peer.ID, message *pubsub.Message) bool {
-		authorPublicKey, err := extractPublicKey(message.GetFrom())
+		authorPublicKey, err := extractPublicKey(message, attestations)
 		if err != nil {
 			logger.Warnf(
 				"could not retrieve message author public key: [%v]",
@@ -416,8 +536,43 @@ func createTopicValidator(filter net.BroadcastChannelFilter) pubsub.Validator {
 	}
 }
 
-func extractPublicKey(peer peer.ID) (*operator.PublicKey, error) {
-	publicKey, err := peer.ExtractPublicKey()
+// extractPublicKey returns the operator public key of the message author.
+// If the sender identity carried by the message is attested by an operator,
+// the attested operator key is returned. Otherwise, the operator key is
+// derived from the author's peer ID.
+func extractPublicKey(
+	message *pubsub.Message,
+	attestations *operatorAttestations,
+) (*operator.PublicKey, error) {
+	author := message.GetFrom()
+
+	var messageProto pb.BroadcastNetworkMessage
+	if err := proto.Unmarshal(message.Data, &messageProto); err != nil {
+		return nil, err
+	}
+
+	if len(messageProto.Sender) > 0 {
+		senderIdentifier := &identity{}
+		if err := senderIdentifier.Unmarshal(messageProto.Sender); err != nil {
+			return nil, err
+		}
+
+		if senderIdentifier.id != author {
+			return nil, fmt.Errorf(
+				"author [%v] does not match sender [%v]",
+				author,
+				senderIdentifier.id,
+			)
+		}
+
+		operatorPublicKey, _, err := attestations.resolve(
+			senderIdentifier.pubKey,
+			senderIdentifier.getOperatorAttestation(),
+		)
+		return operatorPublicKey, err
+	}
+
+	publicKey, err := author.ExtractPublicKey()
 	if err != nil {
 		return nil, err
 	}
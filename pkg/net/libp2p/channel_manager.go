@@ -27,10 +27,18 @@ type channelManager struct {
 	channelsMutex sync.Mutex
 	channels      map[string]*channel
 
-	pubsub *pubsub.PubSub
+	pubsub       *pubsub.PubSub
+	topicsTracer *topicsTracer
 
 	retransmissionTicker *retransmission.Ticker
 
+	peerScorer *peerScorer
+
+	operatorAttestations *operatorAttestations
+
+	maxMessageSize int
+	compression    bool
+
 	forwardersMutex sync.Mutex
 	forwarders      map[string]pubsub.RelayCancelFunc
 
@@ -43,25 +51,44 @@ func newChannelManager(
 	identity *identity,
 	p2phost host.Host,
 	retransmissionTicker *retransmission.Ticker,
+	peerScorer *peerScorer,
+	operatorAttestations *operatorAttestations,
+	maxMessageSize int,
+	compression bool,
+	topicsDiagnostics bool,
 ) (*channelManager, error) {
-	floodsub, err := pubsub.NewFloodSub(
-		ctx,
-		p2phost,
+	pubsubOptions := []pubsub.Option{
 		pubsub.WithMessageAuthor(identity.id),
 		pubsub.WithMessageSignaturePolicy(pubsub.StrictSign),
 		pubsub.WithPeerOutboundQueueSize(libp2pPeerOutboundQueueSize),
 		pubsub.WithValidateQueueSize(libp2pValidationQueueSize),
-	)
+		pubsub.WithMaxMessageSize(maxMessageSize + pubsubMessageOverhead),
+	}
+
+	// The tracer keeps track of topics announced by remote peers so it is
+	// installed only when explicitly requested.
+	var topicsTracer *topicsTracer
+	if topicsDiagnostics {
+		topicsTracer = newTopicsTracer()
+		pubsubOptions = append(pubsubOptions, pubsub.WithRawTracer(topicsTracer))
+	}
+
+	floodsub, err := pubsub.NewFloodSub(ctx, p2phost, pubsubOptions...)
 	if err != nil {
 		return nil, err
 	}
 	return &channelManager{
 		channels:             make(map[string]*channel),
 		pubsub:               floodsub,
+		topicsTracer:         topicsTracer,
 		peerStore:            p2phost.Peerstore(),
 		identity:             identity,
 		ctx:                  ctx,
 		retransmissionTicker: retransmissionTicker,
+		peerScorer:           peerScorer,
+		operatorAttestations: operatorAttestations,
+		maxMessageSize:       maxMessageSize,
+		compression:          compression,
 		forwarders:           make(map[string]pubsub.RelayCancelFunc),
 		topics:               make(map[string]*pubsub.Topic),
 	}, nil
@@ -129,6 +156,24 @@ func (cm *channelManager) newChannel(name string) (*channel, error) {
 		messageHandlers:      make([]*messageHandler, 0),
 		unmarshalersByType:   make(map[string]func() net.TaggedUnmarshaler),
 		retransmissionTicker: cm.retransmissionTicker,
+		peerScorer:           cm.peerScorer,
+		operatorAttestations: cm.operatorAttestations,
+		maxMessageSize:       cm.maxMessageSize,
+		compression:          cm.compression,
+	}
+
+	// Register the misbehavior-checking topic validator up front so the
+	// rate limits are enforced even if the channel filter is never set.
+	err = cm.pubsub.RegisterTopicValidator(
+		name,
+		channel.createMisbehaviorValidator(nil),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not register validator for topic [%v]: [%v]",
+			name,
+			err,
+		)
 	}
 
 	go channel.handleMessages(cm.ctx)
@@ -196,7 +196,7 @@ func TestCreateTopicValidator(t *testing.T) {
 		return isAuthorized
 	}
 
-	validator := createTopicValidator(filter)
+	validator := createTopicValidator(filter, nil)
 
 	expectedResults := []bool{true, false, false, true, false}
 	for i, operatorPublicKey := range operatorPublicKeys {
@@ -0,0 +1,81 @@
+package libp2p
+
+import (
+	"bytes"
+	"compress/flate"
+	"fmt"
+	"io"
+)
+
+const (
+	// DefaultMaxMessageSize is the default maximum size in bytes of a single
+	// broadcast channel message. It applies both to the message transmitted
+	// over the wire and to the decompressed payload. The default value is
+	// the same as the libp2p pubsub default.
+	DefaultMaxMessageSize = 1 << 20
+
+	// pubsubMessageOverhead is the size in bytes reserved for the pubsub
+	// envelope (author, signature, key, topic) on top of the broadcast
+	// channel message size.
+	pubsubMessageOverhead = 64 * 1024
+
+	// compressionThreshold is the minimum size in bytes of a message payload
+	// that is worth compressing. Smaller payloads are sent as they are.
+	compressionThreshold = 1024
+)
+
+// compressPayload compresses the given message payload. The second returned
+// value indicates whether the payload was actually compressed. Payloads
+// below the compression threshold and payloads that do not get smaller
+// after compression are returned unchanged.
+func compressPayload(payload []byte) ([]byte, bool, error) {
+	if len(payload) < compressionThreshold {
+		return payload, false, nil
+	}
+
+	var buffer bytes.Buffer
+
+	writer, err := flate.NewWriter(&buffer, flate.DefaultCompression)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if _, err := writer.Write(payload); err != nil {
+		return nil, false, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, false, err
+	}
+
+	if buffer.Len() >= len(payload) {
+		return payload, false, nil
+	}
+
+	return buffer.Bytes(), true, nil
+}
+
+// decompressPayload decompresses the given message payload. An error is
+// returned if the decompressed payload exceeds the given maximum size.
+func decompressPayload(payload []byte, maxSize int) ([]byte, error) {
+	reader := flate.NewReader(bytes.NewReader(payload))
+	defer reader.Close()
+
+	// Read at most one byte more than allowed to detect oversized payloads
+	// without decompressing them entirely.
+	decompressed, err := io.ReadAll(
+		io.LimitReader(reader, int64(maxSize)+1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress payload: [%v]", err)
+	}
+
+	if len(decompressed) > maxSize {
+		return nil, fmt.Errorf(
+			"decompressed payload exceeds the maximum message size [%v]",
+			maxSize,
+		)
+	}
+
+	return decompressed, nil
+}
@@ -0,0 +1,68 @@
+package libp2p
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCompressPayload_RoundTrip(t *testing.T) {
+	payload := []byte(strings.Repeat("keep network ", 1000))
+
+	compressed, isCompressed, err := compressPayload(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !isCompressed {
+		t.Fatal("payload should be compressed")
+	}
+
+	if len(compressed) >= len(payload) {
+		t.Fatalf(
+			"compressed payload [%v] should be smaller than original [%v]",
+			len(compressed),
+			len(payload),
+		)
+	}
+
+	decompressed, err := decompressPayload(compressed, DefaultMaxMessageSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(payload, decompressed) {
+		t.Fatal("decompressed payload does not match the original one")
+	}
+}
+
+func TestCompressPayload_BelowThreshold(t *testing.T) {
+	payload := []byte(strings.Repeat("a", compressionThreshold-1))
+
+	result, isCompressed, err := compressPayload(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if isCompressed {
+		t.Fatal("payload should not be compressed")
+	}
+
+	if !bytes.Equal(payload, result) {
+		t.Fatal("payload should be returned unchanged")
+	}
+}
+
+func TestDecompressPayload_ExceedsMaxSize(t *testing.T) {
+	payload := []byte(strings.Repeat("a", 10*compressionThreshold))
+
+	compressed, _, err := compressPayload(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = decompressPayload(compressed, len(payload)-1)
+	if err == nil {
+		t.Fatal("expected error for oversized decompressed payload")
+	}
+}
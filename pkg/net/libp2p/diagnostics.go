@@ -0,0 +1,213 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+)
+
+// TopicInfo describes a pubsub topic known to the provider.
+type TopicInfo struct {
+	// Name is the name of the topic.
+	Name string
+	// Subscribed determines whether the provider is subscribed to the topic.
+	Subscribed bool
+	// Peers are identifiers of connected peers subscribed to the topic.
+	Peers []string
+	// MessagesReceived is the number of messages received in the topic,
+	// including duplicates received from different peers.
+	MessagesReceived uint64
+	// MessageRate is the average number of messages received in the topic
+	// per second since the provider started.
+	MessageRate float64
+}
+
+// Diagnostics exposes information about the libp2p network useful for
+// diagnosing connectivity and message propagation problems.
+type Diagnostics interface {
+	// Topics returns information about topics subscribed by the provider.
+	// If the provider was connected with the WithTopicsDiagnostics option,
+	// topics announced by connected peers are returned as well, along with
+	// the number of messages received in each topic.
+	Topics() []TopicInfo
+	// PingPeer measures the round-trip time to the given connected peer.
+	PingPeer(ctx context.Context, peer string) (time.Duration, error)
+}
+
+// Compile time assertion the provider exposes diagnostics.
+var _ Diagnostics = (*provider)(nil)
+
+func (p *provider) Topics() []TopicInfo {
+	return p.broadcastChannelManager.topicsInfo()
+}
+
+func (p *provider) PingPeer(
+	ctx context.Context,
+	peerHash string,
+) (time.Duration, error) {
+	peerID, err := peer.Decode(peerHash)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"failed to decode peer hash [%v]: [%v]",
+			peerHash,
+			err,
+		)
+	}
+
+	select {
+	case result := <-ping.Ping(ctx, p.host, peerID):
+		if result.Error != nil {
+			return 0, result.Error
+		}
+		return result.RTT, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+func (cm *channelManager) topicsInfo() []TopicInfo {
+	subscribedTopics := make(map[string]bool)
+	for _, topic := range cm.pubsub.GetTopics() {
+		subscribedTopics[topic] = true
+	}
+
+	topicsStats := make(map[string]uint64)
+	elapsed := 0.0
+	if cm.topicsTracer != nil {
+		topicsStats = cm.topicsTracer.snapshot()
+		elapsed = time.Since(cm.topicsTracer.startTime).Seconds()
+	}
+
+	for topic := range subscribedTopics {
+		if _, ok := topicsStats[topic]; !ok {
+			topicsStats[topic] = 0
+		}
+	}
+
+	topicsInfo := make([]TopicInfo, 0, len(topicsStats))
+	for topic, messagesReceived := range topicsStats {
+		peers := make([]string, 0)
+		for _, peerID := range cm.pubsub.ListPeers(topic) {
+			peers = append(peers, peerID.String())
+		}
+
+		var messageRate float64
+		if elapsed > 0 {
+			messageRate = float64(messagesReceived) / elapsed
+		}
+
+		topicsInfo = append(topicsInfo, TopicInfo{
+			Name:             topic,
+			Subscribed:       subscribedTopics[topic],
+			Peers:            peers,
+			MessagesReceived: messagesReceived,
+			MessageRate:      messageRate,
+		})
+	}
+
+	sort.Slice(topicsInfo, func(i, j int) bool {
+		return topicsInfo[i].Name < topicsInfo[j].Name
+	})
+
+	return topicsInfo
+}
+
+// maxTracedTopics is the maximum number of topics tracked by the topics
+// tracer. Topic names come from remote peers so the number of tracked topics
+// must be bounded. Topics exceeding the limit are ignored.
+const maxTracedTopics = 1024
+
+// topicsTracer is a pubsub tracer collecting names of topics announced by
+// peers and counting messages received in each topic.
+type topicsTracer struct {
+	startTime time.Time
+
+	mutex            sync.Mutex
+	messagesReceived map[string]uint64
+}
+
+func newTopicsTracer() *topicsTracer {
+	return &topicsTracer{
+		startTime:        time.Now(),
+		messagesReceived: make(map[string]uint64),
+	}
+}
+
+// snapshot returns the number of messages received in each known topic.
+func (tt *topicsTracer) snapshot() map[string]uint64 {
+	tt.mutex.Lock()
+	defer tt.mutex.Unlock()
+
+	snapshot := make(map[string]uint64, len(tt.messagesReceived))
+	for topic, count := range tt.messagesReceived {
+		snapshot[topic] = count
+	}
+
+	return snapshot
+}
+
+func (tt *topicsTracer) RecvRPC(rpc *pubsub.RPC) {
+	tt.mutex.Lock()
+	defer tt.mutex.Unlock()
+
+	for _, subscription := range rpc.GetSubscriptions() {
+		tt.track(subscription.GetTopicid())
+	}
+
+	for _, message := range rpc.GetPublish() {
+		if tt.track(message.GetTopic()) {
+			tt.messagesReceived[message.GetTopic()]++
+		}
+	}
+}
+
+// track starts tracking the given topic unless it is already tracked or the
+// limit of tracked topics is reached. Returns true if the topic is tracked.
+// Must be called with the mutex held.
+func (tt *topicsTracer) track(topic string) bool {
+	if _, ok := tt.messagesReceived[topic]; ok {
+		return true
+	}
+
+	if len(tt.messagesReceived) >= maxTracedTopics {
+		return false
+	}
+
+	tt.messagesReceived[topic] = 0
+	return true
+}
+
+func (tt *topicsTracer) AddPeer(p peer.ID, proto protocol.ID) {}
+
+func (tt *topicsTracer) RemovePeer(p peer.ID) {}
+
+func (tt *topicsTracer) Join(topic string) {}
+
+func (tt *topicsTracer) Leave(topic string) {}
+
+func (tt *topicsTracer) Graft(p peer.ID, topic string) {}
+
+func (tt *topicsTracer) Prune(p peer.ID, topic string) {}
+
+func (tt *topicsTracer) ValidateMessage(msg *pubsub.Message) {}
+
+func (tt *topicsTracer) DeliverMessage(msg *pubsub.Message) {}
+
+func (tt *topicsTracer) RejectMessage(msg *pubsub.Message, reason string) {}
+
+func (tt *topicsTracer) DuplicateMessage(msg *pubsub.Message) {}
+
+func (tt *topicsTracer) ThrottlePeer(p peer.ID) {}
+
+func (tt *topicsTracer) SendRPC(rpc *pubsub.RPC, p peer.ID) {}
+
+func (tt *topicsTracer) DropRPC(rpc *pubsub.RPC, p peer.ID) {}
+
+func (tt *topicsTracer) UndeliverableMessage(msg *pubsub.Message) {}
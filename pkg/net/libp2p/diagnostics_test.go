@@ -0,0 +1,97 @@
+package libp2p
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+)
+
+func TestTopicsTracer(t *testing.T) {
+	tracer := newTopicsTracer()
+
+	topic1 := "topic-1"
+	topic2 := "topic-2"
+
+	tracer.RecvRPC(&pubsub.RPC{
+		RPC: pubsubpb.RPC{
+			Subscriptions: []*pubsubpb.RPC_SubOpts{
+				{Topicid: &topic1},
+				{Topicid: &topic2},
+			},
+		},
+	})
+
+	tracer.RecvRPC(&pubsub.RPC{
+		RPC: pubsubpb.RPC{
+			Publish: []*pubsubpb.Message{
+				{Topic: &topic1},
+				{Topic: &topic1},
+			},
+		},
+	})
+
+	expected := map[string]uint64{
+		topic1: 2,
+		topic2: 0,
+	}
+
+	if actual := tracer.snapshot(); !reflect.DeepEqual(expected, actual) {
+		t.Errorf(
+			"unexpected topics snapshot\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			actual,
+		)
+	}
+}
+
+func TestTopicsTracer_TopicsLimit(t *testing.T) {
+	tracer := newTopicsTracer()
+
+	subscriptions := make([]*pubsubpb.RPC_SubOpts, maxTracedTopics+1)
+	for i := range subscriptions {
+		topic := fmt.Sprintf("topic-%v", i)
+		subscriptions[i] = &pubsubpb.RPC_SubOpts{Topicid: &topic}
+	}
+
+	tracer.RecvRPC(&pubsub.RPC{
+		RPC: pubsubpb.RPC{Subscriptions: subscriptions},
+	})
+
+	trackedTopic := "topic-0"
+	untrackedTopic := fmt.Sprintf("topic-%v", maxTracedTopics)
+
+	tracer.RecvRPC(&pubsub.RPC{
+		RPC: pubsubpb.RPC{
+			Publish: []*pubsubpb.Message{
+				{Topic: &trackedTopic},
+				{Topic: &untrackedTopic},
+			},
+		},
+	})
+
+	snapshot := tracer.snapshot()
+
+	if len(snapshot) != maxTracedTopics {
+		t.Errorf(
+			"unexpected number of tracked topics\nexpected: [%v]\nactual:   [%v]",
+			maxTracedTopics,
+			len(snapshot),
+		)
+	}
+
+	if snapshot[trackedTopic] != 1 {
+		t.Errorf(
+			"unexpected number of messages in tracked topic\n"+
+				"expected: [%v]\nactual:   [%v]",
+			1,
+			snapshot[trackedTopic],
+		)
+	}
+
+	if _, ok := snapshot[untrackedTopic]; ok {
+		t.Errorf("topic exceeding the limit should not be tracked")
+	}
+}
@@ -2,6 +2,7 @@ package libp2p
 
 import (
 	"fmt"
+	"sync"
 
 	"google.golang.org/protobuf/proto"
 
@@ -21,6 +22,13 @@ type identity struct {
 	id      peer.ID
 	pubKey  libp2pcrypto.PubKey
 	privKey libp2pcrypto.PrivKey
+
+	// operatorAttestation is the marshaled attestation binding the network
+	// key to the operator. It is empty if the network key is the operator key.
+	// The attestation of the local identity is renewed periodically so it
+	// must be accessed with the mutex held.
+	operatorAttestationMutex sync.RWMutex
+	operatorAttestation      []byte
 }
 
 type networkIdentity peer.ID
@@ -34,7 +42,28 @@ func createIdentity(privateKey libp2pcrypto.PrivKey) (*identity, error) {
 		)
 	}
 
-	return &identity{peerID, privateKey.GetPublic(), privateKey}, nil
+	return &identity{
+		id:      peerID,
+		pubKey:  privateKey.GetPublic(),
+		privKey: privateKey,
+	}, nil
+}
+
+// getOperatorAttestation returns the current operator attestation of the
+// identity.
+func (i *identity) getOperatorAttestation() []byte {
+	i.operatorAttestationMutex.RLock()
+	defer i.operatorAttestationMutex.RUnlock()
+
+	return i.operatorAttestation
+}
+
+// setOperatorAttestation replaces the operator attestation of the identity.
+func (i *identity) setOperatorAttestation(operatorAttestation []byte) {
+	i.operatorAttestationMutex.Lock()
+	defer i.operatorAttestationMutex.Unlock()
+
+	i.operatorAttestation = operatorAttestation
 }
 
 func (ni networkIdentity) String() string {
@@ -58,7 +87,10 @@ func (i *identity) Marshal() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	return proto.Marshal(&pb.Identity{PubKey: pubKeyBytes})
+	return proto.Marshal(&pb.Identity{
+		PubKey:              pubKeyBytes,
+		OperatorAttestation: i.getOperatorAttestation(),
+	})
 }
 
 func (i *identity) Unmarshal(bytes []byte) error {
@@ -83,6 +115,7 @@ func (i *identity) Unmarshal(bytes []byte) error {
 		)
 	}
 	i.id = pid
+	i.setOperatorAttestation(pbIdentity.OperatorAttestation)
 
 	return nil
 }
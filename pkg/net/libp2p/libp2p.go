@@ -3,9 +3,11 @@ package libp2p
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/operator"
 
 	"github.com/ipfs/go-log"
@@ -19,10 +21,12 @@ import (
 	//lint:ignore SA1019 package deprecated, but we rely on its interface
 	addrutil "github.com/libp2p/go-addr-util"
 	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/event"
 	"github.com/libp2p/go-libp2p-core/host"
 	libp2pnet "github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
+	"github.com/libp2p/go-libp2p/p2p/host/autorelay"
 	rhost "github.com/libp2p/go-libp2p/p2p/host/routed"
 	connmgr "github.com/libp2p/go-libp2p/p2p/net/connmgr"
 
@@ -56,6 +60,9 @@ const (
 	// ConnectedPeersCheckTick is the amount of time between periodic checks of
 	// the number of connected peers.
 	ConnectedPeersCheckTick = time.Minute * 1
+	// PeerScoreCheckTick is the amount of time between periodic checks of
+	// misbehavior scores of all peers.
+	PeerScoreCheckTick = time.Second * 10
 )
 
 // Keep Network protocol identifiers
@@ -77,6 +84,72 @@ type Config struct {
 	Port               int
 	AnnouncedAddresses []string
 	DisseminationTime  int // TODO: Convert to time.Duration
+	// AutoNat makes bootstrap nodes serve reachability dial-back requests of
+	// other peers. The client's own reachability from the outside world is
+	// always detected, no matter of this option.
+	AutoNat bool
+	// Relay enables circuit relay v2 support. Bootstrap nodes with this option
+	// enabled are considered publicly reachable and act as relays while
+	// non-bootstrap nodes use bootstrap nodes as static relays when they are
+	// not publicly reachable.
+	Relay bool
+	// HolePunching enables direct connection upgrade through hole punching
+	// for connections established over a relay.
+	HolePunching bool
+	// MaxMessageSize is the maximum size in bytes of a broadcast channel
+	// message, enforced both on send and receive. If not set, the
+	// DefaultMaxMessageSize is used.
+	MaxMessageSize int
+	// Compression enables compression of broadcast channel message payloads.
+	// All peers are able to receive compressed messages regardless of this
+	// setting.
+	Compression bool
+	// MessageRateLimit is the number of messages per second a single peer
+	// can publish in a single topic. If not set, the DefaultMessageRateLimit
+	// is used.
+	MessageRateLimit float64
+	// MessageRateBurst is the maximum number of messages a single peer can
+	// publish in a single topic at once, exceeding the MessageRateLimit. If
+	// not set, the DefaultMessageRateBurst is used.
+	MessageRateBurst int
+	// PeerScoreThreshold is the misbehavior score above which a peer gets
+	// disconnected and banned. If not set, the DefaultPeerScoreThreshold is
+	// used.
+	PeerScoreThreshold float64
+	// PeerBanDuration is the duration of a misbehaving peer ban. If not set,
+	// the DefaultPeerBanDuration is used.
+	PeerBanDuration time.Duration
+}
+
+// ConnectOptions returns connect options setting the message rate limit and
+// peer banning parameters from the config. Parameters that are not set are
+// left with their default values.
+func (c Config) ConnectOptions() []ConnectOption {
+	var options []ConnectOption
+
+	if c.MessageRateLimit > 0 || c.MessageRateBurst > 0 {
+		limit, burst := c.MessageRateLimit, c.MessageRateBurst
+		if limit <= 0 {
+			limit = DefaultMessageRateLimit
+		}
+		if burst <= 0 {
+			burst = DefaultMessageRateBurst
+		}
+		options = append(options, WithMessageRateLimit(limit, burst))
+	}
+
+	if c.PeerScoreThreshold > 0 || c.PeerBanDuration > 0 {
+		threshold, duration := c.PeerScoreThreshold, c.PeerBanDuration
+		if threshold <= 0 {
+			threshold = DefaultPeerScoreThreshold
+		}
+		if duration <= 0 {
+			duration = DefaultPeerBanDuration
+		}
+		options = append(options, WithPeerBanning(threshold, duration))
+	}
+
+	return options
 }
 
 type provider struct {
@@ -140,12 +213,25 @@ func (p *provider) BroadcastChannelForwarderFor(name string) {
 
 type connectionManager struct {
 	host.Host
+
+	operatorAttestations *operatorAttestations
+
+	reachabilityMutex sync.RWMutex
+	reachability      libp2pnet.Reachability
 }
 
-func newConnectionManager(ctx context.Context, host host.Host) *connectionManager {
-	connectionManager := &connectionManager{host}
+func newConnectionManager(
+	ctx context.Context,
+	host host.Host,
+	operatorAttestations *operatorAttestations,
+) *connectionManager {
+	connectionManager := &connectionManager{
+		Host:                 host,
+		operatorAttestations: operatorAttestations,
+	}
 
 	go connectionManager.monitorConnectedPeers(ctx)
+	go connectionManager.monitorReachability(ctx)
 
 	return connectionManager
 }
@@ -182,7 +268,7 @@ func (cm *connectionManager) GetPeerPublicKey(connectedPeer string) (*operator.P
 		)
 	}
 
-	peerPublicKey, err := peerID.ExtractPublicKey()
+	peerPublicKey, err := cm.operatorAttestations.operatorPublicKey(peerID)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to extract peer [%s] public key: [%v]",
@@ -191,7 +277,7 @@ func (cm *connectionManager) GetPeerPublicKey(connectedPeer string) (*operator.P
 		)
 	}
 
-	return networkPublicKeyToOperatorPublicKey(peerPublicKey)
+	return peerPublicKey, nil
 }
 
 func (cm *connectionManager) DisconnectPeer(peerHash string) {
@@ -230,6 +316,53 @@ func (cm *connectionManager) IsConnected(address string) bool {
 	return cm.Network().Connectedness(peerInfos[0].ID) == libp2pnet.Connected
 }
 
+// Reachability returns the reachability of the client from the outside world
+// as detected by AutoNAT. The AutoNAT client always runs so the reachability
+// is unknown only until the first detection completes. Bootstrap nodes acting
+// as relays are always reported as publicly reachable.
+func (cm *connectionManager) Reachability() string {
+	cm.reachabilityMutex.RLock()
+	defer cm.reachabilityMutex.RUnlock()
+
+	return strings.ToLower(cm.reachability.String())
+}
+
+func (cm *connectionManager) monitorReachability(ctx context.Context) {
+	subscription, err := cm.EventBus().Subscribe(
+		new(event.EvtLocalReachabilityChanged),
+	)
+	if err != nil {
+		logger.Errorf(
+			"could not subscribe for reachability changes: [%v]",
+			err,
+		)
+		return
+	}
+	defer subscription.Close()
+
+	for {
+		select {
+		case e, ok := <-subscription.Out():
+			if !ok {
+				return
+			}
+
+			reachabilityChanged := e.(event.EvtLocalReachabilityChanged)
+
+			cm.reachabilityMutex.Lock()
+			cm.reachability = reachabilityChanged.Reachability
+			cm.reachabilityMutex.Unlock()
+
+			logger.Infof(
+				"detected network reachability: [%v]",
+				reachabilityChanged.Reachability,
+			)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func (cm *connectionManager) monitorConnectedPeers(ctx context.Context) {
 	ticker := time.NewTicker(ConnectedPeersCheckTick)
 	defer ticker.Stop()
@@ -250,6 +383,12 @@ func (cm *connectionManager) monitorConnectedPeers(ctx context.Context) {
 // ConnectOptions allows to set various options used by libp2p.
 type ConnectOptions struct {
 	RoutingTableRefreshPeriod time.Duration
+	MessageRateLimit          float64
+	MessageRateBurst          int
+	PeerScoreThreshold        float64
+	PeerBanDuration           time.Duration
+	TopicsDiagnostics         bool
+	OperatorSigning           chain.Signing
 }
 
 func defaultConnectOptions() *ConnectOptions {
@@ -258,6 +397,11 @@ func defaultConnectOptions() *ConnectOptions {
 	// Half of the default value from libp2p.
 	options.RoutingTableRefreshPeriod = 30 * time.Minute
 
+	options.MessageRateLimit = DefaultMessageRateLimit
+	options.MessageRateBurst = DefaultMessageRateBurst
+	options.PeerScoreThreshold = DefaultPeerScoreThreshold
+	options.PeerBanDuration = DefaultPeerBanDuration
+
 	return &options
 }
 
@@ -277,16 +421,61 @@ func WithRoutingTableRefreshPeriod(period time.Duration) ConnectOption {
 	}
 }
 
+// WithMessageRateLimit sets the number of messages per second a single peer
+// can publish in a single topic and the maximum burst of messages exceeding
+// that limit.
+func WithMessageRateLimit(limit float64, burst int) ConnectOption {
+	return func(options *ConnectOptions) {
+		options.MessageRateLimit = limit
+		options.MessageRateBurst = burst
+	}
+}
+
+// WithPeerBanning sets the misbehavior score above which a peer gets
+// disconnected and the duration for which the peer is banned.
+func WithPeerBanning(scoreThreshold float64, duration time.Duration) ConnectOption {
+	return func(options *ConnectOptions) {
+		options.PeerScoreThreshold = scoreThreshold
+		options.PeerBanDuration = duration
+	}
+}
+
+// WithTopicsDiagnostics enables collecting names of topics announced by peers
+// and counting messages received in each topic. It should be used only by
+// short-lived diagnostic tools as it keeps track of topics the provider is not
+// subscribed to.
+func WithTopicsDiagnostics() ConnectOption {
+	return func(options *ConnectOptions) {
+		options.TopicsDiagnostics = true
+	}
+}
+
+// WithOperatorSigning sets the operator signing used to attest the network
+// key if it is not the operator key and to verify network key attestations
+// of other peers. Without it, the network key must be the operator key and
+// peers using attested network keys are rejected.
+func WithOperatorSigning(signing chain.Signing) ConnectOption {
+	return func(options *ConnectOptions) {
+		options.OperatorSigning = signing
+	}
+}
+
 // Connect connects to a libp2p network based on the provided config. The
 // connection is managed in part by the passed context, and provides access to
 // the functionality specified in the net.Provider interface.
 //
+// The network private key is usually the operator private key. If the
+// operator key is held outside of the client, for example by an external
+// signer, a separate network key can be used. In that case, the operator
+// signing must be provided using the WithOperatorSigning option so the
+// network key is attested by the operator.
+//
 // An error is returned if any part of the connection or bootstrap process
 // fails.
 func Connect(
 	ctx context.Context,
 	config Config,
-	operatorPrivateKey *operator.PrivateKey,
+	privateKey *operator.PrivateKey,
 	firewall net.Firewall,
 	ticker *retransmission.Ticker,
 	options ...ConnectOption,
@@ -298,10 +487,16 @@ func Connect(
 		)
 	}
 
+	if config.MaxMessageSize < 0 {
+		return nil, fmt.Errorf("maximum message size must not be negative")
+	}
+
 	connectOptions := defaultConnectOptions()
 	connectOptions.apply(options...)
 
-	networkPrivateKey, _, err := operatorPrivateKeyToNetworkKeyPair(operatorPrivateKey)
+	networkPrivateKey, _, err := operatorPrivateKeyToNetworkKeyPair(
+		privateKey,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -311,20 +506,63 @@ func Connect(
 		return nil, err
 	}
 
+	operatorAttestations := newOperatorAttestations(
+		connectOptions.OperatorSigning,
+	)
+
+	attestedOperatorPublicKey, err := operatorAttestations.attestIdentity(
+		identity,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if attestedOperatorPublicKey != nil {
+		go operatorAttestations.renewIdentityAttestation(ctx, identity)
+
+		logger.Infof(
+			"using network key attested by operator [%v]",
+			attestedOperatorPublicKey,
+		)
+	}
+
+	peerScorer := newPeerScorer(
+		connectOptions.MessageRateLimit,
+		connectOptions.MessageRateBurst,
+	)
+
+	firewall = &bansAwareFirewall{firewall, peerScorer, operatorAttestations}
+
 	host, err := discoverAndListen(
 		ctx,
 		identity,
-		config.Port,
-		config.AnnouncedAddresses,
+		config,
 		firewall,
+		operatorAttestations,
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	host.Network().Notify(buildNotifiee())
+	host.Network().Notify(operatorAttestations.notifiee())
 
-	broadcastChannelManager, err := newChannelManager(ctx, identity, host, ticker)
+	maxMessageSize := config.MaxMessageSize
+	if maxMessageSize == 0 {
+		maxMessageSize = DefaultMaxMessageSize
+	}
+
+	broadcastChannelManager, err := newChannelManager(
+		ctx,
+		identity,
+		host,
+		ticker,
+		peerScorer,
+		operatorAttestations,
+		maxMessageSize,
+		config.Compression,
+		connectOptions.TopicsDiagnostics,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -359,7 +597,11 @@ func Connect(
 		return nil, fmt.Errorf("bootstrap failed: [%v]", err)
 	}
 
-	provider.connectionManager = newConnectionManager(ctx, provider.host)
+	provider.connectionManager = newConnectionManager(
+		ctx,
+		provider.host,
+		operatorAttestations,
+	)
 
 	// Instantiates and starts the connection management background process.
 	watchtower.NewGuard(
@@ -368,6 +610,12 @@ func Connect(
 		FirewallCheckTick,
 		firewall,
 		provider.connectionManager,
+		&watchtower.MisbehaviorPolicy{
+			Scorer:         peerScorer,
+			ScoreThreshold: connectOptions.PeerScoreThreshold,
+			BanDuration:    connectOptions.PeerBanDuration,
+			CheckTick:      PeerScoreCheckTick,
+		},
 	)
 
 	return provider, nil
@@ -376,22 +624,23 @@ func Connect(
 func discoverAndListen(
 	ctx context.Context,
 	identity *identity,
-	port int,
-	announcedAddresses []string,
+	config Config,
 	firewall net.Firewall,
+	operatorAttestations *operatorAttestations,
 ) (host.Host, error) {
 	var err error
 
 	// Get available network ifaces, for a specific port, as multiaddrs
-	addrs, err := getListenAddrs(port)
+	addrs, err := getListenAddrs(config.Port)
 	if err != nil {
 		return nil, err
 	}
 
 	transport, err := newEncryptedAuthenticatedTransport(
-		identity.privKey,
+		identity,
 		protocolKeep,
 		firewall,
+		operatorAttestations,
 	)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -400,6 +649,32 @@ func discoverAndListen(
 		)
 	}
 
+	securityOptions := []libp2p.Option{
+		libp2p.Security(handshakeID, transport),
+	}
+
+	// The legacy handshake version does not carry operator attestations so
+	// it is offered only if the network key is the operator key. Clients
+	// using an attested network key can connect only with upgraded clients.
+	if len(identity.getOperatorAttestation()) == 0 {
+		legacyTransport, err := newLegacyEncryptedAuthenticatedTransport(
+			identity,
+			protocolKeep,
+			firewall,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not create legacy authenticated transport: [%v]",
+				err,
+			)
+		}
+
+		securityOptions = append(
+			securityOptions,
+			libp2p.Security(legacyHandshakeID, legacyTransport),
+		)
+	}
+
 	connectionManager, err := connmgr.NewConnManager(
 		DefaultConnMgrLowWater,
 		DefaultConnMgrHighWater,
@@ -415,11 +690,20 @@ func discoverAndListen(
 	options := []libp2p.Option{
 		libp2p.ListenAddrs(addrs...),
 		libp2p.Identity(identity.privKey),
-		libp2p.Security(handshakeID, transport),
 		libp2p.ConnectionManager(connectionManager),
 	}
+	options = append(options, securityOptions...)
+
+	natTraversalOptions, err := natTraversalOptions(identity, config)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not configure NAT traversal: [%v]",
+			err,
+		)
+	}
+	options = append(options, natTraversalOptions...)
 
-	if addresses := parseMultiaddresses(announcedAddresses); len(addresses) > 0 {
+	if addresses := parseMultiaddresses(config.AnnouncedAddresses); len(addresses) > 0 {
 		addressFactory := func(addrs []ma.Multiaddr) []ma.Multiaddr {
 			logger.Debugf(
 				"replacing default announced addresses [%v] with [%v]",
@@ -434,6 +718,72 @@ func discoverAndListen(
 	return libp2p.New(options...)
 }
 
+// natTraversalOptions builds libp2p options enabling NAT traversal mechanisms
+// according to the provided config. Bootstrap nodes are expected to be
+// publicly reachable so they serve AutoNAT dial-backs and act as relays.
+// The relay service starts only once the node is considered publicly
+// reachable so bootstrap relays force the public reachability instead of
+// waiting for AutoNAT, which may never confirm it, for example, if there are
+// no other AutoNAT servers in the network. Non-bootstrap nodes use bootstrap
+// nodes as their static relays.
+func natTraversalOptions(
+	identity *identity,
+	config Config,
+) ([]libp2p.Option, error) {
+	options := make([]libp2p.Option, 0)
+
+	if config.AutoNat && config.Bootstrap {
+		options = append(options, libp2p.EnableNATService())
+	}
+
+	if config.Relay {
+		options = append(options, libp2p.EnableRelay())
+
+		if config.Bootstrap {
+			options = append(
+				options,
+				libp2p.EnableRelayService(),
+				libp2p.ForceReachabilityPublic(),
+			)
+		} else {
+			peerInfos, err := extractMultiAddrFromPeers(config.Peers)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"could not parse bootstrap peers: [%v]",
+					err,
+				)
+			}
+
+			staticRelays := make([]peer.AddrInfo, 0)
+			for _, peerInfo := range peerInfos {
+				if peerInfo.ID != identity.id {
+					staticRelays = append(staticRelays, peerInfo)
+				}
+			}
+
+			if len(staticRelays) > 0 {
+				options = append(
+					options,
+					libp2p.EnableAutoRelay(
+						autorelay.WithStaticRelays(staticRelays),
+					),
+				)
+			} else {
+				logger.Warnf(
+					"relay enabled but there are no bootstrap peers " +
+						"that could act as relays",
+				)
+			}
+		}
+	}
+
+	if config.HolePunching {
+		options = append(options, libp2p.EnableHolePunching())
+	}
+
+	return options, nil
+}
+
 func getListenAddrs(port int) ([]ma.Multiaddr, error) {
 	ia, err := addrutil.InterfaceAddresses()
 	if err != nil {
@@ -9,6 +9,11 @@ import (
 	"testing"
 	"time"
 
+	libp2pnet "github.com/libp2p/go-libp2p-core/network"
+	libp2pconfig "github.com/libp2p/go-libp2p/config"
+	circuitproto "github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/proto"
+	"golang.org/x/exp/slices"
+
 	"github.com/keep-network/keep-core/pkg/operator"
 
 	"github.com/keep-network/keep-core/pkg/firewall"
@@ -156,6 +161,128 @@ func TestSendReceive(t *testing.T) {
 	}
 }
 
+func TestSendReceiveCompressed(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	var (
+		config          = generateDeterministicNetworkConfig()
+		name            = "testchannel"
+		expectedPayload = strings.Repeat("some text", 1000)
+	)
+
+	config.Compression = true
+
+	operatorPrivateKey, _, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkPrivateKey, _, err := operatorPrivateKeyToNetworkKeyPair(operatorPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := createIdentity(networkPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := Connect(
+		ctx,
+		config,
+		operatorPrivateKey,
+		firewall.Disabled,
+		idleTicker(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	broadcastChannel, err := provider.BroadcastChannelFor(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broadcastChannel.SetUnmarshaler(
+		func() net.TaggedUnmarshaler { return &testMessage{} },
+	)
+
+	recvChan := make(chan net.Message)
+	broadcastChannel.Recv(ctx, func(msg net.Message) {
+		recvChan <- msg
+	})
+
+	if err := broadcastChannel.Send(
+		ctx,
+		&testMessage{Sender: identity, Payload: expectedPayload},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-recvChan:
+		testPayload, ok := msg.Payload().(*testMessage)
+		if !ok {
+			t.Fatalf(
+				"expected: payload type string\nactual:   payload type [%v]",
+				testPayload,
+			)
+		}
+
+		if expectedPayload != testPayload.Payload {
+			t.Fatalf("unexpected message payload")
+		}
+	case <-ctx.Done():
+		t.Fatal("message not received")
+	}
+}
+
+func TestSendMessageExceedingMaxSize(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	config := generateDeterministicNetworkConfig()
+	config.MaxMessageSize = 256
+
+	operatorPrivateKey, _, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkPrivateKey, _, err := operatorPrivateKeyToNetworkKeyPair(operatorPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := createIdentity(networkPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := Connect(
+		ctx,
+		config,
+		operatorPrivateKey,
+		firewall.Disabled,
+		idleTicker(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	broadcastChannel, err := provider.BroadcastChannelFor("testchannel")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = broadcastChannel.Send(
+		ctx,
+		&testMessage{Sender: identity, Payload: strings.Repeat("a", 512)},
+	)
+	if err == nil {
+		t.Fatal("expected error for message exceeding the maximum size")
+	}
+}
+
 func TestProviderSetAnnouncedAddresses(t *testing.T) {
 	ctx, cancel := newTestContext()
 	defer cancel()
@@ -198,6 +325,248 @@ func TestProviderSetAnnouncedAddresses(t *testing.T) {
 	}
 }
 
+func TestProviderNatTraversal(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	operatorPrivateKey, _, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := generateDeterministicNetworkConfig()
+	config.Bootstrap = true
+	config.AutoNat = true
+	config.Relay = true
+	config.HolePunching = true
+
+	netProvider, err := Connect(
+		ctx,
+		config,
+		operatorPrivateKey,
+		firewall.Disabled,
+		idleTicker(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Bootstrap relays are forced to be publicly reachable so the relay
+	// service starts without waiting for AutoNAT.
+	expectedReachability := "public"
+	var reachability string
+	for i := 0; i < 50; i++ {
+		reachability = netProvider.ConnectionManager().Reachability()
+		if reachability == expectedReachability {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if expectedReachability != reachability {
+		t.Fatalf(
+			"expected: reachability [%v]\nactual:   reachability [%v]",
+			expectedReachability,
+			reachability,
+		)
+	}
+
+	if !slices.Contains(
+		netProvider.(*provider).host.Mux().Protocols(),
+		circuitproto.ProtoIDv2Hop,
+	) {
+		t.Errorf("bootstrap node does not act as a relay")
+	}
+}
+
+func TestNatTraversalOptions(t *testing.T) {
+	newTestIdentity := func() *identity {
+		operatorPrivateKey, _, err := operator.GenerateKeyPair(DefaultCurve)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		networkPrivateKey, _, err := operatorPrivateKeyToNetworkKeyPair(
+			operatorPrivateKey,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		identity, err := createIdentity(networkPrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return identity
+	}
+
+	identity := newTestIdentity()
+
+	bootstrapPeer := fmt.Sprintf(
+		"/ip4/127.0.0.1/tcp/3919/ipfs/%v",
+		newTestIdentity().id,
+	)
+
+	var tests = map[string]struct {
+		config                     Config
+		expectedNATService         bool
+		expectedRelay              bool
+		expectedRelayService       bool
+		expectedForcedPublic       bool
+		expectedAutoRelay          bool
+		expectedEnableHolePunching bool
+	}{
+		"disabled": {
+			config: Config{Bootstrap: true},
+		},
+		"bootstrap node": {
+			config: Config{
+				Bootstrap:    true,
+				AutoNat:      true,
+				Relay:        true,
+				HolePunching: true,
+			},
+			expectedNATService:         true,
+			expectedRelay:              true,
+			expectedRelayService:       true,
+			expectedForcedPublic:       true,
+			expectedEnableHolePunching: true,
+		},
+		"non-bootstrap node": {
+			config: Config{
+				Peers:        []string{bootstrapPeer},
+				AutoNat:      true,
+				Relay:        true,
+				HolePunching: true,
+			},
+			expectedRelay:              true,
+			expectedAutoRelay:          true,
+			expectedEnableHolePunching: true,
+		},
+		"non-bootstrap node without bootstrap peers": {
+			config: Config{
+				Relay: true,
+			},
+			expectedRelay: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			options, err := natTraversalOptions(identity, test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			libp2pConfig := &libp2pconfig.Config{}
+			if err := libp2pConfig.Apply(options...); err != nil {
+				t.Fatal(err)
+			}
+
+			assertBool := func(description string, expected, actual bool) {
+				if expected != actual {
+					t.Errorf(
+						"unexpected %s\nexpected: %v\nactual:   %v",
+						description,
+						expected,
+						actual,
+					)
+				}
+			}
+
+			assertBool(
+				"NAT service",
+				test.expectedNATService,
+				libp2pConfig.AutoNATConfig.EnableService,
+			)
+			assertBool("relay", test.expectedRelay, libp2pConfig.Relay)
+			assertBool(
+				"relay service",
+				test.expectedRelayService,
+				libp2pConfig.EnableRelayService,
+			)
+			assertBool(
+				"forced public reachability",
+				test.expectedForcedPublic,
+				libp2pConfig.AutoNATConfig.ForceReachability != nil &&
+					*libp2pConfig.AutoNATConfig.ForceReachability ==
+						libp2pnet.ReachabilityPublic,
+			)
+			assertBool(
+				"auto relay",
+				test.expectedAutoRelay,
+				libp2pConfig.EnableAutoRelay,
+			)
+			assertBool(
+				"hole punching",
+				test.expectedEnableHolePunching,
+				libp2pConfig.EnableHolePunching,
+			)
+		})
+	}
+}
+
+func TestConfigConnectOptions(t *testing.T) {
+	var tests = map[string]struct {
+		config          Config
+		expectedOptions ConnectOptions
+	}{
+		"nothing set": {
+			config: Config{},
+			expectedOptions: ConnectOptions{
+				MessageRateLimit:   DefaultMessageRateLimit,
+				MessageRateBurst:   DefaultMessageRateBurst,
+				PeerScoreThreshold: DefaultPeerScoreThreshold,
+				PeerBanDuration:    DefaultPeerBanDuration,
+			},
+		},
+		"all set": {
+			config: Config{
+				MessageRateLimit:   20,
+				MessageRateBurst:   200,
+				PeerScoreThreshold: 50,
+				PeerBanDuration:    2 * time.Hour,
+			},
+			expectedOptions: ConnectOptions{
+				MessageRateLimit:   20,
+				MessageRateBurst:   200,
+				PeerScoreThreshold: 50,
+				PeerBanDuration:    2 * time.Hour,
+			},
+		},
+		"partially set": {
+			config: Config{
+				MessageRateBurst: 200,
+				PeerBanDuration:  2 * time.Hour,
+			},
+			expectedOptions: ConnectOptions{
+				MessageRateLimit:   DefaultMessageRateLimit,
+				MessageRateBurst:   200,
+				PeerScoreThreshold: DefaultPeerScoreThreshold,
+				PeerBanDuration:    2 * time.Hour,
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			options := defaultConnectOptions()
+			options.apply(test.config.ConnectOptions()...)
+
+			// Not covered by the config.
+			options.RoutingTableRefreshPeriod = 0
+
+			if !reflect.DeepEqual(test.expectedOptions, *options) {
+				t.Errorf(
+					"unexpected connect options\nexpected: %+v\nactual:   %+v",
+					test.expectedOptions,
+					*options,
+				)
+			}
+		})
+	}
+}
+
 func TestExtractPeersPublicKeys_EmptyList(t *testing.T) {
 	peerAddresses := []string{}
 	peerOperatorPublicKeys, err := ExtractPeersPublicKeys(peerAddresses)
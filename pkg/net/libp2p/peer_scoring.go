@@ -0,0 +1,256 @@
+package libp2p
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"golang.org/x/time/rate"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+// Misbehavior scoring and message rate limiting defaults.
+const (
+	// DefaultMessageRateLimit is the default number of messages per second
+	// a single peer can publish in a single topic.
+	DefaultMessageRateLimit = 10
+	// DefaultMessageRateBurst is the default maximum number of messages a
+	// single peer can publish in a single topic at once, exceeding the
+	// DefaultMessageRateLimit.
+	DefaultMessageRateBurst = 100
+	// DefaultPeerScoreThreshold is the default misbehavior score above which
+	// a peer gets disconnected and temporarily banned.
+	DefaultPeerScoreThreshold = 100
+	// DefaultPeerBanDuration is the default duration of a misbehaving peer
+	// ban.
+	DefaultPeerBanDuration = time.Hour
+
+	// invalidMessagePenalty is the misbehavior score added to a peer every
+	// time it publishes a message that cannot be processed.
+	invalidMessagePenalty = 10
+	// rateLimitExceededPenalty is the misbehavior score added to a peer
+	// every time it publishes a message exceeding the rate limit.
+	rateLimitExceededPenalty = 1
+	// peerScoreHalfLife is the time after which a misbehavior score is
+	// halved if the peer does not misbehave anymore.
+	peerScoreHalfLife = 10 * time.Minute
+	// negligiblePeerScore is the score below which the peer is no longer
+	// considered misbehaving and its score is pruned.
+	negligiblePeerScore = 0.01
+	// messageRateLimiterTTL is the time after which the message rate limiter
+	// of an inactive peer is pruned.
+	messageRateLimiterTTL = 10 * time.Minute
+)
+
+type peerScore struct {
+	value       float64
+	lastUpdated time.Time
+}
+
+// decayedValue returns the score value at the given time, taking into account
+// the exponential decay since the last update.
+func (ps *peerScore) decayedValue(now time.Time) float64 {
+	elapsed := now.Sub(ps.lastUpdated)
+	return ps.value * math.Pow(0.5, float64(elapsed)/float64(peerScoreHalfLife))
+}
+
+type messageRateLimiter struct {
+	*rate.Limiter
+	lastUsed time.Time
+}
+
+// peerScorer tracks misbehavior scores of peers, limits the rate of messages
+// published by peers in topics, and keeps the list of temporarily banned
+// peers.
+type peerScorer struct {
+	messageRateLimit rate.Limit
+	messageRateBurst int
+
+	scoresMutex sync.Mutex
+	scores      map[peer.ID]*peerScore
+
+	bansMutex sync.RWMutex
+	bans      map[peer.ID]time.Time
+
+	limitersMutex sync.Mutex
+	limiters      map[string]map[peer.ID]*messageRateLimiter
+}
+
+func newPeerScorer(messageRateLimit float64, messageRateBurst int) *peerScorer {
+	return &peerScorer{
+		messageRateLimit: rate.Limit(messageRateLimit),
+		messageRateBurst: messageRateBurst,
+		scores:           make(map[peer.ID]*peerScore),
+		bans:             make(map[peer.ID]time.Time),
+		limiters:         make(map[string]map[peer.ID]*messageRateLimiter),
+	}
+}
+
+// penalize increases the misbehavior score of the given peer.
+func (ps *peerScorer) penalize(peerID peer.ID, penalty float64) {
+	ps.scoresMutex.Lock()
+	defer ps.scoresMutex.Unlock()
+
+	now := time.Now()
+
+	score, ok := ps.scores[peerID]
+	if !ok {
+		score = &peerScore{}
+		ps.scores[peerID] = score
+	}
+
+	score.value = score.decayedValue(now) + penalty
+	score.lastUpdated = now
+}
+
+// allowMessage determines whether the given peer can publish another message
+// in the given topic without exceeding the rate limit. If the rate limit is
+// exceeded, the peer is penalized.
+func (ps *peerScorer) allowMessage(topic string, peerID peer.ID) bool {
+	ps.limitersMutex.Lock()
+
+	topicLimiters, ok := ps.limiters[topic]
+	if !ok {
+		topicLimiters = make(map[peer.ID]*messageRateLimiter)
+		ps.limiters[topic] = topicLimiters
+	}
+
+	limiter, ok := topicLimiters[peerID]
+	if !ok {
+		limiter = &messageRateLimiter{
+			Limiter: rate.NewLimiter(ps.messageRateLimit, ps.messageRateBurst),
+		}
+		topicLimiters[peerID] = limiter
+	}
+
+	limiter.lastUsed = time.Now()
+	allowed := limiter.Allow()
+
+	ps.limitersMutex.Unlock()
+
+	if !allowed {
+		logger.Warnf(
+			"peer [%v] exceeded message rate limit in topic [%v]",
+			peerID,
+			topic,
+		)
+		ps.penalize(peerID, rateLimitExceededPenalty)
+	}
+
+	return allowed
+}
+
+// isBanned checks whether the given peer is currently banned.
+func (ps *peerScorer) isBanned(peerID peer.ID) bool {
+	ps.bansMutex.RLock()
+	defer ps.bansMutex.RUnlock()
+
+	bannedUntil, ok := ps.bans[peerID]
+	return ok && time.Now().Before(bannedUntil)
+}
+
+// Scores returns misbehavior scores of all peers with a non-zero score,
+// keyed by the peer identifier. As a side effect, it prunes stale scores,
+// expired bans and rate limiters of inactive peers.
+func (ps *peerScorer) Scores() map[string]float64 {
+	now := time.Now()
+
+	ps.pruneBans(now)
+	ps.pruneLimiters(now)
+
+	ps.scoresMutex.Lock()
+	defer ps.scoresMutex.Unlock()
+
+	scores := make(map[string]float64)
+	for peerID, score := range ps.scores {
+		value := score.decayedValue(now)
+		// Scores decayed almost to zero are no longer worth tracking.
+		if value < negligiblePeerScore {
+			delete(ps.scores, peerID)
+			continue
+		}
+
+		scores[peerID.String()] = value
+	}
+
+	return scores
+}
+
+// Ban bans the given peer for the given duration. All messages and
+// connections from the banned peer are rejected until the ban expires.
+// The misbehavior score of the peer is reset.
+func (ps *peerScorer) Ban(peerHash string, duration time.Duration) {
+	peerID, err := peer.Decode(peerHash)
+	if err != nil {
+		logger.Errorf("failed to decode peer hash [%v]: [%v]", peerHash, err)
+		return
+	}
+
+	ps.bansMutex.Lock()
+	ps.bans[peerID] = time.Now().Add(duration)
+	ps.bansMutex.Unlock()
+
+	ps.scoresMutex.Lock()
+	delete(ps.scores, peerID)
+	ps.scoresMutex.Unlock()
+}
+
+func (ps *peerScorer) pruneBans(now time.Time) {
+	ps.bansMutex.Lock()
+	defer ps.bansMutex.Unlock()
+
+	for peerID, bannedUntil := range ps.bans {
+		if now.After(bannedUntil) {
+			logger.Infof("ban of peer [%v] expired", peerID)
+			delete(ps.bans, peerID)
+		}
+	}
+}
+
+func (ps *peerScorer) pruneLimiters(now time.Time) {
+	ps.limitersMutex.Lock()
+	defer ps.limitersMutex.Unlock()
+
+	for topic, topicLimiters := range ps.limiters {
+		for peerID, limiter := range topicLimiters {
+			if now.Sub(limiter.lastUsed) > messageRateLimiterTTL {
+				delete(topicLimiters, peerID)
+			}
+		}
+
+		if len(topicLimiters) == 0 {
+			delete(ps.limiters, topic)
+		}
+	}
+}
+
+// bansAwareFirewall is a firewall rejecting banned peers before executing
+// checks of the wrapped firewall. An operator is rejected if any of the peers
+// identified by the operator key is banned.
+type bansAwareFirewall struct {
+	net.Firewall
+
+	peerScorer           *peerScorer
+	operatorAttestations *operatorAttestations
+}
+
+func (baf *bansAwareFirewall) Validate(
+	remotePeerPublicKey *operator.PublicKey,
+) error {
+	peerIDs, err := baf.operatorAttestations.peerIDs(remotePeerPublicKey)
+	if err != nil {
+		return err
+	}
+
+	for _, peerID := range peerIDs {
+		if baf.peerScorer.isBanned(peerID) {
+			return fmt.Errorf("peer [%v] is banned", peerID)
+		}
+	}
+
+	return baf.Firewall.Validate(remotePeerPublicKey)
+}
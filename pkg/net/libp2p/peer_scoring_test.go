@@ -0,0 +1,143 @@
+package libp2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestPeerScorer_AllowMessage(t *testing.T) {
+	peerScorer := newPeerScorer(1, 3)
+
+	peerID := generatePeerID(t)
+
+	for i := 0; i < 3; i++ {
+		if !peerScorer.allowMessage("topic-1", peerID) {
+			t.Fatalf("message [%v] should be allowed", i)
+		}
+	}
+
+	if peerScorer.allowMessage("topic-1", peerID) {
+		t.Fatal("message exceeding the burst should not be allowed")
+	}
+
+	if !peerScorer.allowMessage("topic-2", peerID) {
+		t.Fatal("message in another topic should be allowed")
+	}
+
+	scores := peerScorer.Scores()
+	if _, ok := scores[peerID.String()]; !ok {
+		t.Fatal("peer exceeding the rate limit should be penalized")
+	}
+}
+
+func TestPeerScorer_Penalize(t *testing.T) {
+	peerScorer := newPeerScorer(DefaultMessageRateLimit, DefaultMessageRateBurst)
+
+	peerID1 := generatePeerID(t)
+	peerID2 := generatePeerID(t)
+
+	peerScorer.penalize(peerID1, invalidMessagePenalty)
+	peerScorer.penalize(peerID1, invalidMessagePenalty)
+
+	scores := peerScorer.Scores()
+
+	if len(scores) != 1 {
+		t.Fatalf("unexpected number of scores: [%v]", len(scores))
+	}
+
+	score := scores[peerID1.String()]
+	// The score decays a little bit between the penalization and the read.
+	if score > 2*invalidMessagePenalty || score < 2*invalidMessagePenalty-0.1 {
+		t.Errorf("unexpected score of peer 1: [%v]", score)
+	}
+
+	if _, ok := scores[peerID2.String()]; ok {
+		t.Errorf("peer 2 should not have a score")
+	}
+}
+
+func TestPeerScorer_Ban(t *testing.T) {
+	peerScorer := newPeerScorer(DefaultMessageRateLimit, DefaultMessageRateBurst)
+
+	peerID := generatePeerID(t)
+
+	peerScorer.penalize(peerID, invalidMessagePenalty)
+	peerScorer.Ban(peerID.String(), 100*time.Millisecond)
+
+	if !peerScorer.isBanned(peerID) {
+		t.Fatal("peer should be banned")
+	}
+
+	if len(peerScorer.Scores()) != 0 {
+		t.Fatal("score of the banned peer should be reset")
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	if peerScorer.isBanned(peerID) {
+		t.Fatal("ban should expire")
+	}
+}
+
+func TestBansAwareFirewall(t *testing.T) {
+	peerScorer := newPeerScorer(DefaultMessageRateLimit, DefaultMessageRateBurst)
+
+	_, operatorPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkPublicKey, err := operatorPublicKeyToNetworkPublicKey(
+		operatorPublicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peerID, err := peer.IDFromPublicKey(networkPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bansAwareFirewall := &bansAwareFirewall{
+		firewall.Disabled,
+		peerScorer,
+		newOperatorAttestations(nil),
+	}
+
+	if err := bansAwareFirewall.Validate(operatorPublicKey); err != nil {
+		t.Fatalf("unexpected validation error: [%v]", err)
+	}
+
+	peerScorer.Ban(peerID.String(), time.Minute)
+
+	if err := bansAwareFirewall.Validate(operatorPublicKey); err == nil {
+		t.Fatal("expected validation error for banned peer")
+	}
+}
+
+func generatePeerID(t *testing.T) peer.ID {
+	_, operatorPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkPublicKey, err := operatorPublicKeyToNetworkPublicKey(
+		operatorPublicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peerID, err := peer.IDFromPublicKey(networkPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return peerID
+}
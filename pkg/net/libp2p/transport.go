@@ -7,14 +7,23 @@ import (
 	libp2ptls "github.com/libp2p/go-libp2p/p2p/security/tls"
 
 	keepNet "github.com/keep-network/keep-core/pkg/net"
-	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/sec"
 )
 
-// ID is the multistream-select protocol ID that should be used when identifying
-// this security transport.
-const handshakeID = "/keep/handshake/1.0.0"
+const (
+	// handshakeID is the multistream-select protocol ID that should be used
+	// when identifying this security transport. Starting from version 1.1.0,
+	// peers exchange operator attestations of their network keys during the
+	// handshake.
+	handshakeID = "/keep/handshake/1.1.0"
+	// legacyHandshakeID is the multistream-select protocol ID of the security
+	// transport used by clients not supporting operator attestations. Clients
+	// using the operator key as the network key offer this version as well so
+	// they can still connect with clients that have not been upgraded yet.
+	// Operator attestations are neither sent nor accepted over this version.
+	legacyHandshakeID = "/keep/handshake/1.0.0"
+)
 
 // Compile time assertions of custom types
 var _ sec.SecureTransport = (*transport)(nil)
@@ -22,37 +31,71 @@ var _ sec.SecureConn = (*authenticatedConnection)(nil)
 
 // transport constructs an encrypted and authenticated connection for a peer.
 type transport struct {
-	localPeerID     peer.ID
-	privateKey      libp2pcrypto.PrivKey
-	protocol        string
-	firewall        keepNet.Firewall
-	encryptionLayer sec.SecureTransport
+	localIdentity *identity
+	protocol      string
+	firewall      keepNet.Firewall
+	// operatorAttestations is nil if the transport does not support operator
+	// attestations, that is, for the legacy handshake version.
+	operatorAttestations *operatorAttestations
+	encryptionLayer      sec.SecureTransport
 }
 
+// newEncryptedAuthenticatedTransport creates a transport exchanging operator
+// attestations during the handshake.
 func newEncryptedAuthenticatedTransport(
-	pk libp2pcrypto.PrivKey,
+	localIdentity *identity,
 	protocol string,
 	firewall keepNet.Firewall,
+	operatorAttestations *operatorAttestations,
 ) (*transport, error) {
-	id, err := peer.IDFromPrivateKey(pk)
-	if err != nil {
-		return nil, err
-	}
+	return newTransport(
+		localIdentity,
+		protocol,
+		firewall,
+		operatorAttestations,
+	)
+}
+
+// newLegacyEncryptedAuthenticatedTransport creates a transport for the legacy
+// handshake version that does not support operator attestations.
+func newLegacyEncryptedAuthenticatedTransport(
+	localIdentity *identity,
+	protocol string,
+	firewall keepNet.Firewall,
+) (*transport, error) {
+	return newTransport(localIdentity, protocol, firewall, nil)
+}
 
-	encryptionLayer, err := libp2ptls.New(pk)
+func newTransport(
+	localIdentity *identity,
+	protocol string,
+	firewall keepNet.Firewall,
+	operatorAttestations *operatorAttestations,
+) (*transport, error) {
+	encryptionLayer, err := libp2ptls.New(localIdentity.privKey)
 	if err != nil {
 		return nil, err
 	}
 
 	return &transport{
-		localPeerID:     id,
-		privateKey:      pk,
-		firewall:        firewall,
-		encryptionLayer: encryptionLayer,
-		protocol:        protocol,
+		localIdentity:        localIdentity,
+		firewall:             firewall,
+		operatorAttestations: operatorAttestations,
+		encryptionLayer:      encryptionLayer,
+		protocol:             protocol,
 	}, nil
 }
 
+// localOperatorAttestation returns the current operator attestation of the
+// local identity if the transport supports operator attestations.
+func (t *transport) localOperatorAttestation() []byte {
+	if t.operatorAttestations == nil {
+		return nil
+	}
+
+	return t.localIdentity.getOperatorAttestation()
+}
+
 // SecureInbound secures an inbound connection.
 func (t *transport) SecureInbound(
 	ctx context.Context,
@@ -66,9 +109,11 @@ func (t *transport) SecureInbound(
 
 	return newAuthenticatedInboundConnection(
 		encryptedConnection,
-		t.localPeerID,
-		t.privateKey,
+		t.localIdentity.id,
+		t.localIdentity.privKey,
+		t.localOperatorAttestation(),
 		t.firewall,
+		t.operatorAttestations,
 		t.protocol,
 	)
 }
@@ -90,10 +135,12 @@ func (t *transport) SecureOutbound(
 
 	return newAuthenticatedOutboundConnection(
 		encryptedConnection,
-		t.localPeerID,
-		t.privateKey,
+		t.localIdentity.id,
+		t.localIdentity.privKey,
+		t.localOperatorAttestation(),
 		remotePeerID,
 		t.firewall,
+		t.operatorAttestations,
 		t.protocol,
 	)
 }
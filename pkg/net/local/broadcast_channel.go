@@ -24,6 +24,8 @@ type localChannel struct {
 	counter              uint64
 	name                 string
 	identifier           net.TransportIdentifier
+	providerID           localIdentifier
+	faultInjector        *FaultInjector
 	operatorPublicKey    *operator.PublicKey
 	messageHandlersMutex sync.Mutex
 	messageHandlers      []*messageHandler
@@ -84,12 +86,12 @@ func (lc *localChannel) Send(
 		logger,
 		lc.retransmissionTicker,
 		func() error {
-			return broadcastMessage(lc.name, netMessage)
+			return broadcastMessage(lc, netMessage)
 		},
 		retransmission.WithStrategy(strategy),
 	)
 
-	return broadcastMessage(lc.name, netMessage)
+	return broadcastMessage(lc, netMessage)
 }
 
 func (lc *localChannel) deliver(message net.Message) {
@@ -107,6 +109,54 @@ func (lc *localChannel) deliver(message net.Message) {
 	}
 }
 
+// corrupt returns a copy of the given message with one bit of its serialized
+// payload flipped. The flipped bit is determined by the given seed. Returns
+// an error if the corrupted payload cannot be unmarshaled.
+func (lc *localChannel) corrupt(
+	message net.Message,
+	seed uint64,
+) (net.Message, error) {
+	marshaler, ok := message.Payload().(net.TaggedMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("payload is not marshalable")
+	}
+
+	bytes, err := marshaler.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bytes) == 0 {
+		return nil, fmt.Errorf("empty payload")
+	}
+
+	bit := seed % uint64(len(bytes)*8)
+	bytes[bit/8] ^= 1 << (bit % 8)
+
+	lc.unmarshalersMutex.Lock()
+	unmarshaler, found := lc.unmarshalersByType[message.Type()]
+	lc.unmarshalersMutex.Unlock()
+	if !found {
+		return nil, fmt.Errorf(
+			"couldn't find unmarshaler for type %s",
+			message.Type(),
+		)
+	}
+
+	payload := unmarshaler()
+	if err := payload.Unmarshal(bytes); err != nil {
+		return nil, err
+	}
+
+	return internal.BasicMessage(
+		message.TransportSenderID(),
+		payload,
+		message.Type(),
+		message.SenderPublicKey(),
+		message.Seqno(),
+	), nil
+}
+
 func (lc *localChannel) Recv(ctx context.Context, handler func(m net.Message)) {
 	messageHandler := &messageHandler{
 		ctx:     ctx,
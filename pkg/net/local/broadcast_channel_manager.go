@@ -24,7 +24,9 @@ var broadcastChannels map[string][]*localChannel
 // the message.
 func getBroadcastChannel(
 	name string,
+	providerID localIdentifier,
 	operatorPublicKey *operator.PublicKey,
+	faultInjector *FaultInjector,
 ) net.BroadcastChannel {
 	broadcastChannelsMutex.Lock()
 	defer broadcastChannelsMutex.Unlock()
@@ -41,6 +43,8 @@ func getBroadcastChannel(
 	channel := &localChannel{
 		name:                 name,
 		identifier:           &identifier,
+		providerID:           providerID,
+		faultInjector:        faultInjector,
 		operatorPublicKey:    operatorPublicKey,
 		messageHandlersMutex: sync.Mutex{},
 		messageHandlers:      make([]*messageHandler, 0),
@@ -55,13 +59,28 @@ func getBroadcastChannel(
 	return channel
 }
 
-func broadcastMessage(name string, message net.Message) error {
+// broadcastMessage delivers the message sent by the given channel to all
+// channels with the same name. If the sender's provider is connected with
+// a fault injector, the conditions of links between the sender's provider
+// and providers of target channels are applied.
+func broadcastMessage(sender *localChannel, message net.Message) error {
 	broadcastChannelsMutex.Lock()
-	targetChannels := broadcastChannels[name]
+	targetChannels := broadcastChannels[sender.name]
 	broadcastChannelsMutex.Unlock()
 
 	for _, targetChannel := range targetChannels {
-		targetChannel.deliver(message)
+		if sender.faultInjector == nil {
+			targetChannel.deliver(message)
+			continue
+		}
+
+		sender.faultInjector.deliver(
+			sender.providerID,
+			targetChannel.providerID,
+			message,
+			targetChannel.deliver,
+			targetChannel.corrupt,
+		)
 	}
 
 	return nil
@@ -0,0 +1,358 @@
+package local
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// LatencyDistribution is a distribution of the latency of messages sent over
+// a link between two local providers.
+type LatencyDistribution interface {
+	// Sample draws a latency from the distribution using the given source
+	// of randomness. The returned latency is never negative.
+	Sample(random *rand.Rand) time.Duration
+}
+
+type constantLatency struct {
+	latency time.Duration
+}
+
+// ConstantLatency returns a distribution always yielding the given latency.
+func ConstantLatency(latency time.Duration) LatencyDistribution {
+	return &constantLatency{latency}
+}
+
+func (cl *constantLatency) Sample(random *rand.Rand) time.Duration {
+	return nonNegative(cl.latency)
+}
+
+type uniformLatency struct {
+	min time.Duration
+	max time.Duration
+}
+
+// UniformLatency returns a distribution yielding latencies uniformly
+// distributed in range [min, max).
+func UniformLatency(min, max time.Duration) LatencyDistribution {
+	return &uniformLatency{min, max}
+}
+
+func (ul *uniformLatency) Sample(random *rand.Rand) time.Duration {
+	if ul.max <= ul.min {
+		return nonNegative(ul.min)
+	}
+
+	return nonNegative(ul.min + time.Duration(random.Int63n(int64(ul.max-ul.min))))
+}
+
+type normalLatency struct {
+	mean   time.Duration
+	stdDev time.Duration
+}
+
+// NormalLatency returns a distribution yielding normally distributed
+// latencies with the given mean and standard deviation. Negative samples
+// are truncated to zero.
+func NormalLatency(mean, stdDev time.Duration) LatencyDistribution {
+	return &normalLatency{mean, stdDev}
+}
+
+func (nl *normalLatency) Sample(random *rand.Rand) time.Duration {
+	return nonNegative(
+		nl.mean + time.Duration(random.NormFloat64()*float64(nl.stdDev)),
+	)
+}
+
+type exponentialLatency struct {
+	min  time.Duration
+	mean time.Duration
+}
+
+// ExponentialLatency returns a distribution yielding latencies equal to the
+// given minimum plus an exponentially distributed value with the given mean.
+// It models a link with a fixed propagation delay and a long tail of slow
+// messages.
+func ExponentialLatency(min, mean time.Duration) LatencyDistribution {
+	return &exponentialLatency{min, mean}
+}
+
+func (el *exponentialLatency) Sample(random *rand.Rand) time.Duration {
+	tail := random.ExpFloat64() * float64(el.mean)
+	if tail > math.MaxInt64/2 {
+		tail = math.MaxInt64 / 2
+	}
+
+	return nonNegative(el.min + time.Duration(tail))
+}
+
+func nonNegative(latency time.Duration) time.Duration {
+	if latency < 0 {
+		return 0
+	}
+	return latency
+}
+
+// LinkConditions describes the conditions of a directed link between two
+// local providers. The zero value describes a perfect link delivering all
+// messages instantly and in order.
+type LinkConditions struct {
+	// Latency is the distribution of the message latency. Messages are
+	// delivered instantly if not set. Messages with different latencies may
+	// be delivered out of order.
+	Latency LatencyDistribution
+	// LossRate is the probability of losing a message.
+	LossRate float64
+	// DuplicationRate is the probability of delivering a message twice.
+	// Each copy has its latency drawn independently.
+	DuplicationRate float64
+	// ReorderRate is the probability of holding a message back so that
+	// messages sent after it are delivered before it.
+	ReorderRate float64
+	// ReorderDelay is the additional latency of messages held back for
+	// reordering.
+	ReorderDelay time.Duration
+	// CorruptionRate is the probability of corrupting a message. Corrupted
+	// messages have one bit of their serialized payload flipped. Corrupted
+	// messages the receiving channel cannot unmarshal are lost.
+	CorruptionRate float64
+	// Blocked cuts the link; no messages are delivered over it.
+	Blocked bool
+}
+
+// FaultInjectorStats holds the numbers of messages affected by the fault
+// injector. Each message counts once per link it is sent over.
+type FaultInjectorStats struct {
+	// Delivered is the number of messages passed for delivery, including
+	// duplicates. Delayed messages are counted before their latency elapses.
+	Delivered int
+	// Lost is the number of messages lost.
+	Lost int
+	// Blocked is the number of messages not delivered because of a blocked
+	// link or a network partition.
+	Blocked int
+	// Duplicated is the number of messages delivered twice.
+	Duplicated int
+	// Reordered is the number of messages held back for reordering.
+	Reordered int
+	// Corrupted is the number of messages corrupted.
+	Corrupted int
+}
+
+type link struct {
+	from string
+	to   string
+}
+
+// FaultInjector controls the conditions of links between local providers
+// connected with it. All the conditions can be changed at runtime, also
+// while messages are in flight; messages already scheduled for delivery are
+// not affected. Messages a provider sends to its own broadcast channels are
+// never affected. Random decisions are drawn from a source seeded with the
+// seed given at construction, so they are reproducible as long as messages
+// are sent in the same order. The fault injector is safe for concurrent use.
+type FaultInjector struct {
+	mutex sync.Mutex
+
+	random            *rand.Rand
+	defaultConditions LinkConditions
+	linkConditions    map[link]LinkConditions
+	// partitions maps provider identifiers to the indexes of the network
+	// partitions they belong to. Providers in different partitions cannot
+	// communicate.
+	partitions map[string]int
+	stats      FaultInjectorStats
+}
+
+// NewFaultInjector creates a new fault injector with perfect links and
+// random decisions driven by the given seed.
+func NewFaultInjector(seed int64) *FaultInjector {
+	return &FaultInjector{
+		// #nosec G404 (insecure random number source (rand))
+		// Fault injection in local network does not require secure
+		// randomness and must be reproducible.
+		random:         rand.New(rand.NewSource(seed)),
+		linkConditions: make(map[link]LinkConditions),
+		partitions:     make(map[string]int),
+	}
+}
+
+// SetDefaultConditions sets the conditions of all links which do not have
+// their own conditions set with SetLinkConditions.
+func (fi *FaultInjector) SetDefaultConditions(conditions LinkConditions) {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	fi.defaultConditions = conditions
+}
+
+// SetLinkConditions sets the conditions of the directed link between the
+// providers with the given transport identifiers.
+func (fi *FaultInjector) SetLinkConditions(
+	from net.TransportIdentifier,
+	to net.TransportIdentifier,
+	conditions LinkConditions,
+) {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	fi.linkConditions[link{from.String(), to.String()}] = conditions
+}
+
+// ResetLinkConditions makes the directed link between the providers with the
+// given transport identifiers use the default conditions again.
+func (fi *FaultInjector) ResetLinkConditions(
+	from net.TransportIdentifier,
+	to net.TransportIdentifier,
+) {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	delete(fi.linkConditions, link{from.String(), to.String()})
+}
+
+// Partition splits the network into the given partitions. Providers from
+// different partitions cannot communicate. Providers not belonging to any
+// of the partitions can communicate with everyone. Replaces the previous
+// partitioning.
+func (fi *FaultInjector) Partition(partitions ...[]net.TransportIdentifier) {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	fi.partitions = make(map[string]int)
+	for index, partition := range partitions {
+		for _, identifier := range partition {
+			fi.partitions[identifier.String()] = index
+		}
+	}
+}
+
+// Heal removes the network partitioning. Links blocked with
+// SetLinkConditions stay blocked.
+func (fi *FaultInjector) Heal() {
+	fi.Partition()
+}
+
+// Stats returns the numbers of messages affected by the fault injector so
+// far.
+func (fi *FaultInjector) Stats() FaultInjectorStats {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	return fi.stats
+}
+
+// delivery describes a copy of a message that should be delivered over
+// a link.
+type delivery struct {
+	delay time.Duration
+	// corrupted is true if the copy should be corrupted.
+	corrupted bool
+	// corruptionSeed determines the bit flipped in the serialized payload of
+	// the corrupted copy.
+	corruptionSeed uint64
+}
+
+// deliver applies the conditions of the link between the given providers to
+// the message and calls the delivery function for each copy of the message
+// that should be delivered, once its latency elapses. Copies that should be
+// corrupted are passed to the corruption function first.
+func (fi *FaultInjector) deliver(
+	from localIdentifier,
+	to localIdentifier,
+	message net.Message,
+	deliverFn func(net.Message),
+	corruptFn func(net.Message, uint64) (net.Message, error),
+) {
+	if from == to {
+		deliverFn(message)
+		return
+	}
+
+	for _, d := range fi.deliveries(link{from.String(), to.String()}) {
+		d := d
+
+		deliverCopyFn := func() {
+			if !d.corrupted {
+				deliverFn(message)
+				return
+			}
+
+			corrupted, err := corruptFn(message, d.corruptionSeed)
+			if err != nil {
+				logger.Debugf("corrupted message lost: [%v]", err)
+				return
+			}
+
+			deliverFn(corrupted)
+		}
+
+		if d.delay == 0 {
+			deliverCopyFn()
+			continue
+		}
+
+		time.AfterFunc(d.delay, deliverCopyFn)
+	}
+}
+
+// deliveries returns the copies of the message that should be delivered
+// over the given link. The returned slice is empty if the message should not
+// be delivered.
+func (fi *FaultInjector) deliveries(l link) []delivery {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	conditions, ok := fi.linkConditions[l]
+	if !ok {
+		conditions = fi.defaultConditions
+	}
+
+	if conditions.Blocked || fi.isPartitioned(l) {
+		fi.stats.Blocked++
+		return nil
+	}
+
+	if fi.random.Float64() < conditions.LossRate {
+		fi.stats.Lost++
+		return nil
+	}
+
+	copies := 1
+	if fi.random.Float64() < conditions.DuplicationRate {
+		copies = 2
+		fi.stats.Duplicated++
+	}
+
+	deliveries := make([]delivery, copies)
+	for i := range deliveries {
+		if conditions.Latency != nil {
+			deliveries[i].delay = conditions.Latency.Sample(fi.random)
+		}
+
+		if fi.random.Float64() < conditions.ReorderRate {
+			deliveries[i].delay += conditions.ReorderDelay
+			fi.stats.Reordered++
+		}
+
+		if fi.random.Float64() < conditions.CorruptionRate {
+			deliveries[i].corrupted = true
+			deliveries[i].corruptionSeed = fi.random.Uint64()
+			fi.stats.Corrupted++
+		}
+	}
+
+	fi.stats.Delivered += copies
+
+	return deliveries
+}
+
+func (fi *FaultInjector) isPartitioned(l link) bool {
+	fromPartition, fromOk := fi.partitions[l.from]
+	toPartition, toOk := fi.partitions[l.to]
+
+	return fromOk && toOk && fromPartition != toPartition
+}
@@ -0,0 +1,382 @@
+package local
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestFaultInjector_Loss(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	faultInjector := NewFaultInjector(1)
+	providers, channels := initFaultyTestChannels(t, faultInjector, 3)
+
+	faultInjector.SetLinkConditions(
+		providers[0].ID(),
+		providers[1].ID(),
+		LinkConditions{LossRate: 1},
+	)
+
+	received := receiveContents(ctx, channels)
+
+	sendContent(t, channels[0], "lost")
+
+	time.Sleep(200 * time.Millisecond)
+
+	assertReceived(t, received[0], "lost")
+	assertReceived(t, received[1])
+	assertReceived(t, received[2], "lost")
+
+	testutils.AssertIntsEqual(t, "lost", 1, faultInjector.Stats().Lost)
+}
+
+func TestFaultInjector_PartitionAndHeal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	faultInjector := NewFaultInjector(1)
+	providers, channels := initFaultyTestChannels(t, faultInjector, 3)
+
+	faultInjector.Partition(
+		[]net.TransportIdentifier{providers[0].ID()},
+		[]net.TransportIdentifier{providers[1].ID(), providers[2].ID()},
+	)
+
+	received := receiveContents(ctx, channels)
+
+	sendContent(t, channels[0], "partitioned")
+	sendContent(t, channels[1], "in partition")
+
+	time.Sleep(200 * time.Millisecond)
+
+	assertReceived(t, received[0], "partitioned")
+	assertReceived(t, received[1], "in partition")
+	assertReceived(t, received[2], "in partition")
+
+	faultInjector.Heal()
+
+	sendContent(t, channels[0], "healed")
+
+	time.Sleep(200 * time.Millisecond)
+
+	assertReceived(t, received[0], "healed")
+	assertReceived(t, received[1], "healed")
+	assertReceived(t, received[2], "healed")
+
+	testutils.AssertIntsEqual(t, "blocked", 3, faultInjector.Stats().Blocked)
+}
+
+func TestFaultInjector_Latency(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	faultInjector := NewFaultInjector(1)
+	_, channels := initFaultyTestChannels(t, faultInjector, 2)
+
+	faultInjector.SetDefaultConditions(LinkConditions{
+		Latency: ConstantLatency(500 * time.Millisecond),
+	})
+
+	received := receiveContents(ctx, channels)
+
+	sendContent(t, channels[0], "delayed")
+
+	time.Sleep(200 * time.Millisecond)
+
+	// Messages sent to own channels are never delayed.
+	assertReceived(t, received[0], "delayed")
+	assertReceived(t, received[1])
+
+	time.Sleep(500 * time.Millisecond)
+
+	assertReceived(t, received[1], "delayed")
+}
+
+func TestFaultInjector_Duplication(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	faultInjector := NewFaultInjector(1)
+	_, channels := initFaultyTestChannels(t, faultInjector, 2)
+
+	faultInjector.SetDefaultConditions(LinkConditions{DuplicationRate: 1})
+
+	received := receiveContents(ctx, channels)
+
+	sendContent(t, channels[0], "duplicated")
+
+	time.Sleep(200 * time.Millisecond)
+
+	// Duplicates are filtered out the same way as retransmissions.
+	assertReceived(t, received[1], "duplicated")
+
+	stats := faultInjector.Stats()
+	testutils.AssertIntsEqual(t, "duplicated", 1, stats.Duplicated)
+	testutils.AssertIntsEqual(t, "delivered", 2, stats.Delivered)
+}
+
+func TestFaultInjector_Reordering(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	faultInjector := NewFaultInjector(1)
+	_, channels := initFaultyTestChannels(t, faultInjector, 2)
+
+	faultInjector.SetDefaultConditions(LinkConditions{
+		ReorderRate:  1,
+		ReorderDelay: 300 * time.Millisecond,
+	})
+
+	received := receiveContents(ctx, channels)
+
+	sendContent(t, channels[0], "first")
+
+	faultInjector.SetDefaultConditions(LinkConditions{})
+
+	sendContent(t, channels[0], "second")
+
+	time.Sleep(500 * time.Millisecond)
+
+	assertReceived(t, received[0], "first", "second")
+	assertReceived(t, received[1], "second", "first")
+
+	testutils.AssertIntsEqual(t, "reordered", 1, faultInjector.Stats().Reordered)
+}
+
+func TestFaultInjector_Corruption(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	faultInjector := NewFaultInjector(1)
+	_, channels := initFaultyTestChannels(t, faultInjector, 2)
+
+	faultInjector.SetDefaultConditions(LinkConditions{CorruptionRate: 1})
+
+	received := receiveContents(ctx, channels)
+
+	sendContent(t, channels[0], "corrupted")
+
+	time.Sleep(200 * time.Millisecond)
+
+	// Messages sent to own channels are never corrupted.
+	assertReceived(t, received[0], "corrupted")
+
+	select {
+	case content := <-received[1].contents:
+		if content == "corrupted" {
+			t.Errorf("message was not corrupted")
+		}
+
+		differentBits := 0
+		for i := range content {
+			for xor := content[i] ^ "corrupted"[i]; xor != 0; xor >>= 1 {
+				differentBits += int(xor & 1)
+			}
+		}
+		testutils.AssertIntsEqual(t, "flipped bits", 1, differentBits)
+	default:
+		t.Errorf("corrupted message was not delivered")
+	}
+
+	testutils.AssertIntsEqual(t, "corrupted", 1, faultInjector.Stats().Corrupted)
+}
+
+func TestFaultInjector_Deterministic(t *testing.T) {
+	decisions := func(seed int64) []time.Duration {
+		faultInjector := NewFaultInjector(seed)
+		faultInjector.SetDefaultConditions(LinkConditions{
+			Latency:         UniformLatency(0, time.Second),
+			LossRate:        0.3,
+			DuplicationRate: 0.3,
+		})
+
+		result := make([]time.Duration, 0)
+		for i := 0; i < 20; i++ {
+			for _, d := range faultInjector.deliveries(link{"a", "b"}) {
+				result = append(result, d.delay)
+			}
+			result = append(result, -1)
+		}
+		return result
+	}
+
+	if !reflect.DeepEqual(decisions(1), decisions(1)) {
+		t.Errorf("decisions differ for the same seed")
+	}
+	if reflect.DeepEqual(decisions(1), decisions(2)) {
+		t.Errorf("decisions do not depend on the seed")
+	}
+}
+
+func TestLatencyDistributions(t *testing.T) {
+	var tests = map[string]struct {
+		distribution LatencyDistribution
+		min          time.Duration
+		max          time.Duration
+	}{
+		"constant": {
+			distribution: ConstantLatency(100 * time.Millisecond),
+			min:          100 * time.Millisecond,
+			max:          100 * time.Millisecond,
+		},
+		"uniform": {
+			distribution: UniformLatency(100*time.Millisecond, 200*time.Millisecond),
+			min:          100 * time.Millisecond,
+			max:          200 * time.Millisecond,
+		},
+		"normal": {
+			distribution: NormalLatency(100*time.Millisecond, 100*time.Millisecond),
+			min:          0,
+			max:          time.Second,
+		},
+		"exponential": {
+			distribution: ExponentialLatency(100*time.Millisecond, 10*time.Millisecond),
+			min:          100 * time.Millisecond,
+			max:          time.Second,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			random := rand.New(rand.NewSource(1))
+
+			for i := 0; i < 1000; i++ {
+				latency := test.distribution.Sample(random)
+				if latency < test.min || latency > test.max {
+					t.Fatalf(
+						"latency out of range [%v, %v]: [%v]",
+						test.min,
+						test.max,
+						latency,
+					)
+				}
+			}
+		})
+	}
+}
+
+func initFaultyTestChannels(
+	t *testing.T,
+	faultInjector *FaultInjector,
+	count int,
+) ([]Provider, []net.BroadcastChannel) {
+	channelName := "faulty channel " + randomLocalIdentifier().String()
+
+	providers := make([]Provider, count)
+	channels := make([]net.BroadcastChannel, count)
+
+	for i := 0; i < count; i++ {
+		_, operatorPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		providers[i] = ConnectWithFaults(operatorPublicKey, faultInjector)
+
+		channels[i], err = providers[i].BroadcastChannelFor(channelName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		channels[i].SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &contentMessage{}
+		})
+	}
+
+	return providers, channels
+}
+
+type receivedContents struct {
+	contents chan string
+}
+
+func receiveContents(
+	ctx context.Context,
+	channels []net.BroadcastChannel,
+) []*receivedContents {
+	received := make([]*receivedContents, len(channels))
+
+	for i, channel := range channels {
+		received[i] = &receivedContents{contents: make(chan string, 100)}
+
+		contents := received[i].contents
+		channel.Recv(ctx, func(message net.Message) {
+			contents <- message.Payload().(*contentMessage).content
+		})
+	}
+
+	return received
+}
+
+// sendContent sends a message with the given content. The message is not
+// retransmitted.
+func sendContent(
+	t *testing.T,
+	channel net.BroadcastChannel,
+	content string,
+) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := channel.Send(ctx, &contentMessage{content: content})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// assertReceived checks if exactly the expected contents, in the expected
+// order, were received since the last check.
+func assertReceived(
+	t *testing.T,
+	received *receivedContents,
+	expectedContents ...string,
+) {
+	actualContents := make([]string, 0)
+
+loop:
+	for {
+		select {
+		case content := <-received.contents:
+			actualContents = append(actualContents, content)
+		default:
+			break loop
+		}
+	}
+
+	if len(expectedContents) == 0 {
+		expectedContents = []string{}
+	}
+
+	if !reflect.DeepEqual(expectedContents, actualContents) {
+		t.Errorf(
+			"unexpected received contents\nexpected: %v\nactual:   %v",
+			expectedContents,
+			actualContents,
+		)
+	}
+}
+
+type contentMessage struct {
+	content string
+}
+
+func (cm *contentMessage) Type() string {
+	return "content_message"
+}
+
+func (cm *contentMessage) Marshal() ([]byte, error) {
+	return []byte(cm.content), nil
+}
+
+func (cm *contentMessage) Unmarshal(bytes []byte) error {
+	cm.content = string(bytes)
+	return nil
+}
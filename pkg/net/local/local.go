@@ -28,6 +28,7 @@ type localProvider struct {
 	id                localIdentifier
 	operatorPublicKey *operator.PublicKey
 	connectionManager *localConnectionManager
+	faultInjector     *FaultInjector
 }
 
 func (lp *localProvider) ID() net.TransportIdentifier {
@@ -35,7 +36,12 @@ func (lp *localProvider) ID() net.TransportIdentifier {
 }
 
 func (lp *localProvider) BroadcastChannelFor(name string) (net.BroadcastChannel, error) {
-	return getBroadcastChannel(name, lp.operatorPublicKey), nil
+	return getBroadcastChannel(
+		name,
+		lp.id,
+		lp.operatorPublicKey,
+		lp.faultInjector,
+	), nil
 }
 
 func (lp *localProvider) Type() string {
@@ -81,6 +87,21 @@ func ConnectWithKey(operatorPublicKey *operator.PublicKey) Provider {
 	}
 }
 
+// ConnectWithFaults returns a local instance of net provider that does not
+// go over the network. The returned instance uses the provided network key to
+// identify network messages. Messages sent by the returned instance are
+// subject to the link conditions controlled by the given fault injector.
+// Providers connected with the same fault injector form one simulated
+// network whose conditions can be changed at runtime.
+func ConnectWithFaults(
+	operatorPublicKey *operator.PublicKey,
+	faultInjector *FaultInjector,
+) Provider {
+	provider := ConnectWithKey(operatorPublicKey).(*localProvider)
+	provider.faultInjector = faultInjector
+	return provider
+}
+
 func (lp *localProvider) ConnectionManager() net.ConnectionManager {
 	return lp.connectionManager
 }
@@ -135,3 +156,7 @@ func (lcm *localConnectionManager) AddrStrings() []string {
 func (lcm *localConnectionManager) IsConnected(address string) bool {
 	panic("not implemented")
 }
+
+func (lcm *localConnectionManager) Reachability() string {
+	return "unknown"
+}
@@ -88,6 +88,10 @@ type ConnectionManager interface {
 	AddrStrings() []string
 
 	IsConnected(address string) bool
+
+	// Reachability returns the reachability of the provider from the outside
+	// world. Possible values are `unknown`, `public` and `private`.
+	Reachability() string
 }
 
 // TaggedUnmarshaler is an interface that includes the proto.Unmarshaler
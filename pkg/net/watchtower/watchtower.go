@@ -14,6 +14,31 @@ import (
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
+// PeerScorer keeps track of misbehavior scores of peers and allows to
+// temporarily ban them.
+type PeerScorer interface {
+	// Scores returns misbehavior scores of peers with a non-zero score,
+	// keyed by the peer identifier.
+	Scores() map[string]float64
+	// Ban bans the given peer for the given duration. Banned peers should
+	// not be able to connect and publish messages until the ban expires.
+	Ban(peer string, duration time.Duration)
+}
+
+// MisbehaviorPolicy determines how the guard reacts to misbehaving peers.
+type MisbehaviorPolicy struct {
+	// Scorer provides misbehavior scores of peers.
+	Scorer PeerScorer
+	// ScoreThreshold is the score above which a peer is disconnected and
+	// banned.
+	ScoreThreshold float64
+	// BanDuration is the duration of the ban.
+	BanDuration time.Duration
+	// CheckTick is the amount of time between periodic checks of peers'
+	// misbehavior scores.
+	CheckTick time.Duration
+}
+
 // Guard contains the state necessary to make connection pruning decisions.
 type Guard struct {
 	logger log.StandardLogger
@@ -24,28 +49,36 @@ type Guard struct {
 
 	connectionManager net.ConnectionManager
 
+	misbehaviorPolicy *MisbehaviorPolicy
+
 	peerCrossListLock sync.Mutex
 	peerCrossList     map[string]bool
 }
 
 // NewGuard returns a new instance of Guard. Should only be called once per
 // provider. Instantiating a new instance of Guard automatically runs it in the
-// background for the lifetime of the client.
+// background for the lifetime of the client. If the misbehavior policy is
+// nil, peers are not checked against their misbehavior scores.
 func NewGuard(
 	ctx context.Context,
 	logger log.StandardLogger,
 	duration time.Duration,
 	firewall net.Firewall,
 	connectionManager net.ConnectionManager,
+	misbehaviorPolicy *MisbehaviorPolicy,
 ) *Guard {
 	guard := &Guard{
 		logger:            logger,
 		duration:          duration,
 		firewall:          firewall,
 		connectionManager: connectionManager,
+		misbehaviorPolicy: misbehaviorPolicy,
 		peerCrossList:     make(map[string]bool),
 	}
 	go guard.start(ctx)
+	if misbehaviorPolicy != nil {
+		go guard.startMisbehaviorChecks(ctx)
+	}
 	return guard
 }
 
@@ -97,6 +130,42 @@ func (g *Guard) start(ctx context.Context) {
 	}
 }
 
+// startMisbehaviorChecks executes the background worker disconnecting and
+// banning peers whose misbehavior score crossed the threshold. If it receives
+// a signal to stop the execution of the client, it kills this task.
+func (g *Guard) startMisbehaviorChecks(ctx context.Context) {
+	ticker := time.NewTicker(g.misbehaviorPolicy.CheckTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.checkMisbehaviorScores()
+		}
+	}
+}
+
+func (g *Guard) checkMisbehaviorScores() {
+	for peer, score := range g.misbehaviorPolicy.Scorer.Scores() {
+		if score < g.misbehaviorPolicy.ScoreThreshold {
+			continue
+		}
+
+		g.logger.Warningf(
+			"dropping the connection and banning peer [%v] for [%v]; "+
+				"misbehavior score [%.2f] crossed the threshold [%.2f]",
+			peer,
+			g.misbehaviorPolicy.BanDuration,
+			score,
+			g.misbehaviorPolicy.ScoreThreshold,
+		)
+		g.misbehaviorPolicy.Scorer.Ban(peer, g.misbehaviorPolicy.BanDuration)
+		g.connectionManager.DisconnectPeer(peer)
+	}
+}
+
 func (g *Guard) checkFirewallRules(peer string) {
 	defer g.completedCheck(peer)
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/keep-network/keep-core/pkg/internal/testutils"
 	"github.com/keep-network/keep-core/pkg/operator"
+	"sync"
 	"testing"
 	"time"
 
@@ -30,11 +31,11 @@ func TestDisconnect(t *testing.T) {
 
 	// setup the first peer
 	peer1Provider := localNetwork.Connect()
-	_ = NewGuard(ctx, &testutils.MockLogger{}, 1*time.Second, firewall, peer1Provider.ConnectionManager())
+	_ = NewGuard(ctx, &testutils.MockLogger{}, 1*time.Second, firewall, peer1Provider.ConnectionManager(), nil)
 
 	// setup the second peer
 	peer2Provider := localNetwork.Connect()
-	_ = NewGuard(ctx, &testutils.MockLogger{}, 1*time.Second, firewall, peer2Provider.ConnectionManager())
+	_ = NewGuard(ctx, &testutils.MockLogger{}, 1*time.Second, firewall, peer2Provider.ConnectionManager(), nil)
 
 	// connect them with each other
 	peer1Provider.AddPeer(peer2Provider.ID().String(), peer2OperatorPublicKey)
@@ -60,6 +61,97 @@ func TestDisconnect(t *testing.T) {
 	}
 }
 
+func TestBanMisbehavingPeer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, peer2OperatorPublicKey, err := operator.GenerateKeyPair(localNetwork.DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firewall := newMockFirewall()
+	firewall.updatePeer(peer2OperatorPublicKey, true)
+
+	peer1Provider := localNetwork.Connect()
+	peer2Provider := localNetwork.Connect()
+
+	scorer := &mockPeerScorer{
+		scores: map[string]float64{
+			peer2Provider.ID().String(): 150,
+		},
+		bans: make(map[string]time.Duration),
+	}
+
+	_ = NewGuard(
+		ctx,
+		&testutils.MockLogger{},
+		1*time.Minute,
+		firewall,
+		peer1Provider.ConnectionManager(),
+		&MisbehaviorPolicy{
+			Scorer:         scorer,
+			ScoreThreshold: 100,
+			BanDuration:    1 * time.Hour,
+			CheckTick:      1 * time.Second,
+		},
+	)
+
+	peer1Provider.AddPeer(peer2Provider.ID().String(), peer2OperatorPublicKey)
+
+	if len(peer1Provider.ConnectionManager().ConnectedPeers()) != 1 {
+		t.Fatal("peer 1 not connected properly with peer 2")
+	}
+
+	// two seconds to run the misbehavior check loop
+	time.Sleep(2 * time.Second)
+
+	if len(peer1Provider.ConnectionManager().ConnectedPeers()) != 0 {
+		t.Fatal("peer 1 should drop the connection with peer 2")
+	}
+
+	banDuration, ok := scorer.banDuration(peer2Provider.ID().String())
+	if !ok {
+		t.Fatal("peer 2 should be banned")
+	}
+	if banDuration != 1*time.Hour {
+		t.Fatalf("unexpected ban duration: [%v]", banDuration)
+	}
+}
+
+type mockPeerScorer struct {
+	mutex  sync.Mutex
+	scores map[string]float64
+	bans   map[string]time.Duration
+}
+
+func (mps *mockPeerScorer) Scores() map[string]float64 {
+	mps.mutex.Lock()
+	defer mps.mutex.Unlock()
+
+	scores := make(map[string]float64)
+	for peer, score := range mps.scores {
+		scores[peer] = score
+	}
+	return scores
+}
+
+func (mps *mockPeerScorer) Ban(peer string, duration time.Duration) {
+	mps.mutex.Lock()
+	defer mps.mutex.Unlock()
+
+	mps.bans[peer] = duration
+	delete(mps.scores, peer)
+}
+
+func (mps *mockPeerScorer) banDuration(peer string) (time.Duration, bool) {
+	mps.mutex.Lock()
+	defer mps.mutex.Unlock()
+
+	duration, ok := mps.bans[peer]
+	return duration, ok
+}
+
 func newMockFirewall() *mockFirewall {
 	return &mockFirewall{
 		meetsCriteria: make(map[uint64]bool),
@@ -0,0 +1,45 @@
+package tbtc
+
+import "time"
+
+// referenceBlockTime is the average block time of the chain the protocol
+// block durations (DKG and signing attempt phases, result submission delays
+// and so on) were tuned for. It is the Ethereum mainnet block time.
+const referenceBlockTime = 12 * time.Second
+
+// blockScale converts block durations tuned for the reference block time
+// into block durations of a chain with the given average block time. This
+// way, the wall-clock duration of protocol phases stays roughly the same,
+// no matter how fast the chain the tbtc application runs on produces blocks.
+//
+// All members of the group must use the same average block time as block
+// durations determine the moments when protocol phases start and end.
+type blockScale struct {
+	averageBlockTime time.Duration
+}
+
+// newBlockScale returns a block scale for a chain with the given average
+// block time. Non-positive average block time is treated as the reference
+// block time.
+func newBlockScale(averageBlockTime time.Duration) blockScale {
+	return blockScale{averageBlockTime}
+}
+
+// blocks converts the given block duration tuned for the reference block
+// time into the block duration of the chain. The result is rounded up so the
+// wall-clock duration is never shorter than the reference one. A non-zero
+// duration is never converted to zero blocks.
+func (bs blockScale) blocks(referenceBlocks uint64) uint64 {
+	if bs.averageBlockTime <= 0 || bs.averageBlockTime == referenceBlockTime {
+		return referenceBlocks
+	}
+
+	referenceDuration := time.Duration(referenceBlocks) * referenceBlockTime
+
+	blocks := uint64(referenceDuration / bs.averageBlockTime)
+	if referenceDuration%bs.averageBlockTime != 0 {
+		blocks++
+	}
+
+	return blocks
+}
@@ -0,0 +1,72 @@
+package tbtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+func TestBlockScale_Blocks(t *testing.T) {
+	var tests = map[string]struct {
+		averageBlockTime time.Duration
+		referenceBlocks  uint64
+		expectedBlocks   uint64
+	}{
+		"zero average block time": {
+			averageBlockTime: 0,
+			referenceBlocks:  150,
+			expectedBlocks:   150,
+		},
+		"negative average block time": {
+			averageBlockTime: -time.Second,
+			referenceBlocks:  150,
+			expectedBlocks:   150,
+		},
+		"reference average block time": {
+			averageBlockTime: referenceBlockTime,
+			referenceBlocks:  150,
+			expectedBlocks:   150,
+		},
+		"faster chain": {
+			averageBlockTime: 2 * time.Second,
+			referenceBlocks:  150,
+			expectedBlocks:   900,
+		},
+		"faster chain with rounding": {
+			averageBlockTime: 5 * time.Second,
+			referenceBlocks:  1,
+			expectedBlocks:   3,
+		},
+		"slower chain": {
+			averageBlockTime: 24 * time.Second,
+			referenceBlocks:  150,
+			expectedBlocks:   75,
+		},
+		"slower chain with rounding": {
+			averageBlockTime: time.Minute,
+			referenceBlocks:  2,
+			expectedBlocks:   1,
+		},
+		"zero reference blocks": {
+			averageBlockTime: 2 * time.Second,
+			referenceBlocks:  0,
+			expectedBlocks:   0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			blocks := newBlockScale(test.averageBlockTime).blocks(
+				test.referenceBlocks,
+			)
+
+			testutils.AssertIntsEqual(
+				t,
+				"blocks",
+				int(test.expectedBlocks),
+				int(blocks),
+			)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package tbtc
 import (
 	"crypto/ecdsa"
 	"math/big"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/operator"
@@ -81,6 +82,11 @@ type DistributedKeyGenerationChain interface {
 	// SubmitDKGResult submits the DKG result to the chain.
 	SubmitDKGResult(dkgResult *DKGChainResult) error
 
+	// HasFundsForDKGResultSubmission checks whether the operator has enough
+	// funds to cover the estimated cost of submitting the given DKG result
+	// to the chain.
+	HasFundsForDKGResultSubmission(dkgResult *DKGChainResult) (bool, error)
+
 	// GetDKGState returns the current state of the DKG procedure.
 	GetDKGState() (DKGState, error)
 
@@ -186,10 +192,16 @@ type HeartbeatRequestedEvent struct {
 type Chain interface {
 	// BlockCounter returns the chain's block counter.
 	BlockCounter() (chain.BlockCounter, error)
+	// AverageBlockTime returns the average block time of the chain. It is
+	// used to scale block durations of protocol phases tuned for Ethereum
+	// mainnet to the block time of the chain. Non-positive value means the
+	// block time of Ethereum mainnet.
+	AverageBlockTime() time.Duration
 	// Signing returns the chain's signer.
 	Signing() chain.Signing
 	// OperatorKeyPair returns the key pair of the operator assigned to this
-	// chain handle.
+	// chain handle. The private key is nil if the operator key is held
+	// outside of the client, for example by an external signer.
 	OperatorKeyPair() (*operator.PrivateKey, *operator.PublicKey, error)
 
 	sortition.Chain
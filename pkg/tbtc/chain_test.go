@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/chain/local_v1"
@@ -30,6 +31,8 @@ type localChain struct {
 
 	dkgResultApprovalGuard func() bool
 
+	insufficientFunds bool
+
 	dkgResultChallengeHandlersMutex sync.Mutex
 	dkgResultChallengeHandlers      map[int]func(submission *DKGResultChallengedEvent)
 
@@ -38,6 +41,8 @@ type localChain struct {
 	dkgResult      *DKGChainResult
 	dkgResultValid bool
 
+	groupSelectionResult *GroupSelectionResult
+
 	blockCounter       chain.BlockCounter
 	operatorPrivateKey *operator.PrivateKey
 }
@@ -46,6 +51,10 @@ func (lc *localChain) BlockCounter() (chain.BlockCounter, error) {
 	return lc.blockCounter, nil
 }
 
+func (lc *localChain) AverageBlockTime() time.Duration {
+	return 0
+}
+
 func (lc *localChain) Signing() chain.Signing {
 	return local_v1.NewSigner(lc.operatorPrivateKey)
 }
@@ -122,7 +131,23 @@ func (lc *localChain) GetOperatorID(
 }
 
 func (lc *localChain) SelectGroup() (*GroupSelectionResult, error) {
-	panic("not implemented")
+	lc.dkgMutex.Lock()
+	defer lc.dkgMutex.Unlock()
+
+	if lc.groupSelectionResult == nil {
+		return nil, fmt.Errorf("group not selected")
+	}
+
+	return lc.groupSelectionResult, nil
+}
+
+func (lc *localChain) setGroupSelectionResult(
+	groupSelectionResult *GroupSelectionResult,
+) {
+	lc.dkgMutex.Lock()
+	defer lc.dkgMutex.Unlock()
+
+	lc.groupSelectionResult = groupSelectionResult
 }
 
 func (lc *localChain) OnDKGStarted(
@@ -275,6 +300,12 @@ func (lc *localChain) SubmitDKGResult(
 	return nil
 }
 
+func (lc *localChain) HasFundsForDKGResultSubmission(
+	dkgResult *DKGChainResult,
+) (bool, error) {
+	return !lc.insufficientFunds, nil
+}
+
 func (lc *localChain) GetDKGState() (DKGState, error) {
 	lc.dkgMutex.Lock()
 	defer lc.dkgMutex.Unlock()
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
+	"sync/atomic"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -55,11 +57,26 @@ type dkgExecutor struct {
 	waitForBlockFn waitForBlockFn
 
 	tecdsaExecutor *dkg.Executor
+
+	// workPersistence stores evidence of submitted DKG results diverging
+	// from the results computed locally.
+	workPersistence persistence.BasicHandle
+	// localResults holds DKG results computed locally by members controlled
+	// by this node. They are compared against results submitted to the chain.
+	localResults *localDkgResults
+	// divergentResultsCount is the number of submitted DKG results that
+	// diverged from the results computed locally.
+	divergentResultsCount uint64
+	// dkgStates persists the state of DKG executions of members controlled
+	// by this node so they can rejoin the DKG after a restart of the client.
+	dkgStates *dkgStateStorage
 }
 
 // newDkgExecutor creates a new instance of dkgExecutor struct. There should
-// be only one instance of dkgExecutor.
+// be only one instance of dkgExecutor. Background routines of the executor
+// run until the given context is done.
 func newDkgExecutor(
+	ctx context.Context,
 	groupParameters *GroupParameters,
 	operatorIDFn func() (chain.OperatorID, error),
 	operatorAddress chain.Address,
@@ -73,6 +90,7 @@ func newDkgExecutor(
 	waitForBlockFn waitForBlockFn,
 ) *dkgExecutor {
 	tecdsaExecutor := dkg.NewExecutor(
+		ctx,
 		logger,
 		scheduler,
 		workPersistence,
@@ -80,6 +98,7 @@ func newDkgExecutor(
 		config.PreParamsGenerationTimeout,
 		config.PreParamsGenerationDelay,
 		config.PreParamsGenerationConcurrency,
+		config.PreParamsMaxAge,
 		config.KeyGenerationConcurrency,
 	)
 
@@ -93,6 +112,10 @@ func newDkgExecutor(
 		protocolLatch:   protocolLatch,
 		tecdsaExecutor:  tecdsaExecutor,
 		waitForBlockFn:  waitForBlockFn,
+
+		workPersistence:           workPersistence,
+		localResults:              newLocalDkgResults(),
+		dkgStates:                 newDkgStateStorage(workPersistence),
 	}
 }
 
@@ -101,6 +124,17 @@ func (de *dkgExecutor) preParamsCount() int {
 	return de.tecdsaExecutor.PreParamsCount()
 }
 
+// preParamsPoolStats returns statistics of the ECDSA DKG pre-parameters pool.
+func (de *dkgExecutor) preParamsPoolStats() dkg.PreParamsPoolStats {
+	return de.tecdsaExecutor.PreParamsPoolStats()
+}
+
+// dkgResultDivergencesCount returns the number of submitted DKG results that
+// diverged from the results computed locally.
+func (de *dkgExecutor) dkgResultDivergencesCount() uint64 {
+	return atomic.LoadUint64(&de.divergentResultsCount)
+}
+
 // executeDkgIfEligible is the main function of dkgExecutor. It performs the
 // full execution of ECDSA Distributed Key Generation: determining members
 // selected to the signing group, executing off-chain protocol, and publishing
@@ -145,6 +179,7 @@ func (de *dkgExecutor) executeDkgIfEligible(
 			memberIndexes,
 			groupSelectionResult,
 			startBlock,
+			nil,
 		)
 	} else {
 		dkgLogger.Infof("not eligible for DKG")
@@ -224,13 +259,16 @@ func (de *dkgExecutor) setupBroadcastChannel(
 
 // generateSigningGroup executes off-chain protocol for each member controlled
 // by the current operator and upon successful execution of the protocol
-// publishes the result to the chain.
+// publishes the result to the chain. The resumedStates map holds persisted
+// states of members rejoining the DKG after a restart of the client; it is
+// nil when the DKG is executed from the beginning.
 func (de *dkgExecutor) generateSigningGroup(
 	dkgLogger *zap.SugaredLogger,
 	seed *big.Int,
 	memberIndexes []uint8,
 	groupSelectionResult *GroupSelectionResult,
 	startBlock uint64,
+	resumedStates map[group.MemberIndex]*dkgMemberState,
 ) {
 	membershipValidator := group.NewMembershipValidator(
 		dkgLogger,
@@ -260,6 +298,17 @@ func (de *dkgExecutor) generateSigningGroup(
 			de.protocolLatch.Lock()
 			defer de.protocolLatch.Unlock()
 
+			memberState, resumed := resumedStates[memberIndex]
+			if !resumed {
+				memberState = newDkgMemberState(
+					seed,
+					startBlock,
+					memberIndex,
+					groupSelectionResult,
+				)
+			}
+			de.saveDkgMemberState(dkgLogger, memberState)
+
 			ctx, cancelCtx := withCancelOnBlock(
 				context.Background(),
 				dkgTimeoutBlock,
@@ -301,65 +350,105 @@ func (de *dkgExecutor) generateSigningGroup(
 				groupSelectionResult.OperatorsAddresses,
 				de.groupParameters,
 				announcer,
+				newBlockScale(de.chain.AverageBlockTime()),
 			)
 
-			result, err := retryLoop.start(
-				ctx,
-				de.waitForBlockFn,
-				func(attempt *dkgAttemptParams) (*dkg.Result, error) {
-					dkgAttemptLogger := dkgLogger.With(
-						zap.Uint("attempt", attempt.number),
-						zap.Uint64("attemptStartBlock", attempt.startBlock),
-						zap.Uint64("attemptTimeoutBlock", attempt.timeoutBlock),
-					)
+			var result *dkg.Result
+			var err error
+
+			if resumed {
+				result = de.resumeDkgAttempt(
+					ctx,
+					dkgLogger,
+					seed,
+					memberState,
+					broadcastChannel,
+					membershipValidator,
+				)
 
-					dkgAttemptLogger.Infof(
-						"[member:%v] scheduled dkg attempt "+
-							"with [%v] group members (excluded: [%v])",
-						memberIndex,
-						de.groupParameters.GroupSize-len(attempt.excludedMembersIndexes),
-						attempt.excludedMembersIndexes,
-					)
+				if result == nil {
+					// The member cannot take part in attempts that started
+					// while the client was down.
+					currentBlock, err := de.currentBlock()
+					if err != nil {
+						dkgLogger.Errorf(
+							"[member:%v] cannot get current block: [%v]",
+							memberIndex,
+							err,
+						)
+						de.deleteDkgMemberState(dkgLogger, memberState)
+						return
+					}
 
-					// Set up the attempt timeout signal.
-					attemptCtx, _ := withCancelOnBlock(
-						ctx,
-						attempt.timeoutBlock,
-						de.waitForBlockFn,
-					)
+					retryLoop.skipElapsedAttempts(currentBlock)
+				}
+			}
 
-					// sessionID must be different for each attempt.
-					sessionID := fmt.Sprintf(
-						"%v-%v",
-						seed.Text(16),
-						attempt.number,
-					)
+			if result == nil {
+				result, err = retryLoop.start(
+					ctx,
+					de.waitForBlockFn,
+					func(attempt *dkgAttemptParams) (*dkg.Result, error) {
+						dkgAttemptLogger := dkgLogger.With(
+							zap.Uint("attempt", attempt.number),
+							zap.Uint64("attemptStartBlock", attempt.startBlock),
+							zap.Uint64("attemptTimeoutBlock", attempt.timeoutBlock),
+						)
 
-					result, err := de.tecdsaExecutor.Execute(
-						attemptCtx,
-						dkgAttemptLogger,
-						seed,
-						sessionID,
-						memberIndex,
-						de.groupParameters.GroupSize,
-						de.groupParameters.DishonestThreshold(),
-						attempt.excludedMembersIndexes,
-						broadcastChannel,
-						membershipValidator,
-					)
-					if err != nil {
-						dkgAttemptLogger.Errorf(
-							"[member:%v] dkg attempt failed: [%v]",
+						dkgAttemptLogger.Infof(
+							"[member:%v] scheduled dkg attempt "+
+								"with [%v] group members (excluded: [%v])",
 							memberIndex,
-							err,
+							de.groupParameters.GroupSize-len(attempt.excludedMembersIndexes),
+							attempt.excludedMembersIndexes,
 						)
 
-						return nil, err
-					}
+						// Set up the attempt timeout signal.
+						attemptCtx, _ := withCancelOnBlock(
+							ctx,
+							attempt.timeoutBlock,
+							de.waitForBlockFn,
+						)
+
+						// sessionID must be different for each attempt.
+						sessionID := fmt.Sprintf(
+							"%v-%v",
+							seed.Text(16),
+							attempt.number,
+						)
+
+						result, err := de.tecdsaExecutor.Execute(
+							attemptCtx,
+							dkgAttemptLogger,
+							seed,
+							sessionID,
+							memberIndex,
+							de.groupParameters.GroupSize,
+							de.groupParameters.DishonestThreshold(),
+							attempt.excludedMembersIndexes,
+							broadcastChannel,
+							membershipValidator,
+							de.dkgCheckpointFn(memberState, attempt),
+						)
+						if err != nil {
+							dkgAttemptLogger.Errorf(
+								"[member:%v] dkg attempt failed: [%v]",
+								memberIndex,
+								err,
+							)
+
+							return nil, err
+						}
+
+						return result, nil
+					},
+				)
+			}
+
+			// The member is done with the DKG protocol and cannot rejoin it
+			// anymore, regardless of the outcome.
+			de.deleteDkgMemberState(dkgLogger, memberState)
 
-					return result, nil
-				},
-			)
 			if err != nil {
 				if errors.Is(err, context.Canceled) {
 					dkgLogger.Infof(
@@ -393,6 +482,13 @@ func (de *dkgExecutor) generateSigningGroup(
 
 			dkgLogger.Infof("registered %s", signer)
 
+			de.localResults.add(
+				seed,
+				memberIndex,
+				result,
+				groupSelectionResult,
+			)
+
 			err = de.publishDkgResult(
 				ctx,
 				dkgLogger,
@@ -538,66 +634,27 @@ func (de *dkgExecutor) executeDkgValidation(
 
 	if !isValid {
 		dkgLogger.Infof("DKG result is invalid")
-
-		i := uint64(0)
-
-		// Challenges are done along with DKG state confirmations. This is
-		// needed to handle chain reorgs that may wipe out the block holding
-		// the challenge transaction. The state check done upon the confirmation
-		// block makes sure the submitted challenge changed the DKG state
-		// as expected. If the DKG state was not changed, the challenge is
-		// re-submitted.
-		for {
-			i++
-
-			err = de.chain.ChallengeDKGResult(result)
-			if err != nil {
-				dkgLogger.Errorf(
-					"cannot challenge invalid DKG result: [%v]",
-					err,
-				)
-				return
-			}
-
-			confirmationBlock := submissionBlock +
-				(i * dkgResultChallengeConfirmationBlocks)
-
-			dkgLogger.Infof(
-				"challenging invalid DKG result; waiting for "+
-					"block [%v] to confirm DKG state",
-				confirmationBlock,
-			)
-
-			err := de.waitForBlockFn(context.Background(), confirmationBlock)
-			if err != nil {
-				dkgLogger.Errorf(
-					"error while waiting for challenge confirmation: [%v]",
-					err,
-				)
-				return
-			}
-
-			state, err := de.chain.GetDKGState()
-			if err != nil {
-				dkgLogger.Errorf("cannot check DKG state: [%v]", err)
-				return
-			}
-
-			if state != Challenge {
-				dkgLogger.Infof(
-					"invalid DKG result challenged successfully",
-				)
-				return
-			}
-
-			dkgLogger.Infof(
-				"invalid DKG result still not challenged; retrying",
-			)
-		}
+		de.challengeDkgResult(dkgLogger, submissionBlock, result)
+		return
 	}
 
 	dkgLogger.Infof("DKG result is valid")
 
+	if de.isDkgResultDivergent(
+		dkgLogger,
+		seed,
+		submissionBlock,
+		result,
+		resultHash,
+	) {
+		// The result is valid from the chain's standpoint but diverges
+		// from the result computed locally. Members controlled by this
+		// node must not support it with their approvals. It cannot be
+		// challenged either as the chain accepts only challenges of
+		// results it considers invalid.
+		return
+	}
+
 	operatorID, err := de.operatorIDFn()
 	if err != nil {
 		dkgLogger.Errorf("cannot get node's operator ID: [%v]", err)
@@ -642,6 +699,9 @@ func (de *dkgExecutor) executeDkgValidation(
 	approvePeriodStartBlock := approvePrecedencePeriodStartBlock +
 		parameters.ApprovePrecedencePeriodBlocks
 
+	approvalDelayStepBlocks := newBlockScale(de.chain.AverageBlockTime()).
+		blocks(dkgResultApprovalDelayStepBlocks)
+
 	for _, currentMemberIndex := range memberIndexes {
 		go func(memberIndex group.MemberIndex) {
 			var approveBlock uint64
@@ -654,7 +714,7 @@ func (de *dkgExecutor) executeDkgValidation(
 				// Everyone else must approve after the precedence period ends.
 				// Each member preserves a delay according to their index
 				// to avoid simultaneous approval.
-				delayBlocks := uint64(memberIndex-1) * dkgResultApprovalDelayStepBlocks
+				delayBlocks := uint64(memberIndex-1) * approvalDelayStepBlocks
 				approveBlock = approvePeriodStartBlock + delayBlocks
 			}
 
@@ -710,6 +770,144 @@ func (de *dkgExecutor) executeDkgValidation(
 	}
 }
 
+// challengeDkgResult submits an on-chain challenge of the given DKG result.
+// Challenges are confirmed every dkgResultChallengeConfirmationBlocks counting
+// from the given start block and re-submitted until the DKG state changes.
+func (de *dkgExecutor) challengeDkgResult(
+	dkgLogger log.StandardLogger,
+	startBlock uint64,
+	result *DKGChainResult,
+) {
+	i := uint64(0)
+
+	confirmationBlocks := newBlockScale(de.chain.AverageBlockTime()).
+		blocks(dkgResultChallengeConfirmationBlocks)
+
+	// Challenges are done along with DKG state confirmations. This is
+	// needed to handle chain reorgs that may wipe out the block holding
+	// the challenge transaction. The state check done upon the confirmation
+	// block makes sure the submitted challenge changed the DKG state
+	// as expected. If the DKG state was not changed, the challenge is
+	// re-submitted.
+	for {
+		i++
+
+		err := de.chain.ChallengeDKGResult(result)
+		if err != nil {
+			dkgLogger.Errorf(
+				"cannot challenge invalid DKG result: [%v]",
+				err,
+			)
+			return
+		}
+
+		confirmationBlock := startBlock +
+			(i * confirmationBlocks)
+
+		dkgLogger.Infof(
+			"challenging invalid DKG result; waiting for "+
+				"block [%v] to confirm DKG state",
+			confirmationBlock,
+		)
+
+		err = de.waitForBlockFn(context.Background(), confirmationBlock)
+		if err != nil {
+			dkgLogger.Errorf(
+				"error while waiting for challenge confirmation: [%v]",
+				err,
+			)
+			return
+		}
+
+		state, err := de.chain.GetDKGState()
+		if err != nil {
+			dkgLogger.Errorf("cannot check DKG state: [%v]", err)
+			return
+		}
+
+		if state != Challenge {
+			dkgLogger.Infof(
+				"invalid DKG result challenged successfully",
+			)
+			return
+		}
+
+		dkgLogger.Infof(
+			"invalid DKG result still not challenged; retrying",
+		)
+	}
+}
+
+// isDkgResultDivergent compares the given DKG result submitted to the chain
+// against the result computed locally in the same DKG. If the results diverge,
+// the evidence is stored in the work persistence, operators are alerted, and
+// true is returned. False is returned if the results match or there is no
+// local result to compare against, for example, because this node did not
+// participate in the DKG or was restarted in the meantime.
+func (de *dkgExecutor) isDkgResultDivergent(
+	dkgLogger log.StandardLogger,
+	seed *big.Int,
+	submissionBlock uint64,
+	result *DKGChainResult,
+	resultHash [32]byte,
+) bool {
+	localResult := de.localResults.get(seed)
+	if localResult == nil {
+		dkgLogger.Infof(
+			"no local DKG result to compare the submitted result against",
+		)
+		return false
+	}
+
+	divergences, err := compareDkgResults(de.chain, result, localResult)
+	if err != nil {
+		dkgLogger.Errorf(
+			"cannot compare submitted DKG result against the local one: [%v]",
+			err,
+		)
+		return false
+	}
+
+	if len(divergences) == 0 {
+		dkgLogger.Infof("DKG result matches the local result")
+		return false
+	}
+
+	atomic.AddUint64(&de.divergentResultsCount, 1)
+
+	evidence := &dkgResultEvidence{
+		Seed:                 fmt.Sprintf("0x%x", seed),
+		ResultHash:           fmt.Sprintf("0x%x", resultHash),
+		SubmissionBlock:      submissionBlock,
+		SubmitterMemberIndex: result.SubmitterMemberIndex,
+		LocalMemberIndex:     localResult.memberIndex,
+		Divergences:          divergences,
+		DetectedAt:           time.Now(),
+	}
+
+	for _, divergence := range divergences {
+		dkgLogger.Errorf(
+			"ALERT: submitted DKG result diverges from the local result; "+
+				"field [%v] is [%v] but locally computed [%v]",
+			divergence.Field,
+			divergence.Submitted,
+			divergence.Local,
+		)
+	}
+
+	if err := de.saveDkgResultEvidence(evidence); err != nil {
+		dkgLogger.Errorf("cannot record DKG result evidence: [%v]", err)
+	} else {
+		dkgLogger.Warnf(
+			"DKG result evidence recorded in [%v/%v]",
+			dkgEvidenceDirectory,
+			evidence.fileName(),
+		)
+	}
+
+	return true
+}
+
 // finalSigningGroup takes three parameters:
 //   - selectedOperators: Contains addresses of all selected operators. Slice
 //     length equals to the groupSize. Each element with index N corresponds
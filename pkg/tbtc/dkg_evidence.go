@@ -0,0 +1,252 @@
+package tbtc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa/dkg"
+)
+
+const (
+	// localDkgResultsCacheSize determines the number of DKG results computed
+	// locally that are kept in memory in order to compare them against
+	// results submitted to the chain.
+	localDkgResultsCacheSize = 10
+	// dkgEvidenceDirectory is the name of the work persistence directory
+	// holding evidence of submitted DKG results diverging from the results
+	// computed locally.
+	dkgEvidenceDirectory = "dkg_evidence"
+)
+
+// localDkgResult is a DKG result computed locally by one of the members
+// controlled by this node.
+type localDkgResult struct {
+	memberIndex          group.MemberIndex
+	result               *dkg.Result
+	groupSelectionResult *GroupSelectionResult
+}
+
+// localDkgResults is a bounded in-memory cache of DKG results computed
+// locally, keyed by the DKG seed. Once the cache is full, the oldest result
+// is evicted. It is safe for concurrent use.
+type localDkgResults struct {
+	mutex   sync.Mutex
+	results map[string]*localDkgResult
+	seeds   []string
+}
+
+func newLocalDkgResults() *localDkgResults {
+	return &localDkgResults{
+		results: make(map[string]*localDkgResult),
+		seeds:   make([]string, 0),
+	}
+}
+
+// add stores the DKG result computed locally by the given member for the
+// given seed. All members controlled by this node compute the same result
+// so only the first one is stored.
+func (ldr *localDkgResults) add(
+	seed *big.Int,
+	memberIndex group.MemberIndex,
+	result *dkg.Result,
+	groupSelectionResult *GroupSelectionResult,
+) {
+	ldr.mutex.Lock()
+	defer ldr.mutex.Unlock()
+
+	key := seed.Text(16)
+
+	if _, ok := ldr.results[key]; ok {
+		return
+	}
+
+	if len(ldr.seeds) == localDkgResultsCacheSize {
+		delete(ldr.results, ldr.seeds[0])
+		ldr.seeds = ldr.seeds[1:]
+	}
+
+	ldr.results[key] = &localDkgResult{
+		memberIndex:          memberIndex,
+		result:               result,
+		groupSelectionResult: groupSelectionResult,
+	}
+	ldr.seeds = append(ldr.seeds, key)
+}
+
+// get returns the DKG result computed locally for the given seed. Returns
+// nil if there is no such result.
+func (ldr *localDkgResults) get(seed *big.Int) *localDkgResult {
+	ldr.mutex.Lock()
+	defer ldr.mutex.Unlock()
+
+	return ldr.results[seed.Text(16)]
+}
+
+// dkgResultDivergence describes a single difference between a DKG result
+// submitted to the chain and the result computed locally.
+type dkgResultDivergence struct {
+	Field     string `json:"field"`
+	Submitted string `json:"submitted"`
+	Local     string `json:"local"`
+}
+
+// dkgResultEvidence is the evidence of a DKG result submitted to the chain
+// that diverges from the result computed locally in the same DKG.
+type dkgResultEvidence struct {
+	Seed                 string                 `json:"seed"`
+	ResultHash           string                 `json:"resultHash"`
+	SubmissionBlock      uint64                 `json:"submissionBlock"`
+	SubmitterMemberIndex group.MemberIndex      `json:"submitterMemberIndex"`
+	LocalMemberIndex     group.MemberIndex      `json:"localMemberIndex"`
+	Divergences          []*dkgResultDivergence `json:"divergences"`
+	DetectedAt           time.Time              `json:"detectedAt"`
+}
+
+// fileName returns the name of the file the evidence is stored in.
+func (dre *dkgResultEvidence) fileName() string {
+	return fmt.Sprintf("%s.json", dre.ResultHash)
+}
+
+// compareDkgResults compares the DKG result submitted to the chain against
+// the result computed locally. The local result is assembled into the chain
+// format first so both results can be compared regardless of the chain's
+// encoding. Returns the list of divergences which is empty if the results
+// match. Signatures are not compared as the chain verifies them on its own,
+// but the submitted result must not be supported by members the local
+// result considers misbehaved.
+func compareDkgResults(
+	chain Chain,
+	submitted *DKGChainResult,
+	local *localDkgResult,
+) ([]*dkgResultDivergence, error) {
+	groupPublicKey, err := local.result.GroupPublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get local group public key: [%v]", err)
+	}
+
+	localMisbehaved := local.result.MisbehavedMembersIndexes()
+
+	expected, err := chain.AssembleDKGResult(
+		submitted.SubmitterMemberIndex,
+		groupPublicKey,
+		local.result.Group.OperatingMemberIndexes(),
+		localMisbehaved,
+		make(map[group.MemberIndex][]byte),
+		local.groupSelectionResult,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot assemble local DKG result: [%v]", err)
+	}
+
+	divergences := make([]*dkgResultDivergence, 0)
+
+	if !bytes.Equal(submitted.GroupPublicKey, expected.GroupPublicKey) {
+		divergences = append(divergences, &dkgResultDivergence{
+			Field:     "groupPublicKey",
+			Submitted: fmt.Sprintf("0x%x", submitted.GroupPublicKey),
+			Local:     fmt.Sprintf("0x%x", expected.GroupPublicKey),
+		})
+	}
+
+	submittedMisbehaved := sortedMembersIndexes(submitted.MisbehavedMembersIndexes)
+	expectedMisbehaved := sortedMembersIndexes(expected.MisbehavedMembersIndexes)
+	if fmt.Sprint(submittedMisbehaved) != fmt.Sprint(expectedMisbehaved) {
+		divergences = append(divergences, &dkgResultDivergence{
+			Field:     "misbehavedMembersIndexes",
+			Submitted: fmt.Sprint(submittedMisbehaved),
+			Local:     fmt.Sprint(expectedMisbehaved),
+		})
+	}
+
+	if fmt.Sprint(submitted.Members) != fmt.Sprint(expected.Members) {
+		divergences = append(divergences, &dkgResultDivergence{
+			Field:     "members",
+			Submitted: fmt.Sprint(submitted.Members),
+			Local:     fmt.Sprint(expected.Members),
+		})
+	}
+
+	if submitted.MembersHash != expected.MembersHash {
+		divergences = append(divergences, &dkgResultDivergence{
+			Field:     "membersHash",
+			Submitted: fmt.Sprintf("0x%x", submitted.MembersHash),
+			Local:     fmt.Sprintf("0x%x", expected.MembersHash),
+		})
+	}
+
+	isMisbehaved := make(map[group.MemberIndex]bool)
+	for _, memberIndex := range localMisbehaved {
+		isMisbehaved[memberIndex] = true
+	}
+
+	misbehavedSigners := make([]group.MemberIndex, 0)
+	for _, memberIndex := range submitted.SigningMembersIndexes {
+		if isMisbehaved[memberIndex] {
+			misbehavedSigners = append(misbehavedSigners, memberIndex)
+		}
+	}
+	if isMisbehaved[submitted.SubmitterMemberIndex] {
+		misbehavedSigners = append(
+			misbehavedSigners,
+			submitted.SubmitterMemberIndex,
+		)
+	}
+
+	if len(misbehavedSigners) > 0 {
+		divergences = append(divergences, &dkgResultDivergence{
+			Field: "signingMembersIndexes",
+			Submitted: fmt.Sprintf(
+				"%v submitted by %v",
+				sortedMembersIndexes(submitted.SigningMembersIndexes),
+				submitted.SubmitterMemberIndex,
+			),
+			Local: fmt.Sprintf(
+				"misbehaved: %v",
+				sortedMembersIndexes(misbehavedSigners),
+			),
+		})
+	}
+
+	return divergences, nil
+}
+
+// sortedMembersIndexes returns a sorted copy of the given members indexes.
+func sortedMembersIndexes(
+	membersIndexes []group.MemberIndex,
+) []group.MemberIndex {
+	sorted := make([]group.MemberIndex, len(membersIndexes))
+	copy(sorted, membersIndexes)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	return sorted
+}
+
+// saveDkgResultEvidence stores the given evidence in the work persistence.
+func (de *dkgExecutor) saveDkgResultEvidence(
+	evidence *dkgResultEvidence,
+) error {
+	evidenceBytes, err := json.MarshalIndent(evidence, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal DKG result evidence: [%v]", err)
+	}
+
+	err = de.workPersistence.Save(
+		evidenceBytes,
+		dkgEvidenceDirectory,
+		evidence.fileName(),
+	)
+	if err != nil {
+		return fmt.Errorf("cannot save DKG result evidence: [%v]", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,375 @@
+package tbtc
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/internal/tecdsatest"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+	"github.com/keep-network/keep-core/pkg/tecdsa/dkg"
+)
+
+func TestLocalDkgResults(t *testing.T) {
+	localResults := newLocalDkgResults()
+
+	for i := 0; i <= localDkgResultsCacheSize; i++ {
+		localResults.add(
+			big.NewInt(int64(i)),
+			group.MemberIndex(1),
+			&dkg.Result{},
+			&GroupSelectionResult{},
+		)
+	}
+
+	// The oldest result should be evicted.
+	if localResults.get(big.NewInt(0)) != nil {
+		t.Errorf("expected the oldest result to be evicted")
+	}
+
+	// Only the first result for the given seed should be stored.
+	localResults.add(
+		big.NewInt(1),
+		group.MemberIndex(2),
+		&dkg.Result{},
+		&GroupSelectionResult{},
+	)
+
+	result := localResults.get(big.NewInt(1))
+	if result == nil {
+		t.Fatal("expected result for the seed")
+	}
+
+	testutils.AssertIntsEqual(t, "member index", 1, int(result.memberIndex))
+}
+
+func TestCompareDkgResults(t *testing.T) {
+	testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(1)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	groupParameters := &GroupParameters{
+		GroupSize:       5,
+		GroupQuorum:     3,
+		HonestThreshold: 2,
+	}
+
+	localChain := Connect()
+	groupSelectionResult := testGroupSelectionResult(groupParameters, 1)
+
+	newResult := func(inactiveMembers ...group.MemberIndex) *dkg.Result {
+		result := &dkg.Result{
+			Group: group.NewGroup(
+				groupParameters.DishonestThreshold(),
+				groupParameters.GroupSize,
+			),
+			PrivateKeyShare: tecdsa.NewPrivateKeyShare(testData[0]),
+		}
+
+		for _, memberIndex := range inactiveMembers {
+			result.Group.MarkMemberAsInactive(memberIndex)
+		}
+
+		return result
+	}
+
+	assembleResult := func(
+		result *dkg.Result,
+		submitterMemberIndex group.MemberIndex,
+		signingMembers ...group.MemberIndex,
+	) *DKGChainResult {
+		groupPublicKey, err := result.GroupPublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signatures := make(map[group.MemberIndex][]byte)
+		for _, memberIndex := range signingMembers {
+			signatures[memberIndex] = []byte{uint8(memberIndex)}
+		}
+
+		chainResult, err := localChain.AssembleDKGResult(
+			submitterMemberIndex,
+			groupPublicKey,
+			result.Group.OperatingMemberIndexes(),
+			result.MisbehavedMembersIndexes(),
+			signatures,
+			groupSelectionResult,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return chainResult
+	}
+
+	var tests = map[string]struct {
+		localResult         *dkg.Result
+		submittedResult     *DKGChainResult
+		expectedDivergences []string
+	}{
+		"matching results": {
+			localResult:         newResult(5),
+			submittedResult:     assembleResult(newResult(5), 1, 1, 2, 3),
+			expectedDivergences: []string{},
+		},
+		"different group public key": {
+			localResult: newResult(),
+			submittedResult: func() *DKGChainResult {
+				result := assembleResult(newResult(), 1, 1, 2, 3)
+				result.GroupPublicKey[len(result.GroupPublicKey)-1] ^= 1
+				return result
+			}(),
+			expectedDivergences: []string{"groupPublicKey"},
+		},
+		"misbehaved members hidden": {
+			localResult:     newResult(4, 5),
+			submittedResult: assembleResult(newResult(), 1, 1, 2, 3),
+			expectedDivergences: []string{
+				"misbehavedMembersIndexes",
+				"membersHash",
+			},
+		},
+		"honest members marked as misbehaved": {
+			localResult:     newResult(),
+			submittedResult: assembleResult(newResult(2), 1, 1, 3, 4),
+			expectedDivergences: []string{
+				"misbehavedMembersIndexes",
+				"membersHash",
+			},
+		},
+		"result supported by misbehaved members": {
+			localResult:     newResult(5),
+			submittedResult: assembleResult(newResult(5), 5, 3, 4, 5),
+			expectedDivergences: []string{
+				"signingMembersIndexes",
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			divergences, err := compareDkgResults(
+				localChain,
+				test.submittedResult,
+				&localDkgResult{
+					memberIndex:          1,
+					result:               test.localResult,
+					groupSelectionResult: groupSelectionResult,
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fields := make([]string, len(divergences))
+			for i, divergence := range divergences {
+				fields[i] = divergence.Field
+			}
+
+			if !reflect.DeepEqual(test.expectedDivergences, fields) {
+				t.Errorf(
+					"unexpected divergences\nexpected: %v\nactual:   %v",
+					test.expectedDivergences,
+					fields,
+				)
+			}
+		})
+	}
+}
+
+func TestDkgExecutor_ExecuteDkgValidation_DivergentResult(t *testing.T) {
+	testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(1)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	groupParameters := &GroupParameters{
+		GroupSize:       5,
+		GroupQuorum:     3,
+		HonestThreshold: 2,
+	}
+
+	localChain := Connect()
+
+	operatorAddress, err := localChain.operatorAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operatorID, err := localChain.GetOperatorID(operatorAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupSelectionResult := testGroupSelectionResult(
+		groupParameters,
+		operatorID,
+	)
+
+	// The local result marks member 5 as inactive while the submitted
+	// result hides that.
+	localResult := &dkg.Result{
+		Group: group.NewGroup(
+			groupParameters.DishonestThreshold(),
+			groupParameters.GroupSize,
+		),
+		PrivateKeyShare: tecdsa.NewPrivateKeyShare(testData[0]),
+	}
+	localResult.Group.MarkMemberAsInactive(5)
+
+	groupPublicKey, err := localResult.GroupPublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = localChain.startDKG()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submittedResult, err := localChain.AssembleDKGResult(
+		1,
+		groupPublicKey,
+		[]group.MemberIndex{1, 2, 3, 4, 5},
+		[]group.MemberIndex{},
+		map[group.MemberIndex][]byte{1: {1}, 2: {2}, 3: {3}},
+		groupSelectionResult,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submissionBlock, err := localChain.blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = localChain.SubmitDKGResult(submittedResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workPersistence := &mockPersistenceHandle{}
+
+	seed := big.NewInt(100)
+
+	// Setting only the fields really needed for this test.
+	dkgExecutor := &dkgExecutor{
+		groupParameters: groupParameters,
+		operatorIDFn: func() (chain.OperatorID, error) {
+			return operatorID, nil
+		},
+		operatorAddress: operatorAddress,
+		chain:           localChain,
+		waitForBlockFn:  testWaitForBlockFn(localChain),
+		workPersistence: workPersistence,
+		localResults:    newLocalDkgResults(),
+	}
+
+	dkgExecutor.localResults.add(
+		seed,
+		1,
+		localResult,
+		groupSelectionResult,
+	)
+
+	eventChan := make(chan interface{}, 10)
+
+	_ = localChain.OnDKGResultChallenged(
+		func(event *DKGResultChallengedEvent) {
+			eventChan <- event
+		},
+	)
+	_ = localChain.OnDKGResultApproved(
+		func(event *DKGResultApprovedEvent) {
+			eventChan <- event
+		},
+	)
+
+	dkgExecutor.executeDkgValidation(
+		seed,
+		submissionBlock,
+		submittedResult,
+		computeDkgChainResultHash(submittedResult),
+	)
+
+	// Wait until the approval would have been done for a convergent
+	// result. The divergent result must be neither approved nor challenged.
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = blockCounter.WaitForBlockHeight(submissionBlock + 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var event interface{}
+	select {
+	case event = <-eventChan:
+	default:
+	}
+
+	if event != nil {
+		t.Errorf("unexpected event: [%+v]", event)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"divergent results count",
+		1,
+		int(dkgExecutor.dkgResultDivergencesCount()),
+	)
+
+	testutils.AssertIntsEqual(
+		t,
+		"saved evidence count",
+		1,
+		len(workPersistence.saved),
+	)
+
+	evidence := &dkgResultEvidence{}
+	err = json.Unmarshal(workPersistence.saved[0].(*mockDescriptor).content, evidence)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertStringsEqual(
+		t,
+		"evidence directory",
+		dkgEvidenceDirectory,
+		workPersistence.saved[0].Directory(),
+	)
+	testutils.AssertStringsEqual(t, "seed", "0x64", evidence.Seed)
+	testutils.AssertIntsEqual(
+		t,
+		"divergences count",
+		2,
+		len(evidence.Divergences),
+	)
+}
+
+// testGroupSelectionResult returns a group selection result where all
+// members are controlled by the operator with the given ID.
+func testGroupSelectionResult(
+	groupParameters *GroupParameters,
+	operatorID chain.OperatorID,
+) *GroupSelectionResult {
+	operatorsIDs := make(chain.OperatorIDs, groupParameters.GroupSize)
+	operatorsAddresses := make(chain.Addresses, groupParameters.GroupSize)
+	for i := range operatorsIDs {
+		operatorsIDs[i] = operatorID
+		operatorsAddresses[i] = chain.Address("0xAA")
+	}
+
+	return &GroupSelectionResult{
+		OperatorsIDs:       operatorsIDs,
+		OperatorsAddresses: operatorsAddresses,
+	}
+}
@@ -33,12 +33,12 @@ const (
 )
 
 // dkgAttemptMaximumBlocks returns the maximum block duration of a single
-// DKG attempt.
-func dkgAttemptMaximumBlocks() uint {
-	return dkgAttemptAnnouncementDelayBlocks +
-		dkgAttemptAnnouncementActiveBlocks +
-		dkgAttemptMaximumProtocolBlocks +
-		dkgAttemptCoolDownBlocks
+// DKG attempt, scaled using the given block scale.
+func dkgAttemptMaximumBlocks(blockScale blockScale) uint64 {
+	return blockScale.blocks(dkgAttemptAnnouncementDelayBlocks) +
+		blockScale.blocks(dkgAttemptAnnouncementActiveBlocks) +
+		blockScale.blocks(dkgAttemptMaximumProtocolBlocks) +
+		blockScale.blocks(dkgAttemptCoolDownBlocks)
 }
 
 // dkgAnnouncer represents a component responsible for exchanging readiness
@@ -72,6 +72,10 @@ type dkgRetryLoop struct {
 	// Used for the random operator selection. It never changes.
 	attemptSeed        int64
 	attemptDelayBlocks uint64
+
+	// blockScale scales the attempt phases block durations to the block
+	// time of the chain.
+	blockScale blockScale
 }
 
 func newDkgRetryLoop(
@@ -82,6 +86,7 @@ func newDkgRetryLoop(
 	selectedOperators chain.Addresses,
 	groupParameters *GroupParameters,
 	announcer dkgAnnouncer,
+	blockScale blockScale,
 ) *dkgRetryLoop {
 	// Compute the 8-byte seed needed for the random retry algorithm. We take
 	// the first 8 bytes of the hash of the DKG seed. This allows us to not
@@ -100,7 +105,32 @@ func newDkgRetryLoop(
 		attemptCounter:     0,
 		attemptStartBlock:  initialStartBlock,
 		attemptSeed:        attemptSeed,
-		attemptDelayBlocks: 5,
+		attemptDelayBlocks: blockScale.blocks(5),
+		blockScale:         blockScale,
+	}
+}
+
+// skipElapsedAttempts makes the retry loop skip all attempts whose
+// announcement phase ended before the given block. It is used when the
+// member joins the DKG late, for example, after a restart of the client, and
+// cannot take part in attempts that have already started. The loop has to
+// be started after calling this function.
+func (drl *dkgRetryLoop) skipElapsedAttempts(currentBlock uint64) {
+	for {
+		nextAttemptStartBlock := drl.attemptStartBlock
+		if drl.attemptCounter > 0 {
+			nextAttemptStartBlock += dkgAttemptMaximumBlocks(drl.blockScale)
+		}
+
+		announcementEndBlock := nextAttemptStartBlock +
+			drl.blockScale.blocks(dkgAttemptAnnouncementDelayBlocks) +
+			drl.blockScale.blocks(dkgAttemptAnnouncementActiveBlocks)
+		if announcementEndBlock >= currentBlock {
+			return
+		}
+
+		drl.attemptCounter++
+		drl.attemptStartBlock = nextAttemptStartBlock
 	}
 }
 
@@ -143,10 +173,11 @@ func (drl *dkgRetryLoop) start(
 		// mined in the meantime.
 		if drl.attemptCounter > 1 {
 			drl.attemptStartBlock = drl.attemptStartBlock +
-				uint64(dkgAttemptMaximumBlocks())
+				dkgAttemptMaximumBlocks(drl.blockScale)
 		}
 
-		announcementStartBlock := drl.attemptStartBlock + dkgAttemptAnnouncementDelayBlocks
+		announcementStartBlock := drl.attemptStartBlock +
+			drl.blockScale.blocks(dkgAttemptAnnouncementDelayBlocks)
 		err := waitForBlockFn(ctx, announcementStartBlock)
 		if err != nil {
 			return nil, fmt.Errorf(
@@ -160,7 +191,8 @@ func (drl *dkgRetryLoop) start(
 
 		// Set up the announcement phase stop signal.
 		announceCtx, cancelAnnounceCtx := context.WithCancel(ctx)
-		announcementEndBlock := announcementStartBlock + dkgAttemptAnnouncementActiveBlocks
+		announcementEndBlock := announcementStartBlock +
+			drl.blockScale.blocks(dkgAttemptAnnouncementActiveBlocks)
 		go func() {
 			defer cancelAnnounceCtx()
 
@@ -239,7 +271,8 @@ func (drl *dkgRetryLoop) start(
 			drl.memberIndex,
 		)
 
-		timeoutBlock := announcementEndBlock + dkgAttemptMaximumProtocolBlocks
+		timeoutBlock := announcementEndBlock +
+			drl.blockScale.blocks(dkgAttemptMaximumProtocolBlocks)
 
 		var result *dkg.Result
 		var attemptErr error
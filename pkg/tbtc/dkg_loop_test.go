@@ -251,6 +251,7 @@ func TestDkgRetryLoop(t *testing.T) {
 				selectedOperators,
 				groupParameters,
 				announcer,
+				blockScale{},
 			)
 
 			ctx, cancelCtx := test.ctxFn()
@@ -345,3 +346,69 @@ func (mda *mockDkgAnnouncer) Announce(
 
 	return mda.incomingAnnouncementsFn(sessionID)
 }
+
+func TestDkgRetryLoop_SkipElapsedAttempts(t *testing.T) {
+	initialStartBlock := uint64(100)
+	attemptBlocks := dkgAttemptMaximumBlocks(blockScale{})
+
+	var tests = map[string]struct {
+		currentBlock              uint64
+		expectedAttemptCounter    uint
+		expectedAttemptStartBlock uint64
+	}{
+		"before the first attempt": {
+			currentBlock:              initialStartBlock,
+			expectedAttemptCounter:    0,
+			expectedAttemptStartBlock: initialStartBlock,
+		},
+		"at the end of the first attempt's announcement": {
+			currentBlock:              initialStartBlock + 6,
+			expectedAttemptCounter:    0,
+			expectedAttemptStartBlock: initialStartBlock,
+		},
+		"after the first attempt's announcement": {
+			currentBlock:              initialStartBlock + 7,
+			expectedAttemptCounter:    1,
+			expectedAttemptStartBlock: initialStartBlock,
+		},
+		"after the second attempt's announcement": {
+			currentBlock:              initialStartBlock + attemptBlocks + 7,
+			expectedAttemptCounter:    2,
+			expectedAttemptStartBlock: initialStartBlock + attemptBlocks,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			retryLoop := newDkgRetryLoop(
+				&testutils.MockLogger{},
+				big.NewInt(100),
+				initialStartBlock,
+				group.MemberIndex(1),
+				chain.Addresses{"address-1"},
+				&GroupParameters{
+					GroupSize:       1,
+					GroupQuorum:     1,
+					HonestThreshold: 1,
+				},
+				nil,
+				blockScale{},
+			)
+
+			retryLoop.skipElapsedAttempts(test.currentBlock)
+
+			testutils.AssertIntsEqual(
+				t,
+				"attempt counter",
+				int(test.expectedAttemptCounter),
+				int(retryLoop.attemptCounter),
+			)
+			testutils.AssertIntsEqual(
+				t,
+				"attempt start block",
+				int(test.expectedAttemptStartBlock),
+				int(retryLoop.attemptStartBlock),
+			)
+		})
+	}
+}
@@ -0,0 +1,468 @@
+package tbtc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa/dkg"
+)
+
+// dkgStateDirectory is the name of the work persistence directory holding
+// the state of DKG executions of members controlled by this node. The state
+// allows the members to rejoin the DKG after a restart of the client.
+const dkgStateDirectory = "dkg_state"
+
+// dkgMemberState is the persisted state of the DKG execution of a single
+// member controlled by this node. The state is kept as long as the member
+// executes the DKG protocol and is removed once the member is done with it.
+type dkgMemberState struct {
+	Seed               string            `json:"seed"`
+	StartBlock         uint64            `json:"startBlock"`
+	MemberIndex        group.MemberIndex `json:"memberIndex"`
+	OperatorsIDs       chain.OperatorIDs `json:"operatorsIDs"`
+	OperatorsAddresses chain.Addresses   `json:"operatorsAddresses"`
+	// AttemptNumber is the number of the DKG attempt the checkpoint was
+	// recorded in.
+	AttemptNumber uint `json:"attemptNumber,omitempty"`
+	// AttemptTimeoutBlock is the timeout block of the DKG attempt the
+	// checkpoint was recorded in.
+	AttemptTimeoutBlock uint64 `json:"attemptTimeoutBlock,omitempty"`
+	// Checkpoint is the last DKG protocol checkpoint recorded by the member.
+	// It holds ephemeral private keys of the member.
+	Checkpoint []byte `json:"checkpoint,omitempty"`
+}
+
+func newDkgMemberState(
+	seed *big.Int,
+	startBlock uint64,
+	memberIndex group.MemberIndex,
+	groupSelectionResult *GroupSelectionResult,
+) *dkgMemberState {
+	return &dkgMemberState{
+		Seed:               seed.Text(16),
+		StartBlock:         startBlock,
+		MemberIndex:        memberIndex,
+		OperatorsIDs:       groupSelectionResult.OperatorsIDs,
+		OperatorsAddresses: groupSelectionResult.OperatorsAddresses,
+	}
+}
+
+// fileName returns the name of the file the state is stored in.
+func (dms *dkgMemberState) fileName() string {
+	return fmt.Sprintf("%s_%d.json", dms.Seed, dms.MemberIndex)
+}
+
+// groupSelectionResult returns the result of the group selection the DKG
+// was started with.
+func (dms *dkgMemberState) groupSelectionResult() *GroupSelectionResult {
+	return &GroupSelectionResult{
+		OperatorsIDs:       dms.OperatorsIDs,
+		OperatorsAddresses: dms.OperatorsAddresses,
+	}
+}
+
+// checkpoint returns the last DKG protocol checkpoint recorded by the member.
+// Returns nil if the member has not recorded any checkpoint yet.
+func (dms *dkgMemberState) checkpoint() (*dkg.Checkpoint, error) {
+	if len(dms.Checkpoint) == 0 {
+		return nil, nil
+	}
+
+	checkpoint := &dkg.Checkpoint{}
+	if err := checkpoint.Unmarshal(dms.Checkpoint); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal checkpoint: [%v]", err)
+	}
+
+	return checkpoint, nil
+}
+
+// dkgStateStorage is the component that persists the state of DKG executions
+// of members controlled by this node using the work persistence layer.
+type dkgStateStorage struct {
+	persistence persistence.BasicHandle
+}
+
+func newDkgStateStorage(persistence persistence.BasicHandle) *dkgStateStorage {
+	return &dkgStateStorage{
+		persistence: persistence,
+	}
+}
+
+// save stores the given member state, replacing the previous one.
+func (dss *dkgStateStorage) save(memberState *dkgMemberState) error {
+	memberStateBytes, err := json.Marshal(memberState)
+	if err != nil {
+		return fmt.Errorf("cannot marshal DKG member state: [%v]", err)
+	}
+
+	err = dss.persistence.Save(
+		memberStateBytes,
+		dkgStateDirectory,
+		memberState.fileName(),
+	)
+	if err != nil {
+		return fmt.Errorf("cannot save DKG member state: [%v]", err)
+	}
+
+	return nil
+}
+
+// delete removes the given member state.
+func (dss *dkgStateStorage) delete(memberState *dkgMemberState) error {
+	err := dss.persistence.Delete(dkgStateDirectory, memberState.fileName())
+	if err != nil {
+		return fmt.Errorf("cannot delete DKG member state: [%v]", err)
+	}
+
+	return nil
+}
+
+// load reads all member states from the underlying persistence layer.
+// States that cannot be read are skipped.
+func (dss *dkgStateStorage) load() []*dkgMemberState {
+	descriptorsChan, errorsChan := dss.persistence.ReadAll()
+
+	memberStates := make([]*dkgMemberState, 0)
+
+	// Descriptors and errors channels are read by two goroutines at the
+	// same time as the channels do not have to be buffered.
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for descriptor := range descriptorsChan {
+			if descriptor.Directory() != dkgStateDirectory {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"could not read DKG member state from file [%s]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+				continue
+			}
+
+			memberState := &dkgMemberState{}
+			if err := json.Unmarshal(content, memberState); err != nil {
+				logger.Errorf(
+					"could not unmarshal DKG member state from file [%s]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+				continue
+			}
+
+			memberStates = append(memberStates, memberState)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for err := range errorsChan {
+			logger.Errorf("could not load DKG member states from disk: [%v]", err)
+		}
+	}()
+
+	wg.Wait()
+
+	return memberStates
+}
+
+// saveDkgMemberState persists the given member state. Failures are logged
+// as they only prevent the member from rejoining the DKG after a restart.
+func (de *dkgExecutor) saveDkgMemberState(
+	dkgLogger *zap.SugaredLogger,
+	memberState *dkgMemberState,
+) {
+	if err := de.dkgStates.save(memberState); err != nil {
+		dkgLogger.Warnf(
+			"[member:%v] member will not be able to rejoin DKG after "+
+				"restart: [%v]",
+			memberState.MemberIndex,
+			err,
+		)
+	}
+}
+
+// deleteDkgMemberState removes the given member state. Failures are logged.
+func (de *dkgExecutor) deleteDkgMemberState(
+	dkgLogger *zap.SugaredLogger,
+	memberState *dkgMemberState,
+) {
+	if err := de.dkgStates.delete(memberState); err != nil {
+		dkgLogger.Warnf("[member:%v] %v", memberState.MemberIndex, err)
+	}
+}
+
+// dkgCheckpointFn returns a function persisting DKG protocol checkpoints
+// recorded by the member in the given DKG attempt.
+func (de *dkgExecutor) dkgCheckpointFn(
+	memberState *dkgMemberState,
+	attempt *dkgAttemptParams,
+) dkg.CheckpointFn {
+	return func(checkpoint *dkg.Checkpoint) error {
+		checkpointBytes, err := checkpoint.Marshal()
+		if err != nil {
+			return fmt.Errorf("cannot marshal checkpoint: [%v]", err)
+		}
+
+		memberState.AttemptNumber = attempt.number
+		memberState.AttemptTimeoutBlock = attempt.timeoutBlock
+		memberState.Checkpoint = checkpointBytes
+
+		return de.dkgStates.save(memberState)
+	}
+}
+
+// resumeInterruptedDkgs rejoins DKG executions interrupted by a restart of
+// the client. A DKG is rejoined only if it is still awaiting the result,
+// its result submission window is still open, and the chain still selects
+// the same group. The notifyDkgStarted function is called for the seed of
+// each rejoined DKG so DKG started events delivered again after the restart
+// are not processed twice. States of DKG executions that cannot be rejoined
+// are removed.
+func (de *dkgExecutor) resumeInterruptedDkgs(
+	notifyDkgStarted func(seed *big.Int) bool,
+) {
+	memberStatesBySeed := make(map[string][]*dkgMemberState)
+	for _, memberState := range de.dkgStates.load() {
+		memberStatesBySeed[memberState.Seed] = append(
+			memberStatesBySeed[memberState.Seed],
+			memberState,
+		)
+	}
+
+	for seedText, memberStates := range memberStatesBySeed {
+		dkgLogger := logger.With(zap.String("seed", "0x"+seedText))
+
+		deleteMemberStates := func() {
+			for _, memberState := range memberStates {
+				de.deleteDkgMemberState(dkgLogger, memberState)
+			}
+		}
+
+		seed, ok := new(big.Int).SetString(seedText, 16)
+		if !ok {
+			dkgLogger.Errorf("cannot parse seed of interrupted DKG")
+			deleteMemberStates()
+			continue
+		}
+
+		if err := de.checkDkgResumable(memberStates[0]); err != nil {
+			dkgLogger.Infof("cannot rejoin interrupted DKG: [%v]", err)
+			deleteMemberStates()
+			continue
+		}
+
+		if ok := notifyDkgStarted(seed); !ok {
+			dkgLogger.Warnf("interrupted DKG has been already rejoined")
+			continue
+		}
+
+		memberIndexes := make([]uint8, len(memberStates))
+		resumedStates := make(map[group.MemberIndex]*dkgMemberState)
+		for i, memberState := range memberStates {
+			memberIndexes[i] = memberState.MemberIndex
+			resumedStates[memberState.MemberIndex] = memberState
+		}
+
+		dkgLogger.Infof(
+			"rejoining DKG interrupted by restart and controlling "+
+				"[%v] group members",
+			len(memberIndexes),
+		)
+
+		de.generateSigningGroup(
+			dkgLogger,
+			seed,
+			memberIndexes,
+			memberStates[0].groupSelectionResult(),
+			memberStates[0].StartBlock,
+			resumedStates,
+		)
+	}
+}
+
+// checkDkgResumable checks whether the DKG the given member state was
+// persisted for can still be rejoined. Returns an error describing the
+// reason if the DKG cannot be rejoined.
+func (de *dkgExecutor) checkDkgResumable(memberState *dkgMemberState) error {
+	dkgState, err := de.chain.GetDKGState()
+	if err != nil {
+		return fmt.Errorf("cannot check DKG state: [%v]", err)
+	}
+
+	if dkgState != AwaitingResult {
+		return fmt.Errorf("DKG is not awaiting the result")
+	}
+
+	dkgParameters, err := de.chain.DKGParameters()
+	if err != nil {
+		return fmt.Errorf("cannot get DKG parameters: [%v]", err)
+	}
+
+	currentBlock, err := de.currentBlock()
+	if err != nil {
+		return fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	dkgTimeoutBlock := memberState.StartBlock +
+		dkgParameters.SubmissionTimeoutBlocks
+	if currentBlock >= dkgTimeoutBlock {
+		return fmt.Errorf(
+			"DKG timed out at block [%v]; current block is [%v]",
+			dkgTimeoutBlock,
+			currentBlock,
+		)
+	}
+
+	// The DKG in progress may be a different one than the interrupted DKG
+	// if the interrupted DKG completed and a new one started in the meantime.
+	groupSelectionResult, err := de.chain.SelectGroup()
+	if err != nil {
+		return fmt.Errorf("cannot select group: [%v]", err)
+	}
+
+	if !reflect.DeepEqual(
+		groupSelectionResult,
+		memberState.groupSelectionResult(),
+	) {
+		return fmt.Errorf("DKG in progress selected a different group")
+	}
+
+	return nil
+}
+
+// resumeDkgAttempt resumes the DKG attempt the member was executing before
+// the restart of the client. The attempt is resumed only if it has not timed
+// out yet and the member recorded a resumable checkpoint in it. Returns nil
+// if the attempt could not be resumed or failed.
+func (de *dkgExecutor) resumeDkgAttempt(
+	ctx context.Context,
+	dkgLogger *zap.SugaredLogger,
+	seed *big.Int,
+	memberState *dkgMemberState,
+	broadcastChannel net.BroadcastChannel,
+	membershipValidator *group.MembershipValidator,
+) *dkg.Result {
+	checkpoint, err := memberState.checkpoint()
+	if err != nil {
+		dkgLogger.Warnf(
+			"[member:%v] cannot resume interrupted DKG attempt: [%v]",
+			memberState.MemberIndex,
+			err,
+		)
+		return nil
+	}
+
+	if checkpoint == nil {
+		dkgLogger.Infof(
+			"[member:%v] interrupted DKG attempt cannot be resumed; "+
+				"waiting for the next attempt",
+			memberState.MemberIndex,
+		)
+		return nil
+	}
+
+	if !checkpoint.IsResumable() {
+		// Resuming the TSS key generation rounds is not supported so the
+		// member is considered inactive in the interrupted attempt.
+		dkgLogger.Warnf(
+			"[member:%v] interrupted DKG attempt cannot be resumed from "+
+				"[%v] checkpoint as the state of the TSS key generation "+
+				"rounds is not persisted; the member is inactive in the "+
+				"attempt and waits for the next one",
+			memberState.MemberIndex,
+			checkpoint.State,
+		)
+		return nil
+	}
+
+	currentBlock, err := de.currentBlock()
+	if err != nil {
+		dkgLogger.Errorf("cannot get current block: [%v]", err)
+		return nil
+	}
+
+	attempt := &dkgAttemptParams{
+		number:                 memberState.AttemptNumber,
+		timeoutBlock:           memberState.AttemptTimeoutBlock,
+		excludedMembersIndexes: checkpoint.ExcludedMembersIndexes,
+	}
+
+	if currentBlock >= attempt.timeoutBlock {
+		dkgLogger.Infof(
+			"[member:%v] interrupted DKG attempt [%v] timed out; "+
+				"waiting for the next attempt",
+			memberState.MemberIndex,
+			attempt.number,
+		)
+		return nil
+	}
+
+	dkgAttemptLogger := dkgLogger.With(
+		zap.Uint("attempt", attempt.number),
+		zap.Uint64("attemptTimeoutBlock", attempt.timeoutBlock),
+	)
+
+	dkgAttemptLogger.Infof(
+		"[member:%v] resuming interrupted dkg attempt from [%v] checkpoint",
+		memberState.MemberIndex,
+		checkpoint.State,
+	)
+
+	attemptCtx, cancelAttemptCtx := withCancelOnBlock(
+		ctx,
+		attempt.timeoutBlock,
+		de.waitForBlockFn,
+	)
+	defer cancelAttemptCtx()
+
+	result, err := de.tecdsaExecutor.Resume(
+		attemptCtx,
+		dkgAttemptLogger,
+		seed,
+		checkpoint,
+		de.groupParameters.GroupSize,
+		de.groupParameters.DishonestThreshold(),
+		broadcastChannel,
+		membershipValidator,
+		de.dkgCheckpointFn(memberState, attempt),
+	)
+	if err != nil {
+		dkgAttemptLogger.Errorf(
+			"[member:%v] resumed dkg attempt failed: [%v]",
+			memberState.MemberIndex,
+			err,
+		)
+		return nil
+	}
+
+	return result
+}
+
+// currentBlock returns the current block of the chain.
+func (de *dkgExecutor) currentBlock() (uint64, error) {
+	blockCounter, err := de.chain.BlockCounter()
+	if err != nil {
+		return 0, err
+	}
+
+	return blockCounter.CurrentBlock()
+}
@@ -0,0 +1,261 @@
+package tbtc
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa/dkg"
+)
+
+func TestDkgStateStorage(t *testing.T) {
+	workPersistence := &mockPersistenceHandle{}
+	storage := newDkgStateStorage(workPersistence)
+
+	groupSelectionResult := &GroupSelectionResult{
+		OperatorsIDs:       chain.OperatorIDs{1, 2, 3},
+		OperatorsAddresses: chain.Addresses{"0xAA", "0xBB", "0xCC"},
+	}
+
+	memberState1 := newDkgMemberState(
+		big.NewInt(100),
+		200,
+		1,
+		groupSelectionResult,
+	)
+	memberState2 := newDkgMemberState(
+		big.NewInt(100),
+		200,
+		2,
+		groupSelectionResult,
+	)
+
+	if err := storage.save(memberState1); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.save(memberState2); err != nil {
+		t.Fatal(err)
+	}
+
+	// Saving the state again should overwrite the previous one.
+	memberState1.AttemptNumber = 2
+	memberState1.AttemptTimeoutBlock = 500
+	memberState1.Checkpoint = []byte{1, 2, 3}
+	if err := storage.save(memberState1); err != nil {
+		t.Fatal(err)
+	}
+
+	// Files from other directories should be ignored.
+	err := workPersistence.Save([]byte{}, dkgEvidenceDirectory, "evidence.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := storage.load()
+
+	testutils.AssertIntsEqual(t, "loaded states count", 2, len(loaded))
+	if !reflect.DeepEqual(memberState1, loaded[0]) {
+		t.Errorf(
+			"unexpected first state\nexpected: %+v\nactual:   %+v",
+			memberState1,
+			loaded[0],
+		)
+	}
+	if !reflect.DeepEqual(memberState2, loaded[1]) {
+		t.Errorf(
+			"unexpected second state\nexpected: %+v\nactual:   %+v",
+			memberState2,
+			loaded[1],
+		)
+	}
+
+	if err := storage.delete(memberState1); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded = storage.load()
+
+	testutils.AssertIntsEqual(t, "loaded states count", 1, len(loaded))
+	testutils.AssertIntsEqual(
+		t,
+		"loaded member index",
+		2,
+		int(loaded[0].MemberIndex),
+	)
+}
+
+func TestDkgExecutor_DkgCheckpointFn(t *testing.T) {
+	workPersistence := &mockPersistenceHandle{}
+
+	dkgExecutor := &dkgExecutor{
+		dkgStates: newDkgStateStorage(workPersistence),
+	}
+
+	memberState := newDkgMemberState(
+		big.NewInt(100),
+		200,
+		3,
+		&GroupSelectionResult{},
+	)
+
+	checkpointFn := dkgExecutor.dkgCheckpointFn(
+		memberState,
+		&dkgAttemptParams{
+			number:       2,
+			timeoutBlock: 500,
+		},
+	)
+
+	err := checkpointFn(&dkg.Checkpoint{
+		SessionID:              "64-2",
+		MemberIndex:            3,
+		ExcludedMembersIndexes: []group.MemberIndex{5},
+		State:                  dkg.TssStartedCheckpoint,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := dkgExecutor.dkgStates.load()
+	testutils.AssertIntsEqual(t, "loaded states count", 1, len(loaded))
+	testutils.AssertIntsEqual(t, "attempt number", 2, int(loaded[0].AttemptNumber))
+	testutils.AssertIntsEqual(
+		t,
+		"attempt timeout block",
+		500,
+		int(loaded[0].AttemptTimeoutBlock),
+	)
+
+	checkpoint, err := loaded[0].checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertStringsEqual(t, "session ID", "64-2", checkpoint.SessionID)
+	if checkpoint.State != dkg.TssStartedCheckpoint {
+		t.Errorf("unexpected checkpoint state: [%v]", checkpoint.State)
+	}
+}
+
+func TestDkgExecutor_CheckDkgResumable(t *testing.T) {
+	localChain := Connect()
+
+	err := localChain.startDKG()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupSelectionResult := &GroupSelectionResult{
+		OperatorsIDs:       chain.OperatorIDs{1, 2, 3},
+		OperatorsAddresses: chain.Addresses{"0xAA", "0xBB", "0xCC"},
+	}
+	localChain.setGroupSelectionResult(groupSelectionResult)
+
+	dkgParameters, err := localChain.DKGParameters()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Make sure the submission window of a DKG started at the genesis block
+	// has elapsed.
+	err = localChain.blockCounter.WaitForBlockHeight(
+		dkgParameters.SubmissionTimeoutBlocks,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	currentBlock, err := localChain.blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgExecutor := &dkgExecutor{
+		chain: localChain,
+	}
+
+	var tests = map[string]struct {
+		startBlock           uint64
+		groupSelectionResult *GroupSelectionResult
+		expectedError        bool
+	}{
+		"DKG in progress": {
+			startBlock:           currentBlock,
+			groupSelectionResult: groupSelectionResult,
+			expectedError:        false,
+		},
+		"DKG timed out": {
+			startBlock:           0,
+			groupSelectionResult: groupSelectionResult,
+			expectedError:        true,
+		},
+		"different DKG in progress": {
+			startBlock: currentBlock,
+			groupSelectionResult: &GroupSelectionResult{
+				OperatorsIDs:       chain.OperatorIDs{1, 2, 4},
+				OperatorsAddresses: chain.Addresses{"0xAA", "0xBB", "0xDD"},
+			},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := dkgExecutor.checkDkgResumable(
+				newDkgMemberState(
+					big.NewInt(100),
+					test.startBlock,
+					1,
+					test.groupSelectionResult,
+				),
+			)
+
+			testutils.AssertBoolsEqual(
+				t,
+				"error",
+				test.expectedError,
+				err != nil,
+			)
+		})
+	}
+}
+
+func TestDkgExecutor_ResumeInterruptedDkgs_DkgNotAwaitingResult(t *testing.T) {
+	workPersistence := &mockPersistenceHandle{}
+
+	dkgExecutor := &dkgExecutor{
+		chain:     Connect(),
+		dkgStates: newDkgStateStorage(workPersistence),
+	}
+
+	for _, memberIndex := range []group.MemberIndex{1, 2} {
+		err := dkgExecutor.dkgStates.save(
+			newDkgMemberState(
+				big.NewInt(100),
+				0,
+				memberIndex,
+				&GroupSelectionResult{},
+			),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	notifiedSeeds := make([]*big.Int, 0)
+	dkgExecutor.resumeInterruptedDkgs(func(seed *big.Int) bool {
+		notifiedSeeds = append(notifiedSeeds, seed)
+		return true
+	})
+
+	testutils.AssertIntsEqual(t, "notified seeds count", 0, len(notifiedSeeds))
+	testutils.AssertIntsEqual(
+		t,
+		"persisted states count",
+		0,
+		len(workPersistence.saved),
+	)
+}
@@ -105,7 +105,8 @@ func newDkgResultSubmitter(
 // chain. In the process, it checks if the number of signatures is above
 // the required threshold, whether the result was already submitted and waits
 // until the member is eligible for DKG result submission or the given context
-// is done, whichever comes first.
+// is done, whichever comes first. The result is not submitted if the operator
+// does not have enough funds to cover the submission cost.
 func (drs *dkgResultSubmitter) SubmitResult(
 	ctx context.Context,
 	memberIndex group.MemberIndex,
@@ -148,7 +149,9 @@ func (drs *dkgResultSubmitter) SubmitResult(
 	if err != nil {
 		return fmt.Errorf("cannot get current block: [%v]", err)
 	}
-	delayBlocks := uint64(memberIndex-1) * dkgResultSubmissionDelayStepBlocks
+	delayStepBlocks := newBlockScale(drs.chain.AverageBlockTime()).
+		blocks(dkgResultSubmissionDelayStepBlocks)
+	delayBlocks := uint64(memberIndex-1) * delayStepBlocks
 	submissionBlock := currentBlock + delayBlocks
 
 	drs.dkgLogger.Infof(
@@ -201,5 +204,27 @@ func (drs *dkgResultSubmitter) SubmitResult(
 		return fmt.Errorf("cannot assemble DKG chain result [%w]", err)
 	}
 
+	// Members submit the result one after another so there is no need to
+	// volunteer if the operator cannot pay for the submission. The next
+	// member in the queue will submit the result instead. If the check
+	// itself fails, the submission is attempted anyway.
+	hasFunds, err := drs.chain.HasFundsForDKGResultSubmission(dkgResult)
+	if err != nil {
+		drs.dkgLogger.Warnf(
+			"[member:%v] cannot check funds for DKG result submission; "+
+				"attempting to submit anyway: [%v]",
+			memberIndex,
+			err,
+		)
+	} else if !hasFunds {
+		drs.dkgLogger.Warnf(
+			"[member:%v] insufficient funds to cover the estimated cost "+
+				"of DKG result submission; leaving the submission to "+
+				"other members",
+			memberIndex,
+		)
+		return nil
+	}
+
 	return drs.chain.SubmitDKGResult(dkgResult)
 }
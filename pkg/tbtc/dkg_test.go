@@ -326,6 +326,7 @@ func TestDkgExecutor_ExecuteDkgValidation(t *testing.T) {
 				operatorAddress: operatorAddress,
 				chain:           localChain,
 				waitForBlockFn:  testWaitForBlockFn(localChain),
+				localResults:    newLocalDkgResults(),
 			}
 
 			eventChan := make(chan interface{}, 1)
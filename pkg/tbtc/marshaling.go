@@ -125,6 +125,53 @@ func (sdm *signingDoneMessage) Unmarshal(bytes []byte) error {
 	return nil
 }
 
+// Marshal converts the signingBlameMessage to a byte array.
+func (sbm *signingBlameMessage) Marshal() ([]byte, error) {
+	culprits := make([]uint32, len(sbm.culprits))
+	for i, culprit := range sbm.culprits {
+		culprits[i] = uint32(culprit)
+	}
+
+	return proto.Marshal(&pb.SigningBlameMessage{
+		SenderID:      uint32(sbm.senderID),
+		Message:       sbm.message.Bytes(),
+		AttemptNumber: sbm.attemptNumber,
+		Round:         sbm.round,
+		Culprits:      culprits,
+		Evidence:      sbm.evidence,
+	})
+}
+
+// Unmarshal converts a byte array back to the signingBlameMessage.
+func (sbm *signingBlameMessage) Unmarshal(bytes []byte) error {
+	pbMsg := pb.SigningBlameMessage{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return fmt.Errorf("failed to unmarshal SigningBlameMessage: [%v]", err)
+	}
+
+	if err := validateMemberIndex(pbMsg.SenderID); err != nil {
+		return err
+	}
+
+	culprits := make([]group.MemberIndex, len(pbMsg.Culprits))
+	for i, culprit := range pbMsg.Culprits {
+		if err := validateMemberIndex(culprit); err != nil {
+			return err
+		}
+
+		culprits[i] = group.MemberIndex(culprit)
+	}
+
+	sbm.senderID = group.MemberIndex(pbMsg.SenderID)
+	sbm.message = new(big.Int).SetBytes(pbMsg.Message)
+	sbm.attemptNumber = pbMsg.AttemptNumber
+	sbm.round = pbMsg.Round
+	sbm.culprits = culprits
+	sbm.evidence = pbMsg.Evidence
+
+	return nil
+}
+
 // marshalPublicKey converts an ECDSA public key to a byte
 // array (uncompressed).
 func marshalPublicKey(publicKey *ecdsa.PublicKey) ([]byte, error) {
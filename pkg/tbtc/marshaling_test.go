@@ -102,3 +102,63 @@ func TestFuzzSigningDoneMessage_MarshalingRoundtrip(t *testing.T) {
 func TestFuzzSigningDoneMessage_Unmarshaler(t *testing.T) {
 	pbutils.FuzzUnmarshaler(&signingDoneMessage{})
 }
+
+func TestSigningBlameMessage_MarshalingRoundtrip(t *testing.T) {
+	msg := &signingBlameMessage{
+		senderID:      group.MemberIndex(10),
+		message:       big.NewInt(100),
+		attemptNumber: 2,
+		round:         3,
+		culprits:      []group.MemberIndex{4, 7},
+		evidence:      "cannot update using TSS round three message",
+	}
+	unmarshaled := &signingBlameMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzSigningBlameMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID      group.MemberIndex
+			message       big.Int
+			attemptNumber uint64
+			round         uint32
+			culprits      []group.MemberIndex
+			evidence      string
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&message)
+		f.Fuzz(&attemptNumber)
+		f.Fuzz(&round)
+		f.Fuzz(&culprits)
+		f.Fuzz(&evidence)
+
+		blameMessage := &signingBlameMessage{
+			senderID:      senderID,
+			message:       &message,
+			attemptNumber: attemptNumber,
+			round:         round,
+			culprits:      culprits,
+			evidence:      evidence,
+		}
+
+		_ = pbutils.RoundTrip(blameMessage, &signingBlameMessage{})
+	}
+}
+
+func TestFuzzSigningBlameMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&signingBlameMessage{})
+}
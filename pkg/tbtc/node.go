@@ -48,6 +48,9 @@ type node struct {
 	walletRegistry *walletRegistry
 	protocolLatch  *generator.ProtocolLatch
 
+	// config is the configuration of the tBTC protocol.
+	config Config
+
 	dkgExecutor *dkgExecutor
 
 	signingExecutorsMutex sync.Mutex
@@ -57,6 +60,7 @@ type node struct {
 }
 
 func newNode(
+	ctx context.Context,
 	groupParameters *GroupParameters,
 	chain Chain,
 	netProvider net.Provider,
@@ -76,6 +80,7 @@ func newNode(
 		netProvider:      netProvider,
 		walletRegistry:   walletRegistry,
 		protocolLatch:    latch,
+		config:           config,
 		signingExecutors: make(map[string]*signingExecutor),
 	}
 
@@ -90,6 +95,7 @@ func newNode(
 	// TODO: This chicken and egg problem should be solved when
 	// waitForBlockHeight becomes a part of BlockHeightWaiter interface.
 	node.dkgExecutor = newDkgExecutor(
+		ctx,
 		node.groupParameters,
 		node.operatorID,
 		operatorAddress,
@@ -148,6 +154,13 @@ func (n *node) joinDKGIfEligible(seed *big.Int, startBlock uint64) {
 	n.dkgExecutor.executeDkgIfEligible(seed, startBlock)
 }
 
+// resumeInterruptedDKGs rejoins DKG executions interrupted by a restart of
+// the client, if the DKG is still in progress. The notifyDKGStarted function
+// is called for the seed of each rejoined DKG.
+func (n *node) resumeInterruptedDKGs(notifyDKGStarted func(seed *big.Int) bool) {
+	n.dkgExecutor.resumeInterruptedDkgs(notifyDKGStarted)
+}
+
 // validateDKG performs the submitted DKG result validation process.
 // If the result is not valid, this function submits an on-chain result
 // challenge. If the result is valid and the given node was involved in the DKG,
@@ -247,6 +260,7 @@ func (n *node) getSigningExecutor(
 		n.protocolLatch,
 		blockCounter.CurrentBlock,
 		n.waitForBlockHeight,
+		newBlockScale(n.chain.AverageBlockTime()),
 		signingAttemptsLimit,
 	)
 
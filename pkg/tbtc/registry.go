@@ -5,9 +5,11 @@ import (
 	"crypto/elliptic"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/chain"
 )
 
 // walletRegistry is the component that holds the data of the wallets managed
@@ -82,6 +84,32 @@ func (wr *walletRegistry) getSigners(
 	return wr.walletCache[getWalletStorageKey(walletPublicKey)]
 }
 
+// IsSigningGroupOperator returns true if the given operator is a member of
+// the signing group of any wallet held by the walletRegistry.
+func (wr *walletRegistry) IsSigningGroupOperator(operator chain.Address) bool {
+	wr.mutex.Lock()
+	defer wr.mutex.Unlock()
+
+	for _, signers := range wr.walletCache {
+		// All signers of the given wallet share the same signing group
+		// so it is enough to check the first one.
+		if len(signers) == 0 {
+			continue
+		}
+
+		for _, signingGroupOperator := range signers[0].wallet.signingGroupOperators {
+			if strings.EqualFold(
+				signingGroupOperator.String(),
+				operator.String(),
+			) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // walletStorage is the component that persists data of the wallets managed
 // by the given node using the underlying persistence layer. It should be
 // used directly only by the walletRegistry.
@@ -1,10 +1,13 @@
 package tbtc
 
 import (
+	"fmt"
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/internal/testutils"
 )
 
@@ -74,6 +77,48 @@ func TestWalletRegistry_GetSigners(t *testing.T) {
 	}
 }
 
+func TestWalletRegistry_IsSigningGroupOperator(t *testing.T) {
+	persistenceHandle := &mockPersistenceHandle{}
+
+	walletRegistry := newWalletRegistry(persistenceHandle)
+
+	signer := createMockSigner(t)
+
+	err := walletRegistry.registerSigner(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		operator       chain.Address
+		expectedResult bool
+	}{
+		"signing group operator": {
+			operator:       "address-2",
+			expectedResult: true,
+		},
+		"signing group operator with different case": {
+			operator:       "ADDRESS-5",
+			expectedResult: true,
+		},
+		"not a signing group operator": {
+			operator:       "address-4",
+			expectedResult: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testutils.AssertBoolsEqual(
+				t,
+				"signing group operator",
+				test.expectedResult,
+				walletRegistry.IsSigningGroupOperator(test.operator),
+			)
+		})
+	}
+}
+
 func TestWalletRegistry_PrePopulateWalletCache(t *testing.T) {
 	signer := createMockSigner(t)
 	signerBytes, err := signer.Marshal()
@@ -178,6 +223,7 @@ func TestWalletStorage_LoadSigners(t *testing.T) {
 }
 
 type mockPersistenceHandle struct {
+	mutex sync.Mutex
 	saved []persistence.DataDescriptor
 }
 
@@ -186,6 +232,22 @@ func (mph *mockPersistenceHandle) Save(
 	directory string,
 	name string,
 ) error {
+	mph.mutex.Lock()
+	defer mph.mutex.Unlock()
+
+	// Saving a file with the same name overwrites it, just like in the
+	// disk persistence.
+	for i, descriptor := range mph.saved {
+		if descriptor.Directory() == directory && descriptor.Name() == name {
+			mph.saved[i] = &mockDescriptor{
+				name:      name,
+				directory: directory,
+				content:   data,
+			}
+			return nil
+		}
+	}
+
 	mph.saved = append(mph.saved, &mockDescriptor{
 		name:      name,
 		directory: directory,
@@ -207,6 +269,9 @@ func (mph *mockPersistenceHandle) ReadAll() (
 	<-chan persistence.DataDescriptor,
 	<-chan error,
 ) {
+	mph.mutex.Lock()
+	defer mph.mutex.Unlock()
+
 	outputData := make(chan persistence.DataDescriptor, len(mph.saved))
 	outputErrors := make(chan error)
 
@@ -225,7 +290,17 @@ func (mph *mockPersistenceHandle) Archive(directory string) error {
 }
 
 func (mph *mockPersistenceHandle) Delete(directory string, name string) error {
-	panic("not implemented")
+	mph.mutex.Lock()
+	defer mph.mutex.Unlock()
+
+	for i, descriptor := range mph.saved {
+		if descriptor.Directory() == directory && descriptor.Name() == name {
+			mph.saved = append(mph.saved[:i], mph.saved[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("file [%v] in directory [%v] not found", name, directory)
 }
 
 type mockDescriptor struct {
@@ -32,6 +32,12 @@ const (
 	// completed by the slowest signing group member (the one who sends the
 	// signingDoneMessage as the last one).
 	signingBatchInterludeBlocks = 2
+	// signingBatchLanesCount determines the maximum number of messages from
+	// a signing batch that are signed concurrently, in separate signing lanes.
+	// Assignment of messages to lanes determines start blocks of signings so
+	// it must be common for all signing group members and cannot be
+	// configured per node.
+	signingBatchLanesCount = 10
 )
 
 // errSigningExecutorBusy is an error returned when the signing executor
@@ -53,11 +59,17 @@ type signingExecutor struct {
 	currentBlockFn func() (uint64, error)
 	// waitForBlockFn is a function used to wait for the given block.
 	waitForBlockFn waitForBlockFn
+	// blockScale scales the signing block durations to the block time of
+	// the chain.
+	blockScale blockScale
 
 	// signingAttemptsLimit determines the maximum attempts count that will
 	// be made by a single signer for the given message. Once the attempts
 	// limit is hit the signer gives up.
 	signingAttemptsLimit uint
+	// signingLanesCount determines the maximum number of messages from
+	// a signing batch that are signed concurrently.
+	signingLanesCount int
 }
 
 func newSigningExecutor(
@@ -68,6 +80,7 @@ func newSigningExecutor(
 	protocolLatch *generator.ProtocolLatch,
 	currentBlockFn func() (uint64, error),
 	waitForBlockFn waitForBlockFn,
+	blockScale blockScale,
 	signingAttemptsLimit uint,
 ) *signingExecutor {
 	return &signingExecutor{
@@ -79,21 +92,36 @@ func newSigningExecutor(
 		protocolLatch:        protocolLatch,
 		currentBlockFn:       currentBlockFn,
 		waitForBlockFn:       waitForBlockFn,
+		blockScale:           blockScale,
 		signingAttemptsLimit: signingAttemptsLimit,
+		signingLanesCount:    signingBatchLanesCount,
 	}
 }
 
 // signBatch performs the signing process for each message from the given
-// messages batch, one after another. If at least one message cannot be signed,
-// this function returns an error. If all messages were signed successfully,
-// a slice of signatures is returned. Order of the returned signatures matches
-// the order of the messages in the batch, i.e. the first signature corresponds
-// to the first message, and so on.
+// messages batch. Messages are signed concurrently, by at most
+// signingLanesCount protocol instances multiplexed on the wallet's broadcast
+// channel. If at least one message cannot be signed, this function returns
+// an error. If all messages were signed successfully, a slice of signatures is
+// returned. Order of the returned signatures matches the order of the messages
+// in the batch, i.e. the first signature corresponds to the first message,
+// and so on.
+//
+// Messages are assigned to signing lanes in a round-robin manner and messages
+// of the same lane are signed one after another. That means the i-th message
+// is signed right after the (i-signingLanesCount)-th message. The start block
+// of each signing is determined based on the end block of the preceding
+// signing in the lane which is common for all signers.
 func (se *signingExecutor) signBatch(
 	ctx context.Context,
 	messages []*big.Int,
 	startBlock uint64,
 ) ([]*tecdsa.Signature, error) {
+	if lockAcquired := se.lock.TryAcquire(1); !lockAcquired {
+		return nil, errSigningExecutorBusy
+	}
+	defer se.lock.Release(1)
+
 	wallet := se.wallet()
 
 	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
@@ -102,7 +130,18 @@ func (se *signingExecutor) signBatch(
 	}
 
 	messagesDigests := make([]string, len(messages))
+	messagesSet := make(map[string]bool, len(messages))
 	for i, message := range messages {
+		// Signing sessions are identified by messages so concurrent signing
+		// of the same message would mix up messages of both sessions.
+		if messagesSet[message.Text(16)] {
+			return nil, fmt.Errorf(
+				"message [0x%x] is duplicated in the batch",
+				message,
+			)
+		}
+		messagesSet[message.Text(16)] = true
+
 		bytes := message.Bytes()
 
 		// Real-world messages are usually 32-byte however, test ones can be
@@ -129,35 +168,78 @@ func (se *signingExecutor) signBatch(
 		zap.String("messages", strings.Join(messagesDigests, ", ")),
 	)
 
-	signingStartBlock := startBlock // start block for the first signing
+	lanesCount := se.signingLanesCount
+	if lanesCount > len(messages) {
+		lanesCount = len(messages)
+	}
+
+	signingBatchLogger.Infof(
+		"signing [%v] messages using [%v] concurrent signing lanes",
+		len(messages),
+		lanesCount,
+	)
+
 	signatures := make([]*tecdsa.Signature, len(messages))
-	endBlocks := make([]uint64, len(messages))
 
-	for i, message := range messages {
-		signingBatchMessageLogger := signingBatchLogger.With(
-			zap.String("message", fmt.Sprintf("0x%x", message)),
-			zap.String("index", fmt.Sprintf("%v/%v", i+1, len(messages))),
-		)
+	// Cancel signing of all lanes once signing of any message fails as
+	// the batch cannot be signed anyway. Only the first error is returned
+	// as errors of other lanes are most likely caused by the cancellation.
+	batchCtx, cancelBatchCtx := context.WithCancel(ctx)
+	defer cancelBatchCtx()
 
-		signingBatchMessageLogger.Infof("generating signature for message")
+	var batchErr error
+	batchErrOnce := sync.Once{}
 
-		if i > 0 {
-			signingStartBlock = endBlocks[i-1] + signingBatchInterludeBlocks
-		}
+	wg := sync.WaitGroup{}
+	wg.Add(lanesCount)
 
-		signature, endBlock, err := se.sign(ctx, message, signingStartBlock)
-		if err != nil {
-			return nil, err
-		}
+	for lane := 0; lane < lanesCount; lane++ {
+		go func(lane int) {
+			defer wg.Done()
+
+			signingStartBlock := startBlock // start block for the first signing
+
+			for i := lane; i < len(messages); i += lanesCount {
+				message := messages[i]
+
+				signingBatchMessageLogger := signingBatchLogger.With(
+					zap.String("message", fmt.Sprintf("0x%x", message)),
+					zap.String("index", fmt.Sprintf("%v/%v", i+1, len(messages))),
+					zap.Int("lane", lane),
+				)
 
-		signingBatchMessageLogger.Infof(
-			"generated signature [%v] for message at block [%v]",
-			signature,
-			endBlock,
-		)
+				signingBatchMessageLogger.Infof("generating signature for message")
 
-		signatures[i] = signature
-		endBlocks[i] = endBlock
+				signature, endBlock, err := se.signMessage(
+					batchCtx,
+					message,
+					signingStartBlock,
+				)
+				if err != nil {
+					batchErrOnce.Do(func() {
+						batchErr = err
+						cancelBatchCtx()
+					})
+					return
+				}
+
+				signingBatchMessageLogger.Infof(
+					"generated signature [%v] for message at block [%v]",
+					signature,
+					endBlock,
+				)
+
+				signatures[i] = signature
+				signingStartBlock = endBlock +
+					se.blockScale.blocks(signingBatchInterludeBlocks)
+			}
+		}(lane)
+	}
+
+	wg.Wait()
+
+	if batchErr != nil {
+		return nil, batchErr
 	}
 
 	return signatures, nil
@@ -179,6 +261,17 @@ func (se *signingExecutor) sign(
 	}
 	defer se.lock.Release(1)
 
+	return se.signMessage(ctx, message, startBlock)
+}
+
+// signMessage performs the signing process for the given message just as
+// sign does. The caller is responsible for holding the signing executor's
+// lock.
+func (se *signingExecutor) signMessage(
+	ctx context.Context,
+	message *big.Int,
+	startBlock uint64,
+) (*tecdsa.Signature, uint64, error) {
 	wallet := se.wallet()
 
 	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
@@ -187,7 +280,7 @@ func (se *signingExecutor) sign(
 	}
 
 	loopTimeoutBlock := startBlock +
-		uint64(se.signingAttemptsLimit*signingAttemptMaximumBlocks())
+		uint64(se.signingAttemptsLimit)*signingAttemptMaximumBlocks(se.blockScale)
 
 	signingLogger := logger.With(
 		zap.String("wallet", fmt.Sprintf("0x%x", walletPublicKeyBytes)),
@@ -224,6 +317,14 @@ func (se *signingExecutor) sign(
 				se.membershipValidator,
 			)
 
+			blameExchange := newSigningBlameExchange(
+				se.broadcastChannel,
+				se.membershipValidator,
+				wallet.groupDishonestThreshold(
+					se.groupParameters.HonestThreshold,
+				),
+			)
+
 			retryLoop := newSigningRetryLoop(
 				signingLogger,
 				message,
@@ -233,6 +334,8 @@ func (se *signingExecutor) sign(
 				se.groupParameters,
 				announcer,
 				doneCheck,
+				blameExchange,
+				se.blockScale,
 			)
 
 			// Set up the loop timeout signal. This context is associated with
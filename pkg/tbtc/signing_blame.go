@@ -0,0 +1,254 @@
+package tbtc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa/signing"
+	"golang.org/x/exp/slices"
+)
+
+// signingBlameReceiveBuffer is a buffer for messages received from the
+// broadcast channel needed when the signing blame's consumer is temporarily
+// too slow to handle them. Just as for the signing done check, the buffer
+// must be big enough to hold retransmissions of signing protocol messages
+// before they are filtered out as not interesting for the blame exchange.
+const signingBlameReceiveBuffer = 512
+
+// signingBlameMessage is a message used to accuse a signing group member of
+// misbehavior identified during a failed signing attempt. The message holds
+// the evidence of the misbehavior as observed by the sender.
+type signingBlameMessage struct {
+	senderID      group.MemberIndex
+	message       *big.Int
+	attemptNumber uint64
+	round         uint32
+	culprits      []group.MemberIndex
+	evidence      string
+}
+
+func (sbm *signingBlameMessage) Type() string {
+	return "tbtc/signing_blame_message"
+}
+
+// signingBlameExchange is a component that is responsible for exchanging
+// evidence of misbehavior identified during failed signing attempts across
+// all signing group members. The exchange accumulates members blamed during
+// all attempts of the signing retry loop so they can be excluded first while
+// selecting members for subsequent attempts.
+//
+// The evidence is based on messages received by the accuser and other members
+// cannot verify it on their own. That is why a single accusation is never
+// enough to blame a member. A member is blamed only if it was accused during
+// the given attempt by more members than the dishonest threshold so at least
+// one of the accusers is honest. Misbehavior revealed by broadcast messages
+// is observed by all attempt members so honest members accuse the culprit
+// unanimously. Misbehavior observed by a single member, for example in
+// a message sent only to that member, is not enough to blame the culprit.
+type signingBlameExchange struct {
+	broadcastChannel    net.BroadcastChannel
+	membershipValidator *group.MembershipValidator
+	dishonestThreshold  int
+
+	accusationsMutex sync.Mutex
+	// accusations holds the culprits accused by the given members, grouped
+	// by the attempt number.
+	accusations map[uint64]map[group.MemberIndex]group.MemberIndex
+	// blamed holds the members blamed during all attempts so far.
+	blamed map[group.MemberIndex]bool
+}
+
+func newSigningBlameExchange(
+	broadcastChannel net.BroadcastChannel,
+	membershipValidator *group.MembershipValidator,
+	dishonestThreshold int,
+) *signingBlameExchange {
+	broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &signingBlameMessage{}
+	})
+
+	return &signingBlameExchange{
+		broadcastChannel:    broadcastChannel,
+		membershipValidator: membershipValidator,
+		dishonestThreshold:  dishonestThreshold,
+		accusations:         make(map[uint64]map[group.MemberIndex]group.MemberIndex),
+		blamed:              make(map[group.MemberIndex]bool),
+	}
+}
+
+// listen runs the signing blame listening routine. This function listens for
+// accusations sent by members participating in the given signing attempt
+// until the passed context is done. Accusations are taken into account only
+// if they point members who participated in the attempt as well. Only one
+// accusation for the given attempt can be sent by the given signing group
+// member. The accused member is blamed once the number of its accusers
+// exceeds the dishonest threshold. This function should be called before the
+// signing attempt starts to ensure accusations are getting received as early
+// as possible.
+func (sbe *signingBlameExchange) listen(
+	ctx context.Context,
+	message *big.Int,
+	attemptNumber uint64,
+	attemptMembersIndexes []group.MemberIndex,
+) {
+	messagesChan := make(chan net.Message, signingBlameReceiveBuffer)
+	sbe.broadcastChannel.Recv(ctx, func(message net.Message) {
+		messagesChan <- message
+	})
+
+	go func() {
+		for {
+			select {
+			case netMessage := <-messagesChan:
+				blameMessage, ok := netMessage.Payload().(*signingBlameMessage)
+				if !ok {
+					continue
+				}
+
+				sbe.accusationsMutex.Lock()
+				if sbe.isValidBlameMessage(
+					blameMessage,
+					netMessage.SenderPublicKey(),
+					message,
+					attemptNumber,
+					attemptMembersIndexes,
+				) {
+					sbe.accuse(
+						attemptNumber,
+						blameMessage.senderID,
+						blameMessage.culprits[0],
+					)
+				}
+				sbe.accusationsMutex.Unlock()
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// accuse records the accusation of the given culprit sent by the given
+// accuser during the given attempt. The culprit is blamed if the number of
+// its accusers during the attempt exceeds the dishonest threshold. The caller
+// is responsible for holding the accusations mutex.
+func (sbe *signingBlameExchange) accuse(
+	attemptNumber uint64,
+	accuser group.MemberIndex,
+	culprit group.MemberIndex,
+) {
+	if _, ok := sbe.accusations[attemptNumber]; !ok {
+		sbe.accusations[attemptNumber] = make(
+			map[group.MemberIndex]group.MemberIndex,
+		)
+	}
+	sbe.accusations[attemptNumber][accuser] = culprit
+
+	accusersCount := 0
+	for _, accused := range sbe.accusations[attemptNumber] {
+		if accused == culprit {
+			accusersCount++
+		}
+	}
+
+	if accusersCount > sbe.dishonestThreshold {
+		sbe.blamed[culprit] = true
+	}
+}
+
+// blame broadcasts an accusation of a member identified as misbehaving
+// during the given signing attempt, along with the evidence of its
+// misbehavior. If many members were identified as misbehaving, the one with
+// the lowest index is accused so honest members observing the same
+// misbehavior accuse the same member.
+func (sbe *signingBlameExchange) blame(
+	ctx context.Context,
+	memberIndex group.MemberIndex,
+	message *big.Int,
+	attemptNumber uint64,
+	misbehavior *signing.MisbehaviorError,
+) error {
+	if len(misbehavior.Culprits) == 0 {
+		return fmt.Errorf("misbehavior does not point any culprits")
+	}
+
+	return sbe.broadcastChannel.Send(ctx, &signingBlameMessage{
+		senderID:      memberIndex,
+		message:       message,
+		attemptNumber: attemptNumber,
+		round:         uint32(misbehavior.Round),
+		culprits:      misbehavior.Culprits[:1],
+		evidence:      misbehavior.Evidence,
+	}, net.BackoffRetransmissionStrategy)
+}
+
+// blamedMembers returns the members blamed during all signing attempts so
+// far, sorted in ascending order.
+func (sbe *signingBlameExchange) blamedMembers() []group.MemberIndex {
+	sbe.accusationsMutex.Lock()
+	defer sbe.accusationsMutex.Unlock()
+
+	blamedMembers := make([]group.MemberIndex, 0, len(sbe.blamed))
+	for memberIndex := range sbe.blamed {
+		blamedMembers = append(blamedMembers, memberIndex)
+	}
+
+	sort.Slice(blamedMembers, func(i, j int) bool {
+		return blamedMembers[i] < blamedMembers[j]
+	})
+
+	return blamedMembers
+}
+
+// isValidBlameMessage validates the given signingBlameMessage in the context
+// of the given signing attempt.
+func (sbe *signingBlameExchange) isValidBlameMessage(
+	blameMessage *signingBlameMessage,
+	senderPublicKey []byte,
+	message *big.Int,
+	attemptNumber uint64,
+	attemptMembersIndexes []group.MemberIndex,
+) bool {
+	if _, ok := sbe.accusations[attemptNumber][blameMessage.senderID]; ok {
+		// only one blame message allowed
+		return false
+	}
+
+	if !sbe.membershipValidator.IsValidMembership(
+		blameMessage.senderID,
+		senderPublicKey,
+	) {
+		return false
+	}
+
+	if blameMessage.message.Cmp(message) != 0 {
+		return false
+	}
+
+	if blameMessage.attemptNumber != attemptNumber {
+		return false
+	}
+
+	if !slices.Contains(attemptMembersIndexes, blameMessage.senderID) {
+		return false
+	}
+
+	if len(blameMessage.culprits) != 1 {
+		return false
+	}
+
+	// Members cannot blame themselves nor members who did not take part in
+	// the attempt.
+	culprit := blameMessage.culprits[0]
+	if culprit == blameMessage.senderID ||
+		!slices.Contains(attemptMembersIndexes, culprit) {
+		return false
+	}
+
+	return true
+}
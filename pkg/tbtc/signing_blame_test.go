@@ -0,0 +1,189 @@
+package tbtc
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa/signing"
+)
+
+func TestSigningBlameExchange(t *testing.T) {
+	// With the dishonest threshold of 1, a member is blamed once accused
+	// by two members.
+	blameExchange := setupSigningBlameExchange(t, 5, 1)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	message := big.NewInt(100)
+
+	type accusation struct {
+		senderID      group.MemberIndex
+		message       *big.Int
+		attemptNumber uint64
+		culprits      []group.MemberIndex
+	}
+
+	attempts := []struct {
+		attemptNumber         uint64
+		attemptMembersIndexes []group.MemberIndex
+		accusations           []accusation
+		expectedBlamedMembers []group.MemberIndex
+	}{
+		{
+			attemptNumber:         1,
+			attemptMembersIndexes: []group.MemberIndex{1, 2, 3, 4},
+			accusations: []accusation{
+				// valid accusation; a single accusation is not enough
+				// to blame the accused member
+				{1, message, 1, []group.MemberIndex{3}},
+				// second accusation sent by the same member is ignored
+				{1, message, 1, []group.MemberIndex{2}},
+				// sender not participating in the attempt
+				{5, message, 1, []group.MemberIndex{2}},
+				// self-accusation
+				{2, message, 1, []group.MemberIndex{2}},
+				// culprit not participating in the attempt
+				{2, message, 1, []group.MemberIndex{5}},
+				// another message
+				{2, big.NewInt(200), 1, []group.MemberIndex{3}},
+				// another attempt
+				{2, message, 2, []group.MemberIndex{3}},
+				// valid accusation; the member is accused by two members
+				// and is blamed; accusers are not blamed
+				{4, message, 1, []group.MemberIndex{3}},
+			},
+			expectedBlamedMembers: []group.MemberIndex{3},
+		},
+		{
+			attemptNumber:         2,
+			attemptMembersIndexes: []group.MemberIndex{2, 4, 5},
+			accusations: []accusation{
+				// only the lowest culprit is accused
+				{4, message, 2, []group.MemberIndex{2, 5}},
+			},
+			// Members blamed during the previous attempt are still blamed.
+			expectedBlamedMembers: []group.MemberIndex{3},
+		},
+		{
+			attemptNumber:         3,
+			attemptMembersIndexes: []group.MemberIndex{2, 4, 5},
+			accusations: []accusation{
+				// accusations sent during different attempts do not add up
+				{5, message, 3, []group.MemberIndex{2}},
+			},
+			expectedBlamedMembers: []group.MemberIndex{3},
+		},
+		{
+			attemptNumber:         4,
+			attemptMembersIndexes: []group.MemberIndex{2, 4, 5},
+			accusations: []accusation{
+				{4, message, 4, []group.MemberIndex{2}},
+				{5, message, 4, []group.MemberIndex{2}},
+			},
+			expectedBlamedMembers: []group.MemberIndex{2, 3},
+		},
+	}
+
+	for _, attempt := range attempts {
+		attemptCtx, cancelAttemptCtx := context.WithCancel(ctx)
+
+		blameExchange.listen(
+			attemptCtx,
+			message,
+			attempt.attemptNumber,
+			attempt.attemptMembersIndexes,
+		)
+
+		for _, accusation := range attempt.accusations {
+			err := blameExchange.blame(
+				attemptCtx,
+				accusation.senderID,
+				accusation.message,
+				accusation.attemptNumber,
+				&signing.MisbehaviorError{
+					Round:    3,
+					Culprits: accusation.culprits,
+					Evidence: "invalid message",
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// Give the listening routine some time to process all accusations.
+		time.Sleep(1 * time.Second)
+
+		cancelAttemptCtx()
+
+		blamedMembers := blameExchange.blamedMembers()
+		if !reflect.DeepEqual(attempt.expectedBlamedMembers, blamedMembers) {
+			t.Errorf(
+				"unexpected blamed members after attempt [%v]\n"+
+					"expected: [%v]\n"+
+					"actual:   [%v]",
+				attempt.attemptNumber,
+				attempt.expectedBlamedMembers,
+				blamedMembers,
+			)
+		}
+	}
+}
+
+// setupSigningBlameExchange sets up an instance of the signing blame exchange
+// ready to perform test checks.
+func setupSigningBlameExchange(
+	t *testing.T,
+	groupSize int,
+	dishonestThreshold int,
+) *signingBlameExchange {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair(
+		local_v1.DefaultCurve,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localChain := ConnectWithKey(operatorPrivateKey)
+
+	localProvider := local.ConnectWithKey(operatorPublicKey)
+
+	operatorAddress, err := localChain.Signing().PublicKeyToAddress(
+		operatorPublicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var operators []chain.Address
+	for i := 0; i < groupSize; i++ {
+		operators = append(operators, operatorAddress)
+	}
+
+	broadcastChannel, err := localProvider.BroadcastChannelFor("channel")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	membershipValidator := group.NewMembershipValidator(
+		&testutils.MockLogger{},
+		operators,
+		localChain.Signing(),
+	)
+
+	return newSigningBlameExchange(
+		broadcastChannel,
+		membershipValidator,
+		dishonestThreshold,
+	)
+}
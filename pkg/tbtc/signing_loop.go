@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
@@ -35,12 +36,12 @@ const (
 )
 
 // signingAttemptMaximumBlocks returns the maximum block duration of a single
-// signing attempt.
-func signingAttemptMaximumBlocks() uint {
-	return signingAttemptAnnouncementDelayBlocks +
-		signingAttemptAnnouncementActiveBlocks +
-		signingAttemptMaximumProtocolBlocks +
-		signingAttemptCoolDownBlocks
+// signing attempt, scaled using the given block scale.
+func signingAttemptMaximumBlocks(blockScale blockScale) uint64 {
+	return blockScale.blocks(signingAttemptAnnouncementDelayBlocks) +
+		blockScale.blocks(signingAttemptAnnouncementActiveBlocks) +
+		blockScale.blocks(signingAttemptMaximumProtocolBlocks) +
+		blockScale.blocks(signingAttemptCoolDownBlocks)
 }
 
 // signingAnnouncer represents a component responsible for exchanging readiness
@@ -76,6 +77,28 @@ type signingDoneCheckStrategy interface {
 	waitUntilAllDone(ctx context.Context) (*signing.Result, uint64, error)
 }
 
+// signingBlameStrategy is a strategy that determines the way of exchanging
+// evidence of misbehavior identified during failed signing attempts across
+// all signing group members.
+type signingBlameStrategy interface {
+	listen(
+		ctx context.Context,
+		message *big.Int,
+		attemptNumber uint64,
+		attemptMembersIndexes []group.MemberIndex,
+	)
+
+	blame(
+		ctx context.Context,
+		memberIndex group.MemberIndex,
+		message *big.Int,
+		attemptNumber uint64,
+		misbehavior *signing.MisbehaviorError,
+	) error
+
+	blamedMembers() []group.MemberIndex
+}
+
 // signingRetryLoop is a struct that encapsulates the signing retry logic.
 type signingRetryLoop struct {
 	logger log.StandardLogger
@@ -94,6 +117,12 @@ type signingRetryLoop struct {
 	attemptSeed       int64
 
 	doneCheck signingDoneCheckStrategy
+
+	blameExchange signingBlameStrategy
+
+	// blockScale scales the attempt phases block durations to the block
+	// time of the chain.
+	blockScale blockScale
 }
 
 func newSigningRetryLoop(
@@ -105,6 +134,8 @@ func newSigningRetryLoop(
 	groupParameters *GroupParameters,
 	announcer signingAnnouncer,
 	doneCheck signingDoneCheckStrategy,
+	blameExchange signingBlameStrategy,
+	blockScale blockScale,
 ) *signingRetryLoop {
 	// Compute the 8-byte seed needed for the random retry algorithm. We take
 	// the first 8 bytes of the hash of the signed message. This allows us to
@@ -124,6 +155,8 @@ func newSigningRetryLoop(
 		attemptStartBlock:       initialStartBlock,
 		attemptSeed:             attemptSeed,
 		doneCheck:               doneCheck,
+		blameExchange:           blameExchange,
+		blockScale:              blockScale,
 	}
 }
 
@@ -181,7 +214,7 @@ func (srl *signingRetryLoop) start(
 		// mined in the meantime.
 		if srl.attemptCounter > 1 {
 			srl.attemptStartBlock = srl.attemptStartBlock +
-				uint64(signingAttemptMaximumBlocks())
+				signingAttemptMaximumBlocks(srl.blockScale)
 		}
 
 		srl.logger.Infof(
@@ -190,7 +223,8 @@ func (srl *signingRetryLoop) start(
 			srl.attemptCounter,
 		)
 
-		announcementStartBlock := srl.attemptStartBlock + signingAttemptAnnouncementDelayBlocks
+		announcementStartBlock := srl.attemptStartBlock +
+			srl.blockScale.blocks(signingAttemptAnnouncementDelayBlocks)
 		err := waitForBlockFn(ctx, announcementStartBlock)
 		if err != nil {
 			srl.logger.Errorf(
@@ -205,7 +239,8 @@ func (srl *signingRetryLoop) start(
 		}
 
 		// Set up the announcement phase stop signal.
-		announcementEndBlock := announcementStartBlock + signingAttemptAnnouncementActiveBlocks
+		announcementEndBlock := announcementStartBlock +
+			srl.blockScale.blocks(signingAttemptAnnouncementActiveBlocks)
 		announceCtx, _ := withCancelOnBlock(ctx, announcementEndBlock, waitForBlockFn)
 
 		srl.logger.Infof(
@@ -214,10 +249,29 @@ func (srl *signingRetryLoop) start(
 			srl.attemptCounter,
 		)
 
+		// Accusations may reach members differently so members can have
+		// different views of the blamed members. The view is snapshotted
+		// and bound to the announcement session so members announcing
+		// readiness for the same session share the same view and select the
+		// same signing members for the attempt. The honest threshold is
+		// greater than half of the group size so at most one view can
+		// gather enough ready members. Members whose view differs do not
+		// see enough ready members and skip the attempt.
+		blamedMembersIndexes := srl.blameExchange.blamedMembers()
+
+		sessionID := fmt.Sprintf("%v-%v", srl.message, srl.attemptCounter)
+		if len(blamedMembersIndexes) > 0 {
+			sessionID = fmt.Sprintf(
+				"%v-blamed-%v",
+				sessionID,
+				blamedMembersIndexes,
+			)
+		}
+
 		readyMembersIndexes, err := srl.announcer.Announce(
 			announceCtx,
 			srl.signingGroupMemberIndex,
-			fmt.Sprintf("%v-%v", srl.message, srl.attemptCounter),
+			sessionID,
 		)
 		if err != nil {
 			srl.logger.Warnf(
@@ -257,6 +311,7 @@ func (srl *signingRetryLoop) start(
 
 		excludedMembersIndexes, err := srl.performMembersSelection(
 			readyMembersIndexes,
+			blamedMembersIndexes,
 		)
 		if err != nil {
 			return nil, fmt.Errorf(
@@ -279,7 +334,8 @@ func (srl *signingRetryLoop) start(
 			srl.signingGroupMemberIndex,
 		)
 
-		timeoutBlock := announcementEndBlock + signingAttemptMaximumProtocolBlocks
+		timeoutBlock := announcementEndBlock +
+			srl.blockScale.blocks(signingAttemptMaximumProtocolBlocks)
 
 		// doneCheckTimeoutCtx is active until the timeout even if the protocol
 		// completed successfully earlier. This is needed to ensure all protocol
@@ -294,6 +350,24 @@ func (srl *signingRetryLoop) start(
 			includedMembersIndexes,
 		)
 
+		// Accusations can be sent only until the attempt timeout but they
+		// are received until the end of the cool down period preceding the
+		// next attempt. This margin lets accusations reach most of the
+		// members before the view of the blamed members is snapshotted for
+		// the next attempt announcement.
+		blameCtx, _ := withCancelOnBlock(
+			ctx,
+			timeoutBlock+srl.blockScale.blocks(signingAttemptCoolDownBlocks),
+			waitForBlockFn,
+		)
+
+		srl.blameExchange.listen(
+			blameCtx,
+			srl.message,
+			uint64(srl.attemptCounter),
+			includedMembersIndexes,
+		)
+
 		if !attemptSkipped {
 			srl.logger.Infof(
 				"[member:%v] eligible for attempt [%v]",
@@ -315,6 +389,36 @@ func (srl *signingRetryLoop) start(
 					srl.attemptCounter,
 					err,
 				)
+
+				var misbehaviorErr *signing.MisbehaviorError
+				if errors.As(err, &misbehaviorErr) {
+					srl.logger.Warnf(
+						"[member:%v] blaming members [%v] for misbehavior "+
+							"in round [%v] of attempt [%v]",
+						srl.signingGroupMemberIndex,
+						misbehaviorErr.Culprits,
+						misbehaviorErr.Round,
+						srl.attemptCounter,
+					)
+
+					err := srl.blameExchange.blame(
+						doneCheckTimeoutCtx,
+						srl.signingGroupMemberIndex,
+						srl.message,
+						uint64(srl.attemptCounter),
+						misbehaviorErr,
+					)
+					if err != nil {
+						srl.logger.Warnf(
+							"[member:%v] cannot send signing blame "+
+								"for attempt [%v]: [%v]",
+							srl.signingGroupMemberIndex,
+							srl.attemptCounter,
+							err,
+						)
+					}
+				}
+
 				continue
 			}
 
@@ -373,7 +477,10 @@ func (srl *signingRetryLoop) start(
 
 // performMembersSelection runs the member selection process whose result
 // is a list of members' indexes that should be excluded by the client
-// for the given signing attempt.
+// for the given signing attempt. Operators of the blamed members given as
+// the blamedMembersIndexes argument are excluded first. The blamed members
+// must be the ones bound to the attempt announcement so all ready members
+// perform the selection using the same input.
 //
 // The member selection process is done based on the list of ready members
 // provided as the readyMembersIndexes argument. This list is used twice:
@@ -395,8 +502,12 @@ func (srl *signingRetryLoop) start(
 // qualified operator, we must take the ready members list into account.
 func (srl *signingRetryLoop) performMembersSelection(
 	readyMembersIndexes []group.MemberIndex,
+	blamedMembersIndexes []group.MemberIndex,
 ) ([]group.MemberIndex, error) {
-	qualifiedOperatorsSet, err := srl.qualifiedOperatorsSet(readyMembersIndexes)
+	qualifiedOperatorsSet, err := srl.qualifiedOperatorsSet(
+		readyMembersIndexes,
+		blamedMembersIndexes,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("cannot get qualified operators: [%w]", err)
 	}
@@ -412,25 +523,58 @@ func (srl *signingRetryLoop) performMembersSelection(
 // qualifiedOperatorsSet returns a set of operators qualified to participate
 // in the given signing attempt. The set of qualified operators is taken
 // from the set of active operators who announced readiness through
-// their controlled signing group members.
+// their controlled signing group members. Operators whose members were
+// blamed for misbehavior during previous attempts are not qualified as long
+// as the rest of ready operators control enough seats.
 func (srl *signingRetryLoop) qualifiedOperatorsSet(
 	readyMembersIndexes []group.MemberIndex,
+	blamedMembersIndexes []group.MemberIndex,
 ) (map[chain.Address]bool, error) {
 	// The retry algorithm expects that we count retries from 0. Since
 	// the first invocation of the algorithm will be for `attemptCounter == 1`
 	// we need to subtract one while determining the number of the given retry.
 	retryCount := srl.attemptCounter - 1
 
+	blamedOperators := make(map[chain.Address]bool)
+	for _, memberIndex := range blamedMembersIndexes {
+		blamedOperators[srl.signingGroupOperators[memberIndex-1]] = true
+	}
+
 	var readySigningGroupOperators []chain.Address
+	var unblamedSigningGroupOperators []chain.Address
 	for _, memberIndex := range readyMembersIndexes {
+		operator := srl.signingGroupOperators[memberIndex-1]
+
 		readySigningGroupOperators = append(
 			readySigningGroupOperators,
-			srl.signingGroupOperators[memberIndex-1],
+			operator,
+		)
+
+		if !blamedOperators[operator] {
+			unblamedSigningGroupOperators = append(
+				unblamedSigningGroupOperators,
+				operator,
+			)
+		}
+	}
+
+	// Operators blamed for misbehavior during previous attempts are excluded
+	// first. If the remaining operators do not control enough seats, blames
+	// are ignored and the selection falls back to all ready operators.
+	candidateSigningGroupOperators := readySigningGroupOperators
+	if len(unblamedSigningGroupOperators) >= srl.groupParameters.HonestThreshold {
+		candidateSigningGroupOperators = unblamedSigningGroupOperators
+	} else if len(blamedOperators) > 0 {
+		srl.logger.Warnf(
+			"[member:%v] not enough seats left after excluding "+
+				"blamed operators for attempt [%v]; ignoring blames",
+			srl.signingGroupMemberIndex,
+			srl.attemptCounter,
 		)
 	}
 
 	qualifiedOperators, err := retry.EvaluateRetryParticipantsForSigning(
-		readySigningGroupOperators,
+		candidateSigningGroupOperators,
 		srl.attemptSeed,
 		retryCount,
 		uint(srl.groupParameters.HonestThreshold),
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/protocol/group"
 	"github.com/keep-network/keep-core/pkg/tecdsa"
 	"github.com/keep-network/keep-core/pkg/tecdsa/signing"
+	"golang.org/x/exp/slices"
 )
 
 func TestSigningRetryLoop(t *testing.T) {
@@ -59,6 +62,7 @@ func TestSigningRetryLoop(t *testing.T) {
 		signingAttemptFn            signingAttemptFn
 		waitUntilAllDoneOutcomeFn   func(attemptNumber uint64) (*signing.Result, uint64, error)
 		expectedOutgoingDoneChecks  []*signingDoneMessage
+		expectedOutgoingBlames      []*signingBlameMessage
 		expectedErr                 error
 		expectedResult              *signingRetryLoopResult
 		expectedLastExecutedAttempt *signingAttemptParams
@@ -430,6 +434,76 @@ func TestSigningRetryLoop(t *testing.T) {
 			},
 			outgoingAnnouncementsCount: 2,
 		},
+		"misbehavior error on initial attempt": {
+			signingGroupMemberIndex: 4,
+			ctxFn: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Second)
+			},
+			incomingAnnouncementsFn: func(
+				sessionID string,
+			) ([]group.MemberIndex, error) {
+				return signingGroupMembersIndexes, nil
+			},
+			signingAttemptFn: func(
+				attempt *signingAttemptParams,
+			) (*signing.Result, uint64, error) {
+				if attempt.number <= 1 {
+					return nil, 0, fmt.Errorf(
+						"failed to initiate state: [%w]",
+						&signing.MisbehaviorError{
+							Round:    3,
+							Culprits: []group.MemberIndex{6},
+							Evidence: "invalid data",
+						},
+					)
+				}
+
+				return testResult, 260, nil // an arbitrary end block
+			},
+			waitUntilAllDoneOutcomeFn: func(attemptNumber uint64) (*signing.Result, uint64, error) {
+				// Simulate that the done check phase determines the same
+				// end block as the executing signer.
+				return testResult, 260, nil
+			},
+			expectedOutgoingDoneChecks: []*signingDoneMessage{
+				{
+					senderID:      4,
+					message:       message,
+					attemptNumber: 2,
+					signature:     testResult.Signature,
+					endBlock:      260,
+				},
+			},
+			expectedOutgoingBlames: []*signingBlameMessage{
+				{
+					senderID:      4,
+					message:       message,
+					attemptNumber: 1,
+					round:         3,
+					culprits:      []group.MemberIndex{6},
+					evidence:      "invalid data",
+				},
+			},
+			expectedErr: nil,
+			expectedResult: &signingRetryLoopResult{
+				result:              testResult,
+				latestEndBlock:      260, // the end block resolved by the done check phase
+				attemptTimeoutBlock: 277, // start block of the second attempt + 30
+			},
+			// Member 4 is the executing one. The first attempt fails because
+			// member 6 misbehaved. The signing random retry algorithm invoked
+			// with the test seed is run only for operators not being blamed
+			// and excludes 3 members from the second attempt: 1, 2 and 5.
+			// Member 6 is excluded as blamed. Unlike the case without blame,
+			// member 9 is not excluded as there are just enough members left.
+			expectedLastExecutedAttempt: &signingAttemptParams{
+				number:                 2,
+				startBlock:             247, // 206 + 1 * (6 + 30 + 5)
+				timeoutBlock:           277, // start block of the second attempt + 30
+				excludedMembersIndexes: []group.MemberIndex{1, 2, 5, 6},
+			},
+			outgoingAnnouncementsCount: 2,
+		},
 		"loop context done": {
 			signingGroupMemberIndex: 1,
 			ctxFn: func() (context.Context, context.CancelFunc) {
@@ -465,6 +539,10 @@ func TestSigningRetryLoop(t *testing.T) {
 				waitUntilAllDoneOutcomeFn: test.waitUntilAllDoneOutcomeFn,
 			}
 
+			blameExchange := &mockSigningBlameExchange{
+				blamed: make(map[group.MemberIndex]bool),
+			}
+
 			retryLoop := newSigningRetryLoop(
 				&testutils.MockLogger{},
 				message,
@@ -474,6 +552,8 @@ func TestSigningRetryLoop(t *testing.T) {
 				groupParameters,
 				announcer,
 				doneCheck,
+				blameExchange,
+				blockScale{},
 			)
 
 			ctx, cancelCtx := test.ctxFn()
@@ -556,10 +636,212 @@ func TestSigningRetryLoop(t *testing.T) {
 					doneCheck.outgoingDoneChecks,
 				)
 			}
+
+			if !reflect.DeepEqual(
+				test.expectedOutgoingBlames,
+				blameExchange.outgoingBlames,
+			) {
+				t.Errorf(
+					"unexpected outgoing blames\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedOutgoingBlames,
+					blameExchange.outgoingBlames,
+				)
+			}
 		})
 	}
 }
 
+// TestSigningRetryLoop_DivergentBlameViews checks that members who missed
+// some accusations and have a different view of the blamed members do not
+// take part in the next attempt while all members taking part in the attempt
+// select the same signing members.
+func TestSigningRetryLoop_DivergentBlameViews(t *testing.T) {
+	message := big.NewInt(100)
+
+	groupParameters := &GroupParameters{
+		GroupSize:       10,
+		HonestThreshold: 6,
+	}
+
+	signingGroupOperators := make(chain.Addresses, groupParameters.GroupSize)
+	for i := range signingGroupOperators {
+		signingGroupOperators[i] = chain.Address(fmt.Sprintf("address-%v", i+1))
+	}
+
+	testResult := &signing.Result{
+		Signature: &tecdsa.Signature{
+			R:          big.NewInt(300),
+			S:          big.NewInt(400),
+			RecoveryID: 2,
+		},
+	}
+
+	// Member 10 misbehaved during the first attempt and all members but
+	// member 9 received enough accusations to blame it. Member 9 missed
+	// some of the accusations.
+	blamesReceivedDuringFirstAttempt := func(
+		memberIndex group.MemberIndex,
+	) []group.MemberIndex {
+		if memberIndex == 9 {
+			return nil
+		}
+
+		return []group.MemberIndex{10}
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancelCtx()
+
+	announcementBoard := &mockSigningAnnouncementBoard{
+		ctx:           ctx,
+		membersCount:  groupParameters.GroupSize,
+		announcements: make(map[int]map[group.MemberIndex]string),
+	}
+
+	type memberOutcome struct {
+		result           *signingRetryLoopResult
+		err              error
+		executedAttempts []*signingAttemptParams
+	}
+
+	outcomes := make(map[group.MemberIndex]*memberOutcome)
+
+	wg := sync.WaitGroup{}
+	for i := range signingGroupOperators {
+		memberIndex := group.MemberIndex(i + 1)
+
+		outcome := &memberOutcome{}
+		outcomes[memberIndex] = outcome
+
+		retryLoop := newSigningRetryLoop(
+			&testutils.MockLogger{},
+			message,
+			200,
+			memberIndex,
+			signingGroupOperators,
+			groupParameters,
+			&mockSigningBoardAnnouncer{board: announcementBoard},
+			&mockSigningDoneCheck{
+				waitUntilAllDoneOutcomeFn: func(
+					attemptNumber uint64,
+				) (*signing.Result, uint64, error) {
+					if attemptNumber == 1 {
+						return nil, 0, fmt.Errorf("timeout")
+					}
+
+					return testResult, 260, nil
+				},
+			},
+			&mockSigningBlameExchange{
+				blamed: make(map[group.MemberIndex]bool),
+				incomingBlames: map[uint64][]group.MemberIndex{
+					1: blamesReceivedDuringFirstAttempt(memberIndex),
+				},
+			},
+			blockScale{},
+		)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			outcome.result, outcome.err = retryLoop.start(
+				ctx,
+				func(context.Context, uint64) error {
+					return nil
+				},
+				func(params *signingAttemptParams) (*signing.Result, uint64, error) {
+					outcome.executedAttempts = append(
+						outcome.executedAttempts,
+						params,
+					)
+
+					if params.number == 1 {
+						return nil, 0, fmt.Errorf("invalid data")
+					}
+
+					return testResult, 260, nil
+				},
+			)
+		}()
+	}
+
+	wg.Wait()
+
+	var secondAttemptExcludedMembers []group.MemberIndex
+	secondAttemptSigners := 0
+
+	for memberIndex, outcome := range outcomes {
+		if memberIndex == 9 {
+			// Member 9 does not see enough members ready for the second
+			// attempt and waits for subsequent attempts until the context
+			// is done.
+			if !reflect.DeepEqual(context.DeadlineExceeded, outcome.err) {
+				t.Errorf(
+					"unexpected error of member [%v]\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					memberIndex,
+					context.DeadlineExceeded,
+					outcome.err,
+				)
+			}
+		} else {
+			if outcome.err != nil {
+				t.Errorf(
+					"unexpected error of member [%v]: [%v]",
+					memberIndex,
+					outcome.err,
+				)
+			}
+		}
+
+		for _, attempt := range outcome.executedAttempts {
+			if attempt.number != 2 {
+				continue
+			}
+
+			secondAttemptSigners++
+
+			if secondAttemptExcludedMembers == nil {
+				secondAttemptExcludedMembers = attempt.excludedMembersIndexes
+			}
+
+			if !reflect.DeepEqual(
+				secondAttemptExcludedMembers,
+				attempt.excludedMembersIndexes,
+			) {
+				t.Errorf(
+					"members of the second attempt selected different "+
+						"members\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					secondAttemptExcludedMembers,
+					attempt.excludedMembersIndexes,
+				)
+			}
+		}
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"second attempt signers count",
+		groupParameters.HonestThreshold,
+		secondAttemptSigners,
+	)
+
+	for _, memberIndex := range []group.MemberIndex{9, 10} {
+		if !slices.Contains(secondAttemptExcludedMembers, memberIndex) {
+			t.Errorf(
+				"member [%v] should be excluded from the second attempt",
+				memberIndex,
+			)
+		}
+	}
+}
+
 type mockSigningAnnouncer struct {
 	// outgoingAnnouncements holds all announcements that are sent by the
 	// announcer.
@@ -582,6 +864,81 @@ func (msa *mockSigningAnnouncer) Announce(
 	return msa.incomingAnnouncementsFn(sessionID)
 }
 
+// mockSigningAnnouncementBoard simulates the announcement phase of many
+// signing group members. A member announcing readiness receives announcements
+// of all members who announced readiness for the same session in the same
+// announcement round.
+type mockSigningAnnouncementBoard struct {
+	ctx          context.Context
+	membersCount int
+
+	mutex sync.Mutex
+	// announcements holds session IDs announced by members, grouped by the
+	// announcement round.
+	announcements map[int]map[group.MemberIndex]string
+}
+
+func (msab *mockSigningAnnouncementBoard) announce(
+	round int,
+	memberIndex group.MemberIndex,
+	sessionID string,
+) []group.MemberIndex {
+	msab.mutex.Lock()
+	if _, ok := msab.announcements[round]; !ok {
+		msab.announcements[round] = make(map[group.MemberIndex]string)
+	}
+	msab.announcements[round][memberIndex] = sessionID
+	msab.mutex.Unlock()
+
+	// Wait until all members announce or the board's context is done.
+	for msab.ctx.Err() == nil {
+		msab.mutex.Lock()
+		announcementsCount := len(msab.announcements[round])
+		msab.mutex.Unlock()
+
+		if announcementsCount == msab.membersCount {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	msab.mutex.Lock()
+	defer msab.mutex.Unlock()
+
+	readyMembersIndexes := make([]group.MemberIndex, 0)
+	for readyMemberIndex, readySessionID := range msab.announcements[round] {
+		if readySessionID == sessionID {
+			readyMembersIndexes = append(readyMembersIndexes, readyMemberIndex)
+		}
+	}
+
+	sort.Slice(readyMembersIndexes, func(i, j int) bool {
+		return readyMembersIndexes[i] < readyMembersIndexes[j]
+	})
+
+	return readyMembersIndexes
+}
+
+type mockSigningBoardAnnouncer struct {
+	board              *mockSigningAnnouncementBoard
+	announcementsCount int
+}
+
+func (msba *mockSigningBoardAnnouncer) Announce(
+	ctx context.Context,
+	memberIndex group.MemberIndex,
+	sessionID string,
+) ([]group.MemberIndex, error) {
+	msba.announcementsCount++
+
+	return msba.board.announce(
+		msba.announcementsCount,
+		memberIndex,
+		sessionID,
+	), nil
+}
+
 type mockSigningDoneCheck struct {
 	outgoingDoneChecks        []*signingDoneMessage
 	currentAttemptNumber      uint64
@@ -620,3 +977,59 @@ func (msdc *mockSigningDoneCheck) signalDone(
 func (msdc *mockSigningDoneCheck) waitUntilAllDone(ctx context.Context) (*signing.Result, uint64, error) {
 	return msdc.waitUntilAllDoneOutcomeFn(msdc.currentAttemptNumber)
 }
+
+type mockSigningBlameExchange struct {
+	outgoingBlames []*signingBlameMessage
+	blamed         map[group.MemberIndex]bool
+	// incomingBlames holds members blamed by accusations received during
+	// the given attempt.
+	incomingBlames map[uint64][]group.MemberIndex
+}
+
+func (msbe *mockSigningBlameExchange) listen(
+	ctx context.Context,
+	message *big.Int,
+	attemptNumber uint64,
+	attemptMembersIndexes []group.MemberIndex,
+) {
+	for _, memberIndex := range msbe.incomingBlames[attemptNumber] {
+		msbe.blamed[memberIndex] = true
+	}
+}
+
+func (msbe *mockSigningBlameExchange) blame(
+	ctx context.Context,
+	memberIndex group.MemberIndex,
+	message *big.Int,
+	attemptNumber uint64,
+	misbehavior *signing.MisbehaviorError,
+) error {
+	msbe.outgoingBlames = append(msbe.outgoingBlames, &signingBlameMessage{
+		senderID:      memberIndex,
+		message:       message,
+		attemptNumber: attemptNumber,
+		round:         uint32(misbehavior.Round),
+		culprits:      misbehavior.Culprits,
+		evidence:      misbehavior.Evidence,
+	})
+
+	// Simulate the accusation is received by all members.
+	for _, culprit := range misbehavior.Culprits {
+		msbe.blamed[culprit] = true
+	}
+
+	return nil
+}
+
+func (msbe *mockSigningBlameExchange) blamedMembers() []group.MemberIndex {
+	blamedMembers := make([]group.MemberIndex, 0)
+	for memberIndex := range msbe.blamed {
+		blamedMembers = append(blamedMembers, memberIndex)
+	}
+
+	sort.Slice(blamedMembers, func(i, j int) bool {
+		return blamedMembers[i] < blamedMembers[j]
+	})
+
+	return blamedMembers
+}
@@ -3,6 +3,7 @@ package tbtc
 import (
 	"context"
 	"crypto/ecdsa"
+	"fmt"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/chain/local_v1"
 	"github.com/keep-network/keep-core/pkg/generator"
@@ -13,6 +14,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/protocol/group"
 	"github.com/keep-network/keep-core/pkg/tecdsa"
 	"math/big"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -105,6 +107,96 @@ func TestSigningExecutor_SignBatch(t *testing.T) {
 	}
 }
 
+func TestSigningExecutor_SignBatch_Concurrent(t *testing.T) {
+	executor := setupSigningExecutor(t)
+	executor.signingLanesCount = 2
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	messages := []*big.Int{
+		big.NewInt(1000),
+		big.NewInt(2000),
+		big.NewInt(3000),
+		big.NewInt(4000),
+	}
+	startBlock := uint64(0)
+
+	signatures, err := executor.signBatch(ctx, messages, startBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"signatures count",
+		len(messages),
+		len(signatures),
+	)
+
+	walletPublicKey := executor.wallet().publicKey
+
+	for i, signature := range signatures {
+		if !ecdsa.Verify(
+			walletPublicKey,
+			messages[i].Bytes(),
+			signature.R,
+			signature.S,
+		) {
+			t.Errorf("invalid signature [%v]: [%+v]", i, signature)
+		}
+	}
+}
+
+func TestSigningExecutor_SignBatch_DuplicatedMessage(t *testing.T) {
+	executor := setupSigningExecutor(t)
+
+	messages := []*big.Int{
+		big.NewInt(1000),
+		big.NewInt(2000),
+		big.NewInt(1000),
+	}
+
+	_, err := executor.signBatch(context.Background(), messages, 0)
+
+	expectedErr := fmt.Errorf("message [0x3e8] is duplicated in the batch")
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf(
+			"unexpected error\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+}
+
+func TestSigningExecutor_SignBatch_Busy(t *testing.T) {
+	executor := setupSigningExecutor(t)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	messages := []*big.Int{big.NewInt(1000)}
+	startBlock := uint64(0)
+
+	errChan := make(chan error, 1)
+	go func() {
+		_, _, err := executor.sign(ctx, big.NewInt(100), startBlock)
+		errChan <- err
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, err := executor.signBatch(ctx, messages, startBlock)
+	testutils.AssertErrorsSame(t, errSigningExecutorBusy, err)
+
+	err = <-errChan
+	if err != nil {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+}
+
 // setupSigningExecutor sets up an instance of the signing executor ready
 // to perform test signing.
 func setupSigningExecutor(t *testing.T) *signingExecutor {
@@ -160,13 +252,23 @@ func setupSigningExecutor(t *testing.T) *signingExecutor {
 
 	keyStorePersistence := createMockKeyStorePersistence(t, signers...)
 
+	// Nodes of finished tests stay in memory. Stop the computations of their
+	// schedulers so they do not take CPU away from subsequent tests.
+	scheduler := generator.StartScheduler()
+	t.Cleanup(func() {
+		stoppedLatch := generator.NewProtocolLatch()
+		stoppedLatch.Lock()
+		scheduler.RegisterProtocol(stoppedLatch)
+	})
+
 	node, err := newNode(
+		context.Background(),
 		groupParameters,
 		localChain,
 		localProvider,
 		keyStorePersistence,
 		&mockPersistenceHandle{},
-		generator.StartScheduler(),
+		scheduler,
 		Config{},
 	)
 	if err != nil {
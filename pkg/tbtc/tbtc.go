@@ -10,6 +10,7 @@ import (
 	"github.com/ipfs/go-log"
 
 	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/clientinfo"
 	"github.com/keep-network/keep-core/pkg/generator"
 	"github.com/keep-network/keep-core/pkg/net"
@@ -43,11 +44,23 @@ func (gp *GroupParameters) DishonestThreshold() int {
 	return gp.GroupSize - gp.HonestThreshold
 }
 
+// SigningGroups allows checking membership of operators in signing groups
+// of wallets controlled by the node.
+type SigningGroups interface {
+	// IsSigningGroupOperator returns true if the given operator is a member
+	// of the signing group of any wallet controlled by the node.
+	IsSigningGroupOperator(operator chain.Address) bool
+}
+
 const (
 	DefaultPreParamsPoolSize              = 1000
 	DefaultPreParamsGenerationTimeout     = 2 * time.Minute
 	DefaultPreParamsGenerationDelay       = 10 * time.Second
 	DefaultPreParamsGenerationConcurrency = 1
+	// DefaultPreParamsMaxAge is zero so pre-parameters never expire by
+	// default. Pre-parameters do not get weaker with age; the expiry is meant
+	// for operators who prefer to rotate them periodically.
+	DefaultPreParamsMaxAge = 0
 )
 
 var DefaultKeyGenerationConcurrency = runtime.GOMAXPROCS(0)
@@ -62,13 +75,20 @@ type Config struct {
 	PreParamsGenerationDelay time.Duration
 	// Concurrency level for pre-parameters generation for tECDSA.
 	PreParamsGenerationConcurrency int
+	// Maximum age of pre-parameters for tECDSA. Older pre-parameters expire
+	// and are removed from the pool. Pre-parameters never expire if zero.
+	PreParamsMaxAge time.Duration
 	// Concurrency level for key-generation for tECDSA.
 	KeyGenerationConcurrency int
+	// The interval in which the operator's status in the sortition pool is
+	// checked. sortition.DefaultStatusCheckTick is used if zero.
+	SortitionPoolStatusCheckTick time.Duration
 }
 
 // Initialize kicks off the TBTC by initializing internal state, ensuring
 // preconditions like staking are met, and then kicking off the internal TBTC
-// implementation. Returns an error if this failed.
+// implementation. Returns the signing groups of wallets controlled by the
+// node or an error if this failed.
 func Initialize(
 	ctx context.Context,
 	chain Chain,
@@ -78,7 +98,7 @@ func Initialize(
 	scheduler *generator.Scheduler,
 	config Config,
 	clientInfo *clientinfo.Registry,
-) error {
+) (SigningGroups, error) {
 	groupParameters := &GroupParameters{
 		GroupSize:       100,
 		GroupQuorum:     90,
@@ -86,6 +106,7 @@ func Initialize(
 	}
 
 	node, err := newNode(
+		ctx,
 		groupParameters,
 		chain,
 		netProvider,
@@ -95,7 +116,7 @@ func Initialize(
 		config,
 	)
 	if err != nil {
-		return fmt.Errorf("cannot set up TBTC node: [%v]", err)
+		return nil, fmt.Errorf("cannot set up TBTC node: [%v]", err)
 	}
 
 	deduplicator := newDeduplicator()
@@ -108,15 +129,47 @@ func Initialize(
 				"pre_params_count": func() float64 {
 					return float64(node.dkgExecutor.preParamsCount())
 				},
+				"pre_params_min_age_seconds": func() float64 {
+					return node.dkgExecutor.preParamsPoolStats().MinAge.Seconds()
+				},
+				"pre_params_median_age_seconds": func() float64 {
+					return node.dkgExecutor.preParamsPoolStats().MedianAge.Seconds()
+				},
+				"pre_params_max_age_seconds": func() float64 {
+					return node.dkgExecutor.preParamsPoolStats().MaxAge.Seconds()
+				},
+				"pre_params_replenishment_rate": func() float64 {
+					return float64(
+						node.dkgExecutor.preParamsPoolStats().ReplenishmentRate,
+					)
+				},
+				"pre_params_generation_throughput": func() float64 {
+					return node.dkgExecutor.preParamsPoolStats().GenerationThroughput
+				},
+				"pre_params_discarded_count": func() float64 {
+					return float64(
+						node.dkgExecutor.preParamsPoolStats().DiscardedCount,
+					)
+				},
+				"dkg_result_divergences_count": func() float64 {
+					return float64(
+						node.dkgExecutor.dkgResultDivergencesCount(),
+					)
+				},
 			},
 		)
 	}
 
+	statusCheckTick := config.SortitionPoolStatusCheckTick
+	if statusCheckTick == 0 {
+		statusCheckTick = sortition.DefaultStatusCheckTick
+	}
+
 	err = sortition.MonitorPool(
 		ctx,
 		logger,
 		chain,
-		sortition.DefaultStatusCheckTick,
+		statusCheckTick,
 		sortition.NewConjunctionPolicy(
 			sortition.NewBetaOperatorPolicy(chain, logger),
 			&enoughPreParamsInPoolPolicy{
@@ -126,12 +179,17 @@ func Initialize(
 		),
 	)
 	if err != nil {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"could not set up sortition pool monitoring: [%v]",
 			err,
 		)
 	}
 
+	// Rejoin DKGs interrupted by a restart before subscribing to DKG started
+	// events so the events delivered again after the restart are recognized
+	// as duplicates.
+	node.resumeInterruptedDKGs(deduplicator.notifyDKGStarted)
+
 	_ = chain.OnDKGStarted(func(event *DKGStartedEvent) {
 		go func() {
 			if ok := deduplicator.notifyDKGStarted(
@@ -251,7 +309,7 @@ func Initialize(
 		}()
 	})
 
-	return nil
+	return node.walletRegistry, nil
 }
 
 // enoughPreParamsInPoolPolicy is a policy that enforces the sufficient size
@@ -0,0 +1,163 @@
+package dkg
+
+import (
+	"github.com/keep-network/keep-core/pkg/crypto/ephemeral"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// CheckpointState is the last completed state of the DKG protocol recorded
+// in a checkpoint.
+type CheckpointState uint8
+
+const (
+	// EphemeralKeysExchangedCheckpoint is recorded once the member generated
+	// its ephemeral key pairs and received ephemeral public keys of all
+	// other operating members. The protocol can be resumed from this state.
+	EphemeralKeysExchangedCheckpoint CheckpointState = iota + 1
+	// TssStartedCheckpoint is recorded once the member starts the TSS key
+	// generation rounds. The state of the TSS rounds is held by the TSS
+	// party which cannot be exported so the protocol cannot be resumed from
+	// this state. Re-running the rounds is not an option either as the member
+	// would broadcast messages built with fresh randomness, conflicting with
+	// the ones it sent before the restart. The member has to join the next
+	// DKG attempt instead.
+	TssStartedCheckpoint
+)
+
+// String returns a human-readable name of the checkpoint state.
+func (cs CheckpointState) String() string {
+	switch cs {
+	case EphemeralKeysExchangedCheckpoint:
+		return "ephemeral keys exchanged"
+	case TssStartedCheckpoint:
+		return "TSS started"
+	default:
+		return "unknown"
+	}
+}
+
+// Checkpoint is a snapshot of the DKG protocol state of a single member,
+// taken after the member completed one of the protocol states. It allows
+// the member to rejoin the protocol after a restart. The checkpoint holds
+// ephemeral private keys of the member so it must not be shared.
+type Checkpoint struct {
+	// SessionID is the identifier of the DKG session.
+	SessionID string
+	// MemberIndex is the index of the member who recorded the checkpoint.
+	MemberIndex group.MemberIndex
+	// ExcludedMembersIndexes are the members excluded from the DKG session.
+	ExcludedMembersIndexes []group.MemberIndex
+	// State is the last protocol state completed by the member.
+	State CheckpointState
+
+	// ephemeralKeyPairs are the ephemeral key pairs the member generated for
+	// other group members.
+	ephemeralKeyPairs map[group.MemberIndex]*ephemeral.KeyPair
+	// receivedMessages are the messages received by the member before the
+	// checkpoint was taken.
+	receivedMessages []*checkpointMessage
+}
+
+// IsResumable returns true if the DKG protocol can be resumed from the
+// checkpoint. Only the EphemeralKeysExchangedCheckpoint is resumable.
+// Resuming the protocol in the middle of the TSS key generation rounds is not
+// supported as tss-lib does not expose the state of the rounds; a member
+// restarted during the rounds is inactive in the given DKG attempt.
+func (c *Checkpoint) IsResumable() bool {
+	return c.State == EphemeralKeysExchangedCheckpoint
+}
+
+// CheckpointFn is a function called each time a member records a protocol
+// checkpoint. It is meant to persist the checkpoint so the member can resume
+// the protocol after a restart. An error returned by the function does not
+// interrupt the protocol.
+type CheckpointFn func(checkpoint *Checkpoint) error
+
+// checkpointMessage is a network message received before the checkpoint was
+// taken and restored from the checkpoint.
+type checkpointMessage struct {
+	senderPublicKey []byte
+	payload         message
+}
+
+func (cm *checkpointMessage) TransportSenderID() net.TransportIdentifier {
+	return nil
+}
+
+func (cm *checkpointMessage) SenderPublicKey() []byte {
+	return cm.senderPublicKey
+}
+
+func (cm *checkpointMessage) Payload() interface{} {
+	return cm.payload
+}
+
+func (cm *checkpointMessage) Type() string {
+	return cm.payload.Type()
+}
+
+func (cm *checkpointMessage) Seqno() uint64 {
+	return 0
+}
+
+// recordCheckpoint passes the given checkpoint to the member's checkpoint
+// function, if set. Failures are logged but do not interrupt the protocol.
+func (m *member) recordCheckpoint(checkpoint *Checkpoint) {
+	if m.checkpointFn == nil {
+		return
+	}
+
+	if err := m.checkpointFn(checkpoint); err != nil {
+		m.logger.Warnf(
+			"[member:%v] cannot record [%v] checkpoint: [%v]",
+			m.id,
+			checkpoint.State,
+			err,
+		)
+	}
+}
+
+// ephemeralKeysExchangedCheckpoint returns the checkpoint of the member
+// which has received ephemeral public keys from all other operating members.
+// Only the first message from the given sender is recorded.
+func (skgm *symmetricKeyGeneratingMember) ephemeralKeysExchangedCheckpoint(
+	netMessages []net.Message,
+) *Checkpoint {
+	receivedMessages := make([]*checkpointMessage, 0)
+	senders := make(map[group.MemberIndex]bool)
+
+	for _, netMessage := range netMessages {
+		payload, ok := netMessage.Payload().(*ephemeralPublicKeyMessage)
+		if !ok || senders[payload.senderID] {
+			continue
+		}
+
+		senders[payload.senderID] = true
+		receivedMessages = append(receivedMessages, &checkpointMessage{
+			senderPublicKey: netMessage.SenderPublicKey(),
+			payload:         payload,
+		})
+	}
+
+	return &Checkpoint{
+		SessionID:              skgm.sessionID,
+		MemberIndex:            skgm.id,
+		ExcludedMembersIndexes: skgm.group.DisqualifiedMemberIndexes(),
+		State:                  EphemeralKeysExchangedCheckpoint,
+		ephemeralKeyPairs:      skgm.ephemeralKeyPairs,
+		receivedMessages:       receivedMessages,
+	}
+}
+
+// tssStartedCheckpoint returns the checkpoint of the member which starts
+// the TSS key generation rounds. The checkpoint holds no key material as
+// the protocol cannot be resumed from it.
+func (trom *tssRoundOneMember) tssStartedCheckpoint() *Checkpoint {
+	return &Checkpoint{
+		SessionID:              trom.sessionID,
+		MemberIndex:            trom.id,
+		ExcludedMembersIndexes: trom.group.DisqualifiedMemberIndexes(),
+		State:                  TssStartedCheckpoint,
+	}
+}
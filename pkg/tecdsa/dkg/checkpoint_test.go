@@ -0,0 +1,165 @@
+package dkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+func TestCheckpoint_IsResumable(t *testing.T) {
+	var tests = map[string]struct {
+		state             CheckpointState
+		expectedResumable bool
+	}{
+		"ephemeral keys exchanged": {
+			state:             EphemeralKeysExchangedCheckpoint,
+			expectedResumable: true,
+		},
+		"TSS started": {
+			state:             TssStartedCheckpoint,
+			expectedResumable: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			checkpoint := &Checkpoint{State: test.state}
+
+			testutils.AssertBoolsEqual(
+				t,
+				"resumable",
+				test.expectedResumable,
+				checkpoint.IsResumable(),
+			)
+		})
+	}
+}
+
+func TestEphemeralKeysExchangedCheckpoint(t *testing.T) {
+	members, err := initializeEphemeralKeyPairGeneratingMembersGroup(
+		dishonestThreshold,
+		groupSize,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	netMessages := make([]net.Message, 0)
+	for _, member := range members {
+		message, err := member.generateEphemeralKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if member.id == 1 {
+			continue
+		}
+
+		// Add every message twice to simulate retransmissions.
+		for i := 0; i < 2; i++ {
+			netMessages = append(netMessages, &checkpointMessage{
+				senderPublicKey: []byte{uint8(member.id)},
+				payload:         message,
+			})
+		}
+	}
+
+	checkpoint := members[0].initializeSymmetricKeyGeneration().
+		ephemeralKeysExchangedCheckpoint(netMessages)
+
+	testutils.AssertStringsEqual(t, "session ID", sessionID, checkpoint.SessionID)
+	testutils.AssertIntsEqual(t, "member index", 1, int(checkpoint.MemberIndex))
+	testutils.AssertIntsEqual(
+		t,
+		"ephemeral key pairs count",
+		groupSize-1,
+		len(checkpoint.ephemeralKeyPairs),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"received messages count",
+		groupSize-1,
+		len(checkpoint.receivedMessages),
+	)
+	if !checkpoint.IsResumable() {
+		t.Errorf("checkpoint should be resumable")
+	}
+}
+
+func TestGenerateEphemeralKeyPair_RestoredKeyPairs(t *testing.T) {
+	members, err := initializeEphemeralKeyPairGeneratingMembersGroup(
+		dishonestThreshold,
+		groupSize,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	member := members[0]
+
+	message, err := member.generateEphemeralKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpointBytes, err := member.initializeSymmetricKeyGeneration().
+		ephemeralKeysExchangedCheckpoint([]net.Message{}).
+		Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint := &Checkpoint{}
+	if err := checkpoint.Unmarshal(checkpointBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a restart of the member.
+	member.ephemeralKeyPairs = checkpoint.ephemeralKeyPairs
+
+	restoredMessage, err := member.generateEphemeralKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for memberIndex, publicKey := range message.ephemeralPublicKeys {
+		testutils.AssertBytesEqual(
+			t,
+			publicKey.Marshal(),
+			restoredMessage.ephemeralPublicKeys[memberIndex].Marshal(),
+		)
+	}
+}
+
+func TestExecutor_Resume_NotResumable(t *testing.T) {
+	executor := &Executor{}
+
+	_, err := executor.Resume(
+		context.Background(),
+		&testutils.MockLogger{},
+		nil,
+		&Checkpoint{
+			SessionID:   "session-1",
+			MemberIndex: group.MemberIndex(1),
+			State:       TssStartedCheckpoint,
+		},
+		groupSize,
+		dishonestThreshold,
+		nil,
+		nil,
+		nil,
+	)
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	testutils.AssertStringsEqual(
+		t,
+		"error",
+		"cannot resume from [TSS started] checkpoint",
+		err.Error(),
+	)
+}
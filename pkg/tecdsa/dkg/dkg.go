@@ -21,8 +21,10 @@ type Executor struct {
 	keyGenerationConcurrency int
 }
 
-// NewExecutor creates a new Executor instance.
+// NewExecutor creates a new Executor instance. Background routines of the
+// executor run until the given context is done.
 func NewExecutor(
+	ctx context.Context,
 	logger log.StandardLogger,
 	scheduler *generator.Scheduler,
 	persistence persistence.BasicHandle,
@@ -30,6 +32,7 @@ func NewExecutor(
 	preParamsGenerationTimeout time.Duration,
 	preParamsGenerationDelay time.Duration,
 	preParamsGenerationConcurrency int,
+	preParamsMaxAge time.Duration,
 	keyGenerationConcurrency int,
 ) *Executor {
 	logger.Infof(
@@ -38,6 +41,7 @@ func NewExecutor(
 	)
 	return &Executor{
 		tssPreParamsPool: newTssPreParamsPool(
+			ctx,
 			logger,
 			scheduler,
 			persistence,
@@ -45,6 +49,7 @@ func NewExecutor(
 			preParamsGenerationTimeout,
 			preParamsGenerationDelay,
 			preParamsGenerationConcurrency,
+			preParamsMaxAge,
 		),
 		keyGenerationConcurrency: keyGenerationConcurrency,
 	}
@@ -58,6 +63,10 @@ func NewExecutor(
 // This function also supports DKG execution with a subset of the selected
 // group by passing a non-empty excludedMembers slice holding the members that
 // should be excluded.
+//
+// If the checkpointFn is not nil, it is called each time the member completes
+// a protocol state that is recorded as a checkpoint. A resumable checkpoint
+// can be used to resume the protocol with Executor.Resume.
 func (e *Executor) Execute(
 	ctx context.Context,
 	logger log.StandardLogger,
@@ -69,6 +78,84 @@ func (e *Executor) Execute(
 	excludedMembersIndexes []group.MemberIndex,
 	channel net.BroadcastChannel,
 	membershipValidator *group.MembershipValidator,
+	checkpointFn CheckpointFn,
+) (*Result, error) {
+	return execute(
+		ctx,
+		logger,
+		seed,
+		sessionID,
+		memberIndex,
+		groupSize,
+		dishonestThreshold,
+		excludedMembersIndexes,
+		channel,
+		membershipValidator,
+		e.tssPreParamsPool.GetNow,
+		e.keyGenerationConcurrency,
+		nil,
+		checkpointFn,
+	)
+}
+
+// Resume resumes the tECDSA distributed key generation protocol of the member
+// from the given checkpoint recorded during Executor.Execute, for example,
+// before the node was restarted. The member uses the ephemeral keys and
+// messages restored from the checkpoint and broadcasts its ephemeral public
+// keys again so members which have not received them yet can proceed.
+// Other members must still be executing the same DKG session. Returns an
+// error if the checkpoint is not resumable.
+func (e *Executor) Resume(
+	ctx context.Context,
+	logger log.StandardLogger,
+	seed *big.Int,
+	checkpoint *Checkpoint,
+	groupSize int,
+	dishonestThreshold int,
+	channel net.BroadcastChannel,
+	membershipValidator *group.MembershipValidator,
+	checkpointFn CheckpointFn,
+) (*Result, error) {
+	if !checkpoint.IsResumable() {
+		return nil, fmt.Errorf(
+			"cannot resume from [%v] checkpoint",
+			checkpoint.State,
+		)
+	}
+
+	return execute(
+		ctx,
+		logger,
+		seed,
+		checkpoint.SessionID,
+		checkpoint.MemberIndex,
+		groupSize,
+		dishonestThreshold,
+		checkpoint.ExcludedMembersIndexes,
+		channel,
+		membershipValidator,
+		e.tssPreParamsPool.GetNow,
+		e.keyGenerationConcurrency,
+		checkpoint,
+		checkpointFn,
+	)
+}
+
+func execute(
+	ctx context.Context,
+	logger log.StandardLogger,
+	seed *big.Int,
+	sessionID string,
+	memberIndex group.MemberIndex,
+	groupSize int,
+	dishonestThreshold int,
+	excludedMembersIndexes []group.MemberIndex,
+	channel net.BroadcastChannel,
+	membershipValidator *group.MembershipValidator,
+	preParamsFn func() (*PreParams, error),
+	keyGenerationConcurrency int,
+	checkpoint *Checkpoint,
+	checkpointFn CheckpointFn,
 ) (*Result, error) {
 	logger.Debugf("[member:%v] initializing member", memberIndex)
 
@@ -80,8 +167,9 @@ func (e *Executor) Execute(
 		dishonestThreshold,
 		membershipValidator,
 		sessionID,
-		e.tssPreParamsPool.GetNow,
-		e.keyGenerationConcurrency,
+		preParamsFn,
+		keyGenerationConcurrency,
+		checkpointFn,
 	)
 
 	// Mark excluded members as disqualified in order to not exchange messages
@@ -98,6 +186,29 @@ func (e *Executor) Execute(
 		member:         member.initializeEphemeralKeysGeneration(),
 	}
 
+	if checkpoint != nil {
+		logger.Infof(
+			"[member:%v] resuming from [%v] checkpoint",
+			memberIndex,
+			checkpoint.State,
+		)
+
+		// Restore the ephemeral key pairs so the same public keys are
+		// broadcast again and the messages received before the checkpoint
+		// so they do not have to be retransmitted by other members.
+		for otherMemberIndex, keyPair := range checkpoint.ephemeralKeyPairs {
+			initialState.member.ephemeralKeyPairs[otherMemberIndex] = keyPair
+		}
+		for _, receivedMessage := range checkpoint.receivedMessages {
+			if err := initialState.Receive(receivedMessage); err != nil {
+				return nil, fmt.Errorf(
+					"cannot restore checkpoint message: [%v]",
+					err,
+				)
+			}
+		}
+	}
+
 	stateMachine := state.NewAsyncMachine(logger, ctx, channel, initialState)
 
 	lastState, err := stateMachine.Execute()
@@ -118,6 +229,11 @@ func (e *Executor) PreParamsCount() int {
 	return e.tssPreParamsPool.ParametersCount()
 }
 
+// PreParamsPoolStats returns statistics of the DKG pre-parameters pool.
+func (e *Executor) PreParamsPoolStats() PreParamsPoolStats {
+	return e.tssPreParamsPool.Stats()
+}
+
 // SignedResult represents information pertaining to the process of signing
 // a DKG result: the public key used during signing, the resulting signature and
 // the hash of the DKG result that was used during signing.
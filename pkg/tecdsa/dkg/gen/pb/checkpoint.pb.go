@@ -0,0 +1,282 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: pkg/tecdsa/dkg/gen/pb/checkpoint.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Checkpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID              string                        `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+	MemberIndex            uint32                        `protobuf:"varint,2,opt,name=memberIndex,proto3" json:"memberIndex,omitempty"`
+	ExcludedMembersIndexes []uint32                      `protobuf:"varint,3,rep,packed,name=excludedMembersIndexes,proto3" json:"excludedMembersIndexes,omitempty"`
+	State                  uint32                        `protobuf:"varint,4,opt,name=state,proto3" json:"state,omitempty"`
+	EphemeralPrivateKeys   map[uint32][]byte             `protobuf:"bytes,5,rep,name=ephemeralPrivateKeys,proto3" json:"ephemeralPrivateKeys,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ReceivedMessages       []*Checkpoint_ReceivedMessage `protobuf:"bytes,6,rep,name=receivedMessages,proto3" json:"receivedMessages,omitempty"`
+}
+
+func (x *Checkpoint) Reset() {
+	*x = Checkpoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Checkpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Checkpoint) ProtoMessage() {}
+
+func (x *Checkpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Checkpoint.ProtoReflect.Descriptor instead.
+func (*Checkpoint) Descriptor() ([]byte, []int) {
+	return file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Checkpoint) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *Checkpoint) GetMemberIndex() uint32 {
+	if x != nil {
+		return x.MemberIndex
+	}
+	return 0
+}
+
+func (x *Checkpoint) GetExcludedMembersIndexes() []uint32 {
+	if x != nil {
+		return x.ExcludedMembersIndexes
+	}
+	return nil
+}
+
+func (x *Checkpoint) GetState() uint32 {
+	if x != nil {
+		return x.State
+	}
+	return 0
+}
+
+func (x *Checkpoint) GetEphemeralPrivateKeys() map[uint32][]byte {
+	if x != nil {
+		return x.EphemeralPrivateKeys
+	}
+	return nil
+}
+
+func (x *Checkpoint) GetReceivedMessages() []*Checkpoint_ReceivedMessage {
+	if x != nil {
+		return x.ReceivedMessages
+	}
+	return nil
+}
+
+type Checkpoint_ReceivedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SenderPublicKey []byte `protobuf:"bytes,1,opt,name=senderPublicKey,proto3" json:"senderPublicKey,omitempty"`
+	Payload         []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *Checkpoint_ReceivedMessage) Reset() {
+	*x = Checkpoint_ReceivedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Checkpoint_ReceivedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Checkpoint_ReceivedMessage) ProtoMessage() {}
+
+func (x *Checkpoint_ReceivedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Checkpoint_ReceivedMessage.ProtoReflect.Descriptor instead.
+func (*Checkpoint_ReceivedMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Checkpoint_ReceivedMessage) GetSenderPublicKey() []byte {
+	if x != nil {
+		return x.SenderPublicKey
+	}
+	return nil
+}
+
+func (x *Checkpoint_ReceivedMessage) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+var File_pkg_tecdsa_dkg_gen_pb_checkpoint_proto protoreflect.FileDescriptor
+
+var file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x70, 0x6b, 0x67, 0x2f, 0x74, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x64, 0x6b, 0x67,
+	0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x62, 0x2f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x64, 0x6b, 0x67, 0x22, 0xe6, 0x03,
+	0x0a, 0x0a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x36, 0x0a, 0x16,
+	0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x16, 0x65, 0x78,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x5d, 0x0a, 0x14, 0x65, 0x70,
+	0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65,
+	0x79, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x45, 0x70, 0x68, 0x65, 0x6d, 0x65,
+	0x72, 0x61, 0x6c, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x14, 0x65, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x50, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x4b, 0x0a, 0x10, 0x72, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x10, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x1a, 0x55, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x73, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0f, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x4b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x1a, 0x47, 0x0a,
+	0x19, 0x45, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74,
+	0x65, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_rawDescOnce sync.Once
+	file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_rawDescData = file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_rawDesc
+)
+
+func file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_rawDescGZIP() []byte {
+	file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_rawDescOnce.Do(func() {
+		file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_rawDescData)
+	})
+	return file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_rawDescData
+}
+
+var file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_goTypes = []interface{}{
+	(*Checkpoint)(nil),                 // 0: dkg.Checkpoint
+	(*Checkpoint_ReceivedMessage)(nil), // 1: dkg.Checkpoint.ReceivedMessage
+	nil,                                // 2: dkg.Checkpoint.EphemeralPrivateKeysEntry
+}
+var file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_depIdxs = []int32{
+	2, // 0: dkg.Checkpoint.ephemeralPrivateKeys:type_name -> dkg.Checkpoint.EphemeralPrivateKeysEntry
+	1, // 1: dkg.Checkpoint.receivedMessages:type_name -> dkg.Checkpoint.ReceivedMessage
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_init() }
+func file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_init() {
+	if File_pkg_tecdsa_dkg_gen_pb_checkpoint_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Checkpoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Checkpoint_ReceivedMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_goTypes,
+		DependencyIndexes: file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_depIdxs,
+		MessageInfos:      file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_msgTypes,
+	}.Build()
+	File_pkg_tecdsa_dkg_gen_pb_checkpoint_proto = out.File
+	file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_rawDesc = nil
+	file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_goTypes = nil
+	file_pkg_tecdsa_dkg_gen_pb_checkpoint_proto_depIdxs = nil
+}
@@ -76,6 +76,108 @@ func (x *PreParams) GetCreationTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+type PreParamsBundle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PreParams []*PreParams `protobuf:"bytes,1,rep,name=preParams,proto3" json:"preParams,omitempty"`
+}
+
+func (x *PreParamsBundle) Reset() {
+	*x = PreParamsBundle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreParamsBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreParamsBundle) ProtoMessage() {}
+
+func (x *PreParamsBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreParamsBundle.ProtoReflect.Descriptor instead.
+func (*PreParamsBundle) Descriptor() ([]byte, []int) {
+	return file_pkg_tecdsa_dkg_gen_pb_preparams_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PreParamsBundle) GetPreParams() []*PreParams {
+	if x != nil {
+		return x.PreParams
+	}
+	return nil
+}
+
+type EncryptedPreParamsBundle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Salt       []byte `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`
+	Ciphertext []byte `protobuf:"bytes,2,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+}
+
+func (x *EncryptedPreParamsBundle) Reset() {
+	*x = EncryptedPreParamsBundle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncryptedPreParamsBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptedPreParamsBundle) ProtoMessage() {}
+
+func (x *EncryptedPreParamsBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptedPreParamsBundle.ProtoReflect.Descriptor instead.
+func (*EncryptedPreParamsBundle) Descriptor() ([]byte, []int) {
+	return file_pkg_tecdsa_dkg_gen_pb_preparams_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EncryptedPreParamsBundle) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+func (x *EncryptedPreParamsBundle) GetCiphertext() []byte {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return nil
+}
+
 type PreParams_PublicKey struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -87,7 +189,7 @@ type PreParams_PublicKey struct {
 func (x *PreParams_PublicKey) Reset() {
 	*x = PreParams_PublicKey{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[1]
+		mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -100,7 +202,7 @@ func (x *PreParams_PublicKey) String() string {
 func (*PreParams_PublicKey) ProtoMessage() {}
 
 func (x *PreParams_PublicKey) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[1]
+	mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -136,7 +238,7 @@ type PreParams_PrivateKey struct {
 func (x *PreParams_PrivateKey) Reset() {
 	*x = PreParams_PrivateKey{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[2]
+		mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -149,7 +251,7 @@ func (x *PreParams_PrivateKey) String() string {
 func (*PreParams_PrivateKey) ProtoMessage() {}
 
 func (x *PreParams_PrivateKey) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[2]
+	mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -204,7 +306,7 @@ type PreParams_LocalPreParams struct {
 func (x *PreParams_LocalPreParams) Reset() {
 	*x = PreParams_LocalPreParams{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[3]
+		mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -217,7 +319,7 @@ func (x *PreParams_LocalPreParams) String() string {
 func (*PreParams_LocalPreParams) ProtoMessage() {}
 
 func (x *PreParams_LocalPreParams) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[3]
+	mi := &file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -327,8 +429,17 @@ var file_pkg_tecdsa_dkg_gen_pb_preparams_proto_rawDesc = []byte{
 	0x52, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x65, 0x74, 0x61, 0x18,
 	0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x65, 0x74, 0x61, 0x12, 0x0c, 0x0a, 0x01, 0x70,
 	0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x70, 0x12, 0x0c, 0x0a, 0x01, 0x71, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x71, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x71, 0x22, 0x3f, 0x0a, 0x0f, 0x50, 0x72, 0x65, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2c, 0x0a, 0x09, 0x70, 0x72,
+	0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x64, 0x6b, 0x67, 0x2e, 0x50, 0x72, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x09, 0x70,
+	0x72, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x4e, 0x0a, 0x18, 0x45, 0x6e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x65, 0x64, 0x50, 0x72, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x42, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x69, 0x70, 0x68,
+	0x65, 0x72, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x69,
+	0x70, 0x68, 0x65, 0x72, 0x74, 0x65, 0x78, 0x74, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -343,24 +454,27 @@ func file_pkg_tecdsa_dkg_gen_pb_preparams_proto_rawDescGZIP() []byte {
 	return file_pkg_tecdsa_dkg_gen_pb_preparams_proto_rawDescData
 }
 
-var file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_pkg_tecdsa_dkg_gen_pb_preparams_proto_goTypes = []interface{}{
 	(*PreParams)(nil),                // 0: dkg.PreParams
-	(*PreParams_PublicKey)(nil),      // 1: dkg.PreParams.PublicKey
-	(*PreParams_PrivateKey)(nil),     // 2: dkg.PreParams.PrivateKey
-	(*PreParams_LocalPreParams)(nil), // 3: dkg.PreParams.LocalPreParams
-	(*timestamppb.Timestamp)(nil),    // 4: google.protobuf.Timestamp
+	(*PreParamsBundle)(nil),          // 1: dkg.PreParamsBundle
+	(*EncryptedPreParamsBundle)(nil), // 2: dkg.EncryptedPreParamsBundle
+	(*PreParams_PublicKey)(nil),      // 3: dkg.PreParams.PublicKey
+	(*PreParams_PrivateKey)(nil),     // 4: dkg.PreParams.PrivateKey
+	(*PreParams_LocalPreParams)(nil), // 5: dkg.PreParams.LocalPreParams
+	(*timestamppb.Timestamp)(nil),    // 6: google.protobuf.Timestamp
 }
 var file_pkg_tecdsa_dkg_gen_pb_preparams_proto_depIdxs = []int32{
-	3, // 0: dkg.PreParams.data:type_name -> dkg.PreParams.LocalPreParams
-	4, // 1: dkg.PreParams.creationTimestamp:type_name -> google.protobuf.Timestamp
-	1, // 2: dkg.PreParams.PrivateKey.publicKey:type_name -> dkg.PreParams.PublicKey
-	2, // 3: dkg.PreParams.LocalPreParams.paillierSK:type_name -> dkg.PreParams.PrivateKey
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	5, // 0: dkg.PreParams.data:type_name -> dkg.PreParams.LocalPreParams
+	6, // 1: dkg.PreParams.creationTimestamp:type_name -> google.protobuf.Timestamp
+	0, // 2: dkg.PreParamsBundle.preParams:type_name -> dkg.PreParams
+	3, // 3: dkg.PreParams.PrivateKey.publicKey:type_name -> dkg.PreParams.PublicKey
+	4, // 4: dkg.PreParams.LocalPreParams.paillierSK:type_name -> dkg.PreParams.PrivateKey
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_pkg_tecdsa_dkg_gen_pb_preparams_proto_init() }
@@ -382,7 +496,7 @@ func file_pkg_tecdsa_dkg_gen_pb_preparams_proto_init() {
 			}
 		}
 		file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PreParams_PublicKey); i {
+			switch v := v.(*PreParamsBundle); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -394,7 +508,7 @@ func file_pkg_tecdsa_dkg_gen_pb_preparams_proto_init() {
 			}
 		}
 		file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PreParams_PrivateKey); i {
+			switch v := v.(*EncryptedPreParamsBundle); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -406,6 +520,30 @@ func file_pkg_tecdsa_dkg_gen_pb_preparams_proto_init() {
 			}
 		}
 		file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreParams_PublicKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreParams_PrivateKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_tecdsa_dkg_gen_pb_preparams_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PreParams_LocalPreParams); i {
 			case 0:
 				return &v.state
@@ -424,7 +562,7 @@ func file_pkg_tecdsa_dkg_gen_pb_preparams_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_tecdsa_dkg_gen_pb_preparams_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -264,6 +264,23 @@ func (rsm *resultSignatureMessage) Unmarshal(bytes []byte) error {
 
 // Marshal converts the PreParams to a byte array.
 func (pp *PreParams) Marshal() ([]byte, error) {
+	return proto.Marshal(pp.toProto())
+}
+
+// Unmarshal converts a byte array back to the PreParams.
+func (pp *PreParams) Unmarshal(bytes []byte) error {
+	pbPreParams := pb.PreParams{}
+	if err := proto.Unmarshal(bytes, &pbPreParams); err != nil {
+		return fmt.Errorf("failed to unmarshal pre params: [%v]", err)
+	}
+
+	pp.fromProto(&pbPreParams)
+
+	return nil
+}
+
+// toProto converts the PreParams to their protobuf representation.
+func (pp *PreParams) toProto() *pb.PreParams {
 	localPreParams := &pb.PreParams_LocalPreParams{
 		PaillierSK: &pb.PreParams_PrivateKey{
 			PublicKey: &pb.PreParams_PublicKey{
@@ -281,19 +298,14 @@ func (pp *PreParams) Marshal() ([]byte, error) {
 		Q:      pp.data.Q.Bytes(),
 	}
 
-	return proto.Marshal(&pb.PreParams{
+	return &pb.PreParams{
 		Data:              localPreParams,
 		CreationTimestamp: timestamppb.New(pp.creationTimestamp),
-	})
-}
-
-// Unmarshal converts a byte array back to the PreParams.
-func (pp *PreParams) Unmarshal(bytes []byte) error {
-	pbPreParams := pb.PreParams{}
-	if err := proto.Unmarshal(bytes, &pbPreParams); err != nil {
-		return fmt.Errorf("failed to unmarshal pre params: [%v]", err)
 	}
+}
 
+// fromProto sets the PreParams based on their protobuf representation.
+func (pp *PreParams) fromProto(pbPreParams *pb.PreParams) {
 	pp.data = &keygen.LocalPreParams{
 		PaillierSK: &paillier.PrivateKey{
 			PublicKey: paillier.PublicKey{
@@ -311,6 +323,120 @@ func (pp *PreParams) Unmarshal(bytes []byte) error {
 		Q:       new(big.Int).SetBytes(pbPreParams.Data.GetQ()),
 	}
 	pp.creationTimestamp = pbPreParams.CreationTimestamp.AsTime()
+}
+
+// Marshal converts the Checkpoint to a byte array.
+func (c *Checkpoint) Marshal() ([]byte, error) {
+	excludedMembersIndexes := make([]uint32, len(c.ExcludedMembersIndexes))
+	for i, memberIndex := range c.ExcludedMembersIndexes {
+		excludedMembersIndexes[i] = uint32(memberIndex)
+	}
+
+	ephemeralPrivateKeys := make(map[uint32][]byte, len(c.ephemeralKeyPairs))
+	for memberIndex, keyPair := range c.ephemeralKeyPairs {
+		ephemeralPrivateKeys[uint32(memberIndex)] = keyPair.PrivateKey.Marshal()
+	}
+
+	receivedMessages := make(
+		[]*pb.Checkpoint_ReceivedMessage,
+		len(c.receivedMessages),
+	)
+	for i, receivedMessage := range c.receivedMessages {
+		payload, ok := receivedMessage.payload.(*ephemeralPublicKeyMessage)
+		if !ok {
+			return nil, fmt.Errorf(
+				"unexpected checkpoint message type: [%v]",
+				receivedMessage.Type(),
+			)
+		}
+
+		payloadBytes, err := payload.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot marshal checkpoint message: [%v]",
+				err,
+			)
+		}
+
+		receivedMessages[i] = &pb.Checkpoint_ReceivedMessage{
+			SenderPublicKey: receivedMessage.senderPublicKey,
+			Payload:         payloadBytes,
+		}
+	}
+
+	return proto.Marshal(&pb.Checkpoint{
+		SessionID:              c.SessionID,
+		MemberIndex:            uint32(c.MemberIndex),
+		ExcludedMembersIndexes: excludedMembersIndexes,
+		State:                  uint32(c.State),
+		EphemeralPrivateKeys:   ephemeralPrivateKeys,
+		ReceivedMessages:       receivedMessages,
+	})
+}
+
+// Unmarshal converts a byte array produced by Marshal to a Checkpoint.
+func (c *Checkpoint) Unmarshal(bytes []byte) error {
+	pbCheckpoint := pb.Checkpoint{}
+	if err := proto.Unmarshal(bytes, &pbCheckpoint); err != nil {
+		return fmt.Errorf("failed to unmarshal checkpoint: [%v]", err)
+	}
+
+	if err := validateMemberIndex(pbCheckpoint.MemberIndex); err != nil {
+		return err
+	}
+
+	excludedMembersIndexes := make(
+		[]group.MemberIndex,
+		len(pbCheckpoint.ExcludedMembersIndexes),
+	)
+	for i, memberIndex := range pbCheckpoint.ExcludedMembersIndexes {
+		if err := validateMemberIndex(memberIndex); err != nil {
+			return err
+		}
+		excludedMembersIndexes[i] = group.MemberIndex(memberIndex)
+	}
+
+	ephemeralKeyPairs := make(
+		map[group.MemberIndex]*ephemeral.KeyPair,
+		len(pbCheckpoint.EphemeralPrivateKeys),
+	)
+	for memberIndex, privateKeyBytes := range pbCheckpoint.EphemeralPrivateKeys {
+		if err := validateMemberIndex(memberIndex); err != nil {
+			return err
+		}
+
+		privateKey := ephemeral.UnmarshalPrivateKey(privateKeyBytes)
+		ephemeralKeyPairs[group.MemberIndex(memberIndex)] = &ephemeral.KeyPair{
+			PrivateKey: privateKey,
+			PublicKey:  (*ephemeral.PublicKey)(&privateKey.PublicKey),
+		}
+	}
+
+	receivedMessages := make(
+		[]*checkpointMessage,
+		len(pbCheckpoint.ReceivedMessages),
+	)
+	for i, receivedMessage := range pbCheckpoint.ReceivedMessages {
+		payload := &ephemeralPublicKeyMessage{}
+		if err := payload.Unmarshal(receivedMessage.Payload); err != nil {
+			return fmt.Errorf(
+				"cannot unmarshal checkpoint message: [%v]",
+				err,
+			)
+		}
+
+		receivedMessages[i] = &checkpointMessage{
+			senderPublicKey: receivedMessage.SenderPublicKey,
+			payload:         payload,
+		}
+	}
+
+	c.SessionID = pbCheckpoint.SessionID
+	c.MemberIndex = group.MemberIndex(pbCheckpoint.MemberIndex)
+	c.ExcludedMembersIndexes = excludedMembersIndexes
+	c.State = CheckpointState(pbCheckpoint.State)
+	c.ephemeralKeyPairs = ephemeralKeyPairs
+	c.receivedMessages = receivedMessages
 
 	return nil
 }
@@ -351,3 +351,52 @@ func TestPreParamsMarshalling(t *testing.T) {
 		t.Errorf("unmarshaled pre params data are invalid")
 	}
 }
+
+func TestCheckpoint_MarshalingRoundtrip(t *testing.T) {
+	keyPair1, err := ephemeral.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPair2, err := ephemeral.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint := &Checkpoint{
+		SessionID:              "session-1",
+		MemberIndex:            group.MemberIndex(1),
+		ExcludedMembersIndexes: []group.MemberIndex{3},
+		State:                  EphemeralKeysExchangedCheckpoint,
+		ephemeralKeyPairs: map[group.MemberIndex]*ephemeral.KeyPair{
+			2: keyPair1,
+			4: keyPair2,
+		},
+		receivedMessages: []*checkpointMessage{
+			{
+				senderPublicKey: []byte{1, 2, 3},
+				payload: &ephemeralPublicKeyMessage{
+					senderID: group.MemberIndex(2),
+					ephemeralPublicKeys: map[group.MemberIndex]*ephemeral.PublicKey{
+						1: keyPair2.PublicKey,
+					},
+					sessionID: "session-1",
+				},
+			},
+		},
+	}
+	unmarshaled := &Checkpoint{}
+
+	err = pbutils.RoundTrip(checkpoint, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(checkpoint, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled checkpoint")
+	}
+}
+
+func TestFuzzCheckpoint_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&Checkpoint{})
+}
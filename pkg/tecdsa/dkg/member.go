@@ -32,6 +32,9 @@ type member struct {
 	keyGenerationConcurrency int
 	// Instance of the member identity converter.
 	identityConverter *identityConverter
+	// Function recording protocol checkpoints. Checkpoints are not recorded
+	// if nil.
+	checkpointFn CheckpointFn
 }
 
 // newMember creates a new member in an initial state
@@ -45,6 +48,7 @@ func newMember(
 	sessionID string,
 	preParamsFn func() (*PreParams, error),
 	keyGenerationConcurrency int,
+	checkpointFn CheckpointFn,
 ) *member {
 	return &member{
 		logger:                   logger,
@@ -55,6 +59,7 @@ func newMember(
 		preParamsFn:              preParamsFn,
 		keyGenerationConcurrency: keyGenerationConcurrency,
 		identityConverter:        &identityConverter{seed: seed},
+		checkpointFn:             checkpointFn,
 	}
 }
 
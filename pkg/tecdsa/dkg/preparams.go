@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"sort"
 	"sync"
 	"time"
@@ -31,16 +32,243 @@ func newPreParams(data *keygen.LocalPreParams) *PreParams {
 	return &PreParams{data, time.Now().UTC()}
 }
 
+// age returns the age of the pre-parameters at the given time.
+func (pp *PreParams) age(now time.Time) time.Duration {
+	return now.Sub(pp.creationTimestamp)
+}
+
+const (
+	// minModulusBitLength is the minimum bit length of the Paillier modulus
+	// and the NTilde modulus. tss-lib generates both as products of two
+	// 1024-bit safe primes so the product has at least 2047 bits.
+	minModulusBitLength = 2047
+
+	// preParamsPoolSweepInterval is the interval of the pre-parameters pool
+	// sweep removing expired entries and refreshing the pool statistics.
+	preParamsPoolSweepInterval = 1 * time.Minute
+
+	// preParamsReplenishmentWindow is the window used to compute the pool
+	// replenishment rate.
+	preParamsReplenishmentWindow = 1 * time.Hour
+
+	// preParamsGenerationSamples is the number of the most recent generations
+	// used to compute the generation throughput.
+	preParamsGenerationSamples = 10
+)
+
+// validatePreParams checks the quality of the given pre-parameters. Apart
+// from the presence of all fields checked by tss-lib, it verifies the
+// relations between the numbers so that corrupted pre-parameters never enter
+// the DKG.
+func validatePreParams(data *keygen.LocalPreParams) error {
+	if data == nil || !data.ValidateWithProof() {
+		return fmt.Errorf("missing fields")
+	}
+
+	paillierSK := data.PaillierSK
+	if paillierSK.N == nil || paillierSK.PhiN == nil || paillierSK.LambdaN == nil {
+		return fmt.Errorf("missing Paillier key fields")
+	}
+
+	for _, value := range []*big.Int{
+		paillierSK.N,
+		paillierSK.PhiN,
+		paillierSK.LambdaN,
+		data.NTildei,
+		data.H1i,
+		data.H2i,
+		data.Alpha,
+		data.Beta,
+		data.P,
+		data.Q,
+	} {
+		if value.Sign() <= 0 {
+			return fmt.Errorf("non-positive value")
+		}
+	}
+
+	if paillierSK.N.BitLen() < minModulusBitLength {
+		return fmt.Errorf(
+			"Paillier modulus too short: [%d] bits",
+			paillierSK.N.BitLen(),
+		)
+	}
+
+	if paillierSK.PhiN.Cmp(paillierSK.N) >= 0 ||
+		new(big.Int).Mod(paillierSK.PhiN, paillierSK.LambdaN).Sign() != 0 ||
+		new(big.Int).GCD(nil, nil, paillierSK.N, paillierSK.PhiN).Cmp(
+			big.NewInt(1),
+		) != 0 {
+		return fmt.Errorf("inconsistent Paillier private key")
+	}
+
+	if data.NTildei.BitLen() < minModulusBitLength {
+		return fmt.Errorf(
+			"NTilde modulus too short: [%d] bits",
+			data.NTildei.BitLen(),
+		)
+	}
+
+	// NTilde is the product of safe primes 2P+1 and 2Q+1.
+	safePrime := func(prime *big.Int) *big.Int {
+		safePrime := new(big.Int).Lsh(prime, 1)
+		return safePrime.Add(safePrime, big.NewInt(1))
+	}
+	if new(big.Int).Mul(safePrime(data.P), safePrime(data.Q)).Cmp(
+		data.NTildei,
+	) != 0 {
+		return fmt.Errorf("NTilde does not match its factors")
+	}
+
+	if data.H1i.Cmp(data.NTildei) >= 0 ||
+		data.H2i.Cmp(data.NTildei) >= 0 ||
+		data.H1i.Cmp(data.H2i) == 0 {
+		return fmt.Errorf("invalid h1 and h2 values")
+	}
+
+	// h2 = h1^alpha mod NTilde and h1 = h2^beta mod NTilde.
+	if new(big.Int).Exp(data.H1i, data.Alpha, data.NTildei).Cmp(data.H2i) != 0 ||
+		new(big.Int).Exp(data.H2i, data.Beta, data.NTildei).Cmp(data.H1i) != 0 {
+		return fmt.Errorf("h1 and h2 do not match alpha and beta")
+	}
+
+	return nil
+}
+
+// PreParamsPoolStats holds statistics of the pre-parameters pool.
+type PreParamsPoolStats struct {
+	// MinAge is the age of the youngest pre-parameters in the pool.
+	MinAge time.Duration
+	// MedianAge is the median age of pre-parameters in the pool.
+	MedianAge time.Duration
+	// MaxAge is the age of the oldest pre-parameters in the pool.
+	MaxAge time.Duration
+	// ReplenishmentRate is the number of pre-parameters added to the pool
+	// during the last hour.
+	ReplenishmentRate int
+	// GenerationThroughput is the number of pre-parameters the pool is able
+	// to generate per hour, based on the duration of the most recent
+	// generations. Zero if nothing was generated yet.
+	GenerationThroughput float64
+	// DiscardedCount is the number of pre-parameters discarded because they
+	// expired or failed the validation.
+	DiscardedCount int
+}
+
+// preParamsPoolStats tracks statistics of the pre-parameters pool.
+type preParamsPoolStats struct {
+	mutex sync.Mutex
+
+	// ages holds the ages of pre-parameters in the pool as observed during
+	// the last pool sweep, sorted in ascending order.
+	ages []time.Duration
+	// sweepTime is the time of the last pool sweep.
+	sweepTime time.Time
+	// generationTimes holds the times of generations within the
+	// replenishment window.
+	generationTimes []time.Time
+	// generationDurations holds the durations of the most recent generations.
+	generationDurations []time.Duration
+	discardedCount      int
+}
+
+func (ppps *preParamsPoolStats) recordAges(ages []time.Duration, now time.Time) {
+	ppps.mutex.Lock()
+	defer ppps.mutex.Unlock()
+
+	sort.Slice(ages, func(i, j int) bool {
+		return ages[i] < ages[j]
+	})
+
+	ppps.ages = ages
+	ppps.sweepTime = now
+}
+
+func (ppps *preParamsPoolStats) recordGeneration(
+	generationTime time.Time,
+	duration time.Duration,
+) {
+	ppps.mutex.Lock()
+	defer ppps.mutex.Unlock()
+
+	ppps.generationTimes = append(ppps.generationTimes, generationTime)
+	ppps.pruneGenerationTimes(generationTime)
+
+	ppps.generationDurations = append(ppps.generationDurations, duration)
+	if len(ppps.generationDurations) > preParamsGenerationSamples {
+		ppps.generationDurations = ppps.generationDurations[1:]
+	}
+}
+
+func (ppps *preParamsPoolStats) recordDiscarded(count int) {
+	ppps.mutex.Lock()
+	defer ppps.mutex.Unlock()
+
+	ppps.discardedCount += count
+}
+
+// pruneGenerationTimes removes generation times that are outside of the
+// replenishment window. Must be called with the mutex held.
+func (ppps *preParamsPoolStats) pruneGenerationTimes(now time.Time) {
+	windowStart := now.Add(-preParamsReplenishmentWindow)
+
+	for len(ppps.generationTimes) > 0 &&
+		!ppps.generationTimes[0].After(windowStart) {
+		ppps.generationTimes = ppps.generationTimes[1:]
+	}
+}
+
+func (ppps *preParamsPoolStats) snapshot(now time.Time) PreParamsPoolStats {
+	ppps.mutex.Lock()
+	defer ppps.mutex.Unlock()
+
+	ppps.pruneGenerationTimes(now)
+
+	stats := PreParamsPoolStats{
+		ReplenishmentRate: len(ppps.generationTimes),
+		DiscardedCount:    ppps.discardedCount,
+	}
+
+	if len(ppps.ages) > 0 {
+		// Ages were observed during the last sweep so all of them have to be
+		// increased by the time elapsed since then.
+		elapsed := now.Sub(ppps.sweepTime)
+		stats.MinAge = ppps.ages[0] + elapsed
+		stats.MedianAge = ppps.ages[len(ppps.ages)/2] + elapsed
+		stats.MaxAge = ppps.ages[len(ppps.ages)-1] + elapsed
+	}
+
+	if len(ppps.generationDurations) > 0 {
+		var total time.Duration
+		for _, duration := range ppps.generationDurations {
+			total += duration
+		}
+
+		average := total / time.Duration(len(ppps.generationDurations))
+		if average > 0 {
+			stats.GenerationThroughput = float64(time.Hour) / float64(average)
+		}
+	}
+
+	return stats
+}
+
 // tssPreParamsPool is a pool holding TSS pre parameters. It autogenerates
 // entries up to the pool size. When an entry is pulled from the pool it
-// will generate a new entry.
+// will generate a new entry. Entries older than the maximum age expire and
+// are removed from the pool. Entries that expired or do not pass the
+// validation are never returned from the pool.
 type tssPreParamsPool struct {
 	*generator.ParameterPool[PreParams]
 	logger log.StandardLogger
+	maxAge time.Duration
+	stats  *preParamsPoolStats
 }
 
-// newTssPreParamsPool initializes a new TSS pre-parameters pool.
+// newTssPreParamsPool initializes a new TSS pre-parameters pool. The pool is
+// swept periodically until the given context is done.
 func newTssPreParamsPool(
+	ctx context.Context,
 	logger log.StandardLogger,
 	scheduler *generator.Scheduler,
 	persistence persistence.BasicHandle,
@@ -48,17 +276,24 @@ func newTssPreParamsPool(
 	generationTimeout time.Duration,
 	generationDelay time.Duration,
 	generationConcurrency int,
+	maxAge time.Duration,
 ) *tssPreParamsPool {
 	logger.Infof(
 		"TSS pre-parameters target pool size is [%d], generation timeout is [%s] "+
-			"generation delay is [%v], and concurrency level is [%d]",
+			"generation delay is [%v], concurrency level is [%d], "+
+			"and maximum age is [%v]",
 		poolSize,
 		generationTimeout,
 		generationDelay,
 		generationConcurrency,
+		maxAge,
 	)
 
+	stats := &preParamsPoolStats{}
+
 	newPreParamsFn := func(ctx context.Context) *PreParams {
+		start := time.Now()
+
 		timingOutCtx, cancel := context.WithTimeout(ctx, generationTimeout)
 		defer cancel()
 
@@ -85,28 +320,175 @@ func newTssPreParamsPool(
 			return nil
 		}
 
+		stats.recordGeneration(time.Now(), time.Since(start))
+
 		return newPreParams(preParams)
 	}
 
+	tppp := &tssPreParamsPool{
+		logger: logger,
+		maxAge: maxAge,
+		stats:  stats,
+	}
+
 	tssPreParamsPersistance := newPreParamsStorage(persistence, logger)
 
-	return &tssPreParamsPool{
-		generator.NewParameterPool[PreParams](
-			logger,
-			scheduler,
-			&tssPreParamsPersistance,
-			poolSize,
-			newPreParamsFn,
-			generationDelay,
-		),
+	tppp.ParameterPool = generator.NewParameterPool[PreParams](
 		logger,
+		scheduler,
+		&checkingPreParamsStorage{
+			preParamsStorage: &tssPreParamsPersistance,
+			check: func(pp *PreParams) error {
+				return tppp.check(pp, time.Now())
+			},
+			stats: stats,
+		},
+		poolSize,
+		newPreParamsFn,
+		generationDelay,
+	)
+
+	tppp.sweep()
+
+	go func() {
+		ticker := time.NewTicker(preParamsPoolSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				tppp.sweep()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return tppp
+}
+
+// GetNow returns pre-parameters from the pool. Pre-parameters that expired or
+// do not pass the validation are discarded and the next ones are taken.
+// Returns generator.ErrEmptyPool when there are no more pre-parameters in the
+// pool.
+func (tppp *tssPreParamsPool) GetNow() (*PreParams, error) {
+	for {
+		preParams, err := tppp.ParameterPool.GetNow()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tppp.check(preParams, time.Now()); err != nil {
+			tppp.logger.Warnf("discarding pre-parameters: [%v]", err)
+			tppp.stats.recordDiscarded(1)
+			continue
+		}
+
+		return preParams, nil
 	}
 }
 
+// Stats returns statistics of the pool.
+func (tppp *tssPreParamsPool) Stats() PreParamsPoolStats {
+	return tppp.stats.snapshot(time.Now())
+}
+
+// check returns an error if the given pre-parameters expired at the given
+// time or do not pass the validation.
+func (tppp *tssPreParamsPool) check(pp *PreParams, now time.Time) error {
+	if tppp.isExpired(pp, now) {
+		return fmt.Errorf(
+			"pre-parameters expired; created at [%v]",
+			pp.creationTimestamp,
+		)
+	}
+
+	if err := validatePreParams(pp.data); err != nil {
+		return fmt.Errorf("pre-parameters failed validation: [%v]", err)
+	}
+
+	return nil
+}
+
+// isExpired returns true if the given pre-parameters are older than the
+// maximum age at the given time. Pre-parameters never expire if the maximum
+// age is not set.
+func (tppp *tssPreParamsPool) isExpired(pp *PreParams, now time.Time) bool {
+	return tppp.maxAge > 0 && pp.age(now) > tppp.maxAge
+}
+
+// sweep removes expired pre-parameters from the pool so that they can be
+// replaced with new ones and refreshes the pool age statistics.
+func (tppp *tssPreParamsPool) sweep() {
+	now := time.Now()
+
+	var ages []time.Duration
+	expired := tppp.Prune(func(pp *PreParams) bool {
+		if tppp.isExpired(pp, now) {
+			return true
+		}
+
+		ages = append(ages, pp.age(now))
+		return false
+	})
+
+	if expired > 0 {
+		tppp.logger.Infof(
+			"removed [%d] expired pre-parameters from the pool",
+			expired,
+		)
+		tppp.stats.recordDiscarded(expired)
+	}
+
+	tppp.stats.recordAges(ages, now)
+}
+
 const (
 	dirName = "preparams"
 )
 
+// checkingPreParamsStorage is a pre-parameters storage re-validating
+// pre-parameters read from the persistence. Pre-parameters that do not pass
+// the check are deleted from the persistence and never loaded into the pool.
+type checkingPreParamsStorage struct {
+	*preParamsStorage
+
+	check func(*PreParams) error
+	stats *preParamsPoolStats
+}
+
+// ReadAll reads all the PreParams stored in the storage that pass the check
+// and returns them as a slice. PreParams that do not pass the check are
+// deleted from the storage.
+func (cpps *checkingPreParamsStorage) ReadAll() ([]*PersistedPreParams, error) {
+	allPreParams, err := cpps.preParamsStorage.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	checkedPreParams := make([]*PersistedPreParams, 0, len(allPreParams))
+	for _, pp := range allPreParams {
+		if err := cpps.check(&pp.Data); err != nil {
+			cpps.logger.Warnf("discarding persisted [%s]: [%v]", pp.ID, err)
+			cpps.stats.recordDiscarded(1)
+
+			if err := cpps.Delete(pp); err != nil {
+				cpps.logger.Errorf(
+					"could not delete persisted [%s]: [%v]",
+					pp.ID,
+					err,
+				)
+			}
+
+			continue
+		}
+
+		checkedPreParams = append(checkedPreParams, pp)
+	}
+
+	return checkedPreParams, nil
+}
+
 // PersistedPreParams is an alias for Persisted PreParams used in generator.Persistence
 // interface implementation.
 type PersistedPreParams = generator.Persisted[PreParams]
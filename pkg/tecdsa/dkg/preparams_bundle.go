@@ -0,0 +1,309 @@
+package dkg
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/ecdsa/keygen"
+	"github.com/ipfs/go-log/v2"
+	"golang.org/x/crypto/scrypt"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/keep-network/keep-common/pkg/encryption"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/tecdsa/dkg/gen/pb"
+)
+
+// Parameters of the scrypt key derivation function used to derive the
+// pre-parameters bundle encryption key from the password. The cost parameters
+// are the ones recommended for interactive logins.
+const (
+	bundleSaltLength = 32
+	bundleScryptN    = 1 << 15
+	bundleScryptR    = 8
+	bundleScryptP    = 1
+)
+
+// GeneratePreParams generates the given number of tECDSA DKG pre-parameters
+// one after another. It is meant to be used to generate pre-parameters
+// offline, on a machine other than the one running the client. Generation of
+// a single pre-parameters entry times out after the given timeout and is
+// retried until the context is done.
+func GeneratePreParams(
+	ctx context.Context,
+	logger log.StandardLogger,
+	count int,
+	generationTimeout time.Duration,
+	generationConcurrency int,
+) ([]*PreParams, error) {
+	generated := make([]*PreParams, 0, count)
+
+	for len(generated) < count {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf(
+				"generated [%d] out of [%d] pre-parameters: [%w]",
+				len(generated),
+				count,
+				err,
+			)
+		}
+
+		timingOutCtx, cancel := context.WithTimeout(ctx, generationTimeout)
+		data, err := keygen.GeneratePreParamsWithContext(
+			timingOutCtx,
+			generationConcurrency,
+		)
+		cancel()
+		if err != nil {
+			logger.Warnf("failed to generate TSS pre-params: [%v]", err)
+			continue
+		}
+
+		generated = append(generated, newPreParams(data))
+
+		logger.Infof(
+			"generated [%d] out of [%d] pre-parameters",
+			len(generated),
+			count,
+		)
+	}
+
+	return generated, nil
+}
+
+// EncryptPreParamsBundle packs the given pre-parameters into a bundle
+// encrypted with the given password. The encryption key is derived from the
+// password using scrypt with a random salt stored in the bundle. The bundle
+// can be transferred between machines and imported into the pool of a client
+// using ImportPreParams.
+func EncryptPreParamsBundle(
+	preParams []*PreParams,
+	password string,
+) ([]byte, error) {
+	bundle := &pb.PreParamsBundle{
+		PreParams: make([]*pb.PreParams, len(preParams)),
+	}
+
+	for i, pp := range preParams {
+		bundle.PreParams[i] = pp.toProto()
+	}
+
+	bundleBytes, err := proto.Marshal(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal pre-parameters bundle: [%v]", err)
+	}
+
+	salt := make([]byte, bundleSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("cannot generate salt: [%v]", err)
+	}
+
+	box, err := newBundleBox(password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := box.Encrypt(bundleBytes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encrypt pre-parameters bundle: [%v]", err)
+	}
+
+	encrypted, err := proto.Marshal(&pb.EncryptedPreParamsBundle{
+		Salt:       salt,
+		Ciphertext: ciphertext,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot marshal encrypted pre-parameters bundle: [%v]",
+			err,
+		)
+	}
+
+	return encrypted, nil
+}
+
+// DecryptPreParamsBundle decrypts the given pre-parameters bundle with the
+// given password and unpacks pre-parameters from it. Pre-parameters are not
+// validated by this function.
+func DecryptPreParamsBundle(
+	encrypted []byte,
+	password string,
+) ([]*PreParams, error) {
+	encryptedBundle := &pb.EncryptedPreParamsBundle{}
+	if err := proto.Unmarshal(encrypted, encryptedBundle); err != nil {
+		return nil, fmt.Errorf(
+			"cannot unmarshal encrypted pre-parameters bundle: [%v]",
+			err,
+		)
+	}
+
+	if len(encryptedBundle.Salt) != bundleSaltLength {
+		return nil, fmt.Errorf(
+			"invalid pre-parameters bundle salt length: [%d]",
+			len(encryptedBundle.Salt),
+		)
+	}
+
+	box, err := newBundleBox(password, encryptedBundle.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	bundleBytes, err := box.Decrypt(encryptedBundle.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot decrypt pre-parameters bundle; "+
+				"make sure the password is correct: [%v]",
+			err,
+		)
+	}
+
+	bundle := &pb.PreParamsBundle{}
+	if err := proto.Unmarshal(bundleBytes, bundle); err != nil {
+		return nil, fmt.Errorf(
+			"cannot unmarshal pre-parameters bundle: [%v]",
+			err,
+		)
+	}
+
+	preParams := make([]*PreParams, len(bundle.PreParams))
+	for i, pbPreParams := range bundle.PreParams {
+		preParams[i] = &PreParams{}
+		preParams[i].fromProto(pbPreParams)
+	}
+
+	return preParams, nil
+}
+
+// newBundleBox creates the box encrypting pre-parameters bundles with the key
+// derived from the given password and salt.
+func newBundleBox(password string, salt []byte) (encryption.Box, error) {
+	derivedKey, err := scrypt.Key(
+		[]byte(password),
+		salt,
+		bundleScryptN,
+		bundleScryptR,
+		bundleScryptP,
+		encryption.KeyLength,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot derive bundle encryption key: [%v]", err)
+	}
+
+	var key [encryption.KeyLength]byte
+	copy(key[:], derivedKey)
+
+	return encryption.NewBox(key), nil
+}
+
+// ExportPreParams takes up to the given number of the oldest pre-parameters
+// from the pool persisted using the given persistence handle and passes them
+// to the export function. If the count is zero, all pre-parameters are taken.
+// Once the export function succeeds, the exported pre-parameters are deleted
+// from the pool so they are never used by two clients. The client owning the
+// pool must not run during the export. The function returns the number of
+// exported pre-parameters.
+func ExportPreParams(
+	persistence persistence.BasicHandle,
+	logger log.StandardLogger,
+	count int,
+	exportFn func([]*PreParams) error,
+) (int, error) {
+	storage := newPreParamsStorage(persistence, logger)
+
+	persisted, err := storage.ReadAll()
+	if err != nil {
+		return 0, fmt.Errorf("cannot read pre-parameters pool: [%v]", err)
+	}
+
+	if count > 0 && count < len(persisted) {
+		persisted = persisted[:count]
+	}
+
+	preParams := make([]*PreParams, len(persisted))
+	for i := range persisted {
+		preParams[i] = &persisted[i].Data
+	}
+
+	if err := exportFn(preParams); err != nil {
+		return 0, fmt.Errorf("cannot export pre-parameters: [%w]", err)
+	}
+
+	for _, pp := range persisted {
+		if err := storage.Delete(pp); err != nil {
+			return 0, fmt.Errorf(
+				"pre-parameters were exported but [%s] could not be "+
+					"deleted from the pool; delete it manually: [%v]",
+				pp.ID,
+				err,
+			)
+		}
+	}
+
+	return len(preParams), nil
+}
+
+// ImportPreParams validates the given pre-parameters and adds them to the pool
+// persisted using the given persistence handle. Pre-parameters that do not
+// pass the validation or are already present in the pool are skipped.
+// The client owning the pool must not run during the import; imported
+// pre-parameters are loaded into the pool on the next client start.
+// The function returns the number of imported pre-parameters.
+func ImportPreParams(
+	persistence persistence.BasicHandle,
+	logger log.StandardLogger,
+	preParams []*PreParams,
+) (int, error) {
+	storage := newPreParamsStorage(persistence, logger)
+
+	persisted, err := storage.ReadAll()
+	if err != nil {
+		return 0, fmt.Errorf("cannot read pre-parameters pool: [%v]", err)
+	}
+
+	known := make(map[[sha256.Size]byte]bool)
+	for _, pp := range persisted {
+		known[pp.Data.fingerprint()] = true
+	}
+
+	imported := 0
+	for i, pp := range preParams {
+		if err := validatePreParams(pp.data); err != nil {
+			logger.Warnf(
+				"pre-parameters [%d] failed validation; skipping: [%v]",
+				i,
+				err,
+			)
+			continue
+		}
+
+		fingerprint := pp.fingerprint()
+		if known[fingerprint] {
+			logger.Warnf("pre-parameters [%d] already in the pool; skipping", i)
+			continue
+		}
+
+		if _, err := storage.Save(pp); err != nil {
+			return imported, fmt.Errorf(
+				"cannot save pre-parameters [%d]: [%v]",
+				i,
+				err,
+			)
+		}
+
+		known[fingerprint] = true
+		imported++
+	}
+
+	return imported, nil
+}
+
+// fingerprint returns a value uniquely identifying the pre-parameters. The
+// fingerprint is the hash of the Paillier modulus, which is different for
+// each pre-parameters entry.
+func (pp *PreParams) fingerprint() [sha256.Size]byte {
+	return sha256.Sum256(pp.data.PaillierSK.PublicKey.N.Bytes())
+}
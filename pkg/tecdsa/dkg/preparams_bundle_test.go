@@ -0,0 +1,242 @@
+package dkg
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/ecdsa/keygen"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/keep-network/keep-core/pkg/internal/tecdsatest"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/tecdsa/dkg/gen/pb"
+)
+
+func TestPreParamsBundle_EncryptDecrypt(t *testing.T) {
+	preParams := loadTestPreParams(t, 2)
+
+	bundle, err := EncryptPreParamsBundle(preParams, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decrypted, err := DecryptPreParamsBundle(bundle, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(preParams, decrypted) {
+		t.Errorf("unexpected content of decrypted pre-parameters")
+	}
+
+	_, err = DecryptPreParamsBundle(bundle, "other password")
+	if err == nil {
+		t.Errorf("expected error for the wrong password")
+	}
+}
+
+func TestImportPreParams(t *testing.T) {
+	handle := newTestPersistence(t)
+
+	preParams := loadTestPreParams(t, 2)
+
+	invalidPreParams := newPreParams(&keygen.LocalPreParams{
+		PaillierSK: preParams[0].data.PaillierSK,
+		NTildei:    preParams[0].data.NTildei,
+		H1i:        preParams[0].data.H1i,
+		H2i:        preParams[0].data.H2i,
+	})
+
+	imported, err := ImportPreParams(
+		handle,
+		&testutils.MockLogger{},
+		[]*PreParams{preParams[0], preParams[0], invalidPreParams},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "imported count", 1, imported)
+	assertPoolSize(t, handle, 1)
+
+	// Pre-parameters already present in the pool should be skipped.
+	imported, err = ImportPreParams(
+		handle,
+		&testutils.MockLogger{},
+		preParams,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "imported count", 1, imported)
+	assertPoolSize(t, handle, 2)
+}
+
+func TestExportPreParams(t *testing.T) {
+	handle := newTestPersistence(t)
+
+	preParams := loadTestPreParams(t, 2)
+
+	_, err := ImportPreParams(handle, &testutils.MockLogger{}, preParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Pre-parameters should stay in the pool if the export failed.
+	_, err = ExportPreParams(
+		handle,
+		&testutils.MockLogger{},
+		1,
+		func([]*PreParams) error {
+			return fmt.Errorf("unexpected error")
+		},
+	)
+	if err == nil {
+		t.Fatal("expected export error")
+	}
+
+	assertPoolSize(t, handle, 2)
+
+	var exported []*PreParams
+	count, err := ExportPreParams(
+		handle,
+		&testutils.MockLogger{},
+		1,
+		func(preParams []*PreParams) error {
+			exported = preParams
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "exported count", 1, count)
+	testutils.AssertIntsEqual(t, "exported pre-parameters", 1, len(exported))
+	assertPoolSize(t, handle, 1)
+
+	// Exporting all pre-parameters.
+	count, err = ExportPreParams(
+		handle,
+		&testutils.MockLogger{},
+		0,
+		func(preParams []*PreParams) error {
+			exported = append(exported, preParams...)
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "exported count", 1, count)
+	assertPoolSize(t, handle, 0)
+
+	if exported[0].fingerprint() == exported[1].fingerprint() {
+		t.Errorf("the same pre-parameters were exported twice")
+	}
+}
+
+func loadTestPreParams(t *testing.T, count int) []*PreParams {
+	testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(count)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	preParams := make([]*PreParams, count)
+	for i := range testData {
+		localPreParams := testData[i].LocalPreParams
+		preParams[i] = newPreParams(&localPreParams)
+	}
+
+	// Pass pre-parameters through the marshaling roundtrip to normalize
+	// the creation timestamp precision.
+	for i := range preParams {
+		bytes, err := preParams[i].Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		preParams[i] = &PreParams{}
+		if err := preParams[i].Unmarshal(bytes); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return preParams
+}
+
+func newTestPersistence(t *testing.T) persistence.BasicHandle {
+	handle, err := persistence.NewBasicDiskHandle(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return handle
+}
+
+func assertPoolSize(
+	t *testing.T,
+	handle persistence.BasicHandle,
+	expectedSize int,
+) {
+	storage := newPreParamsStorage(handle, &testutils.MockLogger{})
+
+	persisted, err := storage.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "pool size", expectedSize, len(persisted))
+}
+
+func TestPreParamsBundle_Salt(t *testing.T) {
+	preParams := loadTestPreParams(t, 1)
+
+	bundle1, err := EncryptPreParamsBundle(preParams, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle2, err := EncryptPreParamsBundle(preParams, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted1 := &pb.EncryptedPreParamsBundle{}
+	if err := proto.Unmarshal(bundle1, encrypted1); err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted2 := &pb.EncryptedPreParamsBundle{}
+	if err := proto.Unmarshal(bundle2, encrypted2); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"salt length",
+		bundleSaltLength,
+		len(encrypted1.Salt),
+	)
+
+	if bytes.Equal(encrypted1.Salt, encrypted2.Salt) {
+		t.Errorf("the same salt used for two bundles")
+	}
+
+	// Bundle with a tampered salt should not be decrypted.
+	encrypted1.Salt[0] ^= 0xff
+	tampered, err := proto.Marshal(encrypted1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = DecryptPreParamsBundle(tampered, "password")
+	if err == nil {
+		t.Errorf("expected error for the tampered salt")
+	}
+}
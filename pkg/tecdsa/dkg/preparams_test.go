@@ -0,0 +1,276 @@
+package dkg
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/ecdsa/keygen"
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/pkg/generator"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+func TestValidatePreParams(t *testing.T) {
+	preParams := loadTestPreParams(t, 1)[0]
+
+	one := big.NewInt(1)
+
+	var tests = map[string]struct {
+		corruptFn     func(data *keygen.LocalPreParams)
+		expectedError bool
+	}{
+		"valid pre-parameters": {
+			corruptFn:     func(data *keygen.LocalPreParams) {},
+			expectedError: false,
+		},
+		"missing alpha": {
+			corruptFn: func(data *keygen.LocalPreParams) {
+				data.Alpha = nil
+			},
+			expectedError: true,
+		},
+		"short Paillier modulus": {
+			corruptFn: func(data *keygen.LocalPreParams) {
+				data.PaillierSK.N = new(big.Int).Rsh(data.PaillierSK.N, 2)
+			},
+			expectedError: true,
+		},
+		"inconsistent Paillier private key": {
+			corruptFn: func(data *keygen.LocalPreParams) {
+				data.PaillierSK.PhiN = new(big.Int).Add(data.PaillierSK.PhiN, one)
+			},
+			expectedError: true,
+		},
+		"NTilde not matching its factors": {
+			corruptFn: func(data *keygen.LocalPreParams) {
+				data.P = new(big.Int).Add(data.P, one)
+			},
+			expectedError: true,
+		},
+		"h2 not matching alpha": {
+			corruptFn: func(data *keygen.LocalPreParams) {
+				data.H2i = new(big.Int).Add(data.H2i, one)
+			},
+			expectedError: true,
+		},
+		"h1 not matching beta": {
+			corruptFn: func(data *keygen.LocalPreParams) {
+				data.Beta = new(big.Int).Add(data.Beta, one)
+			},
+			expectedError: true,
+		},
+		"zero h1": {
+			corruptFn: func(data *keygen.LocalPreParams) {
+				data.H1i = big.NewInt(0)
+			},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			data := copyLocalPreParams(preParams.data)
+
+			test.corruptFn(data)
+
+			err := validatePreParams(data)
+
+			if test.expectedError && err == nil {
+				t.Errorf("expected validation error")
+			}
+			if !test.expectedError && err != nil {
+				t.Errorf("unexpected validation error: [%v]", err)
+			}
+		})
+	}
+}
+
+func TestTssPreParamsPool_Startup(t *testing.T) {
+	handle := newTestPersistence(t)
+	storage := newPreParamsStorage(handle, &testutils.MockLogger{})
+
+	preParams := loadTestPreParams(t, 3)
+
+	// Fresh pre-parameters should be loaded into the pool.
+	preParams[0].creationTimestamp = time.Now().Add(-1 * time.Hour)
+	// Expired pre-parameters should be removed.
+	preParams[1].creationTimestamp = time.Now().Add(-3 * time.Hour)
+	// Corrupted pre-parameters should be removed.
+	preParams[2].data.H2i = new(big.Int).Add(preParams[2].data.H2i, big.NewInt(1))
+
+	for _, pp := range preParams {
+		if _, err := storage.Save(pp); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pool := newTestTssPreParamsPool(handle, 5, 2*time.Hour)
+
+	testutils.AssertIntsEqual(t, "pool size", 1, pool.ParametersCount())
+	assertPoolSize(t, handle, 1)
+
+	stats := pool.Stats()
+	testutils.AssertIntsEqual(t, "discarded count", 2, stats.DiscardedCount)
+	if stats.MaxAge < 1*time.Hour || stats.MaxAge > 2*time.Hour {
+		t.Errorf("unexpected max age: [%v]", stats.MaxAge)
+	}
+	if stats.MinAge != stats.MaxAge {
+		t.Errorf("expected min age to be equal to max age")
+	}
+
+	pp, err := pool.GetNow()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pp.fingerprint() != preParams[0].fingerprint() {
+		t.Errorf("unexpected pre-parameters returned from the pool")
+	}
+}
+
+func TestTssPreParamsPool_Expiry(t *testing.T) {
+	handle := newTestPersistence(t)
+	storage := newPreParamsStorage(handle, &testutils.MockLogger{})
+
+	preParams := loadTestPreParams(t, 3)
+	preParams[0].creationTimestamp = time.Now().Add(-3 * time.Hour)
+	preParams[1].creationTimestamp = time.Now().Add(-2 * time.Hour)
+	preParams[2].creationTimestamp = time.Now().Add(-1 * time.Hour)
+
+	for _, pp := range preParams {
+		if _, err := storage.Save(pp); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pool := newTestTssPreParamsPool(handle, 5, 4*time.Hour)
+
+	testutils.AssertIntsEqual(t, "pool size", 3, pool.ParametersCount())
+
+	// The oldest pre-parameters expire and should be discarded.
+	pool.maxAge = 150 * time.Minute
+
+	pp, err := pool.GetNow()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pp.fingerprint() != preParams[1].fingerprint() {
+		t.Errorf("unexpected pre-parameters returned from the pool")
+	}
+
+	testutils.AssertIntsEqual(t, "pool size", 1, pool.ParametersCount())
+
+	// The remaining pre-parameters expire and should be removed by the sweep.
+	pool.maxAge = 30 * time.Minute
+
+	pool.sweep()
+
+	testutils.AssertIntsEqual(t, "pool size", 0, pool.ParametersCount())
+	assertPoolSize(t, handle, 0)
+
+	testutils.AssertIntsEqual(
+		t,
+		"discarded count",
+		2,
+		pool.Stats().DiscardedCount,
+	)
+
+	_, err = pool.GetNow()
+	testutils.AssertErrorsSame(t, generator.ErrEmptyPool, err)
+}
+
+func TestPreParamsPoolStats(t *testing.T) {
+	stats := &preParamsPoolStats{}
+
+	now := time.Now()
+
+	stats.recordAges(
+		[]time.Duration{3 * time.Hour, 1 * time.Hour, 2 * time.Hour},
+		now,
+	)
+
+	// Generations outside of the replenishment window should not be counted.
+	stats.recordGeneration(now.Add(-90*time.Minute), 1*time.Minute)
+	stats.recordGeneration(now.Add(-30*time.Minute), 2*time.Minute)
+	stats.recordGeneration(now.Add(-10*time.Minute), 3*time.Minute)
+
+	stats.recordDiscarded(4)
+
+	snapshot := stats.snapshot(now.Add(10 * time.Minute))
+
+	expected := PreParamsPoolStats{
+		MinAge:               70 * time.Minute,
+		MedianAge:            130 * time.Minute,
+		MaxAge:               190 * time.Minute,
+		ReplenishmentRate:    2,
+		GenerationThroughput: 30,
+		DiscardedCount:       4,
+	}
+
+	if snapshot != expected {
+		t.Errorf(
+			"unexpected stats\nexpected: [%+v]\nactual:   [%+v]",
+			expected,
+			snapshot,
+		)
+	}
+}
+
+// newTestTssPreParamsPool creates a pre-parameters pool that does not
+// generate new pre-parameters.
+func newTestTssPreParamsPool(
+	handle persistence.BasicHandle,
+	poolSize int,
+	maxAge time.Duration,
+) *tssPreParamsPool {
+	scheduler := generator.StartScheduler()
+	scheduler.RegisterProtocol(&executingProtocol{})
+
+	// Wait until the scheduler observes the executing protocol and stops
+	// computations so that no pre-parameters are generated.
+	time.Sleep(2500 * time.Millisecond)
+
+	return newTssPreParamsPool(
+		context.Background(),
+		&testutils.MockLogger{},
+		scheduler,
+		handle,
+		poolSize,
+		time.Minute,
+		time.Duration(0),
+		1,
+		maxAge,
+	)
+}
+
+type executingProtocol struct{}
+
+func (ep *executingProtocol) IsExecuting() bool {
+	return true
+}
+
+func copyLocalPreParams(data *keygen.LocalPreParams) *keygen.LocalPreParams {
+	copyInt := func(value *big.Int) *big.Int {
+		return new(big.Int).Set(value)
+	}
+
+	return &keygen.LocalPreParams{
+		PaillierSK: &paillier.PrivateKey{
+			PublicKey: paillier.PublicKey{N: copyInt(data.PaillierSK.N)},
+			LambdaN:   copyInt(data.PaillierSK.LambdaN),
+			PhiN:      copyInt(data.PaillierSK.PhiN),
+		},
+		NTildei: copyInt(data.NTildei),
+		H1i:     copyInt(data.H1i),
+		H2i:     copyInt(data.H2i),
+		Alpha:   copyInt(data.Alpha),
+		Beta:    copyInt(data.Beta),
+		P:       copyInt(data.P),
+		Q:       copyInt(data.Q),
+	}
+}
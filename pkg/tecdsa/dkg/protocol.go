@@ -26,13 +26,19 @@ func (ekpgm *ephemeralKeyPairGeneratingMember) generateEphemeralKeyPair() (
 			continue
 		}
 
-		ephemeralKeyPair, err := ephemeral.GenerateKeyPair()
-		if err != nil {
-			return nil, err
-		}
+		// Key pairs restored from a checkpoint are reused as other members
+		// may have already received their public keys.
+		ephemeralKeyPair, ok := ekpgm.ephemeralKeyPairs[member]
+		if !ok {
+			var err error
+			ephemeralKeyPair, err = ephemeral.GenerateKeyPair()
+			if err != nil {
+				return nil, err
+			}
 
-		// save the generated ephemeral key to our state
-		ekpgm.ephemeralKeyPairs[member] = ephemeralKeyPair
+			// save the generated ephemeral key to our state
+			ekpgm.ephemeralKeyPairs[member] = ephemeralKeyPair
+		}
 
 		// store the public key to the map for the message
 		ephemeralKeys[member] = ephemeralKeyPair.PublicKey
@@ -81,6 +81,14 @@ type symmetricKeyGenerationState struct {
 }
 
 func (skgs *symmetricKeyGenerationState) Initiate(ctx context.Context) error {
+	skgs.member.recordCheckpoint(
+		skgs.member.ephemeralKeysExchangedCheckpoint(
+			skgs.GetAllReceivedMessages(
+				(&ephemeralPublicKeyMessage{}).Type(),
+			),
+		),
+	)
+
 	return skgs.member.generateSymmetricKeys(
 		receivedMessages[*ephemeralPublicKeyMessage](skgs.BaseAsyncState),
 	)
@@ -134,6 +142,8 @@ type tssRoundOneState struct {
 }
 
 func (tros *tssRoundOneState) Initiate(ctx context.Context) error {
+	tros.member.recordCheckpoint(tros.member.tssStartedCheckpoint())
+
 	message, err := tros.member.tssRoundOne(ctx)
 	if err != nil {
 		return err
@@ -0,0 +1,279 @@
+package resharing
+
+import (
+	fuzz "github.com/google/gofuzz"
+	"github.com/keep-network/keep-core/pkg/crypto/ephemeral"
+	"github.com/keep-network/keep-core/pkg/internal/pbutils"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"reflect"
+	"testing"
+)
+
+func TestEphemeralPublicKeyMessage_MarshalingRoundtrip(t *testing.T) {
+	keyPair1, err := ephemeral.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPair2, err := ephemeral.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKeys := make(map[group.MemberIndex]*ephemeral.PublicKey)
+	publicKeys[group.MemberIndex(211)] = keyPair1.PublicKey
+	publicKeys[group.MemberIndex(19)] = keyPair2.PublicKey
+
+	msg := &ephemeralPublicKeyMessage{
+		senderID:            group.MemberIndex(38),
+		ephemeralPublicKeys: publicKeys,
+		sessionID:           "session-1",
+	}
+	unmarshaled := &ephemeralPublicKeyMessage{}
+
+	err = pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzEphemeralPublicKeyMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID            group.MemberIndex
+			ephemeralPublicKeys map[group.MemberIndex]*ephemeral.PublicKey
+			sessionID           string
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&ephemeralPublicKeys)
+		f.Fuzz(&sessionID)
+
+		message := &ephemeralPublicKeyMessage{
+			senderID:            senderID,
+			ephemeralPublicKeys: ephemeralPublicKeys,
+			sessionID:           sessionID,
+		}
+
+		_ = pbutils.RoundTrip(message, &ephemeralPublicKeyMessage{})
+	}
+}
+
+func TestFuzzEphemeralPublicKeyMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&ephemeralPublicKeyMessage{})
+}
+
+func TestTssRoundOneMessage_MarshalingRoundtrip(t *testing.T) {
+	msg := &tssRoundOneMessage{
+		senderID:         group.MemberIndex(50),
+		tssPartyIDKey:    []byte{1, 2, 3},
+		broadcastPayload: []byte{4, 5, 6, 7, 8},
+		sessionID:        "session-1",
+	}
+	unmarshaled := &tssRoundOneMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzTssRoundOneMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID         group.MemberIndex
+			tssPartyIDKey    []byte
+			broadcastPayload []byte
+			sessionID        string
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&tssPartyIDKey)
+		f.Fuzz(&broadcastPayload)
+		f.Fuzz(&sessionID)
+
+		message := &tssRoundOneMessage{
+			senderID:         senderID,
+			tssPartyIDKey:    tssPartyIDKey,
+			broadcastPayload: broadcastPayload,
+			sessionID:        sessionID,
+		}
+
+		_ = pbutils.RoundTrip(message, &tssRoundOneMessage{})
+	}
+}
+
+func TestFuzzTssRoundOneMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&tssRoundOneMessage{})
+}
+
+func TestTssRoundTwoMessage_MarshalingRoundtrip(t *testing.T) {
+	msg := &tssRoundTwoMessage{
+		senderID:        group.MemberIndex(50),
+		oldGroupPayload: []byte{1, 2, 3, 4, 5},
+		newGroupPayload: []byte{6, 7, 8, 9, 10},
+		sessionID:       "session-1",
+	}
+	unmarshaled := &tssRoundTwoMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzTssRoundTwoMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID        group.MemberIndex
+			oldGroupPayload []byte
+			newGroupPayload []byte
+			sessionID       string
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&oldGroupPayload)
+		f.Fuzz(&newGroupPayload)
+		f.Fuzz(&sessionID)
+
+		message := &tssRoundTwoMessage{
+			senderID:        senderID,
+			oldGroupPayload: oldGroupPayload,
+			newGroupPayload: newGroupPayload,
+			sessionID:       sessionID,
+		}
+
+		_ = pbutils.RoundTrip(message, &tssRoundTwoMessage{})
+	}
+}
+
+func TestFuzzTssRoundTwoMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&tssRoundTwoMessage{})
+}
+
+func TestTssRoundThreeMessage_MarshalingRoundtrip(t *testing.T) {
+	msg := &tssRoundThreeMessage{
+		senderID:         group.MemberIndex(50),
+		broadcastPayload: []byte{1, 2, 3, 4, 5},
+		peersPayload: map[group.MemberIndex][]byte{
+			1: {6, 7, 8, 9, 10},
+			2: {11, 12, 13, 14, 15},
+		},
+		sessionID: "session-1",
+	}
+	unmarshaled := &tssRoundThreeMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzTssRoundThreeMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID         group.MemberIndex
+			broadcastPayload []byte
+			peersPayload     map[group.MemberIndex][]byte
+			sessionID        string
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&broadcastPayload)
+		f.Fuzz(&peersPayload)
+		f.Fuzz(&sessionID)
+
+		message := &tssRoundThreeMessage{
+			senderID:         senderID,
+			broadcastPayload: broadcastPayload,
+			peersPayload:     peersPayload,
+			sessionID:        sessionID,
+		}
+
+		_ = pbutils.RoundTrip(message, &tssRoundThreeMessage{})
+	}
+}
+
+func TestFuzzTssRoundThreeMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&tssRoundThreeMessage{})
+}
+
+func TestTssRoundFourMessage_MarshalingRoundtrip(t *testing.T) {
+	msg := &tssRoundFourMessage{
+		senderID:         group.MemberIndex(50),
+		broadcastPayload: []byte{1, 2, 3, 4, 5},
+		sessionID:        "session-1",
+	}
+	unmarshaled := &tssRoundFourMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzTssRoundFourMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID         group.MemberIndex
+			broadcastPayload []byte
+			sessionID        string
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&broadcastPayload)
+		f.Fuzz(&sessionID)
+
+		message := &tssRoundFourMessage{
+			senderID:         senderID,
+			broadcastPayload: broadcastPayload,
+			sessionID:        sessionID,
+		}
+
+		_ = pbutils.RoundTrip(message, &tssRoundFourMessage{})
+	}
+}
+
+func TestFuzzTssRoundFourMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&tssRoundFourMessage{})
+}
@@ -0,0 +1,573 @@
+package resharing
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/ecdsa/keygen"
+	tssresharing "github.com/bnb-chain/tss-lib/ecdsa/resharing"
+	"github.com/bnb-chain/tss-lib/tss"
+	"github.com/ipfs/go-log/v2"
+	"github.com/keep-network/keep-core/pkg/crypto/ephemeral"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+	"github.com/keep-network/keep-core/pkg/tecdsa/common"
+)
+
+// Member represents a resharing protocol member.
+//
+// The resharing protocol is executed jointly by the old and the new group.
+// Members of both groups are indexed within a single combined group where
+// old group members have indexes 1..O and new group members have indexes
+// O+1..O+N, O and N being the old and new group sizes respectively. An
+// operator belonging to both groups runs two separate members.
+type member struct {
+	// Logger used to produce log messages.
+	logger log.StandardLogger
+	// id of this group member.
+	id group.MemberIndex
+	// Combined group of old and new group members to which this member
+	// belongs.
+	group *group.Group
+	// Validator allowing to check public key and member index against
+	// group members
+	membershipValidator *group.MembershipValidator
+	// Identifier of the particular resharing session this member is part of.
+	sessionID string
+	// Size of the old group, i.e. the group currently holding the key shares.
+	oldGroupSize int
+	// Dishonest threshold of the old group.
+	oldDishonestThreshold int
+	// Size of the new group, i.e. the group receiving the key shares.
+	newGroupSize int
+	// Dishonest threshold of the new group.
+	newDishonestThreshold int
+	// Public key of the wallet whose key shares are refreshed. The public
+	// key is not changed by the resharing protocol.
+	walletPublicKey *ecdsa.PublicKey
+	// tECDSA private key share of the member. Set only for old group members.
+	privateKeyShare *tecdsa.PrivateKeyShare
+	// TSS pre-parameters of the member. Set only for new group members.
+	preParams *keygen.LocalPreParams
+	// Instance of the member identity converter.
+	identityConverter *identityConverter
+}
+
+// newMember creates a new member in an initial state
+func newMember(
+	logger log.StandardLogger,
+	seed *big.Int,
+	memberID group.MemberIndex,
+	oldGroupSize,
+	oldDishonestThreshold,
+	newGroupSize,
+	newDishonestThreshold int,
+	membershipValidator *group.MembershipValidator,
+	sessionID string,
+	walletPublicKey *ecdsa.PublicKey,
+	privateKeyShare *tecdsa.PrivateKeyShare,
+	preParams *keygen.LocalPreParams,
+) *member {
+	identityConverter := &identityConverter{
+		oldGroupSize: oldGroupSize,
+		oldKeys:      make(map[group.MemberIndex]*big.Int),
+		newGroupSize: newGroupSize,
+		seed:         seed,
+	}
+
+	// Old group members know the party IDs of all old group members from
+	// their key shares. New group members learn them during the protocol.
+	if privateKeyShare != nil {
+		for i, key := range privateKeyShare.Data().Ks {
+			identityConverter.oldKeys[group.MemberIndex(i+1)] = key
+		}
+	}
+
+	return &member{
+		logger: logger,
+		id:     memberID,
+		group: group.NewGroup(
+			oldDishonestThreshold+newDishonestThreshold,
+			oldGroupSize+newGroupSize,
+		),
+		membershipValidator:   membershipValidator,
+		sessionID:             sessionID,
+		oldGroupSize:          oldGroupSize,
+		oldDishonestThreshold: oldDishonestThreshold,
+		newGroupSize:          newGroupSize,
+		newDishonestThreshold: newDishonestThreshold,
+		walletPublicKey:       walletPublicKey,
+		privateKeyShare:       privateKeyShare,
+		preParams:             preParams,
+		identityConverter:     identityConverter,
+	}
+}
+
+// validate checks whether the member can take part in the resharing with
+// the given parameters.
+func (m *member) validate() error {
+	oldHonestThreshold := m.oldGroupSize - m.oldDishonestThreshold
+	if len(m.operatingOldGroupMemberIndexes()) < oldHonestThreshold {
+		return fmt.Errorf(
+			"[%v] operating old group members is less than the "+
+				"honest threshold [%v]",
+			len(m.operatingOldGroupMemberIndexes()),
+			oldHonestThreshold,
+		)
+	}
+
+	if newHonestThreshold := m.newGroupSize - m.newDishonestThreshold; newHonestThreshold < 1 {
+		return fmt.Errorf(
+			"invalid new group honest threshold [%v]",
+			newHonestThreshold,
+		)
+	}
+
+	switch {
+	case m.isOldGroupMember(m.id):
+		if m.privateKeyShare == nil {
+			return fmt.Errorf("old group member must have a private key share")
+		}
+
+		keys := m.privateKeyShare.Data().Ks
+		if len(keys) != m.oldGroupSize {
+			return fmt.Errorf(
+				"private key share holds [%v] party IDs instead of [%v]",
+				len(keys),
+				m.oldGroupSize,
+			)
+		}
+
+		publicKey := m.privateKeyShare.PublicKey()
+		if publicKey.X.Cmp(m.walletPublicKey.X) != 0 ||
+			publicKey.Y.Cmp(m.walletPublicKey.Y) != 0 {
+			return fmt.Errorf(
+				"private key share does not match the wallet public key",
+			)
+		}
+
+		// Party IDs of new group members must not collide with party IDs
+		// of old group members.
+		for _, memberIndex := range m.newGroupMemberIndexes() {
+			partyID := m.identityConverter.MemberIndexToTssPartyID(memberIndex)
+			if m.identityConverter.TssPartyIDToMemberIndex(partyID) != memberIndex {
+				return fmt.Errorf(
+					"party ID of new group member [%v] collides with "+
+						"an old group member",
+					memberIndex,
+				)
+			}
+		}
+	case m.isNewGroupMember(m.id):
+		if m.preParams == nil || !m.preParams.ValidateWithProof() {
+			return fmt.Errorf("new group member must have valid pre-parameters")
+		}
+	default:
+		return fmt.Errorf("member [%v] is not in the old or new group", m.id)
+	}
+
+	return nil
+}
+
+// isOldGroupMember returns true if the given member index belongs to
+// the old group.
+func (m *member) isOldGroupMember(memberIndex group.MemberIndex) bool {
+	return int(memberIndex) >= 1 && int(memberIndex) <= m.oldGroupSize
+}
+
+// isNewGroupMember returns true if the given member index belongs to
+// the new group.
+func (m *member) isNewGroupMember(memberIndex group.MemberIndex) bool {
+	return int(memberIndex) > m.oldGroupSize &&
+		int(memberIndex) <= m.oldGroupSize+m.newGroupSize
+}
+
+// operatingOldGroupMemberIndexes returns indexes of all old group members
+// that take part in the protocol. Old group members excluded from the
+// protocol are not returned.
+func (m *member) operatingOldGroupMemberIndexes() []group.MemberIndex {
+	var indexes []group.MemberIndex
+	for _, memberIndex := range m.group.OperatingMemberIndexes() {
+		if m.isOldGroupMember(memberIndex) {
+			indexes = append(indexes, memberIndex)
+		}
+	}
+	return indexes
+}
+
+// newGroupMemberIndexes returns indexes of all new group members. New group
+// members cannot be excluded from the protocol.
+func (m *member) newGroupMemberIndexes() []group.MemberIndex {
+	indexes := make([]group.MemberIndex, m.newGroupSize)
+	for i := range indexes {
+		indexes[i] = group.MemberIndex(m.oldGroupSize + i + 1)
+	}
+	return indexes
+}
+
+// expectedSendersCount returns the number of members other than the current
+// one, from the given list of member indexes.
+func (m *member) expectedSendersCount(senders []group.MemberIndex) int {
+	count := 0
+	for _, sender := range senders {
+		if sender != m.id {
+			count++
+		}
+	}
+	return count
+}
+
+// shouldAcceptMessage indicates whether the given member should accept
+// a message from the given sender.
+func (m *member) shouldAcceptMessage(
+	senderID group.MemberIndex,
+	senderPublicKey []byte,
+) bool {
+	isMessageFromSelf := senderID == m.id
+	isSenderValid := m.membershipValidator.IsValidMembership(
+		senderID,
+		senderPublicKey,
+	)
+	isSenderAccepted := m.group.IsOperating(senderID)
+
+	return !isMessageFromSelf && isSenderValid && isSenderAccepted
+}
+
+// isExpectedSender indicates whether the given message comes from the group
+// supposed to produce it. TSS round one and three messages are produced only
+// by old group members while TSS round two and four messages are produced
+// only by new group members.
+func (m *member) isExpectedSender(message message) bool {
+	switch message.(type) {
+	case *tssRoundOneMessage, *tssRoundThreeMessage:
+		return m.isOldGroupMember(message.SenderID())
+	case *tssRoundTwoMessage, *tssRoundFourMessage:
+		return m.isNewGroupMember(message.SenderID())
+	default:
+		return true
+	}
+}
+
+// initializeEphemeralKeysGeneration performs a transition of a member state
+// from the initial state to the first phase of the protocol.
+func (m *member) initializeEphemeralKeysGeneration() *ephemeralKeyPairGeneratingMember {
+	return &ephemeralKeyPairGeneratingMember{
+		member:            m,
+		ephemeralKeyPairs: make(map[group.MemberIndex]*ephemeral.KeyPair),
+	}
+}
+
+// ephemeralKeyPairGeneratingMember represents one member in a resharing group
+// performing ephemeral key pair generation. It has a full list of
+// `memberIndexes` that belong to the combined group.
+type ephemeralKeyPairGeneratingMember struct {
+	*member
+
+	// Ephemeral key pairs used to create symmetric keys,
+	// generated individually for each other group member.
+	ephemeralKeyPairs map[group.MemberIndex]*ephemeral.KeyPair
+}
+
+// initializeSymmetricKeyGeneration performs a transition of the member state
+// to the next phase. It returns a member instance ready to execute the
+// next phase of the protocol.
+func (ekpgm *ephemeralKeyPairGeneratingMember) initializeSymmetricKeyGeneration() *symmetricKeyGeneratingMember {
+	return &symmetricKeyGeneratingMember{
+		ephemeralKeyPairGeneratingMember: ekpgm,
+		symmetricKeys:                    make(map[group.MemberIndex]ephemeral.SymmetricKey),
+	}
+}
+
+// symmetricKeyGeneratingMember represents one member in a resharing group
+// performing ephemeral symmetric key generation.
+type symmetricKeyGeneratingMember struct {
+	*ephemeralKeyPairGeneratingMember
+
+	// Symmetric keys used to encrypt confidential information,
+	// generated individually for each other group member by ECDH'ing the
+	// broadcasted ephemeral public key intended for this member and the
+	// ephemeral private key generated for the other member.
+	symmetricKeys map[group.MemberIndex]ephemeral.SymmetricKey
+}
+
+// initializeTssRoundOne returns a member to perform next protocol operations.
+func (skgm *symmetricKeyGeneratingMember) initializeTssRoundOne() *tssRoundOneMember {
+	// Old group members send one message in TSS round one and two
+	// messages in TSS round three, new group members send two messages in
+	// TSS round two and one message in TSS round four.
+	tssOutgoingMessagesChan := make(chan tss.Message, skgm.newGroupSize+1)
+	tssResultChan := make(chan keygen.LocalPartySaveData, 1)
+
+	return &tssRoundOneMember{
+		symmetricKeyGeneratingMember: skgm,
+		tssOutgoingMessagesChan:      tssOutgoingMessagesChan,
+		tssResultChan:                tssResultChan,
+	}
+}
+
+// tssRoundOneMember represents one member in a resharing group performing the
+// first round of the TSS resharing.
+type tssRoundOneMember struct {
+	*symmetricKeyGeneratingMember
+
+	// The local TSS party and its parameters. Old group members set them up
+	// in TSS round one. New group members set them up in TSS round two,
+	// once they learn party IDs of the old group members.
+	tssParty                tss.Party
+	tssParameters           *tss.ReSharingParameters
+	tssOutgoingMessagesChan chan tss.Message
+	tssResultChan           chan keygen.LocalPartySaveData
+}
+
+// setUpTssParty sets up the local TSS party using operating old group members
+// and all new group members. This effectively removes all excluded old group
+// members who were marked as disqualified at the beginning of the protocol.
+func (trom *tssRoundOneMember) setUpTssParty() error {
+	oldGroupMemberIndexes := trom.operatingOldGroupMemberIndexes()
+	newGroupMemberIndexes := trom.newGroupMemberIndexes()
+
+	for _, memberIndex := range oldGroupMemberIndexes {
+		if !trom.identityConverter.isKnown(memberIndex) {
+			return fmt.Errorf(
+				"TSS party ID of old group member [%v] is unknown",
+				memberIndex,
+			)
+		}
+	}
+
+	oldTssPartyID, oldGroupTssPartiesIDs := common.GenerateTssPartiesIDs(
+		trom.id,
+		oldGroupMemberIndexes,
+		trom.identityConverter,
+	)
+	newTssPartyID, newGroupTssPartiesIDs := common.GenerateTssPartiesIDs(
+		trom.id,
+		newGroupMemberIndexes,
+		trom.identityConverter,
+	)
+
+	tssPartyID := oldTssPartyID
+	if tssPartyID == nil {
+		tssPartyID = newTssPartyID
+	}
+
+	tssParameters := tss.NewReSharingParameters(
+		tecdsa.Curve,
+		tss.NewPeerContext(tss.SortPartyIDs(oldGroupTssPartiesIDs)),
+		tss.NewPeerContext(tss.SortPartyIDs(newGroupTssPartiesIDs)),
+		tssPartyID,
+		len(oldGroupTssPartiesIDs),
+		trom.oldGroupSize-trom.oldDishonestThreshold-1,
+		len(newGroupTssPartiesIDs),
+		trom.newGroupSize-trom.newDishonestThreshold-1,
+	)
+
+	var tssKey keygen.LocalPartySaveData
+	if trom.isOldGroupMember(trom.id) {
+		tssKey = trom.privateKeyShare.Data()
+		// TSS zeroes the private key share of old group members once
+		// the resharing completes. Work on a copy in order to not modify
+		// the private key share owned by the caller.
+		tssKey.Xi = new(big.Int).Set(tssKey.Xi)
+	} else {
+		tssKey = keygen.NewLocalPartySaveData(len(newGroupTssPartiesIDs))
+		tssKey.LocalPreParams = *trom.preParams
+	}
+
+	trom.tssParameters = tssParameters
+	trom.tssParty = tssresharing.NewLocalParty(
+		tssParameters,
+		tssKey,
+		trom.tssOutgoingMessagesChan,
+		trom.tssResultChan,
+	)
+
+	return nil
+}
+
+// resolveSortedTssPartyID resolves the TSS party ID for the given member index
+// based on the sorted parties IDs of the old or new group, depending on the
+// group the member belongs to. Such a resolved party ID has an index which
+// indicates its position in the parties IDs set and can be used for
+// UpdateFromBytes call.
+func (trom *tssRoundOneMember) resolveSortedTssPartyID(
+	memberIndex group.MemberIndex,
+) *tss.PartyID {
+	sortedPartiesIDs := trom.tssParameters.OldParties().IDs()
+	if trom.isNewGroupMember(memberIndex) {
+		sortedPartiesIDs = trom.tssParameters.NewParties().IDs()
+	}
+
+	partyIDKey := trom.identityConverter.MemberIndexToTssPartyIDKey(memberIndex)
+	return sortedPartiesIDs.FindByKey(partyIDKey)
+}
+
+// initializeTssRoundTwo returns a member to perform next protocol operations.
+func (trom *tssRoundOneMember) initializeTssRoundTwo() *tssRoundTwoMember {
+	return &tssRoundTwoMember{
+		tssRoundOneMember: trom,
+	}
+}
+
+// tssRoundTwoMember represents one member in a resharing group performing the
+// second round of the TSS resharing.
+type tssRoundTwoMember struct {
+	*tssRoundOneMember
+}
+
+// initializeTssRoundThree returns a member to perform next protocol operations.
+func (trtm *tssRoundTwoMember) initializeTssRoundThree() *tssRoundThreeMember {
+	return &tssRoundThreeMember{
+		tssRoundTwoMember: trtm,
+	}
+}
+
+// tssRoundThreeMember represents one member in a resharing group performing
+// the third round of the TSS resharing.
+type tssRoundThreeMember struct {
+	*tssRoundTwoMember
+}
+
+// initializeTssRoundFour returns a member to perform next protocol operations.
+func (trtm *tssRoundThreeMember) initializeTssRoundFour() *tssRoundFourMember {
+	return &tssRoundFourMember{
+		tssRoundThreeMember: trtm,
+	}
+}
+
+// tssRoundFourMember represents one member in a resharing group performing
+// the fourth round of the TSS resharing.
+type tssRoundFourMember struct {
+	*tssRoundThreeMember
+}
+
+// initializeFinalization returns a member to perform next protocol operations.
+func (trfm *tssRoundFourMember) initializeFinalization() *finalizingMember {
+	return &finalizingMember{
+		tssRoundFourMember: trfm,
+	}
+}
+
+// finalizingMember represents one member of the given group, after it
+// completed the resharing process.
+//
+// Prepares a result in the last phase of the protocol.
+type finalizingMember struct {
+	*tssRoundFourMember
+
+	// tssResult is set only for new group members. Old group members do not
+	// get any key share as result of the protocol.
+	tssResult *keygen.LocalPartySaveData
+}
+
+// Result is a successful computation of the refreshed tECDSA private key
+// share. The private key share is nil for old group members.
+func (fm *finalizingMember) Result() *Result {
+	result := &Result{}
+
+	if fm.tssResult != nil {
+		result.PrivateKeyShare = tecdsa.NewPrivateKeyShare(*fm.tssResult)
+	}
+
+	return result
+}
+
+// identityConverter implements the common.IdentityConverter for tECDSA
+// resharing. Old group members keep using party IDs of their key shares
+// while new group members get party IDs derived from the resharing seed,
+// the same way as during DKG.
+type identityConverter struct {
+	oldGroupSize int
+	// Party ID keys of old group members. New group members learn them
+	// during the protocol.
+	oldKeys      map[group.MemberIndex]*big.Int
+	newGroupSize int
+	seed         *big.Int
+}
+
+// isKnown returns true if the party ID key of the given member is known.
+func (ic *identityConverter) isKnown(memberIndex group.MemberIndex) bool {
+	if int(memberIndex) > ic.oldGroupSize {
+		return int(memberIndex) <= ic.oldGroupSize+ic.newGroupSize
+	}
+
+	_, ok := ic.oldKeys[memberIndex]
+	return ok
+}
+
+// registerOldKey registers the party ID key of the given old group member.
+// The key must not collide with party ID keys of other members.
+func (ic *identityConverter) registerOldKey(
+	memberIndex group.MemberIndex,
+	key *big.Int,
+) error {
+	if int(memberIndex) < 1 || int(memberIndex) > ic.oldGroupSize {
+		return fmt.Errorf("member [%v] is not an old group member", memberIndex)
+	}
+
+	if key.Sign() <= 0 {
+		return fmt.Errorf("invalid party ID key of member [%v]", memberIndex)
+	}
+
+	if index := ic.TssPartyIDToMemberIndex(
+		tss.NewPartyID("", "", key),
+	); index != 0 && index != memberIndex {
+		return fmt.Errorf(
+			"party ID key of member [%v] collides with member [%v]",
+			memberIndex,
+			index,
+		)
+	}
+
+	ic.oldKeys[memberIndex] = key
+
+	return nil
+}
+
+func (ic *identityConverter) MemberIndexToTssPartyID(
+	memberIndex group.MemberIndex,
+) *tss.PartyID {
+	partyIDKey := ic.MemberIndexToTssPartyIDKey(memberIndex)
+
+	return tss.NewPartyID(
+		partyIDKey.Text(10),
+		fmt.Sprintf("member-%v", memberIndex),
+		partyIDKey,
+	)
+}
+
+func (ic *identityConverter) MemberIndexToTssPartyIDKey(
+	memberIndex group.MemberIndex,
+) *big.Int {
+	if int(memberIndex) > ic.oldGroupSize {
+		// Same as in DKG, the seed is added to the index within the new group
+		// to make the party ID key unique for the given resharing.
+		return new(big.Int).Add(
+			ic.seed,
+			big.NewInt(int64(int(memberIndex)-ic.oldGroupSize)),
+		)
+	}
+
+	return ic.oldKeys[memberIndex]
+}
+
+func (ic *identityConverter) TssPartyIDToMemberIndex(
+	partyID *tss.PartyID,
+) group.MemberIndex {
+	partyIDKey := partyID.KeyInt()
+
+	for memberIndex, key := range ic.oldKeys {
+		if key.Cmp(partyIDKey) == 0 {
+			return memberIndex
+		}
+	}
+
+	newGroupIndex := new(big.Int).Sub(partyIDKey, ic.seed)
+	if newGroupIndex.Cmp(big.NewInt(1)) >= 0 &&
+		newGroupIndex.Cmp(big.NewInt(int64(ic.newGroupSize))) <= 0 {
+		return group.MemberIndex(ic.oldGroupSize + int(newGroupIndex.Int64()))
+	}
+
+	return group.MemberIndex(0)
+}
@@ -0,0 +1,256 @@
+package resharing
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/tss"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+func TestIsExpectedSender(t *testing.T) {
+	member := &member{oldGroupSize: 3, newGroupSize: 2}
+
+	tests := map[string]struct {
+		message        message
+		expectedResult bool
+	}{
+		"ephemeral public key message from old group member": {
+			message:        &ephemeralPublicKeyMessage{senderID: 1},
+			expectedResult: true,
+		},
+		"ephemeral public key message from new group member": {
+			message:        &ephemeralPublicKeyMessage{senderID: 4},
+			expectedResult: true,
+		},
+		"TSS round one message from old group member": {
+			message:        &tssRoundOneMessage{senderID: 3},
+			expectedResult: true,
+		},
+		"TSS round one message from new group member": {
+			message:        &tssRoundOneMessage{senderID: 4},
+			expectedResult: false,
+		},
+		"TSS round two message from old group member": {
+			message:        &tssRoundTwoMessage{senderID: 3},
+			expectedResult: false,
+		},
+		"TSS round two message from new group member": {
+			message:        &tssRoundTwoMessage{senderID: 4},
+			expectedResult: true,
+		},
+		"TSS round three message from old group member": {
+			message:        &tssRoundThreeMessage{senderID: 1},
+			expectedResult: true,
+		},
+		"TSS round three message from new group member": {
+			message:        &tssRoundThreeMessage{senderID: 5},
+			expectedResult: false,
+		},
+		"TSS round four message from old group member": {
+			message:        &tssRoundFourMessage{senderID: 1},
+			expectedResult: false,
+		},
+		"TSS round four message from new group member": {
+			message:        &tssRoundFourMessage{senderID: 5},
+			expectedResult: true,
+		},
+		"TSS round four message from outside of both groups": {
+			message:        &tssRoundFourMessage{senderID: 6},
+			expectedResult: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testutils.AssertBoolsEqual(
+				t,
+				"result of sender validation",
+				test.expectedResult,
+				member.isExpectedSender(test.message),
+			)
+		})
+	}
+}
+
+func TestIdentityConverter_MemberIndexToTssPartyID(t *testing.T) {
+	converter := newTestIdentityConverter()
+
+	tests := map[string]struct {
+		memberIndex group.MemberIndex
+		expectedKey *big.Int
+	}{
+		"old group member": {
+			memberIndex: 2,
+			expectedKey: big.NewInt(303),
+		},
+		"new group member": {
+			memberIndex: 5,
+			expectedKey: big.NewInt(1001),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			tssPartyID := converter.MemberIndexToTssPartyID(test.memberIndex)
+
+			testutils.AssertStringsEqual(
+				t,
+				"ID of the TSS party ID",
+				test.expectedKey.Text(10),
+				tssPartyID.Id,
+			)
+
+			testutils.AssertBytesEqual(
+				t,
+				test.expectedKey.Bytes(),
+				tssPartyID.Key,
+			)
+
+			testutils.AssertStringsEqual(
+				t,
+				"moniker of the TSS party ID",
+				fmt.Sprintf("member-%v", test.memberIndex),
+				tssPartyID.Moniker,
+			)
+
+			testutils.AssertIntsEqual(
+				t,
+				"index of the TSS party ID",
+				-1,
+				tssPartyID.Index,
+			)
+		})
+	}
+}
+
+func TestIdentityConverter_TssPartyIDToMemberIndex(t *testing.T) {
+	converter := newTestIdentityConverter()
+
+	tests := map[string]struct {
+		partyIDKey          *big.Int
+		expectedMemberIndex group.MemberIndex
+	}{
+		"old group member": {
+			partyIDKey:          big.NewInt(304),
+			expectedMemberIndex: 3,
+		},
+		"first new group member": {
+			partyIDKey:          big.NewInt(1001),
+			expectedMemberIndex: 5,
+		},
+		"last new group member": {
+			partyIDKey:          big.NewInt(1003),
+			expectedMemberIndex: 7,
+		},
+		// Party ID key is unknown; it should never happen, so the party ID is
+		// considered corrupted and MemberIndex(0) is returned.
+		"unknown old group member": {
+			partyIDKey:          big.NewInt(306),
+			expectedMemberIndex: 0,
+		},
+		"unknown new group member": {
+			partyIDKey:          big.NewInt(1004),
+			expectedMemberIndex: 0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			partyID := tss.NewPartyID("", "", test.partyIDKey)
+
+			testutils.AssertIntsEqual(
+				t,
+				"member index",
+				int(test.expectedMemberIndex),
+				int(converter.TssPartyIDToMemberIndex(partyID)),
+			)
+		})
+	}
+}
+
+func TestIdentityConverter_RegisterOldKey(t *testing.T) {
+	tests := map[string]struct {
+		memberIndex   group.MemberIndex
+		key           *big.Int
+		expectedError string
+	}{
+		"valid key": {
+			memberIndex:   4,
+			key:           big.NewInt(306),
+			expectedError: "",
+		},
+		"key already registered for the member": {
+			memberIndex:   1,
+			key:           big.NewInt(301),
+			expectedError: "",
+		},
+		"new group member": {
+			memberIndex:   5,
+			key:           big.NewInt(306),
+			expectedError: "member [5] is not an old group member",
+		},
+		"zero key": {
+			memberIndex:   4,
+			key:           big.NewInt(0),
+			expectedError: "invalid party ID key of member [4]",
+		},
+		"key of another old group member": {
+			memberIndex:   4,
+			key:           big.NewInt(303),
+			expectedError: "party ID key of member [4] collides with member [2]",
+		},
+		"key of a new group member": {
+			memberIndex:   4,
+			key:           big.NewInt(1002),
+			expectedError: "party ID key of member [4] collides with member [6]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			converter := newTestIdentityConverter()
+			delete(converter.oldKeys, 4)
+
+			err := converter.registerOldKey(test.memberIndex, test.key)
+
+			if test.expectedError != "" {
+				if err == nil || err.Error() != test.expectedError {
+					t.Fatalf(
+						"unexpected error\nexpected: [%v]\nactual:   [%v]",
+						test.expectedError,
+						err,
+					)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: [%v]", err)
+			}
+
+			testutils.AssertBigIntsEqual(
+				t,
+				"registered key",
+				test.key,
+				converter.MemberIndexToTssPartyIDKey(test.memberIndex),
+			)
+		})
+	}
+}
+
+func newTestIdentityConverter() *identityConverter {
+	return &identityConverter{
+		oldGroupSize: 4,
+		oldKeys: map[group.MemberIndex]*big.Int{
+			1: big.NewInt(301),
+			2: big.NewInt(303),
+			3: big.NewInt(304),
+			4: big.NewInt(305),
+		},
+		newGroupSize: 3,
+		seed:         big.NewInt(1000),
+	}
+}
@@ -0,0 +1,156 @@
+package resharing
+
+import (
+	"github.com/keep-network/keep-core/pkg/crypto/ephemeral"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+const messageTypePrefix = "tecdsa_resharing/"
+
+// message holds common traits of all resharing protocol messages.
+type message interface {
+	// SenderID returns protocol-level identifier of the message sender.
+	SenderID() group.MemberIndex
+	// SessionID returns the session identifier of the message.
+	SessionID() string
+	// Type returns the exact type of the message.
+	Type() string
+}
+
+// ephemeralPublicKeyMessage is a message payload that carries the sender's
+// ephemeral public keys generated for all other group members.
+//
+// The receiver performs ECDH on a sender's ephemeral public key intended for
+// the receiver and on the receiver's private ephemeral key, creating a symmetric
+// key used for encrypting a conversation between the sender and the receiver.
+type ephemeralPublicKeyMessage struct {
+	senderID group.MemberIndex
+
+	ephemeralPublicKeys map[group.MemberIndex]*ephemeral.PublicKey
+	sessionID           string
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (epkm *ephemeralPublicKeyMessage) SenderID() group.MemberIndex {
+	return epkm.senderID
+}
+
+// SessionID returns the session identifier of the message.
+func (epkm *ephemeralPublicKeyMessage) SessionID() string {
+	return epkm.sessionID
+}
+
+// Type returns a string describing an ephemeralPublicKeyMessage type for
+// marshaling purposes.
+func (epkm *ephemeralPublicKeyMessage) Type() string {
+	return messageTypePrefix + "ephemeral_public_key_message"
+}
+
+// tssRoundOneMessage is a message payload that carries the sender's
+// TSS round one components. It is sent by old group members to new group
+// members and carries the TSS party ID key of the sender as new group
+// members do not know the old group party IDs upfront.
+type tssRoundOneMessage struct {
+	senderID group.MemberIndex
+
+	tssPartyIDKey    []byte
+	broadcastPayload []byte
+	sessionID        string
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (trom *tssRoundOneMessage) SenderID() group.MemberIndex {
+	return trom.senderID
+}
+
+// SessionID returns the session identifier of the message.
+func (trom *tssRoundOneMessage) SessionID() string {
+	return trom.sessionID
+}
+
+// Type returns a string describing a tssRoundOneMessage type for
+// marshaling purposes.
+func (trom *tssRoundOneMessage) Type() string {
+	return messageTypePrefix + "tss_round_one_message"
+}
+
+// tssRoundTwoMessage is a message payload that carries the sender's
+// TSS round two components. It is sent by new group members to both old
+// and new group members. Each group gets its own payload.
+type tssRoundTwoMessage struct {
+	senderID group.MemberIndex
+
+	oldGroupPayload []byte
+	newGroupPayload []byte
+	sessionID       string
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (trtm *tssRoundTwoMessage) SenderID() group.MemberIndex {
+	return trtm.senderID
+}
+
+// SessionID returns the session identifier of the message.
+func (trtm *tssRoundTwoMessage) SessionID() string {
+	return trtm.sessionID
+}
+
+// Type returns a string describing a tssRoundTwoMessage type for
+// marshaling purposes.
+func (trtm *tssRoundTwoMessage) Type() string {
+	return messageTypePrefix + "tss_round_two_message"
+}
+
+// tssRoundThreeMessage is a message payload that carries the sender's
+// TSS round three components. It is sent by old group members to new group
+// members. The peers payload holds the new key shares, encrypted for
+// specific new group members.
+type tssRoundThreeMessage struct {
+	senderID group.MemberIndex
+
+	broadcastPayload []byte
+	peersPayload     map[group.MemberIndex][]byte
+	sessionID        string
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (trtm *tssRoundThreeMessage) SenderID() group.MemberIndex {
+	return trtm.senderID
+}
+
+// SessionID returns the session identifier of the message.
+func (trtm *tssRoundThreeMessage) SessionID() string {
+	return trtm.sessionID
+}
+
+// Type returns a string describing a tssRoundThreeMessage type for
+// marshaling purposes.
+func (trtm *tssRoundThreeMessage) Type() string {
+	return messageTypePrefix + "tss_round_three_message"
+}
+
+// tssRoundFourMessage is a message payload that carries the sender's
+// TSS round four components. It is sent by new group members to both old
+// and new group members.
+type tssRoundFourMessage struct {
+	senderID group.MemberIndex
+
+	broadcastPayload []byte
+	sessionID        string
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (trfm *tssRoundFourMessage) SenderID() group.MemberIndex {
+	return trfm.senderID
+}
+
+// SessionID returns the session identifier of the message.
+func (trfm *tssRoundFourMessage) SessionID() string {
+	return trfm.sessionID
+}
+
+// Type returns a string describing a tssRoundFourMessage type for
+// marshaling purposes.
+func (trfm *tssRoundFourMessage) Type() string {
+	return messageTypePrefix + "tss_round_four_message"
+}
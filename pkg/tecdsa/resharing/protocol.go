@@ -0,0 +1,514 @@
+package resharing
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	tssresharing "github.com/bnb-chain/tss-lib/ecdsa/resharing"
+	"github.com/bnb-chain/tss-lib/tss"
+	"github.com/keep-network/keep-core/pkg/crypto/ephemeral"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa/common"
+	"google.golang.org/protobuf/proto"
+)
+
+// tssRoundTwoOldGroupMessageType is the type of the TSS round two message
+// produced by new group members for old group members.
+var tssRoundTwoOldGroupMessageType = string(
+	proto.MessageName(&tssresharing.DGRound2Message2{}),
+)
+
+// generateEphemeralKeyPair takes the group member list and generates an
+// ephemeral ECDH keypair for every other group member. Generated public
+// ephemeral keys are broadcasted within the group.
+func (ekpgm *ephemeralKeyPairGeneratingMember) generateEphemeralKeyPair() (
+	*ephemeralPublicKeyMessage,
+	error,
+) {
+	ephemeralKeys := make(map[group.MemberIndex]*ephemeral.PublicKey)
+
+	// Calculate ephemeral key pair for every other group member
+	for _, member := range ekpgm.group.MemberIndexes() {
+		if member == ekpgm.id {
+			// don’t actually generate a key with ourselves
+			continue
+		}
+
+		ephemeralKeyPair, err := ephemeral.GenerateKeyPair()
+		if err != nil {
+			return nil, err
+		}
+
+		// save the generated ephemeral key to our state
+		ekpgm.ephemeralKeyPairs[member] = ephemeralKeyPair
+
+		// store the public key to the map for the message
+		ephemeralKeys[member] = ephemeralKeyPair.PublicKey
+	}
+
+	return &ephemeralPublicKeyMessage{
+		senderID:            ekpgm.id,
+		ephemeralPublicKeys: ephemeralKeys,
+		sessionID:           ekpgm.sessionID,
+	}, nil
+}
+
+// generateSymmetricKeys attempts to generate symmetric keys for all remote group
+// members via ECDH. It generates this symmetric key for each remote group member
+// by doing an ECDH between the ephemeral private key generated for a remote
+// group member, and the public key for this member, generated and broadcasted by
+// the remote group member.
+func (skgm *symmetricKeyGeneratingMember) generateSymmetricKeys(
+	ephemeralPubKeyMessages []*ephemeralPublicKeyMessage,
+) error {
+	for _, ephemeralPubKeyMessage := range ephemeralPubKeyMessages {
+		otherMember := ephemeralPubKeyMessage.senderID
+
+		if !skgm.isValidEphemeralPublicKeyMessage(ephemeralPubKeyMessage) {
+			return fmt.Errorf(
+				"member [%v] sent invalid ephemeral public key message",
+				otherMember,
+			)
+		}
+
+		// Find the ephemeral key pair generated by this group member for
+		// the other group member.
+		ephemeralKeyPair, ok := skgm.ephemeralKeyPairs[otherMember]
+		if !ok {
+			return fmt.Errorf(
+				"ephemeral key pair does not exist for member [%v]",
+				otherMember,
+			)
+		}
+
+		// Get the ephemeral private key generated by this group member for
+		// the other group member.
+		thisMemberEphemeralPrivateKey := ephemeralKeyPair.PrivateKey
+
+		// Get the ephemeral public key broadcasted by the other group member,
+		// which was intended for this group member.
+		otherMemberEphemeralPublicKey :=
+			ephemeralPubKeyMessage.ephemeralPublicKeys[skgm.id]
+
+		// Create symmetric key for the current group member and the other
+		// group member by ECDH'ing the public and private key.
+		symmetricKey := thisMemberEphemeralPrivateKey.Ecdh(
+			otherMemberEphemeralPublicKey,
+		)
+		skgm.symmetricKeys[otherMember] = symmetricKey
+	}
+
+	return nil
+}
+
+// isValidEphemeralPublicKeyMessage validates a given EphemeralPublicKeyMessage.
+// Message is considered valid if it contains ephemeral public keys for
+// all other group members.
+func (skgm *symmetricKeyGeneratingMember) isValidEphemeralPublicKeyMessage(
+	message *ephemeralPublicKeyMessage,
+) bool {
+	for _, memberID := range skgm.group.MemberIndexes() {
+		if memberID == message.senderID {
+			// Message contains ephemeral public keys only for other group members
+			continue
+		}
+
+		if _, ok := message.ephemeralPublicKeys[memberID]; !ok {
+			skgm.logger.Warnf(
+				"[member:%v] ephemeral public key message from member [%v] "+
+					"does not contain public key for member [%v]",
+				skgm.id,
+				message.senderID,
+				memberID,
+			)
+			return false
+		}
+	}
+
+	return true
+}
+
+// tssRoundOne starts the TSS process by executing its first round. Only old
+// group members produce a message in this round. The outcome of that round
+// is a message containing TSS round one components, sent to new group members
+// along with the TSS party ID key of the sender. For new group members,
+// the returned message is nil.
+func (trom *tssRoundOneMember) tssRoundOne(
+	ctx context.Context,
+) (*tssRoundOneMessage, error) {
+	if !trom.isOldGroupMember(trom.id) {
+		return nil, nil
+	}
+
+	if err := trom.setUpTssParty(); err != nil {
+		return nil, fmt.Errorf("cannot set up TSS party: [%v]", err)
+	}
+
+	if err := trom.tssParty.Start(); err != nil {
+		return nil, fmt.Errorf(
+			"failed to start TSS round one: [%v]",
+			err,
+		)
+	}
+
+	// We expect exactly one TSS message to be produced in this phase.
+	select {
+	case tssMessage := <-trom.tssOutgoingMessagesChan:
+		tssMessageBytes, _, err := tssMessage.WireBytes()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to encode TSS round one message: [%v]",
+				err,
+			)
+		}
+
+		return &tssRoundOneMessage{
+			senderID: trom.id,
+			tssPartyIDKey: trom.identityConverter.MemberIndexToTssPartyIDKey(
+				trom.id,
+			).Bytes(),
+			broadcastPayload: tssMessageBytes,
+			sessionID:        trom.sessionID,
+		}, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf(
+			"TSS round one outgoing message was not generated on time",
+		)
+	}
+}
+
+// tssRoundTwo performs the second round of the TSS process. Only new group
+// members take part in this round. They learn party IDs of old group members,
+// set up their local TSS party and produce a message containing separate
+// TSS round two components for old and new group members. For old group
+// members, the returned message is nil.
+func (trtm *tssRoundTwoMember) tssRoundTwo(
+	ctx context.Context,
+	tssRoundOneMessages []*tssRoundOneMessage,
+) (*tssRoundTwoMessage, error) {
+	if !trtm.isNewGroupMember(trtm.id) {
+		return nil, nil
+	}
+
+	for _, tssRoundOneMessage := range tssRoundOneMessages {
+		err := trtm.identityConverter.registerOldKey(
+			tssRoundOneMessage.SenderID(),
+			new(big.Int).SetBytes(tssRoundOneMessage.tssPartyIDKey),
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid TSS party ID key in the TSS round one message "+
+					"from member [%v]: [%v]",
+				tssRoundOneMessage.SenderID(),
+				err,
+			)
+		}
+	}
+
+	if err := trtm.setUpTssParty(); err != nil {
+		return nil, fmt.Errorf("cannot set up TSS party: [%v]", err)
+	}
+
+	// New group members do not produce any messages in the first round.
+	if err := trtm.tssParty.Start(); err != nil {
+		return nil, fmt.Errorf(
+			"failed to start TSS round one: [%v]",
+			err,
+		)
+	}
+
+	// Use messages from round one to update the local party and advance
+	// to round two.
+	for _, tssRoundOneMessage := range tssRoundOneMessages {
+		senderID := tssRoundOneMessage.SenderID()
+
+		_, tssErr := trtm.tssParty.UpdateFromBytes(
+			tssRoundOneMessage.broadcastPayload,
+			trtm.resolveSortedTssPartyID(senderID),
+			true,
+		)
+		if tssErr != nil {
+			return nil, fmt.Errorf(
+				"cannot update using TSS round one message "+
+					"from member [%v]: [%v]",
+				senderID,
+				tssErr,
+			)
+		}
+	}
+
+	// We expect exactly two TSS broadcast messages to be produced in this
+	// phase: one for old group members and one for new group members.
+	message := &tssRoundTwoMessage{
+		senderID:  trtm.id,
+		sessionID: trtm.sessionID,
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case tssMessage := <-trtm.tssOutgoingMessagesChan:
+			tssMessageBytes, _, err := tssMessage.WireBytes()
+			if err != nil {
+				return nil, fmt.Errorf(
+					"failed to encode TSS round two message: [%v]",
+					err,
+				)
+			}
+
+			if tssMessage.Type() == tssRoundTwoOldGroupMessageType {
+				message.oldGroupPayload = tssMessageBytes
+			} else {
+				message.newGroupPayload = tssMessageBytes
+			}
+		case <-ctx.Done():
+			return nil, fmt.Errorf(
+				"TSS round two outgoing messages were not generated on time",
+			)
+		}
+	}
+
+	if len(message.oldGroupPayload) == 0 || len(message.newGroupPayload) == 0 {
+		return nil, fmt.Errorf("cannot produce a proper TSS round two message")
+	}
+
+	return message, nil
+}
+
+// tssRoundThree performs the third round of the TSS process. Old group
+// members produce a message containing the new key shares encrypted for
+// specific new group members. New group members only update their local
+// TSS party and the returned message is nil for them.
+func (trtm *tssRoundThreeMember) tssRoundThree(
+	ctx context.Context,
+	tssRoundTwoMessages []*tssRoundTwoMessage,
+) (*tssRoundThreeMessage, error) {
+	// Use messages from round two to update the local party and advance
+	// to round three. Each group uses its own part of the message.
+	for _, tssRoundTwoMessage := range tssRoundTwoMessages {
+		senderID := tssRoundTwoMessage.SenderID()
+
+		payload := tssRoundTwoMessage.newGroupPayload
+		if trtm.isOldGroupMember(trtm.id) {
+			payload = tssRoundTwoMessage.oldGroupPayload
+		}
+
+		_, tssErr := trtm.tssParty.UpdateFromBytes(
+			payload,
+			trtm.resolveSortedTssPartyID(senderID),
+			true,
+		)
+		if tssErr != nil {
+			return nil, fmt.Errorf(
+				"cannot update using TSS round two message "+
+					"from member [%v]: [%v]",
+				senderID,
+				tssErr,
+			)
+		}
+	}
+
+	if !trtm.isOldGroupMember(trtm.id) {
+		return nil, nil
+	}
+
+	// Listen for TSS outgoing messages. We expect N P2P messages (where N
+	// is the number of new group members) and 1 broadcast message.
+	var tssMessages []tss.Message
+outgoingMessagesLoop:
+	for {
+		select {
+		case tssMessage := <-trtm.tssOutgoingMessagesChan:
+			tssMessages = append(tssMessages, tssMessage)
+
+			if len(tssMessages) == trtm.newGroupSize+1 {
+				break outgoingMessagesLoop
+			}
+		case <-ctx.Done():
+			return nil, fmt.Errorf(
+				"TSS round three outgoing messages were not " +
+					"generated on time",
+			)
+		}
+	}
+
+	broadcastPayload, peersPayload, err := common.AggregateTssMessages(
+		tssMessages,
+		trtm.symmetricKeys,
+		trtm.identityConverter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot aggregate TSS round three outgoing messages: [%w]",
+			err,
+		)
+	}
+
+	ok := len(broadcastPayload) > 0 && len(peersPayload) == trtm.newGroupSize
+	if !ok {
+		return nil, fmt.Errorf("cannot produce a proper TSS round three message")
+	}
+
+	return &tssRoundThreeMessage{
+		senderID:         trtm.id,
+		broadcastPayload: broadcastPayload,
+		peersPayload:     peersPayload,
+		sessionID:        trtm.sessionID,
+	}, nil
+}
+
+// tssRoundFour performs the fourth round of the TSS process. Only new group
+// members take part in this round. They compute their new key shares and
+// produce a message confirming that. For old group members, the returned
+// message is nil.
+func (trfm *tssRoundFourMember) tssRoundFour(
+	ctx context.Context,
+	tssRoundThreeMessages []*tssRoundThreeMessage,
+) (*tssRoundFourMessage, error) {
+	if !trfm.isNewGroupMember(trfm.id) {
+		return nil, nil
+	}
+
+	// Use messages from round three to update the local party and advance
+	// to round four.
+	for _, tssRoundThreeMessage := range tssRoundThreeMessages {
+		senderID := tssRoundThreeMessage.SenderID()
+		senderTssPartyID := trfm.resolveSortedTssPartyID(senderID)
+
+		// Update the local TSS party using the broadcast part of the message
+		// produced in round three.
+		_, tssErr := trfm.tssParty.UpdateFromBytes(
+			tssRoundThreeMessage.broadcastPayload,
+			senderTssPartyID,
+			true,
+		)
+		if tssErr != nil {
+			return nil, fmt.Errorf(
+				"cannot update using the broadcast part of the "+
+					"TSS round three message from member [%v]: [%v]",
+				senderID,
+				tssErr,
+			)
+		}
+
+		// Check if the sender produced a P2P part of the TSS round three
+		// message for this member.
+		encryptedPeerPayload, ok := tssRoundThreeMessage.peersPayload[trfm.id]
+		if !ok {
+			return nil, fmt.Errorf(
+				"no P2P part in the TSS round three message from member [%v]",
+				senderID,
+			)
+		}
+		// Get the symmetric key with the sender. If the symmetric key
+		// cannot be found, something awful happened.
+		symmetricKey, ok := trfm.symmetricKeys[senderID]
+		if !ok {
+			return nil, fmt.Errorf(
+				"cannot get symmetric key with member [%v]",
+				senderID,
+			)
+		}
+		// Decrypt the P2P part of the TSS round three message.
+		peerPayload, err := symmetricKey.Decrypt(encryptedPeerPayload)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot decrypt P2P part of the TSS round three "+
+					"message from member [%v]: [%v]",
+				senderID,
+				err,
+			)
+		}
+		// Update the local TSS party using the P2P part of the message
+		// produced in round three.
+		_, tssErr = trfm.tssParty.UpdateFromBytes(
+			peerPayload,
+			senderTssPartyID,
+			false,
+		)
+		if tssErr != nil {
+			return nil, fmt.Errorf(
+				"cannot update using the P2P part of the TSS round "+
+					"three message from member [%v]: [%v]",
+				senderID,
+				tssErr,
+			)
+		}
+	}
+
+	// We expect exactly one TSS message to be produced in this phase.
+	select {
+	case tssMessage := <-trfm.tssOutgoingMessagesChan:
+		tssMessageBytes, _, err := tssMessage.WireBytes()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to encode TSS round four message: [%v]",
+				err,
+			)
+		}
+
+		return &tssRoundFourMessage{
+			senderID:         trfm.id,
+			broadcastPayload: tssMessageBytes,
+			sessionID:        trfm.sessionID,
+		}, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf(
+			"TSS round four outgoing message was not generated on time",
+		)
+	}
+}
+
+// tssFinalize finalizes the TSS process by producing a result. New group
+// members get their new key shares and make sure the wallet public key
+// has not changed.
+func (fm *finalizingMember) tssFinalize(
+	ctx context.Context,
+	tssRoundFourMessages []*tssRoundFourMessage,
+) error {
+	// Use messages from round four to update the local party and get the
+	// result.
+	for _, tssRoundFourMessage := range tssRoundFourMessages {
+		senderID := tssRoundFourMessage.SenderID()
+
+		_, tssErr := fm.tssParty.UpdateFromBytes(
+			tssRoundFourMessage.broadcastPayload,
+			fm.resolveSortedTssPartyID(senderID),
+			true,
+		)
+		if tssErr != nil {
+			return fmt.Errorf(
+				"cannot update using TSS round four message "+
+					"from member [%v]: [%v]",
+				senderID,
+				tssErr,
+			)
+		}
+	}
+
+	select {
+	case tssResult := <-fm.tssResultChan:
+		if !fm.isNewGroupMember(fm.id) {
+			// Old group members do not get any key share. Their old key
+			// share should no longer be used once new group members
+			// complete the protocol.
+			return nil
+		}
+
+		publicKey := tssResult.ECDSAPub.ToECDSAPubKey()
+		if publicKey.X.Cmp(fm.walletPublicKey.X) != 0 ||
+			publicKey.Y.Cmp(fm.walletPublicKey.Y) != 0 {
+			return fmt.Errorf(
+				"public key of the new key share does not match " +
+					"the wallet public key",
+			)
+		}
+
+		fm.tssResult = &tssResult
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf(
+			"TSS result was not generated on time",
+		)
+	}
+}
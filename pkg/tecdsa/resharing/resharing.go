@@ -0,0 +1,132 @@
+// Package resharing implements the tECDSA key share resharing protocol. The
+// protocol lets the group holding shares of a wallet key (the old group)
+// refresh them or hand them over to a different set of operators (the new
+// group) without changing the wallet public key.
+package resharing
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/ecdsa/keygen"
+	"github.com/ipfs/go-log/v2"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/state"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+// Execute runs the tECDSA resharing protocol, given a seed used to generate
+// party IDs of new group members, broadcast channel to mediate with, a member
+// index to use in the combined group, wallet public key, sizes and dishonest
+// thresholds of the old and new groups.
+//
+// Members of both groups are indexed within a single combined group where
+// old group members have indexes 1..oldGroupSize and new group members have
+// indexes oldGroupSize+1..oldGroupSize+newGroupSize. The membership validator
+// must reflect this order. Old group members must pass their current private
+// key share while new group members must pass TSS pre-parameters they will
+// use with their new private key shares. A new private key share is returned
+// only to new group members. An operator refreshing its own share must take
+// part in the protocol both as an old and a new group member.
+//
+// This function also supports resharing execution with a subset of the old
+// group by passing a non-empty excludedMembers slice holding the old group
+// members that should be excluded, e.g. those who left the group. New group
+// members cannot be excluded.
+func Execute(
+	ctx context.Context,
+	logger log.StandardLogger,
+	seed *big.Int,
+	sessionID string,
+	memberIndex group.MemberIndex,
+	privateKeyShare *tecdsa.PrivateKeyShare,
+	preParams *keygen.LocalPreParams,
+	walletPublicKey *ecdsa.PublicKey,
+	oldGroupSize int,
+	oldDishonestThreshold int,
+	newGroupSize int,
+	newDishonestThreshold int,
+	excludedMembersIndexes []group.MemberIndex,
+	channel net.BroadcastChannel,
+	membershipValidator *group.MembershipValidator,
+) (*Result, error) {
+	logger.Debugf("[member:%v] initializing member", memberIndex)
+
+	member := newMember(
+		logger,
+		seed,
+		memberIndex,
+		oldGroupSize,
+		oldDishonestThreshold,
+		newGroupSize,
+		newDishonestThreshold,
+		membershipValidator,
+		sessionID,
+		walletPublicKey,
+		privateKeyShare,
+		preParams,
+	)
+
+	// Mark excluded members as disqualified in order to not exchange messages
+	// with them.
+	for _, excludedMemberIndex := range excludedMembersIndexes {
+		if !member.isOldGroupMember(excludedMemberIndex) {
+			return nil, fmt.Errorf(
+				"cannot exclude member [%v] not being an old group member",
+				excludedMemberIndex,
+			)
+		}
+
+		if excludedMemberIndex != member.id {
+			member.group.MarkMemberAsDisqualified(excludedMemberIndex)
+		}
+	}
+
+	if err := member.validate(); err != nil {
+		return nil, fmt.Errorf("invalid resharing parameters: [%v]", err)
+	}
+
+	initialState := &ephemeralKeyPairGenerationState{
+		BaseAsyncState: state.NewBaseAsyncState(),
+		channel:        channel,
+		member:         member.initializeEphemeralKeysGeneration(),
+	}
+
+	stateMachine := state.NewAsyncMachine(logger, ctx, channel, initialState)
+
+	lastState, err := stateMachine.Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	finalizationState, ok := lastState.(*finalizationState)
+	if !ok {
+		return nil, fmt.Errorf("execution ended on state: %T", lastState)
+	}
+
+	return finalizationState.result(), nil
+}
+
+// RegisterUnmarshallers initializes the given broadcast channel to be able to
+// perform resharing protocol interactions by registering all the required
+// protocol message unmarshallers.
+func RegisterUnmarshallers(channel net.BroadcastChannel) {
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &ephemeralPublicKeyMessage{}
+	})
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &tssRoundOneMessage{}
+	})
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &tssRoundTwoMessage{}
+	})
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &tssRoundThreeMessage{}
+	})
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &tssRoundFourMessage{}
+	})
+}
@@ -0,0 +1,373 @@
+package resharing
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/crypto"
+	"github.com/bnb-chain/tss-lib/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/ecdsa/signing"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/internal/tecdsatest"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+const (
+	// Test fixtures represent a signing group 3-of-5.
+	oldGroupSize          = 5
+	oldDishonestThreshold = 2
+	newGroupSize          = 3
+	newDishonestThreshold = 1
+	sessionID             = "session-1"
+)
+
+var seed = big.NewInt(1000)
+
+func TestExecute(t *testing.T) {
+	testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(oldGroupSize)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	walletPublicKey := tecdsa.NewPrivateKeyShare(testData[0]).PublicKey()
+
+	// Old group members 4 and 5 left the group and do not take part in
+	// the resharing.
+	excludedMembersIndexes := []group.MemberIndex{4, 5}
+
+	channel, membershipValidator := newTestChannel(t)
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancelCtx()
+
+	results := make(map[group.MemberIndex]*Result)
+	errors := make(map[group.MemberIndex]error)
+	mutex := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	execute := func(
+		memberIndex group.MemberIndex,
+		privateKeyShare *tecdsa.PrivateKeyShare,
+		preParams *keygen.LocalPreParams,
+	) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result, err := Execute(
+				ctx,
+				&testutils.MockLogger{},
+				seed,
+				sessionID,
+				memberIndex,
+				privateKeyShare,
+				preParams,
+				walletPublicKey,
+				oldGroupSize,
+				oldDishonestThreshold,
+				newGroupSize,
+				newDishonestThreshold,
+				excludedMembersIndexes,
+				channel,
+				membershipValidator,
+			)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			results[memberIndex] = result
+			errors[memberIndex] = err
+		}()
+	}
+
+	for i := 1; i <= oldGroupSize-len(excludedMembersIndexes); i++ {
+		execute(
+			group.MemberIndex(i),
+			tecdsa.NewPrivateKeyShare(testData[i-1]),
+			nil,
+		)
+	}
+	for i := 1; i <= newGroupSize; i++ {
+		// Pre-parameters of test fixtures are reused for new group members.
+		preParams := testData[i-1].LocalPreParams
+		execute(
+			group.MemberIndex(oldGroupSize+i),
+			nil,
+			&preParams,
+		)
+	}
+
+	wg.Wait()
+
+	for memberIndex, err := range errors {
+		if err != nil {
+			t.Fatalf("unexpected error of member [%v]: [%v]", memberIndex, err)
+		}
+	}
+
+	// Private key shares held by old group members must not be modified.
+	for i := 0; i < oldGroupSize-len(excludedMembersIndexes); i++ {
+		if testData[i].Xi.Sign() == 0 {
+			t.Errorf("private key share of old group member [%v] was modified", i+1)
+		}
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"number of results",
+		oldGroupSize-len(excludedMembersIndexes)+newGroupSize,
+		len(results),
+	)
+
+	var newShares []keygen.LocalPartySaveData
+	for memberIndex, result := range results {
+		if int(memberIndex) <= oldGroupSize {
+			if result.PrivateKeyShare != nil {
+				t.Errorf(
+					"old group member [%v] should not get a private key share",
+					memberIndex,
+				)
+			}
+			continue
+		}
+
+		if result.PrivateKeyShare == nil {
+			t.Fatalf(
+				"new group member [%v] should get a private key share",
+				memberIndex,
+			)
+		}
+
+		publicKey := result.PrivateKeyShare.PublicKey()
+		if publicKey.X.Cmp(walletPublicKey.X) != 0 ||
+			publicKey.Y.Cmp(walletPublicKey.Y) != 0 {
+			t.Errorf(
+				"public key of member [%v] does not match the wallet public key",
+				memberIndex,
+			)
+		}
+
+		data := result.PrivateKeyShare.Data()
+		testutils.AssertBigIntsEqual(
+			t,
+			fmt.Sprintf("share ID of member [%v]", memberIndex),
+			new(big.Int).Add(
+				seed,
+				big.NewInt(int64(int(memberIndex)-oldGroupSize)),
+			),
+			data.ShareID,
+		)
+
+		newShares = append(newShares, data)
+	}
+
+	// The new key shares of any honest majority of the new group must
+	// correspond to the wallet private key.
+	newHonestThreshold := newGroupSize - newDishonestThreshold
+	shares := newShares[:newHonestThreshold]
+	ks := make([]*big.Int, len(shares))
+	bigXs := make([]*crypto.ECPoint, len(shares))
+	for i, share := range shares {
+		ks[i] = share.ShareID
+		for j, key := range share.Ks {
+			if key.Cmp(share.ShareID) == 0 {
+				bigXs[i] = share.BigXj[j]
+			}
+		}
+	}
+
+	var publicKey *crypto.ECPoint
+	for i, share := range shares {
+		wi, _ := signing.PrepareForSigning(
+			tecdsa.Curve,
+			i,
+			len(shares),
+			share.Xi,
+			ks,
+			bigXs,
+		)
+
+		point := crypto.ScalarBaseMult(tecdsa.Curve, wi)
+		if publicKey == nil {
+			publicKey = point
+			continue
+		}
+
+		publicKey, err = publicKey.Add(point)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if publicKey.X().Cmp(walletPublicKey.X) != 0 ||
+		publicKey.Y().Cmp(walletPublicKey.Y) != 0 {
+		t.Errorf("new key shares do not correspond to the wallet public key")
+	}
+}
+
+func TestExecute_InvalidParameters(t *testing.T) {
+	testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(2)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	privateKeyShare := tecdsa.NewPrivateKeyShare(testData[0])
+	walletPublicKey := privateKeyShare.PublicKey()
+	otherWalletPublicKey := tecdsa.NewPrivateKeyShare(
+		keygen.LocalPartySaveData{
+			ECDSAPub: crypto.ScalarBaseMult(tecdsa.Curve, big.NewInt(1)),
+		},
+	).PublicKey()
+	preParams := testData[0].LocalPreParams
+
+	var tests = map[string]struct {
+		memberIndex            group.MemberIndex
+		privateKeyShare        *tecdsa.PrivateKeyShare
+		preParams              *keygen.LocalPreParams
+		walletPublicKey        *ecdsa.PublicKey
+		seed                   *big.Int
+		excludedMembersIndexes []group.MemberIndex
+		expectedError          string
+	}{
+		"new group member excluded": {
+			memberIndex:            1,
+			privateKeyShare:        privateKeyShare,
+			walletPublicKey:        walletPublicKey,
+			seed:                   seed,
+			excludedMembersIndexes: []group.MemberIndex{6},
+			expectedError:          "cannot exclude member [6] not being an old group member",
+		},
+		"too many old group members excluded": {
+			memberIndex:            1,
+			privateKeyShare:        privateKeyShare,
+			walletPublicKey:        walletPublicKey,
+			seed:                   seed,
+			excludedMembersIndexes: []group.MemberIndex{3, 4, 5},
+			expectedError:          "[2] operating old group members is less than the honest threshold [3]",
+		},
+		"old group member without private key share": {
+			memberIndex:     1,
+			walletPublicKey: walletPublicKey,
+			seed:            seed,
+			expectedError:   "old group member must have a private key share",
+		},
+		"private key share of another wallet": {
+			memberIndex:     1,
+			privateKeyShare: privateKeyShare,
+			walletPublicKey: otherWalletPublicKey,
+			seed:            seed,
+			expectedError:   "private key share does not match the wallet public key",
+		},
+		"new group party IDs colliding with old group": {
+			memberIndex:     1,
+			privateKeyShare: privateKeyShare,
+			walletPublicKey: walletPublicKey,
+			// Test fixtures party IDs keys are consecutive numbers
+			// starting from the given one.
+			seed:          new(big.Int).Sub(testData[0].ShareID, big.NewInt(1)),
+			expectedError: "party ID of new group member [6] collides with an old group member",
+		},
+		"new group member without pre-parameters": {
+			memberIndex:     6,
+			walletPublicKey: walletPublicKey,
+			seed:            seed,
+			expectedError:   "new group member must have valid pre-parameters",
+		},
+		"member out of both groups": {
+			memberIndex:     9,
+			preParams:       &preParams,
+			walletPublicKey: walletPublicKey,
+			seed:            seed,
+			expectedError:   "member [9] is not in the old or new group",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			channel, membershipValidator := newTestChannel(t)
+
+			_, err := Execute(
+				context.Background(),
+				&testutils.MockLogger{},
+				test.seed,
+				sessionID,
+				test.memberIndex,
+				test.privateKeyShare,
+				test.preParams,
+				test.walletPublicKey,
+				oldGroupSize,
+				oldDishonestThreshold,
+				newGroupSize,
+				newDishonestThreshold,
+				test.excludedMembersIndexes,
+				channel,
+				membershipValidator,
+			)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if !strings.Contains(err.Error(), test.expectedError) {
+				t.Errorf(
+					"unexpected error\nexpected to contain: [%v]\nactual: [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+// newTestChannel creates a local broadcast channel with registered
+// unmarshallers and a membership validator for the combined group where
+// all members are controlled by the same operator.
+func newTestChannel(t *testing.T) (
+	net.BroadcastChannel,
+	*group.MembershipValidator,
+) {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair(
+		local_v1.DefaultCurve,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer := local_v1.NewSigner(operatorPrivateKey)
+
+	operatorAddress, err := signer.PublicKeyToAddress(operatorPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operators := make([]chain.Address, oldGroupSize+newGroupSize)
+	for i := range operators {
+		operators[i] = operatorAddress
+	}
+
+	channel, err := local.ConnectWithKey(operatorPublicKey).BroadcastChannelFor(
+		t.Name(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	RegisterUnmarshallers(channel)
+
+	return channel, group.NewMembershipValidator(
+		&testutils.MockLogger{},
+		operators,
+		signer,
+	)
+}
@@ -0,0 +1,15 @@
+package resharing
+
+import (
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+// Result of the tECDSA resharing protocol.
+type Result struct {
+	// PrivateKeyShare is the refreshed tECDSA private key share produced as
+	// result of the resharing process. It corresponds to the same wallet
+	// public key as the old private key share. The private key share is set
+	// only for new group members; old group members must no longer use their
+	// old private key shares once the resharing completes.
+	PrivateKeyShare *tecdsa.PrivateKeyShare
+}
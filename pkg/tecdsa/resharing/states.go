@@ -0,0 +1,382 @@
+package resharing
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/state"
+)
+
+// ephemeralKeyPairGenerationState is the state during which members broadcast
+// public ephemeral keys generated for other members of the group.
+// `ephemeralPublicKeyMessage`s are valid in this state.
+type ephemeralKeyPairGenerationState struct {
+	*state.BaseAsyncState
+
+	channel net.BroadcastChannel
+	member  *ephemeralKeyPairGeneratingMember
+}
+
+func (ekpgs *ephemeralKeyPairGenerationState) Initiate(ctx context.Context) error {
+	message, err := ekpgs.member.generateEphemeralKeyPair()
+	if err != nil {
+		return err
+	}
+
+	if err := ekpgs.channel.Send(ctx, message, net.BackoffRetransmissionStrategy); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ekpgs *ephemeralKeyPairGenerationState) Receive(netMessage net.Message) error {
+	return receive(ekpgs.BaseAsyncState, ekpgs.member.member, netMessage)
+}
+
+func (ekpgs *ephemeralKeyPairGenerationState) CanTransition() bool {
+	messagingDone := len(receivedMessages[*ephemeralPublicKeyMessage](ekpgs.BaseAsyncState)) ==
+		len(ekpgs.member.group.OperatingMemberIndexes())-1
+
+	return messagingDone
+}
+
+func (ekpgs *ephemeralKeyPairGenerationState) Next() (state.AsyncState, error) {
+	return &symmetricKeyGenerationState{
+		BaseAsyncState: ekpgs.BaseAsyncState,
+		channel:        ekpgs.channel,
+		member:         ekpgs.member.initializeSymmetricKeyGeneration(),
+	}, nil
+}
+
+func (ekpgs *ephemeralKeyPairGenerationState) MemberIndex() group.MemberIndex {
+	return ekpgs.member.id
+}
+
+// symmetricKeyGenerationState is the state during which members compute
+// symmetric keys from the previously exchanged ephemeral public keys.
+// No messages are valid in this state.
+type symmetricKeyGenerationState struct {
+	*state.BaseAsyncState
+
+	channel net.BroadcastChannel
+	member  *symmetricKeyGeneratingMember
+}
+
+func (skgs *symmetricKeyGenerationState) Initiate(ctx context.Context) error {
+	return skgs.member.generateSymmetricKeys(
+		receivedMessages[*ephemeralPublicKeyMessage](skgs.BaseAsyncState),
+	)
+}
+
+func (skgs *symmetricKeyGenerationState) Receive(netMessage net.Message) error {
+	return receive(skgs.BaseAsyncState, skgs.member.member, netMessage)
+}
+
+func (skgs *symmetricKeyGenerationState) CanTransition() bool {
+	return true
+}
+
+func (skgs *symmetricKeyGenerationState) Next() (state.AsyncState, error) {
+	return &tssRoundOneState{
+		BaseAsyncState: skgs.BaseAsyncState,
+		channel:        skgs.channel,
+		member:         skgs.member.initializeTssRoundOne(),
+	}, nil
+}
+
+func (skgs *symmetricKeyGenerationState) MemberIndex() group.MemberIndex {
+	return skgs.member.id
+}
+
+// tssRoundOneState is the state during which old group members broadcast TSS
+// round one messages.
+// `tssRoundOneMessage`s are valid in this state.
+type tssRoundOneState struct {
+	*state.BaseAsyncState
+
+	channel net.BroadcastChannel
+	member  *tssRoundOneMember
+}
+
+func (tros *tssRoundOneState) Initiate(ctx context.Context) error {
+	message, err := tros.member.tssRoundOne(ctx)
+	if err != nil {
+		return err
+	}
+
+	if message == nil {
+		return nil
+	}
+
+	if err := tros.channel.Send(ctx, message, net.BackoffRetransmissionStrategy); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (tros *tssRoundOneState) Receive(netMessage net.Message) error {
+	return receive(tros.BaseAsyncState, tros.member.member, netMessage)
+}
+
+func (tros *tssRoundOneState) CanTransition() bool {
+	messagingDone := len(receivedMessages[*tssRoundOneMessage](tros.BaseAsyncState)) ==
+		tros.member.expectedSendersCount(tros.member.operatingOldGroupMemberIndexes())
+
+	return messagingDone
+}
+
+func (tros *tssRoundOneState) Next() (state.AsyncState, error) {
+	return &tssRoundTwoState{
+		BaseAsyncState: tros.BaseAsyncState,
+		channel:        tros.channel,
+		member:         tros.member.initializeTssRoundTwo(),
+	}, nil
+}
+
+func (tros *tssRoundOneState) MemberIndex() group.MemberIndex {
+	return tros.member.id
+}
+
+// tssRoundTwoState is the state during which new group members broadcast TSS
+// round two messages.
+// `tssRoundTwoMessage`s are valid in this state.
+type tssRoundTwoState struct {
+	*state.BaseAsyncState
+
+	channel net.BroadcastChannel
+	member  *tssRoundTwoMember
+}
+
+func (trts *tssRoundTwoState) Initiate(ctx context.Context) error {
+	message, err := trts.member.tssRoundTwo(
+		ctx,
+		receivedMessages[*tssRoundOneMessage](trts.BaseAsyncState),
+	)
+	if err != nil {
+		return err
+	}
+
+	if message == nil {
+		return nil
+	}
+
+	if err := trts.channel.Send(ctx, message, net.BackoffRetransmissionStrategy); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (trts *tssRoundTwoState) Receive(netMessage net.Message) error {
+	return receive(trts.BaseAsyncState, trts.member.member, netMessage)
+}
+
+func (trts *tssRoundTwoState) CanTransition() bool {
+	messagingDone := len(receivedMessages[*tssRoundTwoMessage](trts.BaseAsyncState)) ==
+		trts.member.expectedSendersCount(trts.member.newGroupMemberIndexes())
+
+	return messagingDone
+}
+
+func (trts *tssRoundTwoState) Next() (state.AsyncState, error) {
+	return &tssRoundThreeState{
+		BaseAsyncState: trts.BaseAsyncState,
+		channel:        trts.channel,
+		member:         trts.member.initializeTssRoundThree(),
+	}, nil
+}
+
+func (trts *tssRoundTwoState) MemberIndex() group.MemberIndex {
+	return trts.member.id
+}
+
+// tssRoundThreeState is the state during which old group members broadcast
+// TSS round three messages.
+// `tssRoundThreeMessage`s are valid in this state.
+type tssRoundThreeState struct {
+	*state.BaseAsyncState
+
+	channel net.BroadcastChannel
+	member  *tssRoundThreeMember
+}
+
+func (trts *tssRoundThreeState) Initiate(ctx context.Context) error {
+	message, err := trts.member.tssRoundThree(
+		ctx,
+		receivedMessages[*tssRoundTwoMessage](trts.BaseAsyncState),
+	)
+	if err != nil {
+		return err
+	}
+
+	if message == nil {
+		return nil
+	}
+
+	if err := trts.channel.Send(ctx, message, net.BackoffRetransmissionStrategy); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (trts *tssRoundThreeState) Receive(netMessage net.Message) error {
+	return receive(trts.BaseAsyncState, trts.member.member, netMessage)
+}
+
+func (trts *tssRoundThreeState) CanTransition() bool {
+	messagingDone := len(receivedMessages[*tssRoundThreeMessage](trts.BaseAsyncState)) ==
+		trts.member.expectedSendersCount(trts.member.operatingOldGroupMemberIndexes())
+
+	return messagingDone
+}
+
+func (trts *tssRoundThreeState) Next() (state.AsyncState, error) {
+	return &tssRoundFourState{
+		BaseAsyncState: trts.BaseAsyncState,
+		channel:        trts.channel,
+		member:         trts.member.initializeTssRoundFour(),
+	}, nil
+}
+
+func (trts *tssRoundThreeState) MemberIndex() group.MemberIndex {
+	return trts.member.id
+}
+
+// tssRoundFourState is the state during which new group members broadcast
+// TSS round four messages.
+// `tssRoundFourMessage`s are valid in this state.
+type tssRoundFourState struct {
+	*state.BaseAsyncState
+
+	channel net.BroadcastChannel
+	member  *tssRoundFourMember
+}
+
+func (trfs *tssRoundFourState) Initiate(ctx context.Context) error {
+	message, err := trfs.member.tssRoundFour(
+		ctx,
+		receivedMessages[*tssRoundThreeMessage](trfs.BaseAsyncState),
+	)
+	if err != nil {
+		return err
+	}
+
+	if message == nil {
+		return nil
+	}
+
+	if err := trfs.channel.Send(ctx, message, net.BackoffRetransmissionStrategy); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (trfs *tssRoundFourState) Receive(netMessage net.Message) error {
+	return receive(trfs.BaseAsyncState, trfs.member.member, netMessage)
+}
+
+func (trfs *tssRoundFourState) CanTransition() bool {
+	messagingDone := len(receivedMessages[*tssRoundFourMessage](trfs.BaseAsyncState)) ==
+		trfs.member.expectedSendersCount(trfs.member.newGroupMemberIndexes())
+
+	return messagingDone
+}
+
+func (trfs *tssRoundFourState) Next() (state.AsyncState, error) {
+	return &finalizationState{
+		BaseAsyncState: trfs.BaseAsyncState,
+		channel:        trfs.channel,
+		member:         trfs.member.initializeFinalization(),
+	}, nil
+}
+
+func (trfs *tssRoundFourState) MemberIndex() group.MemberIndex {
+	return trfs.member.id
+}
+
+// finalizationState is the last state of the resharing protocol - in this
+// state, resharing is completed. No messages are valid in this state.
+//
+// State prepares a result that is returned to the caller.
+type finalizationState struct {
+	*state.BaseAsyncState
+
+	channel net.BroadcastChannel
+	member  *finalizingMember
+}
+
+func (fs *finalizationState) Initiate(ctx context.Context) error {
+	err := fs.member.tssFinalize(
+		ctx,
+		receivedMessages[*tssRoundFourMessage](fs.BaseAsyncState),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (fs *finalizationState) Receive(net.Message) error {
+	return nil
+}
+
+func (fs *finalizationState) CanTransition() bool {
+	return true
+}
+
+func (fs *finalizationState) Next() (state.AsyncState, error) {
+	return nil, nil
+}
+
+func (fs *finalizationState) MemberIndex() group.MemberIndex {
+	return fs.member.id
+}
+
+func (fs *finalizationState) result() *Result {
+	return fs.member.Result()
+}
+
+// receive records the given network message in the history of the given
+// state if the message comes from an accepted sender, belongs to the
+// current session and was produced by the expected group.
+func receive(
+	base *state.BaseAsyncState,
+	member *member,
+	netMessage net.Message,
+) error {
+	if protocolMessage, ok := netMessage.Payload().(message); ok {
+		if member.shouldAcceptMessage(
+			protocolMessage.SenderID(),
+			netMessage.SenderPublicKey(),
+		) && member.sessionID == protocolMessage.SessionID() &&
+			member.isExpectedSender(protocolMessage) {
+			base.ReceiveToHistory(netMessage)
+		}
+	}
+
+	return nil
+}
+
+// receivedMessages returns all messages of type T that have been received
+// and validated so far. Returned messages are deduplicated so there is a
+// guarantee that only one message of the given type is returned for the
+// given sender.
+func receivedMessages[T message](base *state.BaseAsyncState) []T {
+	var messageTemplate T
+
+	payloads := state.ExtractMessagesPayloads[T](base, messageTemplate.Type())
+
+	return state.DeduplicateMessagesPayloads(
+		payloads,
+		func(message T) string {
+			return strconv.Itoa(int(message.SenderID()))
+		},
+	)
+}
@@ -0,0 +1,84 @@
+package signing
+
+import (
+	"sort"
+
+	"github.com/bnb-chain/tss-lib/tss"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa/common"
+)
+
+// MisbehaviorError is returned by the signing protocol if the protocol failed
+// because of an identified misbehavior of some signing group members. Such
+// members are either the senders of invalid messages or the parties marked as
+// culprits by the TSS protocol itself, for example, because their
+// zero-knowledge proofs did not verify.
+type MisbehaviorError struct {
+	// Round is the number of the TSS round whose messages revealed the
+	// misbehavior. Round 0 denotes the ephemeral key generation phase
+	// preceding the TSS rounds.
+	Round int
+	// Culprits are indexes of the misbehaving members, sorted in
+	// ascending order.
+	Culprits []group.MemberIndex
+	// Evidence is a description of the invalid message or failed verification
+	// that revealed the misbehavior.
+	Evidence string
+}
+
+func (me *MisbehaviorError) Error() string {
+	return me.Evidence
+}
+
+// newMisbehaviorError creates a misbehavior error blaming the given culprits
+// for the given failure.
+func newMisbehaviorError(
+	round int,
+	failure error,
+	culprits ...group.MemberIndex,
+) *MisbehaviorError {
+	sortedCulprits := make([]group.MemberIndex, len(culprits))
+	copy(sortedCulprits, culprits)
+	sort.Slice(sortedCulprits, func(i, j int) bool {
+		return sortedCulprits[i] < sortedCulprits[j]
+	})
+
+	return &MisbehaviorError{
+		Round:    round,
+		Culprits: sortedCulprits,
+		Evidence: failure.Error(),
+	}
+}
+
+// newTssMisbehaviorError creates a misbehavior error for the given failure
+// caused by the given TSS error. Culprits pointed by the TSS error are blamed.
+// If the TSS error does not point any culprits, the sender of the message
+// whose processing failed is blamed instead.
+func newTssMisbehaviorError(
+	round int,
+	failure error,
+	tssErr *tss.Error,
+	senderID group.MemberIndex,
+	identityConverter common.IdentityConverter,
+) *MisbehaviorError {
+	culprits := make([]group.MemberIndex, 0)
+	for _, culprit := range tssErr.Culprits() {
+		if culprit == nil {
+			continue
+		}
+
+		memberIndex := identityConverter.TssPartyIDToMemberIndex(culprit)
+		// Member index 0 means the party ID is unknown.
+		if memberIndex == 0 {
+			continue
+		}
+
+		culprits = append(culprits, memberIndex)
+	}
+
+	if len(culprits) == 0 {
+		culprits = append(culprits, senderID)
+	}
+
+	return newMisbehaviorError(round, failure, culprits...)
+}
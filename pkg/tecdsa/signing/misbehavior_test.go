@@ -0,0 +1,108 @@
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/tss"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+func TestNewMisbehaviorError(t *testing.T) {
+	failure := fmt.Errorf("invalid message")
+
+	misbehaviorErr := newMisbehaviorError(3, failure, 5, 2)
+
+	testutils.AssertIntsEqual(t, "round", 3, misbehaviorErr.Round)
+	testutils.AssertStringsEqual(
+		t,
+		"evidence",
+		failure.Error(),
+		misbehaviorErr.Evidence,
+	)
+	testutils.AssertStringsEqual(
+		t,
+		"error message",
+		failure.Error(),
+		misbehaviorErr.Error(),
+	)
+	assertCulprits(t, []group.MemberIndex{2, 5}, misbehaviorErr.Culprits)
+
+	var target *MisbehaviorError
+	wrappedErr := fmt.Errorf("failed to initiate state: [%w]", misbehaviorErr)
+	if !errors.As(wrappedErr, &target) {
+		t.Fatal("misbehavior error should be extractable from the wrapped error")
+	}
+}
+
+func TestNewTssMisbehaviorError(t *testing.T) {
+	converter := &identityConverter{
+		keys: []*big.Int{
+			big.NewInt(101),
+			big.NewInt(102),
+			big.NewInt(103),
+			big.NewInt(104),
+		},
+	}
+
+	failure := fmt.Errorf("cannot update using TSS round three message")
+
+	tests := map[string]struct {
+		culprits         []*tss.PartyID
+		expectedCulprits []group.MemberIndex
+	}{
+		"culprits pointed by the TSS error": {
+			culprits: []*tss.PartyID{
+				converter.MemberIndexToTssPartyID(4),
+				converter.MemberIndexToTssPartyID(3),
+			},
+			expectedCulprits: []group.MemberIndex{3, 4},
+		},
+		"no culprits pointed by the TSS error": {
+			culprits:         []*tss.PartyID{},
+			expectedCulprits: []group.MemberIndex{2},
+		},
+		"unknown culprits pointed by the TSS error": {
+			culprits: []*tss.PartyID{
+				tss.NewPartyID("200", "unknown", big.NewInt(200)),
+				nil,
+			},
+			expectedCulprits: []group.MemberIndex{2},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			tssErr := tss.NewError(failure, "signing", 3, nil, test.culprits...)
+
+			misbehaviorErr := newTssMisbehaviorError(
+				3,
+				failure,
+				tssErr,
+				2,
+				converter,
+			)
+
+			testutils.AssertIntsEqual(t, "round", 3, misbehaviorErr.Round)
+			assertCulprits(t, test.expectedCulprits, misbehaviorErr.Culprits)
+		})
+	}
+}
+
+func assertCulprits(
+	t *testing.T,
+	expected []group.MemberIndex,
+	actual []group.MemberIndex,
+) {
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf(
+			"unexpected culprits\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			actual,
+		)
+	}
+}
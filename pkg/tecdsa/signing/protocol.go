@@ -57,8 +57,12 @@ func (skgm *symmetricKeyGeneratingMember) generateSymmetricKeys(
 		otherMember := ephemeralPubKeyMessage.senderID
 
 		if !skgm.isValidEphemeralPublicKeyMessage(ephemeralPubKeyMessage) {
-			return fmt.Errorf(
-				"member [%v] sent invalid ephemeral public key message",
+			return newMisbehaviorError(
+				0,
+				fmt.Errorf(
+					"member [%v] sent invalid ephemeral public key message",
+					otherMember,
+				),
 				otherMember,
 			)
 		}
@@ -202,11 +206,17 @@ func (trtm *tssRoundTwoMember) tssRoundTwo(
 			true,
 		)
 		if tssErr != nil {
-			return nil, fmt.Errorf(
-				"cannot update using the broadcast part of the "+
-					"TSS round one message from member [%v]: [%v]",
-				senderID,
+			return nil, newTssMisbehaviorError(
+				1,
+				fmt.Errorf(
+					"cannot update using the broadcast part of the "+
+						"TSS round one message from member [%v]: [%v]",
+					senderID,
+					tssErr,
+				),
 				tssErr,
+				senderID,
+				trtm.identityConverter,
 			)
 		}
 
@@ -214,8 +224,12 @@ func (trtm *tssRoundTwoMember) tssRoundTwo(
 		// for this member.
 		encryptedPeerPayload, ok := tssRoundOneMessage.peersPayload[trtm.id]
 		if !ok {
-			return nil, fmt.Errorf(
-				"no P2P part in the TSS round one message from member [%v]",
+			return nil, newMisbehaviorError(
+				1,
+				fmt.Errorf(
+					"no P2P part in the TSS round one message from member [%v]",
+					senderID,
+				),
 				senderID,
 			)
 		}
@@ -231,11 +245,15 @@ func (trtm *tssRoundTwoMember) tssRoundTwo(
 		// Decrypt the P2P part of the TSS round one message.
 		peerPayload, err := symmetricKey.Decrypt(encryptedPeerPayload)
 		if err != nil {
-			return nil, fmt.Errorf(
-				"cannot decrypt P2P part of the TSS round one "+
-					"message from member [%v]: [%v]",
+			return nil, newMisbehaviorError(
+				1,
+				fmt.Errorf(
+					"cannot decrypt P2P part of the TSS round one "+
+						"message from member [%v]: [%v]",
+					senderID,
+					err,
+				),
 				senderID,
-				err,
 			)
 		}
 		// Update the local TSS party using the P2P part of the message
@@ -246,11 +264,17 @@ func (trtm *tssRoundTwoMember) tssRoundTwo(
 			false,
 		)
 		if tssErr != nil {
-			return nil, fmt.Errorf(
-				"cannot update using the P2P part of the TSS round "+
-					"one message from member [%v]: [%v]",
-				senderID,
+			return nil, newTssMisbehaviorError(
+				1,
+				fmt.Errorf(
+					"cannot update using the P2P part of the TSS round "+
+						"one message from member [%v]: [%v]",
+					senderID,
+					tssErr,
+				),
 				tssErr,
+				senderID,
+				trtm.identityConverter,
 			)
 		}
 	}
@@ -322,8 +346,12 @@ func (trtm *tssRoundThreeMember) tssRoundThree(
 		// for this member.
 		encryptedPeerPayload, ok := tssRoundTwoMessage.peersPayload[trtm.id]
 		if !ok {
-			return nil, fmt.Errorf(
-				"no P2P part in the TSS round two message from member [%v]",
+			return nil, newMisbehaviorError(
+				2,
+				fmt.Errorf(
+					"no P2P part in the TSS round two message from member [%v]",
+					senderID,
+				),
 				senderID,
 			)
 		}
@@ -339,11 +367,15 @@ func (trtm *tssRoundThreeMember) tssRoundThree(
 		// Decrypt the P2P part of the TSS round two message.
 		peerPayload, err := symmetricKey.Decrypt(encryptedPeerPayload)
 		if err != nil {
-			return nil, fmt.Errorf(
-				"cannot decrypt P2P part of the TSS round two "+
-					"message from member [%v]: [%v]",
+			return nil, newMisbehaviorError(
+				2,
+				fmt.Errorf(
+					"cannot decrypt P2P part of the TSS round two "+
+						"message from member [%v]: [%v]",
+					senderID,
+					err,
+				),
 				senderID,
-				err,
 			)
 		}
 		// Update the local TSS party using the P2P part of the message
@@ -354,11 +386,17 @@ func (trtm *tssRoundThreeMember) tssRoundThree(
 			false,
 		)
 		if tssErr != nil {
-			return nil, fmt.Errorf(
-				"cannot update using the P2P part of the TSS round "+
-					"two message from member [%v]: [%v]",
-				senderID,
+			return nil, newTssMisbehaviorError(
+				2,
+				fmt.Errorf(
+					"cannot update using the P2P part of the TSS round "+
+						"two message from member [%v]: [%v]",
+					senderID,
+					tssErr,
+				),
 				tssErr,
+				senderID,
+				trtm.identityConverter,
 			)
 		}
 	}
@@ -407,11 +445,17 @@ func (trfm *tssRoundFourMember) tssRoundFour(
 			true,
 		)
 		if tssErr != nil {
-			return nil, fmt.Errorf(
-				"cannot update using TSS round three message "+
-					"from member [%v]: [%v]",
-				senderID,
+			return nil, newTssMisbehaviorError(
+				3,
+				fmt.Errorf(
+					"cannot update using TSS round three message "+
+						"from member [%v]: [%v]",
+					senderID,
+					tssErr,
+				),
 				tssErr,
+				senderID,
+				trfm.identityConverter,
 			)
 		}
 	}
@@ -460,11 +504,17 @@ func (trfm *tssRoundFiveMember) tssRoundFive(
 			true,
 		)
 		if tssErr != nil {
-			return nil, fmt.Errorf(
-				"cannot update using TSS round four message "+
-					"from member [%v]: [%v]",
-				senderID,
+			return nil, newTssMisbehaviorError(
+				4,
+				fmt.Errorf(
+					"cannot update using TSS round four message "+
+						"from member [%v]: [%v]",
+					senderID,
+					tssErr,
+				),
 				tssErr,
+				senderID,
+				trfm.identityConverter,
 			)
 		}
 	}
@@ -513,11 +563,17 @@ func (trsm *tssRoundSixMember) tssRoundSix(
 			true,
 		)
 		if tssErr != nil {
-			return nil, fmt.Errorf(
-				"cannot update using TSS round five message "+
-					"from member [%v]: [%v]",
-				senderID,
+			return nil, newTssMisbehaviorError(
+				5,
+				fmt.Errorf(
+					"cannot update using TSS round five message "+
+						"from member [%v]: [%v]",
+					senderID,
+					tssErr,
+				),
 				tssErr,
+				senderID,
+				trsm.identityConverter,
 			)
 		}
 	}
@@ -566,11 +622,17 @@ func (trsm *tssRoundSevenMember) tssRoundSeven(
 			true,
 		)
 		if tssErr != nil {
-			return nil, fmt.Errorf(
-				"cannot update using TSS round six message "+
-					"from member [%v]: [%v]",
-				senderID,
+			return nil, newTssMisbehaviorError(
+				6,
+				fmt.Errorf(
+					"cannot update using TSS round six message "+
+						"from member [%v]: [%v]",
+					senderID,
+					tssErr,
+				),
 				tssErr,
+				senderID,
+				trsm.identityConverter,
 			)
 		}
 	}
@@ -619,11 +681,17 @@ func (trem *tssRoundEightMember) tssRoundEight(
 			true,
 		)
 		if tssErr != nil {
-			return nil, fmt.Errorf(
-				"cannot update using TSS round seven message "+
-					"from member [%v]: [%v]",
-				senderID,
+			return nil, newTssMisbehaviorError(
+				7,
+				fmt.Errorf(
+					"cannot update using TSS round seven message "+
+						"from member [%v]: [%v]",
+					senderID,
+					tssErr,
+				),
 				tssErr,
+				senderID,
+				trem.identityConverter,
 			)
 		}
 	}
@@ -672,11 +740,17 @@ func (trnm *tssRoundNineMember) tssRoundNine(
 			true,
 		)
 		if tssErr != nil {
-			return nil, fmt.Errorf(
-				"cannot update using TSS round eight message "+
-					"from member [%v]: [%v]",
-				senderID,
+			return nil, newTssMisbehaviorError(
+				8,
+				fmt.Errorf(
+					"cannot update using TSS round eight message "+
+						"from member [%v]: [%v]",
+					senderID,
+					tssErr,
+				),
 				tssErr,
+				senderID,
+				trnm.identityConverter,
 			)
 		}
 	}
@@ -724,11 +798,17 @@ func (fm *finalizingMember) tssFinalize(
 			true,
 		)
 		if tssErr != nil {
-			return fmt.Errorf(
-				"cannot update using TSS round nine message "+
-					"from member [%v]: [%v]",
-				senderID,
+			return newTssMisbehaviorError(
+				9,
+				fmt.Errorf(
+					"cannot update using TSS round nine message "+
+						"from member [%v]: [%v]",
+					senderID,
+					tssErr,
+				),
 				tssErr,
+				senderID,
+				fm.identityConverter,
 			)
 		}
 	}
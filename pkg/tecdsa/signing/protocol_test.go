@@ -242,11 +242,15 @@ func TestGenerateSymmetricKeys_InvalidEphemeralPublicKeyMessage(t *testing.T) {
 		var expectedErr error
 		// The misbehaved member should not get an error.
 		if member.id != misbehavingMemberID {
-			expectedErr = fmt.Errorf(
-				"member [%v] sent invalid ephemeral "+
-					"public key message",
-				misbehavingMemberID,
-			)
+			expectedErr = &MisbehaviorError{
+				Round:    0,
+				Culprits: []group.MemberIndex{misbehavingMemberID},
+				Evidence: fmt.Sprintf(
+					"member [%v] sent invalid ephemeral "+
+						"public key message",
+					misbehavingMemberID,
+				),
+			}
 		}
 
 		if !reflect.DeepEqual(expectedErr, err) {